@@ -19,6 +19,16 @@ import (
 var scriptsPath = "../_scripts/tpc-h/"
 
 func BenchmarkTpch(b *testing.B) {
+	benchmarkTpch(b, readRows)
+}
+
+// BenchmarkTpchBatch runs the same queries as BenchmarkTpch, reading their
+// results in batches instead of row by row.
+func BenchmarkTpchBatch(b *testing.B) {
+	benchmarkTpch(b, readBatches)
+}
+
+func benchmarkTpch(b *testing.B, read func(sql.RowIter) error) {
 	b.Log("generating data")
 	if err := genData(b); err != nil {
 		b.Fatal(err)
@@ -34,12 +44,12 @@ func BenchmarkTpch(b *testing.B) {
 	e.AddDatabase(db)
 	b.ResetTimer()
 
-	if err := executeQueries(b, e); err != nil {
+	if err := executeQueries(b, e, read); err != nil {
 		b.Fatal(err)
 	}
 }
 
-func executeQueries(b *testing.B, e *sqle.Engine) error {
+func executeQueries(b *testing.B, e *sqle.Engine, read func(sql.RowIter) error) error {
 	base := path.Join(scriptsPath, "queries")
 	infos, err := ioutil.ReadDir(base)
 	if err != nil {
@@ -65,15 +75,8 @@ func executeQueries(b *testing.B, e *sqle.Engine) error {
 					b.Fatal(err)
 				}
 
-				for {
-					_, err = iter.Next()
-					if err == io.EOF {
-						break
-					}
-
-					if err != nil {
-						b.Fatal(err)
-					}
+				if err := read(iter); err != nil {
+					b.Fatal(err)
 				}
 			}
 		})
@@ -82,6 +85,33 @@ func executeQueries(b *testing.B, e *sqle.Engine) error {
 	return nil
 }
 
+func readRows(iter sql.RowIter) error {
+	for {
+		_, err := iter.Next()
+		if err == io.EOF {
+			return iter.Close()
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func readBatches(iter sql.RowIter) error {
+	batches := sql.ToBatchIter(iter)
+	for {
+		_, err := batches.NextBatch(sql.DefaultBatchSize)
+		if err == io.EOF {
+			return iter.Close()
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func genDB(b *testing.B) (sql.Database, error) {
 	db := mem.NewDatabase("tpch")
 
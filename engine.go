@@ -1,6 +1,9 @@
 package sqle // import "gopkg.in/src-d/go-mysql-server.v0"
 
 import (
+	"context"
+	"time"
+
 	opentracing "github.com/opentracing/opentracing-go"
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
 	"gopkg.in/src-d/go-mysql-server.v0/sql/analyzer"
@@ -12,11 +15,28 @@ import (
 type Engine struct {
 	Catalog  *sql.Catalog
 	Analyzer *analyzer.Analyzer
+	// Memory tracks the memory used by all the queries running in the
+	// engine. Its limit, if any, is shared by all of them.
+	Memory *sql.MemoryTracker
+	// QueryMemoryLimit is the maximum number of bytes a single query can
+	// use to buffer rows. Zero means there is no limit.
+	QueryMemoryLimit uint64
+	// MaxExecutionTime is the maximum time a query can take to execute,
+	// unless its session sets a different one. Zero means there is no limit.
+	MaxExecutionTime time.Duration
+	// PlanCache caches the analyzed plans of the queries so they don't need
+	// to be parsed and analyzed again. It's disabled if nil.
+	PlanCache *PlanCache
 }
 
 // New creates a new Engine
 func New(c *sql.Catalog, a *analyzer.Analyzer) *Engine {
-	return &Engine{c, a}
+	return &Engine{
+		Catalog:   c,
+		Analyzer:  a,
+		Memory:    sql.NewMemoryTracker(0, nil),
+		PlanCache: NewPlanCache(DefaultPlanCacheSize),
+	}
 }
 
 // NewDefault creates a new default Engine.
@@ -25,10 +45,24 @@ func NewDefault() *Engine {
 	c.RegisterFunctions(function.Defaults)
 
 	a := analyzer.NewDefault(c)
-	return &Engine{c, a}
+	return New(c, a)
 }
 
-// Query executes a query without attaching to any context.
+// NewQueryMemoryTracker returns a new memory tracker for a query, limited by
+// both the query memory limit and the memory limit of the engine.
+func (e *Engine) NewQueryMemoryTracker() *sql.MemoryTracker {
+	return sql.NewMemoryTracker(e.QueryMemoryLimit, e.Memory)
+}
+
+// Query executes a query without attaching to any context. If the context
+// has no memory tracker, a new one will be created for the query using
+// NewQueryMemoryTracker.
+// The analyzed plan of the query is taken from the plan cache, if any, when
+// the same query has been executed before in the current database, which is
+// the one of the session if it implements sql.DatabaseSession and has one.
+// If the session of the context implements sql.ExecutionTimeLimiter and has a
+// maximum execution time, the query will be interrupted after that time.
+// Otherwise, the MaxExecutionTime of the engine is used.
 func (e *Engine) Query(
 	ctx *sql.Context,
 	query string,
@@ -36,27 +70,107 @@ func (e *Engine) Query(
 	span, ctx := ctx.Span("query", opentracing.Tag{Key: "query", Value: query})
 	defer span.Finish()
 
-	parsed, err := parse.Parse(ctx, query)
-	if err != nil {
-		return nil, nil, err
+	if ctx.Memory() == nil {
+		ctx = ctx.WithMemory(e.NewQueryMemoryTracker())
 	}
 
-	analyzed, err := e.Analyzer.Analyze(ctx, parsed)
+	var cancel context.CancelFunc = func() {}
+	timeout := e.maxExecutionTime(ctx)
+	if timeout > 0 {
+		ctx, cancel = ctx.WithTimeout(timeout)
+	}
+
+	analyzed, err := e.analyze(ctx, query)
 	if err != nil {
+		cancel()
 		return nil, nil, err
 	}
 
 	iter, err := analyzed.RowIter(ctx)
+	if modifiesCatalog(analyzed) {
+		e.Catalog.Invalidate()
+	}
+
 	if err != nil {
+		cancel()
 		return nil, nil, err
 	}
 
+	if timeout > 0 {
+		iter = &cancelIter{iter, sql.ToBatchIter(iter), cancel}
+	}
+
 	return analyzed.Schema(), iter, nil
 }
 
-// AddDatabase adds the given database to the catalog.
+// analyze returns the analyzed plan of the given query, which is taken from
+// the plan cache if possible.
+func (e *Engine) analyze(ctx *sql.Context, query string) (sql.Node, error) {
+	a := e.analyzer(ctx)
+	version := e.Catalog.Version()
+	if node, ok := e.PlanCache.Get(version, a.CurrentDatabase, query); ok {
+		return node, nil
+	}
+
+	parsed, err := parse.Parse(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	analyzed, err := a.Analyze(ctx, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	e.PlanCache.Put(version, a.CurrentDatabase, query, analyzed)
+	return analyzed, nil
+}
+
+// analyzer returns the analyzer for the queries of the session of the given
+// context. If the session implements sql.DatabaseSession and has a current
+// database, the analyzer uses it instead of the database of the engine's
+// analyzer.
+func (e *Engine) analyzer(ctx *sql.Context) *analyzer.Analyzer {
+	s, ok := ctx.Session.(sql.DatabaseSession)
+	if !ok || s.CurrentDatabase() == "" || s.CurrentDatabase() == e.Analyzer.CurrentDatabase {
+		return e.Analyzer
+	}
+
+	a := *e.Analyzer
+	a.CurrentDatabase = s.CurrentDatabase()
+	return &a
+}
+
+func (e *Engine) maxExecutionTime(ctx *sql.Context) time.Duration {
+	if s, ok := ctx.Session.(sql.ExecutionTimeLimiter); ok && s.MaxExecutionTime() > 0 {
+		return s.MaxExecutionTime()
+	}
+
+	return e.MaxExecutionTime
+}
+
+// cancelIter cancels the context of the query once the iterator is closed.
+type cancelIter struct {
+	sql.RowIter
+	batch  sql.BatchIter
+	cancel context.CancelFunc
+}
+
+func (i *cancelIter) NextBatch(max int) ([]sql.Row, error) {
+	return i.batch.NextBatch(max)
+}
+
+func (i *cancelIter) Close() error {
+	defer i.cancel()
+	return i.RowIter.Close()
+}
+
+// AddDatabase adds the given database to the catalog. If tables are added to
+// or removed from a database outside of the engine and it's not a
+// sql.VersionedDatabase, Catalog.Invalidate must be called so the cached
+// plans are discarded.
 func (e *Engine) AddDatabase(db sql.Database) {
-	e.Catalog.Databases = append(e.Catalog.Databases, db)
+	e.Catalog.AddDatabase(db)
 	e.Analyzer.CurrentDatabase = db.Name()
 }
 
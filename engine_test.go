@@ -12,11 +12,13 @@ import (
 	"gopkg.in/src-d/go-mysql-server.v0"
 	"gopkg.in/src-d/go-mysql-server.v0/mem"
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/analyzer"
 	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
 	"gopkg.in/src-d/go-mysql-server.v0/sql/index/pilosa"
 	"gopkg.in/src-d/go-mysql-server.v0/sql/parse"
 	"gopkg.in/src-d/go-mysql-server.v0/test"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 )
 
@@ -34,6 +36,153 @@ var queries = []struct {
 		"SELECT i FROM mytable WHERE i = 2;",
 		[]sql.Row{{int64(2)}},
 	},
+	{
+		"SELECT i FROM mytable WHERE s LIKE '%ROW' AND s NOT LIKE 's_cond%'",
+		[]sql.Row{{int64(1)}, {int64(3)}},
+	},
+	{
+		`SELECT CASE i WHEN 1 THEN 'one' WHEN 2 THEN 'two' ELSE 'many' END,
+			CASE WHEN i > 2 THEN i END FROM mytable`,
+		[]sql.Row{{"one", nil}, {"two", nil}, {"many", int64(3)}},
+	},
+	{
+		`SELECT IF(i > 1, s, i), IFNULL(NULL, i), COALESCE(NULL, NULL, i),
+			NULLIF(i, 2) FROM mytable`,
+		[]sql.Row{
+			{"1", int64(1), int64(1), int64(1)},
+			{"second row", int64(2), int64(2), nil},
+			{"third row", int64(3), int64(3), int64(3)},
+		},
+	},
+	{
+		`SELECT UPPER(LEFT(s, 1)), SUBSTRING_INDEX(s, ' ', -1), LPAD(i, 3, '0'),
+			CONCAT_WS('-', s, NULL, i), INSTR(s, 'ROW'), REGEXP_REPLACE(s, 'r(o)w', '$1')
+			FROM mytable WHERE CHAR_LENGTH(TRIM(s)) > 9`,
+		[]sql.Row{{"S", "row", "002", "second row-2", int32(8), "second o"}},
+	},
+	{
+		`SELECT DATE_FORMAT('2018-01-31' + INTERVAL i MONTH, '%Y-%m-%d'),
+			DATEDIFF('2018-03-01', '2018-01-31' + INTERVAL i MONTH),
+			TIMESTAMPDIFF(DAY, '2018-01-31', '2018-02-01'), WEEKDAY('2018-01-01')
+			FROM mytable WHERE i < 3`,
+		[]sql.Row{
+			{"2018-02-28", int64(1), int64(1), int32(0)},
+			{"2018-03-31", int64(-30), int64(1), int32(0)},
+		},
+	},
+	{
+		`SELECT ABS(i - 3), MOD(i, 2), POW(i, 2), GREATEST(i, 2), LEAST(i, 2),
+			HEX(i * 255), CONV(i, 10, 2), SIGN(i - 2) FROM mytable`,
+		[]sql.Row{
+			{int64(2), int64(1), float64(1), int64(2), int64(1), "FF", "1", int32(-1)},
+			{int64(1), int64(0), float64(4), int64(2), int64(2), "1FE", "10", int32(0)},
+			{int64(0), int64(1), float64(9), int64(3), int64(2), "2FD", "11", int32(1)},
+		},
+	},
+	{
+		`SELECT JSON_OBJECT('i', i, 's', s), JSON_EXTRACT(JSON_ARRAY(i, s), '$[1]'),
+			JSON_UNQUOTE(JSON_EXTRACT(JSON_OBJECT('s', s), '$.s'))
+			FROM mytable WHERE i = 1`,
+		[]sql.Row{
+			{[]byte(`{"i": 1, "s": "first row"}`), []byte(`"first row"`), "first row"},
+		},
+	},
+	{
+		`SELECT JSON_ARRAYAGG(i), JSON_OBJECTAGG(s, i) FROM mytable`,
+		[]sql.Row{
+			{
+				[]byte(`[1, 2, 3]`),
+				[]byte(`{"first row": 1, "third row": 3, "second row": 2}`),
+			},
+		},
+	},
+	{
+		`SELECT COUNT(DISTINCT i % 2), SUM(DISTINCT i % 2), AVG(DISTINCT i % 2),
+			COUNT(DISTINCT i % 2, s), COUNT(i % 2) FROM mytable`,
+		[]sql.Row{
+			{int32(2), decimal.New(1, 0), decimal.New(5000, -4), int32(3), int32(3)},
+		},
+	},
+	{
+		`SELECT GROUP_CONCAT(s ORDER BY i DESC SEPARATOR '|'), GROUP_CONCAT(DISTINCT i % 2),
+			VAR_POP(i), STDDEV_SAMP(i), BIT_OR(i), BIT_AND(i), BIT_XOR(i)
+			FROM mytable`,
+		[]sql.Row{
+			{
+				"third row|second row|first row", "1,0",
+				float64(2) / 3, float64(1), uint64(3), uint64(0), uint64(0),
+			},
+		},
+	},
+	{
+		`SELECT ANY_VALUE(s), GROUP_CONCAT(i) FROM mytable GROUP BY i % 2`,
+		[]sql.Row{
+			{"first row", "1,3"},
+			{"second row", "2"},
+		},
+	},
+	{
+		`SELECT APPROX_COUNT_DISTINCT(i % 2), APPROX_MEDIAN(i), APPROX_PERCENTILE(i, 0.75)
+			FROM mytable`,
+		[]sql.Row{{int64(2), float64(2), 2.5}},
+	},
+	{
+		`SELECT i, ROW_NUMBER() OVER (ORDER BY i DESC) AS n,
+			RANK() OVER (ORDER BY i % 2), DENSE_RANK() OVER (ORDER BY i % 2)
+			FROM mytable ORDER BY i`,
+		[]sql.Row{
+			{int64(1), uint64(3), uint64(2), uint64(2)},
+			{int64(2), uint64(2), uint64(1), uint64(1)},
+			{int64(3), uint64(1), uint64(2), uint64(2)},
+		},
+	},
+	{
+		`SELECT i, SUM(i) OVER (ORDER BY i ROWS BETWEEN 1 PRECEDING AND 1 FOLLOWING),
+			LAG(s) OVER (ORDER BY i), FIRST_VALUE(s) OVER (ORDER BY i DESC),
+			NTILE(2) OVER (ORDER BY i)
+			FROM mytable ORDER BY i`,
+		[]sql.Row{
+			{int64(1), decimal.New(3, 0), nil, "third row", uint64(1)},
+			{int64(2), decimal.New(6, 0), "first row", "third row", uint64(1)},
+			{int64(3), decimal.New(5, 0), "second row", "third row", uint64(2)},
+		},
+	},
+	{
+		`SELECT COUNT(*) OVER (PARTITION BY i > 1 ORDER BY s DESC) AS c FROM mytable ORDER BY s`,
+		[]sql.Row{{int32(1)}, {int32(2)}, {int32(1)}},
+	},
+	{
+		`SELECT CASE WHEN i > 0 THEN 1 ELSE 1 / 0 END, i DIV 0, i % 0 FROM mytable ORDER BY i`,
+		[]sql.Row{{int64(1), nil, nil}, {int64(1), nil, nil}, {int64(1), nil, nil}},
+	},
+	{
+		`SELECT * FROM (SELECT i, ROW_NUMBER() OVER (ORDER BY i DESC) AS rn FROM mytable) t ORDER BY i`,
+		[]sql.Row{
+			{int64(1), uint64(3)},
+			{int64(2), uint64(2)},
+			{int64(3), uint64(1)},
+		},
+	},
+	{
+		`SELECT i, s, SUM(i), GROUPING(i, s) FROM mytable GROUP BY i, s WITH ROLLUP`,
+		[]sql.Row{
+			{int64(1), "first row", decimal.New(1, 0), int64(0)},
+			{int64(1), nil, decimal.New(1, 0), int64(1)},
+			{int64(2), "second row", decimal.New(2, 0), int64(0)},
+			{int64(2), nil, decimal.New(2, 0), int64(1)},
+			{int64(3), "third row", decimal.New(3, 0), int64(0)},
+			{int64(3), nil, decimal.New(3, 0), int64(1)},
+			{nil, nil, decimal.New(6, 0), int64(3)},
+		},
+	},
+	{
+		`SELECT i, CONCAT('x', 'y') FROM mytable WHERE i = 1 + 1 AND NOT NOT s = 'second row'`,
+		[]sql.Row{{int64(2), "xy"}},
+	},
+	{
+		`SELECT i FROM mytable WHERE i > 1 AND 1 = 2`,
+		[]sql.Row{},
+	},
 	{
 		"SELECT i FROM mytable ORDER BY i DESC;",
 		[]sql.Row{{int64(3)}, {int64(2)}, {int64(1)}},
@@ -115,6 +264,28 @@ var queries = []struct {
 			{"third row"},
 		},
 	},
+	{
+		"SELECT i, s2 FROM mytable, othertable WHERE i = i2 AND i > 1 AND s2 <> 'first'",
+		[]sql.Row{
+			{int64(2), "second"},
+		},
+	},
+	{
+		`SELECT mt.i, ot.s2, tt.number FROM tabletest tt, mytable mt, othertable ot
+		WHERE mt.i = ot.i2 AND tt.number = mt.i AND ot.s2 = 'second'`,
+		[]sql.Row{
+			{int64(2), "second", int32(2)},
+		},
+	},
+	{
+		`SELECT n, t FROM (
+			SELECT i AS n, s AS t FROM mytable
+		) sq
+		WHERE n < 3 AND t <> 'first row'`,
+		[]sql.Row{
+			{int64(2), "second row"},
+		},
+	},
 	{
 		`SELECT COUNT(*) as cnt, fi FROM (
 			SELECT tbl.s AS fi
@@ -243,7 +414,7 @@ var queries = []struct {
 	},
 	{
 		`SELECT SUM(i) FROM mytable`,
-		[]sql.Row{{float64(6)}},
+		[]sql.Row{{decimal.New(6, 0)}},
 	},
 	{
 		`SELECT * FROM mytable mt INNER JOIN othertable ot ON mt.i = ot.i2 AND mt.i > 2`,
@@ -285,6 +456,17 @@ func TestQueries(t *testing.T) {
 	}
 }
 
+func TestQueriesFromPlanCache(t *testing.T) {
+	e := newEngine(t)
+
+	// The second execution of every query uses a copy of its cached plan.
+	for _, tt := range queries {
+		for i := 0; i < 2; i++ {
+			testQuery(t, e, tt.query, tt.expected)
+		}
+	}
+}
+
 func TestOrderByColumns(t *testing.T) {
 	require := require.New(t)
 	e := newEngine(t)
@@ -391,13 +573,39 @@ func TestDDL(t *testing.T) {
 		{Name: "b", Type: sql.Text, Nullable: true, Source: "t1"},
 		{Name: "c", Type: sql.Date, Nullable: true, Source: "t1"},
 		{Name: "d", Type: sql.Timestamp, Nullable: true, Source: "t1"},
-		{Name: "e", Type: sql.Text, Nullable: true, Source: "t1"},
+		{Name: "e", Type: sql.MustVarChar(20), Nullable: true, Source: "t1"},
 		{Name: "f", Type: sql.Blob, Source: "t1"},
 	}
 
 	require.Equal(s, testTable.Schema())
 }
 
+func TestAnalyzeTable(t *testing.T) {
+	e := newEngine(t)
+	testQuery(t, e,
+		"ANALYZE TABLE mytable, othertable",
+		[]sql.Row{
+			{"mydb.mytable", "analyze", "status", "Table is already up to date"},
+			{"mydb.othertable", "analyze", "status", "Table is already up to date"},
+		},
+	)
+}
+
+func TestUnknownHintIndex(t *testing.T) {
+	e := newEngine(t)
+
+	for _, q := range []string{
+		`SELECT * FROM mytable USE INDEX (foo) WHERE i = 2`,
+		`SELECT mytable.s FROM mytable IGNORE INDEX (foo) WHERE mytable.i = 1`,
+		`SELECT mt.i FROM mytable AS mt USE INDEX (foo) WHERE mt.s = 'third row'`,
+		`SELECT i FROM mytable FORCE INDEX (foo) WHERE i = 2`,
+	} {
+		_, _, err := e.Query(sql.NewEmptyContext(), q)
+		require.Error(t, err, q)
+		require.True(t, analyzer.ErrHintIndexNotFound.Is(err), q)
+	}
+}
+
 func TestNaturalJoin(t *testing.T) {
 	require := require.New(t)
 
@@ -561,9 +769,9 @@ func TestInnerNestedInNaturalJoins(t *testing.T) {
 		{Name: "t3", Type: sql.Text, Source: "table3"},
 	})
 
-	require.Nil(table3.Insert(sql.NewRow(int32(1), float64(2.3), "table3")))
-	require.Nil(table3.Insert(sql.NewRow(int32(2), float64(2.3), "table3")))
-	require.Nil(table3.Insert(sql.NewRow(int32(30), float64(2.3), "table3")))
+	require.Nil(table3.Insert(sql.NewRow(int32(1), float64(2.2), "table3")))
+	require.Nil(table3.Insert(sql.NewRow(int32(2), float64(2.2), "table3")))
+	require.Nil(table3.Insert(sql.NewRow(int32(30), float64(2.2), "table3")))
 
 	db := mem.NewDatabase("mydb")
 	db.AddTable("table1", table1)
@@ -613,6 +821,328 @@ func testQuery(t *testing.T, e *sqle.Engine, q string, r []sql.Row) {
 	})
 }
 
+func TestEnginePlanCache(t *testing.T) {
+	require := require.New(t)
+
+	e := newEngine(t)
+	ctx := sql.NewEmptyContext()
+
+	_, iter, err := e.Query(ctx, "SELECT i FROM mytable")
+	require.NoError(err)
+	_, err = sql.RowIterToRows(iter)
+	require.NoError(err)
+	require.Equal(1, e.PlanCache.Len())
+
+	_, iter, err = e.Query(ctx, "SELECT  i\nFROM mytable")
+	require.NoError(err)
+	rows, err := sql.RowIterToRows(iter)
+	require.NoError(err)
+	require.Len(rows, 3)
+	require.Equal(1, e.PlanCache.Len())
+
+	db, err := e.Catalog.Database("mydb")
+	require.NoError(err)
+	version := e.Catalog.Version()
+	db.(*mem.Database).AddTable("newtable", mem.NewTable("newtable", nil))
+	require.NotEqual(version, e.Catalog.Version())
+
+	_, iter, err = e.Query(ctx, "SELECT i FROM mytable")
+	require.NoError(err)
+	_, err = sql.RowIterToRows(iter)
+	require.NoError(err)
+	require.Equal(1, e.PlanCache.Len())
+
+	_, iter, err = e.Query(ctx, "CREATE TABLE t1(a INTEGER)")
+	require.NoError(err)
+	_, err = sql.RowIterToRows(iter)
+	require.NoError(err)
+
+	_, iter, err = e.Query(ctx, "SELECT a FROM t1")
+	require.NoError(err)
+	_, err = sql.RowIterToRows(iter)
+	require.NoError(err)
+	require.Equal(1, e.PlanCache.Len())
+}
+
+func TestEnginePlanCacheComments(t *testing.T) {
+	require := require.New(t)
+
+	e := newEngine(t)
+	ctx := sql.NewEmptyContext()
+
+	_, iter, err := e.Query(ctx, "SELECT i FROM mytable -- x\nWHERE i = 1")
+	require.NoError(err)
+	rows, err := sql.RowIterToRows(iter)
+	require.NoError(err)
+	require.Equal([]sql.Row{{int64(1)}}, rows)
+
+	_, iter, err = e.Query(ctx, "SELECT i FROM mytable -- x WHERE i = 1")
+	require.NoError(err)
+	rows, err = sql.RowIterToRows(iter)
+	require.NoError(err)
+	require.Len(rows, 3)
+	require.Equal(2, e.PlanCache.Len())
+}
+
+func TestEnginePlanCacheSessionDatabase(t *testing.T) {
+	require := require.New(t)
+
+	e := newEngine(t)
+
+	table := mem.NewTable("mytable", sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "mytable"},
+	})
+	require.NoError(table.Insert(sql.NewRow(int64(42))))
+	db := mem.NewDatabase("otherdb")
+	db.AddTable("mytable", table)
+	e.Catalog.AddDatabase(db)
+
+	session := sql.NewBaseSession().(*sql.BaseSession)
+	ctx := sql.NewContext(context.TODO(), sql.WithSession(session))
+
+	var results [][]sql.Row
+	for _, db := range []string{"", "otherdb", "mydb", "otherdb"} {
+		session.SetCurrentDatabase(db)
+		_, iter, err := e.Query(ctx, "SELECT i FROM mytable ORDER BY i")
+		require.NoError(err)
+		rows, err := sql.RowIterToRows(iter)
+		require.NoError(err)
+		results = append(results, rows)
+	}
+
+	expected := []sql.Row{{int64(1)}, {int64(2)}, {int64(3)}}
+	require.Equal(expected, results[0])
+	require.Equal([]sql.Row{{int64(42)}}, results[1])
+	require.Equal(expected, results[2])
+	require.Equal([]sql.Row{{int64(42)}}, results[3])
+	require.Equal(2, e.PlanCache.Len())
+}
+
+func TestEnginePlanCacheRand(t *testing.T) {
+	require := require.New(t)
+
+	e := newEngine(t)
+
+	var results [][]sql.Row
+	for i := 0; i < 2; i++ {
+		_, iter, err := e.Query(sql.NewEmptyContext(), "SELECT RAND(1) FROM mytable")
+		require.NoError(err)
+		rows, err := sql.RowIterToRows(iter)
+		require.NoError(err)
+		results = append(results, rows)
+	}
+
+	require.Equal(results[0], results[1])
+	require.NotEqual(results[0][0], results[0][1])
+}
+
+func TestEnginePlanCacheNow(t *testing.T) {
+	require := require.New(t)
+
+	e := newEngine(t)
+
+	for _, tt := range []time.Time{
+		time.Date(2018, 1, 2, 3, 4, 5, 6000000, time.UTC),
+		time.Date(2019, 6, 7, 8, 9, 10, 11000000, time.UTC),
+	} {
+		ctx := sql.NewContext(context.TODO(), sql.WithQueryTime(tt))
+		_, iter, err := e.Query(ctx, "SELECT NOW(3)")
+		require.NoError(err)
+		rows, err := sql.RowIterToRows(iter)
+		require.NoError(err)
+		require.Equal([]sql.Row{{tt}}, rows)
+	}
+
+	require.Equal(1, e.PlanCache.Len())
+}
+
+func TestDecimal(t *testing.T) {
+	e := newEngine(t)
+	ctx := sql.NewEmptyContext()
+
+	_, iter, err := e.Query(ctx, "CREATE TABLE prices(item TEXT, price DECIMAL(10,2))")
+	require.NoError(t, err)
+	_, err = sql.RowIterToRows(iter)
+	require.NoError(t, err)
+
+	_, iter, err = e.Query(ctx, `INSERT INTO prices (item, price) VALUES
+		('a', 0.10), ('b', 0.20), ('c', 0.20), ('d', 1.15), ('e', '3.1')`)
+	require.NoError(t, err)
+	_, err = sql.RowIterToRows(iter)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		query    string
+		expected []string
+	}{
+		{"SELECT SUM(price) FROM prices", []string{"4.75"}},
+		{"SELECT AVG(price) FROM prices", []string{"0.950000"}},
+		{"SELECT price * 3 FROM prices WHERE item = 'a'", []string{"0.30"}},
+		{"SELECT price / 3 FROM prices WHERE item = 'a'", []string{"0.033333"}},
+		{"SELECT item FROM prices WHERE price > 1", []string{"d", "e"}},
+		{"SELECT DISTINCT price FROM prices WHERE price < 1", []string{"0.10", "0.20"}},
+		{"SELECT COUNT(*) FROM prices GROUP BY price", []string{"1", "2", "1", "1"}},
+		{"SELECT price % 1 FROM prices WHERE item = 'd'", []string{"0.15"}},
+		{"SELECT price DIV 1 FROM prices WHERE item = 'd'", []string{"1"}},
+		{"SELECT price + 0.1 FROM prices WHERE item = 'a'", []string{"0.20"}},
+		{"SELECT SUM(price + 0.01) FROM prices", []string{"4.80"}},
+		{"SELECT 0.1 + 0.2 = 0.3 FROM prices WHERE item = 'a'", []string{"1"}},
+		{"SELECT CAST('1.005' AS DECIMAL(10,2)) FROM prices WHERE item = 'a'", []string{"1.01"}},
+		{"SELECT CAST(price AS DECIMAL(10,1)) FROM prices WHERE item = 'd'", []string{"1.2"}},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			require := require.New(t)
+			schema, iter, err := e.Query(ctx, tt.query)
+			require.NoError(err)
+			rows, err := sql.RowIterToRows(iter)
+			require.NoError(err)
+
+			var result []string
+			for _, row := range rows {
+				result = append(result, schema[0].Type.SQL(row[0]).ToString())
+			}
+			require.ElementsMatch(tt.expected, result)
+		})
+	}
+}
+
+func TestJSONColumn(t *testing.T) {
+	e := newEngine(t)
+	ctx := sql.NewEmptyContext()
+
+	_, iter, err := e.Query(ctx, "CREATE TABLE j(id INTEGER, doc JSON)")
+	require.NoError(t, err)
+	_, err = sql.RowIterToRows(iter)
+	require.NoError(t, err)
+
+	_, iter, err = e.Query(ctx, `INSERT INTO j (id, doc) VALUES
+		(1, '{"a":{"b":"x"},"n":2}'), (2, '[1, 2, 3]')`)
+	require.NoError(t, err)
+	_, err = sql.RowIterToRows(iter)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		query    string
+		expected []string
+	}{
+		{"SELECT doc->'$.n' FROM j WHERE id = 1", []string{"2"}},
+		{"SELECT doc->>'$.a.b' FROM j WHERE id = 1", []string{"x"}},
+		{"SELECT JSON_EXTRACT(doc, '$.n') FROM j WHERE id = 1", []string{"2"}},
+		{"SELECT JSON_EXTRACT(doc, '$[1]') FROM j WHERE id = 2", []string{"2"}},
+		{"SELECT JSON_KEYS(doc) FROM j WHERE id = 1", []string{`["a", "n"]`}},
+		{"SELECT JSON_LENGTH(doc) FROM j", []string{"2", "3"}},
+		{"SELECT id FROM j WHERE doc->>'$.a.b' = 'x'", []string{"1"}},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			require := require.New(t)
+			schema, iter, err := e.Query(ctx, tt.query)
+			require.NoError(err)
+			rows, err := sql.RowIterToRows(iter)
+			require.NoError(err)
+
+			var result []string
+			for _, row := range rows {
+				result = append(result, schema[0].Type.SQL(row[0]).ToString())
+			}
+			require.ElementsMatch(tt.expected, result)
+		})
+	}
+
+	_, iter, err = e.Query(ctx, "INSERT INTO j (id, doc) VALUES (3, 'not json')")
+	if err == nil {
+		_, err = sql.RowIterToRows(iter)
+	}
+	require.True(t, sql.ErrInvalidType.Is(err), "%v", err)
+}
+
+func TestCollation(t *testing.T) {
+	e := newEngine(t)
+	ctx := sql.NewEmptyContext()
+
+	_, iter, err := e.Query(ctx, "CREATE TABLE people(name VARCHAR(20), code VARCHAR(5) COLLATE utf8mb4_bin)")
+	require.NoError(t, err)
+	_, err = sql.RowIterToRows(iter)
+	require.NoError(t, err)
+
+	_, iter, err = e.Query(ctx, `INSERT INTO people (name, code) VALUES
+		('John', 'a'), ('john', 'A'), ('Jane', 'b'), ('bob', 'B')`)
+	require.NoError(t, err)
+	_, err = sql.RowIterToRows(iter)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		query    string
+		expected []sql.Row
+	}{
+		{
+			"SELECT name FROM people WHERE name = 'JOHN'",
+			[]sql.Row{{"John"}, {"john"}},
+		},
+		{
+			"SELECT code FROM people WHERE code = 'a'",
+			[]sql.Row{{"a"}},
+		},
+		{
+			"SELECT code FROM people WHERE code = 'a' COLLATE utf8mb4_general_ci",
+			[]sql.Row{{"a"}, {"A"}},
+		},
+		{
+			"SELECT name FROM people WHERE name COLLATE utf8mb4_bin = 'john'",
+			[]sql.Row{{"john"}},
+		},
+		{
+			"SELECT COUNT(*) FROM people GROUP BY name",
+			[]sql.Row{{int32(2)}, {int32(1)}, {int32(1)}},
+		},
+		{
+			"SELECT DISTINCT name FROM people",
+			[]sql.Row{{"John"}, {"Jane"}, {"bob"}},
+		},
+		{
+			"SELECT DISTINCT code FROM people",
+			[]sql.Row{{"a"}, {"A"}, {"b"}, {"B"}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			_, iter, err := e.Query(ctx, tt.query)
+			require.NoError(t, err)
+			rows, err := sql.RowIterToRows(iter)
+			require.NoError(t, err)
+			require.ElementsMatch(t, tt.expected, rows)
+		})
+	}
+
+	sorted := []struct {
+		query    string
+		expected []sql.Row
+	}{
+		{
+			"SELECT name FROM people WHERE name != 'JOHN' ORDER BY name",
+			[]sql.Row{{"bob"}, {"Jane"}},
+		},
+		{
+			"SELECT code FROM people ORDER BY code",
+			[]sql.Row{{"A"}, {"B"}, {"a"}, {"b"}},
+		},
+	}
+
+	for _, tt := range sorted {
+		t.Run(tt.query, func(t *testing.T) {
+			_, iter, err := e.Query(ctx, tt.query)
+			require.NoError(t, err)
+			rows, err := sql.RowIterToRows(iter)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, rows)
+		})
+	}
+}
+
 func newEngine(t *testing.T) *sqle.Engine {
 	require := require.New(t)
 
@@ -844,3 +1374,70 @@ func TestTracing(t *testing.T) {
 
 	require.Equal(expectedSpans, spanOperations)
 }
+
+func TestQueryMemoryLimit(t *testing.T) {
+	require := require.New(t)
+	e := newEngine(t)
+	e.QueryMemoryLimit = 100
+
+	_, iter, err := e.Query(sql.NewEmptyContext(), "SELECT i FROM mytable ORDER BY s")
+	require.NoError(err)
+
+	_, err = sql.RowIterToRows(iter)
+	require.Error(err)
+	require.True(sql.ErrMemoryLimitExceeded.Is(err))
+	require.Equal(uint64(0), e.Memory.Usage())
+
+	e.QueryMemoryLimit = 0
+	e.Memory = sql.NewMemoryTracker(100, nil)
+
+	_, iter, err = e.Query(sql.NewEmptyContext(), "SELECT i FROM mytable ORDER BY s")
+	require.NoError(err)
+
+	_, err = sql.RowIterToRows(iter)
+	require.Error(err)
+	require.True(sql.ErrMemoryLimitExceeded.Is(err))
+	require.Equal(uint64(0), e.Memory.Usage())
+
+	e.Memory = sql.NewMemoryTracker(0, nil)
+	_, iter, err = e.Query(sql.NewEmptyContext(), "SELECT i FROM mytable ORDER BY s")
+	require.NoError(err)
+
+	rows, err := sql.RowIterToRows(iter)
+	require.NoError(err)
+	require.Len(rows, 3)
+	require.Equal(uint64(0), e.Memory.Usage())
+}
+
+func TestMaxExecutionTime(t *testing.T) {
+	require := require.New(t)
+	e := newEngine(t)
+	e.MaxExecutionTime = time.Nanosecond
+
+	_, iter, err := e.Query(sql.NewEmptyContext(), "SELECT * FROM mytable ORDER BY i")
+	if err == nil {
+		_, err = sql.RowIterToRows(iter)
+	}
+	require.Error(err)
+	require.True(sql.ErrQueryTimeout.Is(err))
+
+	e.MaxExecutionTime = time.Hour
+	session := sql.NewBaseSession().(*sql.BaseSession)
+	session.SetMaxExecutionTime(time.Nanosecond)
+	ctx := sql.NewContext(context.TODO(), sql.WithSession(session))
+
+	_, iter, err = e.Query(ctx, "SELECT * FROM mytable ORDER BY i")
+	if err == nil {
+		_, err = sql.RowIterToRows(iter)
+	}
+	require.Error(err)
+	require.True(sql.ErrQueryTimeout.Is(err))
+
+	session.SetMaxExecutionTime(0)
+	_, iter, err = e.Query(ctx, "SELECT * FROM mytable ORDER BY i")
+	require.NoError(err)
+
+	rows, err := sql.RowIterToRows(iter)
+	require.NoError(err)
+	require.Len(rows, 3)
+}
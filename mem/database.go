@@ -1,13 +1,16 @@
 package mem // import "gopkg.in/src-d/go-mysql-server.v0/mem"
 
 import (
+	"sync/atomic"
+
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
 )
 
 // Database is an in-memory database.
 type Database struct {
-	name   string
-	tables map[string]sql.Table
+	name    string
+	tables  map[string]sql.Table
+	version uint64
 }
 
 // NewDatabase creates a new database with the given name.
@@ -28,9 +31,16 @@ func (d *Database) Tables() map[string]sql.Table {
 	return d.tables
 }
 
+// Version returns the current version of the tables of the database, which
+// changes every time a table is added.
+func (d *Database) Version() uint64 {
+	return atomic.LoadUint64(&d.version)
+}
+
 // AddTable adds a new table to the database.
 func (d *Database) AddTable(name string, t sql.Table) {
 	d.tables[name] = t
+	atomic.AddUint64(&d.version, 1)
 }
 
 // Create creates a table with the given name and schema
@@ -41,6 +51,7 @@ func (d *Database) Create(name string, schema sql.Schema) error {
 	}
 
 	d.tables[name] = NewTable(name, schema)
+	atomic.AddUint64(&d.version, 1)
 
 	return nil
 }
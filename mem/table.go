@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"sync"
 
 	errors "gopkg.in/src-d/go-errors.v1"
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
@@ -15,6 +16,9 @@ type Table struct {
 	name   string
 	schema sql.Schema
 	data   []sql.Row
+
+	mu    *sync.Mutex
+	stats *sql.TableStatistics
 }
 
 // NewTable creates a new Table with the given name and schema.
@@ -22,6 +26,7 @@ func NewTable(name string, schema sql.Schema) *Table {
 	return &Table{
 		name:   name,
 		schema: schema,
+		mu:     new(sync.Mutex),
 	}
 }
 
@@ -47,7 +52,7 @@ func (t *Table) Children() []sql.Node {
 
 // RowIter implements the Node interface.
 func (t *Table) RowIter(ctx *sql.Context) (sql.RowIter, error) {
-	return sql.RowsToRowIter(t.data...), nil
+	return &tableIter{ctx: ctx, rows: t.data}, nil
 }
 
 // TransformUp implements the Transformer interface.
@@ -66,14 +71,31 @@ func (t *Table) Insert(row sql.Row) error {
 		return sql.ErrUnexpectedRowLength.New(len(t.schema), len(row))
 	}
 
+	// Values are stored converted to the type of their column, so they
+	// are returned the same way no matter how they were inserted.
+	var converted = make(sql.Row, len(row))
 	for idx, value := range row {
 		c := t.schema[idx]
 		if !c.Check(value) {
 			return sql.ErrInvalidType.New(value)
 		}
+
+		if value == nil {
+			continue
+		}
+
+		v, err := c.Type.Convert(value)
+		if err != nil {
+			return err
+		}
+		converted[idx] = v
 	}
 
-	t.data = append(t.data, row.Copy())
+	t.mu.Lock()
+	t.data = append(t.data, converted)
+	t.stats = nil
+	t.mu.Unlock()
+
 	return nil
 }
 
@@ -137,7 +159,75 @@ func (t *Table) WithProjectFiltersAndIndex(
 	columns, filters []sql.Expression,
 	index sql.IndexValueIter,
 ) (sql.RowIter, error) {
-	return &indexIter{t.data, index}, nil
+	return &indexIter{ctx, t.data, index}, nil
+}
+
+var _ sql.StatisticsTable = (*Table)(nil)
+
+// Statistics implements the StatisticsTable interface. They are computed
+// from the rows the first time they are needed after a row is inserted, so
+// they are always up to date. The returned statistics must not be modified.
+func (t *Table) Statistics(ctx *sql.Context) (*sql.TableStatistics, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stats == nil {
+		stats, err := sql.ComputeStatistics(t.schema, sql.RowsToRowIter(t.data...))
+		if err != nil {
+			return nil, err
+		}
+		t.stats = stats
+	}
+
+	return t.stats, nil
+}
+
+type tableIter struct {
+	ctx  *sql.Context
+	rows []sql.Row
+	idx  int
+}
+
+func (i *tableIter) Next() (sql.Row, error) {
+	if i.idx >= len(i.rows) {
+		return nil, io.EOF
+	}
+
+	if err := i.ctx.Interrupted(); err != nil {
+		return nil, err
+	}
+
+	row := i.rows[i.idx]
+	i.idx++
+	return row.Copy(), nil
+}
+
+func (i *tableIter) NextBatch(max int) ([]sql.Row, error) {
+	if i.idx >= len(i.rows) {
+		return nil, io.EOF
+	}
+
+	if err := i.ctx.Interrupted(); err != nil {
+		return nil, err
+	}
+
+	end := i.idx + max
+	if end > len(i.rows) {
+		end = len(i.rows)
+	}
+
+	rows := make([]sql.Row, end-i.idx)
+	for j, row := range i.rows[i.idx:end] {
+		rows[j] = row.Copy()
+	}
+
+	i.idx = end
+	return rows, nil
+}
+
+func (i *tableIter) Close() error {
+	i.rows = nil
+	return nil
 }
 
 type keyValueIter struct {
@@ -172,11 +262,16 @@ func (i *keyValueIter) Close() error {
 }
 
 type indexIter struct {
+	ctx   *sql.Context
 	data  []sql.Row
 	index sql.IndexValueIter
 }
 
 func (i *indexIter) Next() (sql.Row, error) {
+	if err := i.ctx.Interrupted(); err != nil {
+		return nil, err
+	}
+
 	data, err := i.index.Next()
 	if err != nil {
 		return nil, err
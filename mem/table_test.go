@@ -61,6 +61,55 @@ func TestTable_Insert_RowIter(t *testing.T) {
 	require.Nil(s.CheckRow(rows[1]))
 }
 
+func TestTable_InsertConverted(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	s := sql.Schema{
+		{"col1", sql.MustEnum("foo", "bar"), nil, true, ""},
+		{"col2", sql.MustChar(5), nil, true, ""},
+		{"col3", sql.Int32, nil, true, ""},
+	}
+
+	table := NewTable("test", s)
+	require.NoError(table.Insert(sql.NewRow(int64(2), "ab   ", "3")))
+	require.NoError(table.Insert(sql.NewRow("foo", nil, nil)))
+
+	rows, err := sql.NodeToRows(ctx, table)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		sql.NewRow("bar", "ab", int32(3)),
+		sql.NewRow("foo", nil, nil),
+	}, rows)
+
+	require.Error(table.Insert(sql.NewRow("baz", nil, nil)))
+}
+
+func TestTable_NextBatch(t *testing.T) {
+	require := require.New(t)
+
+	table := NewTable("test", sql.Schema{{Name: "col1", Type: sql.Int64}})
+	for i := 0; i < 5; i++ {
+		require.NoError(table.Insert(sql.NewRow(int64(i))))
+	}
+
+	iter, err := table.RowIter(sql.NewEmptyContext())
+	require.NoError(err)
+
+	b := iter.(sql.BatchIter)
+	batch, err := b.NextBatch(3)
+	require.NoError(err)
+	require.Equal([]sql.Row{{int64(0)}, {int64(1)}, {int64(2)}}, batch)
+
+	batch, err = b.NextBatch(3)
+	require.NoError(err)
+	require.Equal([]sql.Row{{int64(3)}, {int64(4)}}, batch)
+
+	_, err = b.NextBatch(3)
+	require.Equal(io.EOF, err)
+	require.NoError(b.Close())
+}
+
 func TestTableIndexKeyValueIter(t *testing.T) {
 	require := require.New(t)
 
@@ -155,3 +204,40 @@ func (i *index) Close() error {
 	i.pos = len(i.keys)
 	return nil
 }
+
+func TestTableStatistics(t *testing.T) {
+	require := require.New(t)
+
+	table := NewTable("test", sql.Schema{
+		{Name: "i", Type: sql.Int64, Nullable: true, Source: "test"},
+		{Name: "s", Type: sql.Text, Source: "test"},
+	})
+
+	for _, row := range []sql.Row{
+		{int64(1), "a"},
+		{int64(2), "a"},
+		{nil, "b"},
+		{int64(1), "c"},
+	} {
+		require.NoError(table.Insert(row))
+	}
+
+	stats, err := table.Statistics(sql.NewEmptyContext())
+	require.NoError(err)
+	require.Equal(uint64(4), stats.RowCount)
+	require.Equal(uint64(2), stats.Column("i").DistinctCount)
+	require.Equal(uint64(1), stats.Column("i").NullCount)
+	require.Equal(uint64(3), stats.Column("s").DistinctCount)
+	require.Equal(uint64(0), stats.Column("s").NullCount)
+
+	// Statistics are computed again only after rows are inserted.
+	cached, err := table.Statistics(sql.NewEmptyContext())
+	require.NoError(err)
+	require.True(stats == cached)
+
+	require.NoError(table.Insert(sql.NewRow(int64(3), "d")))
+	stats, err = table.Statistics(sql.NewEmptyContext())
+	require.NoError(err)
+	require.Equal(uint64(5), stats.RowCount)
+	require.Equal(uint64(3), stats.Column("i").DistinctCount)
+}
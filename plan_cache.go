@@ -0,0 +1,274 @@
+package sqle
+
+import (
+	"bytes"
+	"container/list"
+	"reflect"
+	"strings"
+	"sync"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+)
+
+// DefaultPlanCacheSize is the number of analyzed plans kept in the plan cache
+// of the engines created with New.
+const DefaultPlanCacheSize = 256
+
+// PlanCache is a LRU cache of analyzed plans keyed by query text and current
+// database. All the plans are discarded as soon as the version of the catalog
+// changes.
+//
+// Only plans that are read-only and don't use indexes are cached: the
+// analyzer retains the indexes used by a plan until its execution finishes,
+// so those plans can't be executed more than once.
+//
+// Plans are copied when they are put in the cache and every time they are
+// taken from it, so each execution has its own nodes and expressions, which
+// may keep state of the execution. The tables of the plans are not copied,
+// so they must be safe for concurrent use.
+type PlanCache struct {
+	mu      sync.Mutex
+	size    int
+	version uint64
+	entries map[planCacheKey]*list.Element
+	lru     *list.List
+}
+
+type planCacheKey struct {
+	db, query string
+}
+
+type planCacheEntry struct {
+	key  planCacheKey
+	node sql.Node
+}
+
+// NewPlanCache creates a new PlanCache that can hold at most size plans.
+func NewPlanCache(size int) *PlanCache {
+	return &PlanCache{
+		size:    size,
+		entries: make(map[planCacheKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Get returns a copy of the plan cached for the given query and database, if
+// any, as long as it was cached with the given catalog version.
+func (c *PlanCache) Get(version uint64, db, query string) (sql.Node, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	c.checkVersion(version)
+	e, ok := c.entries[planCacheKey{db, normalizeQuery(query)}]
+	if !ok {
+		c.mu.Unlock()
+		return nil, false
+	}
+
+	c.lru.MoveToFront(e)
+	cached := e.Value.(*planCacheEntry).node
+	c.mu.Unlock()
+
+	node, err := copyPlan(cached)
+	if err != nil {
+		return nil, false
+	}
+
+	return node, true
+}
+
+// Put caches the plan of the given query and database, computed with the
+// given catalog version. Plans that can't be cached are ignored.
+func (c *PlanCache) Put(version uint64, db, query string, node sql.Node) {
+	if c == nil || c.size <= 0 || !isCacheable(node) {
+		return
+	}
+
+	node, err := copyPlan(node)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checkVersion(version)
+	if version != c.version {
+		// The plan was computed with an older version of the catalog.
+		return
+	}
+
+	key := planCacheKey{db, normalizeQuery(query)}
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*planCacheEntry).node = node
+		c.lru.MoveToFront(e)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(&planCacheEntry{key, node})
+	for c.lru.Len() > c.size {
+		e := c.lru.Back()
+		c.lru.Remove(e)
+		delete(c.entries, e.Value.(*planCacheEntry).key)
+	}
+}
+
+// Len returns the number of cached plans.
+func (c *PlanCache) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Purge discards all the cached plans.
+func (c *PlanCache) Purge() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.purge()
+	c.mu.Unlock()
+}
+
+// checkVersion discards all cached plans if the given catalog version is
+// newer than the one of the cache. It must be called with the lock held.
+func (c *PlanCache) checkVersion(version uint64) {
+	if version > c.version {
+		c.purge()
+		c.version = version
+	}
+}
+
+func (c *PlanCache) purge() {
+	c.entries = make(map[planCacheKey]*list.Element)
+	c.lru.Init()
+}
+
+func isCacheable(node sql.Node) bool {
+	var cacheable = true
+	plan.Inspect(node, func(node sql.Node) bool {
+		switch node.(type) {
+		case *plan.IndexableTable, *plan.IndexJoin, *plan.InsertInto,
+			*plan.CreateTable, *plan.CreateIndex, *plan.DropIndex,
+			*plan.AnalyzeTable:
+			cacheable = false
+		}
+		return cacheable
+	})
+	return cacheable
+}
+
+// copyPlan returns a copy of the given plan with new nodes and expressions.
+func copyPlan(node sql.Node) (sql.Node, error) {
+	return node.TransformExpressionsUp(copyExpression)
+}
+
+// copyExpression returns a copy of the given expression, which shares with
+// it the values its fields point to, such as its children. Expressions that
+// are not pointers are already copied when they are assigned.
+func copyExpression(e sql.Expression) (sql.Expression, error) {
+	v := reflect.ValueOf(e)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return e, nil
+	}
+
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+	return c.Interface().(sql.Expression), nil
+}
+
+// modifiesCatalog returns whether the execution of the given plan changes the
+// catalog.
+func modifiesCatalog(node sql.Node) bool {
+	var modifies bool
+	plan.Inspect(node, func(node sql.Node) bool {
+		switch node.(type) {
+		case *plan.CreateTable, *plan.CreateIndex, *plan.DropIndex:
+			modifies = true
+		}
+		return !modifies
+	})
+	return modifies
+}
+
+// normalizeQuery removes the leading and trailing spaces and semicolons of
+// the given query and replaces every run of whitespace between tokens with a
+// single space, so irrelevant formatting changes don't prevent the cache from
+// being used. Quoted strings and comments are kept as they are, including the
+// line break that ends a comment.
+func normalizeQuery(query string) string {
+	query = strings.TrimRightFunc(strings.TrimLeftFunc(query, isQuerySpace), func(r rune) bool {
+		return r == ';' || isQuerySpace(r)
+	})
+
+	var buf bytes.Buffer
+	var space bool
+	for i := 0; i < len(query); {
+		if isQuerySpace(rune(query[i])) {
+			space = true
+			i++
+			continue
+		}
+
+		if space {
+			buf.WriteByte(' ')
+			space = false
+		}
+
+		end := verbatimEnd(query, i)
+		buf.WriteString(query[i:end])
+		i = end
+	}
+
+	return buf.String()
+}
+
+// isQuerySpace returns whether r is one of the whitespace characters that
+// separate the tokens of a query.
+func isQuerySpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+// verbatimEnd returns the end of the quoted string or comment that starts at
+// the position i of the given query, or the position of the next byte if
+// there is none there.
+func verbatimEnd(query string, i int) int {
+	rest := query[i:]
+	switch {
+	case rest[0] == '\'' || rest[0] == '"' || rest[0] == '`':
+		quote := rest[0]
+		for j := 1; j < len(rest); j++ {
+			switch rest[j] {
+			case '\\':
+				// Backslashes only escape characters in strings.
+				if quote != '`' {
+					j++
+				}
+			case quote:
+				return i + j + 1
+			}
+		}
+	case rest[0] == '#' || strings.HasPrefix(rest, "--"):
+		// A "--" not followed by a space is not a comment, but keeping it
+		// and the rest of its line as they are is harmless.
+		if j := strings.IndexByte(rest, '\n'); j >= 0 {
+			return i + j + 1
+		}
+	case strings.HasPrefix(rest, "/*"):
+		if j := strings.Index(rest[2:], "*/"); j >= 0 {
+			return i + j + 4
+		}
+	default:
+		return i + 1
+	}
+
+	return len(query)
+}
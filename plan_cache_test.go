@@ -0,0 +1,107 @@
+package sqle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/mem"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected string
+	}{
+		{"SELECT 1", "SELECT 1"},
+		{"  SELECT\n\t1  ;  ", "SELECT 1"},
+		{"SELECT  a,\n  b FROM t", "SELECT a, b FROM t"},
+		{"SELECT 'a  b' FROM t", "SELECT 'a  b' FROM t"},
+		{`SELECT "a \"  b"  FROM t`, `SELECT "a \"  b" FROM t`},
+		{"SELECT `a  b`  FROM t", "SELECT `a  b` FROM t"},
+		{"SELECT `a\\`  FROM t", "SELECT `a\\` FROM t"},
+		{"SELECT 'a\\'  b'  FROM t", "SELECT 'a\\'  b' FROM t"},
+		{"SELECT a -- x\nFROM  t", "SELECT a -- x\nFROM t"},
+		{"SELECT a # x\n\tFROM t", "SELECT a # x\n FROM t"},
+		{"SELECT a -- x FROM  t", "SELECT a -- x FROM  t"},
+		{"SELECT a /* x\n  y */  FROM t", "SELECT a /* x\n  y */ FROM t"},
+		{"SELECT 1 /* don't */  ,  'a  b'", "SELECT 1 /* don't */ , 'a  b'"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			require.Equal(t, tt.expected, normalizeQuery(tt.query))
+		})
+	}
+}
+
+func TestPlanCache(t *testing.T) {
+	require := require.New(t)
+
+	node := mem.NewTable("foo", nil)
+	cache := NewPlanCache(2)
+
+	cache.Put(1, "db", "SELECT 1", node)
+	cache.Put(1, "db", "SELECT 2", node)
+
+	n, ok := cache.Get(1, "db", "SELECT  1;")
+	require.True(ok)
+	require.Equal(node, n)
+
+	_, ok = cache.Get(1, "otherdb", "SELECT 1")
+	require.False(ok)
+
+	// SELECT 2 is the least recently used plan.
+	cache.Put(1, "db", "SELECT 3", node)
+	require.Equal(2, cache.Len())
+	_, ok = cache.Get(1, "db", "SELECT 2")
+	require.False(ok)
+	_, ok = cache.Get(1, "db", "SELECT 1")
+	require.True(ok)
+
+	// Plans computed with an old version of the catalog are not cached.
+	cache.Put(0, "db", "SELECT 4", node)
+	_, ok = cache.Get(1, "db", "SELECT 4")
+	require.False(ok)
+
+	_, ok = cache.Get(2, "db", "SELECT 1")
+	require.False(ok)
+	require.Equal(0, cache.Len())
+
+	cache.Put(2, "db", "INSERT INTO foo VALUES (1)", plan.NewInsertInto(node, node, nil))
+	require.Equal(0, cache.Len())
+}
+
+func TestPlanCacheCopies(t *testing.T) {
+	require := require.New(t)
+
+	table := mem.NewTable("foo", sql.Schema{{Name: "a", Type: sql.Int64, Source: "foo"}})
+	cond := expression.NewEquals(
+		expression.NewGetFieldWithTable(0, sql.Int64, "foo", "a", false),
+		expression.NewLiteral(int64(1), sql.Int64),
+	)
+	node := plan.NewFilter(cond, table)
+
+	cache := NewPlanCache(1)
+	cache.Put(1, "db", "SELECT a FROM foo WHERE a = 1", node)
+
+	// Every execution gets its own nodes and expressions, but the tables
+	// are shared.
+	n1, ok := cache.Get(1, "db", "SELECT a FROM foo WHERE a = 1")
+	require.True(ok)
+	n2, ok := cache.Get(1, "db", "SELECT a FROM foo WHERE a = 1")
+	require.True(ok)
+
+	require.Equal(node, n1)
+	require.Equal(node, n2)
+	require.False(n1 == sql.Node(node))
+	require.False(n1 == n2)
+	require.False(n1.(*plan.Filter).Expression == n2.(*plan.Filter).Expression)
+	left1 := n1.(*plan.Filter).Expression.(*expression.Equals).Left()
+	left2 := n2.(*plan.Filter).Expression.(*expression.Equals).Left()
+	require.False(left1 == left2)
+	require.False(left1 == cond.Left())
+	require.True(n1.(*plan.Filter).Child == n2.(*plan.Filter).Child)
+}
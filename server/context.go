@@ -2,10 +2,12 @@ package server
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 
 	opentracing "github.com/opentracing/opentracing-go"
-	uuid "github.com/satori/go.uuid"
+	errors "gopkg.in/src-d/go-errors.v1"
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
 	"gopkg.in/src-d/go-vitess.v0/mysql"
 )
@@ -22,27 +24,90 @@ func DefaultSessionBuilder(_ *mysql.Conn) sql.Session {
 	return sql.NewBaseSession()
 }
 
+var errQueryNotFound = errors.NewKind("No query running in connection: %d")
+
+// Process states reported in the process list.
+const (
+	// ProcessStateExecuting is the state of a query that is being parsed,
+	// analyzed or is computing its first results.
+	ProcessStateExecuting = "executing"
+	// ProcessStateSendingData is the state of a query that is sending its
+	// results to the client.
+	ProcessStateSendingData = "sending data"
+)
+
+// Process is a query running in the server. A connection runs at most one
+// query at a time, so processes are identified by the id of their
+// connection, as MySQL does. Only State and Rows may change while the
+// process is running, so they must be accessed through their methods.
+type Process struct {
+	// Connection is the id of the connection running the query.
+	Connection uint32
+	// User is the user of the connection.
+	User string
+	// Database is the default database of the connection.
+	Database string
+	// Query is the text of the query.
+	Query string
+	// StartedAt is the time at which the query started.
+	StartedAt time.Time
+	// Memory is the memory tracker of the query.
+	Memory *sql.MemoryTracker
+
+	mu     sync.Mutex
+	state  string
+	rows   uint64
+	cancel context.CancelFunc
+}
+
+// State returns the current state of the process.
+func (p *Process) State() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state
+}
+
+// SetState changes the current state of the process.
+func (p *Process) SetState(state string) {
+	p.mu.Lock()
+	p.state = state
+	p.mu.Unlock()
+}
+
+// Rows returns the number of rows produced by the process so far.
+func (p *Process) Rows() uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.rows
+}
+
+// AddRows adds n to the number of rows produced by the process.
+func (p *Process) AddRows(n uint64) {
+	p.mu.Lock()
+	p.rows += n
+	p.mu.Unlock()
+}
+
 // SessionManager is in charge of creating new sessions for the given
-// connections and keep track of which sessions are in each connection, so
-// they can be cancelled is the connection is closed.
+// connections and keep track of which sessions are in each connection and
+// the queries running in them, so they can be cancelled is the connection is
+// closed or the query is killed.
 type SessionManager struct {
-	tracer          opentracing.Tracer
-	mu              *sync.Mutex
-	builder         SessionBuilder
-	sessions        map[uint32]sql.Session
-	sessionContexts map[uint32][]uuid.UUID
-	contexts        map[uuid.UUID]context.CancelFunc
+	tracer    opentracing.Tracer
+	mu        *sync.Mutex
+	builder   SessionBuilder
+	sessions  map[uint32]sql.Session
+	processes map[uint32]*Process
 }
 
 // NewSessionManager creates a SessionManager with the given ContextBuilder.
 func NewSessionManager(builder SessionBuilder, tracer opentracing.Tracer) *SessionManager {
 	return &SessionManager{
-		tracer:          tracer,
-		mu:              new(sync.Mutex),
-		builder:         builder,
-		sessions:        make(map[uint32]sql.Session),
-		sessionContexts: make(map[uint32][]uuid.UUID),
-		contexts:        make(map[uuid.UUID]context.CancelFunc),
+		tracer:    tracer,
+		mu:        new(sync.Mutex),
+		builder:   builder,
+		sessions:  make(map[uint32]sql.Session),
+		processes: make(map[uint32]*Process),
 	}
 }
 
@@ -53,37 +118,80 @@ func (s *SessionManager) NewSession(conn *mysql.Conn) {
 	s.mu.Unlock()
 }
 
-// NewContext creates a new context for the session at the given conn.
-func (s *SessionManager) NewContext(conn *mysql.Conn) (*sql.Context, DoneFunc, error) {
+// NewContext creates a new context for the session at the given conn to run
+// the given query. The query is registered in the process list until the
+// returned DoneFunc is called.
+func (s *SessionManager) NewContext(
+	conn *mysql.Conn,
+	query string,
+	opts ...sql.ContextOption,
+) (*sql.Context, *Process, DoneFunc) {
 	ctx, cancel := context.WithCancel(context.Background())
+
 	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	sess := s.sessions[conn.ConnectionID]
-	s.mu.Unlock()
-	context := sql.NewContext(ctx, sql.WithSession(sess), sql.WithTracer(s.tracer))
-	id, err := uuid.NewV4()
-	if err != nil {
+	opts = append([]sql.ContextOption{
+		sql.WithSession(sess),
+		sql.WithTracer(s.tracer),
+	}, opts...)
+	context := sql.NewContext(ctx, opts...)
+
+	p := &Process{
+		Connection: conn.ConnectionID,
+		User:       conn.User,
+		Database:   conn.SchemaName,
+		Query:      query,
+		StartedAt:  time.Now(),
+		Memory:     context.Memory(),
+		state:      ProcessStateExecuting,
+		cancel:     cancel,
+	}
+	s.processes[p.Connection] = p
+
+	return context, p, func() {
+		s.mu.Lock()
+		if s.processes[p.Connection] == p {
+			delete(s.processes, p.Connection)
+		}
+		s.mu.Unlock()
 		cancel()
-		return nil, nil, err
 	}
+}
 
+// Processes returns the queries currently running, sorted by the id of
+// their connection.
+func (s *SessionManager) Processes() []*Process {
 	s.mu.Lock()
-	s.sessionContexts[conn.ConnectionID] = append(s.sessionContexts[conn.ConnectionID], id)
-	s.contexts[id] = cancel
-	s.mu.Unlock()
+	defer s.mu.Unlock()
 
-	return context, func() {
-		s.mu.Lock()
-		defer s.mu.Unlock()
-
-		delete(s.contexts, id)
-		ids := s.sessionContexts[conn.ConnectionID]
-		for i, sessID := range ids {
-			if sessID == id {
-				s.sessionContexts[conn.ConnectionID] = append(ids[:i], ids[i+1:]...)
-				break
-			}
-		}
-	}, nil
+	var result = make([]*Process, 0, len(s.processes))
+	for _, p := range s.processes {
+		result = append(result, p)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Connection < result[j].Connection
+	})
+
+	return result
+}
+
+// KillQuery cancels the query running in the connection with the given id.
+// The connection is left open.
+func (s *SessionManager) KillQuery(conn uint32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.processes[conn]
+	if !ok {
+		return errQueryNotFound.New(conn)
+	}
+
+	p.cancel()
+	delete(s.processes, conn)
+	return nil
 }
 
 // CloseConn closes the connection in the session manager and all its
@@ -92,9 +200,8 @@ func (s *SessionManager) CloseConn(conn *mysql.Conn) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	for _, id := range s.sessionContexts[conn.ConnectionID] {
-		s.contexts[id]()
-		delete(s.contexts, id)
+	if p, ok := s.processes[conn.ConnectionID]; ok {
+		p.cancel()
+		delete(s.processes, conn.ConnectionID)
 	}
-	delete(s.sessionContexts, conn.ConnectionID)
 }
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	errors "gopkg.in/src-d/go-errors.v1"
 	"gopkg.in/src-d/go-mysql-server.v0"
@@ -19,7 +20,9 @@ import (
 
 var regKillCmd = regexp.MustCompile(`^kill (?:(query|connection) )?(\d+)$`)
 
-var errConnectionNotFound = errors.NewKind("Connection not found: %c")
+var regShowProcessList = regexp.MustCompile(`^show\s+(full\s+)?processlist;?$`)
+
+var errConnectionNotFound = errors.NewKind("Connection not found: %d")
 
 // TODO parametrize
 const rowsBatch = 100
@@ -70,14 +73,23 @@ func (h *Handler) ComQuery(
 	query string,
 	callback func(*sqltypes.Result) error,
 ) error {
-	ctx, done, err := h.sm.NewContext(c)
+	ctx, proc, done := h.sm.NewContext(
+		c,
+		query,
+		sql.WithMemoryTracker(h.e.NewQueryMemoryTracker()),
+	)
+	defer done()
+
+	handled, err := h.handleKill(query)
 	if err != nil {
 		return err
 	}
 
-	defer done()
+	if handled {
+		return nil
+	}
 
-	handled, err := h.handleKill(query)
+	handled, err = h.handleShowProcessList(query, callback)
 	if err != nil {
 		return err
 	}
@@ -86,11 +98,16 @@ func (h *Handler) ComQuery(
 		return nil
 	}
 
-	schema, rows, err := h.e.Query(ctx, query)
+	schema, iter, err := h.e.Query(ctx, query)
 	if err != nil {
 		return err
 	}
 
+	rows := sql.ToBatchIter(iter)
+	defer rows.Close()
+
+	proc.SetState(ProcessStateSendingData)
+
 	var r *sqltypes.Result
 	var proccesedAtLeastOneBatch bool
 	for {
@@ -109,7 +126,7 @@ func (h *Handler) ComQuery(
 			continue
 		}
 
-		row, err := rows.Next()
+		batch, err := rows.NextBatch(rowsBatch - int(r.RowsAffected))
 		if err != nil {
 			if err == io.EOF {
 				break
@@ -118,8 +135,11 @@ func (h *Handler) ComQuery(
 			return err
 		}
 
-		r.Rows = append(r.Rows, rowToSQL(schema, row))
-		r.RowsAffected++
+		for _, row := range batch {
+			r.Rows = append(r.Rows, rowToSQL(schema, row))
+		}
+		r.RowsAffected += uint64(len(batch))
+		proc.AddRows(uint64(len(batch)))
 	}
 
 	// Even if r.RowsAffected = 0, the callback must be
@@ -140,13 +160,22 @@ func (h *Handler) handleKill(query string) (bool, error) {
 		return false, nil
 	}
 
-	id, err := strconv.Atoi(s[2])
+	id, err := strconv.ParseUint(s[2], 10, 32)
 	if err != nil {
 		return false, err
 	}
 
 	logrus.Infof("handleKill: id %v", id)
 
+	// KILL CONNECTION and KILL should close the connection. KILL QUERY only
+	// cancels the query running in the connection. In both cases the id is
+	// the connection id, which is the one shown in the process list.
+	//
+	// https://dev.mysql.com/doc/refman/5.7/en/kill.html
+	if s[1] == "query" {
+		return true, h.sm.KillQuery(uint32(id))
+	}
+
 	h.mu.Lock()
 	c, ok := h.c[uint32(id)]
 	h.mu.Unlock()
@@ -155,21 +184,79 @@ func (h *Handler) handleKill(query string) (bool, error) {
 	}
 
 	h.sm.CloseConn(c)
+	c.Close()
 
-	// KILL CONNECTION and KILL should close the connection. KILL QUERY only
-	// cancels the query.
-	//
-	// https://dev.mysql.com/doc/refman/5.7/en/kill.html
+	h.mu.Lock()
+	delete(h.c, uint32(id))
+	h.mu.Unlock()
+
+	return true, nil
+}
 
-	if s[1] != "query" {
-		c.Close()
+// processListInfoLength is the maximum length of the query shown in the
+// process list unless SHOW FULL PROCESSLIST is used.
+const processListInfoLength = 100
 
-		h.mu.Lock()
-		delete(h.c, uint32(id))
-		h.mu.Unlock()
+// truncateQuery returns the first n characters of the given query.
+func truncateQuery(query string, n int) string {
+	var chars int
+	for i := range query {
+		if chars == n {
+			return query[:i]
+		}
+		chars++
 	}
+	return query
+}
 
-	return true, nil
+var processListSchema = sql.Schema{
+	{Name: "Id", Type: sql.Uint32},
+	{Name: "User", Type: sql.Text},
+	{Name: "db", Type: sql.Text},
+	{Name: "Command", Type: sql.Text},
+	{Name: "Time", Type: sql.Int64},
+	{Name: "State", Type: sql.Text},
+	{Name: "Info", Type: sql.Text},
+	{Name: "Rows", Type: sql.Uint64},
+	{Name: "Memory", Type: sql.Uint64},
+}
+
+func (h *Handler) handleShowProcessList(
+	query string,
+	callback func(*sqltypes.Result) error,
+) (bool, error) {
+	q := strings.ToLower(strings.TrimSpace(query))
+	s := regShowProcessList.FindStringSubmatch(q)
+	if s == nil {
+		return false, nil
+	}
+
+	full := s[1] != ""
+	now := time.Now()
+	r := &sqltypes.Result{Fields: schemaToFields(processListSchema)}
+	for _, p := range h.sm.Processes() {
+		info := p.Query
+		if !full {
+			info = truncateQuery(info, processListInfoLength)
+		}
+
+		row := sql.NewRow(
+			p.Connection,
+			p.User,
+			p.Database,
+			"Query",
+			int64(now.Sub(p.StartedAt)/time.Second),
+			p.State(),
+			info,
+			p.Rows(),
+			p.Memory.Usage(),
+		)
+
+		r.Rows = append(r.Rows, rowToSQL(processListSchema, row))
+		r.RowsAffected++
+	}
+
+	return true, callback(r)
 }
 
 func rowToSQL(s sql.Schema, row sql.Row) []sqltypes.Value {
@@ -181,14 +268,58 @@ func rowToSQL(s sql.Schema, row sql.Row) []sqltypes.Value {
 	return o
 }
 
+// schemaToFields returns the fields describing the columns of a result set.
+// The source of a column is the name the query gives to its table, which may
+// be an alias of a table of any database, so neither the original table nor
+// its database are known and they are left empty.
 func schemaToFields(s sql.Schema) []*query.Field {
 	fields := make([]*query.Field, len(s))
 	for i, c := range s {
 		fields[i] = &query.Field{
-			Name: c.Name,
-			Type: c.Type.Type(),
+			Name:         c.Name,
+			Type:         c.Type.Type(),
+			Table:        c.Source,
+			OrgName:      c.Name,
+			ColumnLength: sql.ColumnLength(c.Type),
+			Charset:      uint32(sql.CollationOf(c.Type)),
+			Decimals:     uint32(sql.Decimals(c.Type)),
+			Flags:        columnFlags(c),
 		}
 	}
 
 	return fields
 }
+
+// columnFlags returns the flags of the given column.
+func columnFlags(c *sql.Column) uint32 {
+	var flags query.MySqlFlag
+	if !c.Nullable {
+		flags |= query.MySqlFlag_NOT_NULL_FLAG
+	}
+
+	t := c.Type
+	switch sql.CollationOf(t) {
+	case sql.CollationBinary, sql.CollationUtf8mb4Bin:
+		if t != sql.Null {
+			flags |= query.MySqlFlag_BINARY_FLAG
+		}
+	}
+
+	switch {
+	case sql.IsNumber(t):
+		flags |= query.MySqlFlag_NUM_FLAG
+		if sql.IsUnsigned(t) {
+			flags |= query.MySqlFlag_UNSIGNED_FLAG
+		}
+	case sql.IsText(t) && !sql.IsChar(t):
+		flags |= query.MySqlFlag_BLOB_FLAG
+	case t == sql.Timestamp:
+		flags |= query.MySqlFlag_TIMESTAMP_FLAG
+	case sql.IsEnum(t):
+		flags |= query.MySqlFlag_ENUM_FLAG
+	case sql.IsSet(t):
+		flags |= query.MySqlFlag_SET_FLAG
+	}
+
+	return uint32(flags)
+}
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"gopkg.in/src-d/go-mysql-server.v0"
@@ -8,6 +10,7 @@ import (
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
 	"gopkg.in/src-d/go-vitess.v0/mysql"
 	"gopkg.in/src-d/go-vitess.v0/sqltypes"
+	"gopkg.in/src-d/go-vitess.v0/vt/proto/query"
 
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/stretchr/testify/require"
@@ -151,19 +154,189 @@ func TestHandlerKill(t *testing.T) {
 	require.True(ok)
 	require.Equal(conn1, c)
 
+	ctx, proc, done := handler.sm.NewContext(conn1, "SELECT * FROM test")
+	defer done()
+
 	conn2 := newConn(2)
 
-	err := handler.ComQuery(conn2, "KILL QUERY 1", func(res *sqltypes.Result) error {
+	err := handler.ComQuery(conn2, fmt.Sprintf("KILL QUERY %d", proc.Connection), func(res *sqltypes.Result) error {
 		return nil
 	})
 
 	require.NoError(err)
 
+	select {
+	case <-ctx.Done():
+	default:
+		require.FailNow("query context was not cancelled")
+	}
+
 	require.Len(handler.c, 1)
 	c, ok = handler.c[1]
 	require.True(ok)
 	require.Equal(conn1, c)
 
+	err = handler.ComQuery(conn2, fmt.Sprintf("KILL QUERY %d", proc.Connection), func(res *sqltypes.Result) error {
+		return nil
+	})
+	require.Error(err)
+	require.True(errQueryNotFound.Is(err))
+
 	// Cannot test KILL CONNECTION as the connection can not be mocked. Calling
 	// mysql.Conn.Close panics.
 }
+
+func TestHandlerShowProcessList(t *testing.T) {
+	require := require.New(t)
+	e := setupMemDB(require)
+
+	handler := NewHandler(e, NewSessionManager(DefaultSessionBuilder, opentracing.NoopTracer{}))
+
+	conn1 := &mysql.Conn{ConnectionID: 1, User: "root", SchemaName: "test"}
+	handler.NewConnection(conn1)
+	longQuery := "SELECT c1 FROM test WHERE " + strings.Repeat("c1 > 0 AND ", 20) + "c1 < 5"
+	_, proc, done := handler.sm.NewContext(conn1, longQuery)
+	defer done()
+	proc.AddRows(5)
+
+	conn2 := newConn(2)
+	handler.NewConnection(conn2)
+
+	var result *sqltypes.Result
+	err := handler.ComQuery(conn2, "SHOW PROCESSLIST", func(res *sqltypes.Result) error {
+		result = res
+		return nil
+	})
+	require.NoError(err)
+
+	require.Len(result.Fields, len(processListSchema))
+	// The SHOW PROCESSLIST query itself is also in the list.
+	require.Len(result.Rows, 2)
+
+	row := result.Rows[0]
+	require.Equal("1", row[0].ToString())
+	require.Equal("root", row[1].ToString())
+	require.Equal("test", row[2].ToString())
+	require.Equal("Query", row[3].ToString())
+	require.Equal(ProcessStateExecuting, row[5].ToString())
+	require.Equal(longQuery[:processListInfoLength], row[6].ToString())
+	require.Equal("5", row[7].ToString())
+
+	require.Equal("2", result.Rows[1][0].ToString())
+	require.Equal("SHOW PROCESSLIST", result.Rows[1][6].ToString())
+
+	err = handler.ComQuery(conn2, "show full processlist", func(res *sqltypes.Result) error {
+		result = res
+		return nil
+	})
+	require.NoError(err)
+	require.Equal(longQuery, result.Rows[0][6].ToString())
+}
+
+func TestTruncateQuery(t *testing.T) {
+	require := require.New(t)
+	require.Equal("SELECT", truncateQuery("SELECT", 10))
+	require.Equal("SEL", truncateQuery("SELECT", 3))
+	require.Equal("SELECT 'ñá", truncateQuery("SELECT 'ñáé'", 10))
+	require.Equal("", truncateQuery("ñ", 0))
+}
+
+func TestSchemaToFields(t *testing.T) {
+	require := require.New(t)
+
+	schema := sql.Schema{
+		{Name: "a", Type: sql.Uint8, Source: "foo"},
+		{Name: "b", Type: sql.MustVarChar(10), Source: "foo", Nullable: true},
+		{Name: "c", Type: sql.MustEnum("x", "yy"), Source: "foo"},
+		{Name: "d", Type: sql.MustSet("x", "yy"), Source: "foo"},
+		{Name: "e", Type: sql.MustDecimal(10, 2), Source: "foo"},
+		{Name: "f", Type: sql.Timestamp, Source: "foo", Nullable: true},
+		{Name: "g", Type: sql.Blob, Source: "foo", Nullable: true},
+		{Name: "h", Type: sql.Float64, Nullable: true},
+	}
+
+	fields := schemaToFields(schema)
+	require.Len(fields, len(schema))
+
+	flags := func(fs ...query.MySqlFlag) uint32 {
+		var result query.MySqlFlag
+		for _, f := range fs {
+			result |= f
+		}
+		return uint32(result)
+	}
+
+	require.Equal(&query.Field{
+		Name:         "a",
+		Type:         sqltypes.Uint8,
+		Table:        "foo",
+		OrgName:      "a",
+		ColumnLength: 3,
+		Charset:      uint32(sql.CollationBinary),
+		Flags: flags(
+			query.MySqlFlag_NOT_NULL_FLAG,
+			query.MySqlFlag_BINARY_FLAG,
+			query.MySqlFlag_NUM_FLAG,
+			query.MySqlFlag_UNSIGNED_FLAG,
+		),
+	}, fields[0])
+
+	require.Equal(&query.Field{
+		Name:         "b",
+		Type:         sqltypes.VarChar,
+		Table:        "foo",
+		OrgName:      "b",
+		ColumnLength: 40,
+		Charset:      uint32(sql.CollationUtf8mb4GeneralCI),
+	}, fields[1])
+
+	require.Equal(uint32(8), fields[2].ColumnLength)
+	require.Equal(
+		flags(query.MySqlFlag_NOT_NULL_FLAG, query.MySqlFlag_ENUM_FLAG),
+		fields[2].Flags,
+	)
+
+	require.Equal(uint32(16), fields[3].ColumnLength)
+	require.Equal(
+		flags(query.MySqlFlag_NOT_NULL_FLAG, query.MySqlFlag_SET_FLAG),
+		fields[3].Flags,
+	)
+
+	require.Equal(uint32(12), fields[4].ColumnLength)
+	require.Equal(uint32(2), fields[4].Decimals)
+
+	require.Equal(
+		flags(query.MySqlFlag_BINARY_FLAG, query.MySqlFlag_TIMESTAMP_FLAG),
+		fields[5].Flags,
+	)
+
+	require.Equal(
+		flags(query.MySqlFlag_BINARY_FLAG, query.MySqlFlag_BLOB_FLAG),
+		fields[6].Flags,
+	)
+
+	require.Equal("", fields[7].Table)
+	require.Equal("", fields[7].Database)
+	require.Equal(uint32(31), fields[7].Decimals)
+}
+
+func TestRowToSQLNull(t *testing.T) {
+	require := require.New(t)
+
+	schema := sql.Schema{
+		{Name: "a", Type: sql.Int16, Nullable: true},
+		{Name: "b", Type: sql.MustChar(3), Nullable: true},
+		{Name: "c", Type: sql.MustVarChar(3), Nullable: true},
+		{Name: "d", Type: sql.Datetime, Nullable: true},
+		{Name: "e", Type: sql.Time, Nullable: true},
+		{Name: "f", Type: sql.Year, Nullable: true},
+		{Name: "g", Type: sql.MustEnum("x", "yy"), Nullable: true},
+		{Name: "h", Type: sql.MustSet("x", "yy"), Nullable: true},
+		{Name: "i", Type: sql.Timestamp, Nullable: true},
+	}
+
+	row := make(sql.Row, len(schema))
+	for _, v := range rowToSQL(schema, row) {
+		require.Equal(sqltypes.NULL, v)
+	}
+}
@@ -0,0 +1,233 @@
+package analyzer
+
+import (
+	"fmt"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+)
+
+// foldConstants replaces the deterministic expressions whose arguments are
+// all literals with a literal of their value and simplifies the boolean
+// expressions with literals, so they are not evaluated for each row and
+// can be used to look up indexes. Filters whose condition becomes a literal
+// are removed if it's true, and replaced by an empty table otherwise, as no
+// row can satisfy it.
+func foldConstants(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
+	span, ctx := ctx.Span("fold_constants")
+	defer span.Finish()
+
+	a.Log("folding constants, node of type: %T", n)
+	var changed bool
+	fold := foldExpression(ctx, &changed)
+	return n.TransformUp(func(n sql.Node) (sql.Node, error) {
+		switch n := n.(type) {
+		case *plan.Sort:
+			// Integer literals in the sort fields are column indexes, so
+			// folding the expressions into them would change their meaning.
+			return n, nil
+		case *plan.Project:
+			projections, err := foldProjections(fold, n.Projections)
+			if err != nil {
+				return nil, err
+			}
+
+			return plan.NewProject(projections, n.Child), nil
+		case *plan.Window:
+			exprs, err := foldProjections(fold, n.SelectExprs)
+			if err != nil {
+				return nil, err
+			}
+
+			return plan.NewWindow(exprs, n.Child), nil
+		case *plan.GroupBy:
+			aggregate, err := foldProjections(fold, n.Aggregate)
+			if err != nil {
+				return nil, err
+			}
+
+			var grouping = make([]sql.Expression, len(n.Grouping))
+			for i, e := range n.Grouping {
+				grouping[i], err = e.TransformUp(fold)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			return n.WithExpressions(aggregate, grouping, n.Child), nil
+		case *plan.Filter:
+			cond, err := n.Expression.TransformUp(fold)
+			if err != nil {
+				return nil, err
+			}
+
+			if !isLiteral(cond) || !n.Child.Resolved() {
+				return plan.NewFilter(cond, n.Child), nil
+			}
+
+			if isLiteralValue(cond, true) {
+				a.Log("removing filter that is always true")
+				return n.Child, nil
+			}
+
+			a.Log("replacing filter that is never true with an empty table")
+			return plan.NewEmptyTable(n.Child.Schema()), nil
+		case sql.Expressioner:
+			// Nodes are only replaced if some expression was folded, as
+			// some of them do not keep all their fields when transformed.
+			changed = false
+			node, err := n.TransformExpressions(fold)
+			if err != nil || !changed {
+				return n.(sql.Node), err
+			}
+
+			return node, nil
+		default:
+			return n, nil
+		}
+	})
+}
+
+// foldProjections folds the constants of the expressions that define the
+// columns of a node, keeping the names of the columns.
+func foldProjections(
+	fold sql.TransformExprFunc,
+	exprs []sql.Expression,
+) ([]sql.Expression, error) {
+	var result = make([]sql.Expression, len(exprs))
+	for i, e := range exprs {
+		folded, err := e.TransformUp(fold)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := e.(sql.Nameable); !ok && folded.String() != e.String() {
+			folded = expression.NewAlias(folded, e.String())
+		}
+
+		result[i] = folded
+	}
+
+	return result, nil
+}
+
+// foldExpression returns a function that simplifies an expression whose
+// children have already been folded, setting changed if it does.
+func foldExpression(ctx *sql.Context, changed *bool) sql.TransformExprFunc {
+	return func(e sql.Expression) (sql.Expression, error) {
+		if simplified, ok := simplifyBoolean(e); ok {
+			*changed = true
+			e = simplified
+		}
+
+		if !isFoldable(e) {
+			return e, nil
+		}
+
+		v, err := evalConstant(ctx, e)
+		if err != nil {
+			// The expression is left as is so the error is only returned
+			// if it's evaluated.
+			return e, nil
+		}
+
+		*changed = true
+		return expression.NewLiteral(v, e.Type()), nil
+	}
+}
+
+// evalConstant evaluates an expression without a row. Constants may be in
+// branches that are never evaluated, so a panic is returned as an error
+// instead of crashing the analysis.
+func evalConstant(ctx *sql.Context, e sql.Expression) (v interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("unable to evaluate %s: %v", e, r)
+		}
+	}()
+
+	return e.Eval(ctx, nil)
+}
+
+// isFoldable returns whether the expression can be replaced by its value,
+// which is the case of resolved deterministic expressions whose children
+// are all literals. Expressions which are not just their value, such as
+// tuples, intervals or collations, are never replaced.
+func isFoldable(e sql.Expression) bool {
+	switch e.(type) {
+	case *expression.Literal,
+		*expression.Alias,
+		*expression.Collate,
+		expression.Tuple,
+		*expression.Interval,
+		*expression.Window,
+		sql.Aggregation,
+		sql.WindowFunction:
+		return false
+	}
+
+	if nd, ok := e.(sql.NonDeterministicExpression); ok && nd.IsNonDeterministic() {
+		return false
+	}
+
+	children := e.Children()
+	if len(children) == 0 || !e.Resolved() {
+		return false
+	}
+
+	for _, child := range children {
+		if !isLiteral(child) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// simplifyBoolean simplifies AND and OR with true or false literals and
+// double negations, returning whether it did. Expressions are only replaced
+// by their boolean arguments, as the result of the logical operators is
+// always a boolean.
+func simplifyBoolean(e sql.Expression) (sql.Expression, bool) {
+	switch e := e.(type) {
+	case *expression.And:
+		switch {
+		case isLiteralValue(e.Left, false) || isLiteralValue(e.Right, false):
+			return expression.NewLiteral(false, sql.Boolean), true
+		case isLiteralValue(e.Left, true) && e.Right.Type() == sql.Boolean:
+			return e.Right, true
+		case isLiteralValue(e.Right, true) && e.Left.Type() == sql.Boolean:
+			return e.Left, true
+		}
+	case *expression.Or:
+		switch {
+		case isLiteralValue(e.Left, true) || isLiteralValue(e.Right, true):
+			return expression.NewLiteral(true, sql.Boolean), true
+		case isLiteralValue(e.Left, false) && e.Right.Type() == sql.Boolean:
+			return e.Right, true
+		case isLiteralValue(e.Right, false) && e.Left.Type() == sql.Boolean:
+			return e.Left, true
+		}
+	case *expression.Not:
+		if not, ok := e.Child.(*expression.Not); ok && not.Child.Type() == sql.Boolean {
+			return not.Child, true
+		}
+	}
+
+	return e, false
+}
+
+func isLiteral(e sql.Expression) bool {
+	_, ok := e.(*expression.Literal)
+	return ok
+}
+
+func isLiteralValue(e sql.Expression, value interface{}) bool {
+	if !isLiteral(e) {
+		return false
+	}
+
+	v, err := e.Eval(nil, nil)
+	return err == nil && v == value
+}
@@ -0,0 +1,146 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/mem"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression/function"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+)
+
+func TestFoldConstants(t *testing.T) {
+	table := mem.NewTable("mytable", sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "mytable"},
+		{Name: "t", Type: sql.Text, Source: "mytable"},
+	})
+
+	i := expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "i", false)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+	two := expression.NewLiteral(int64(2), sql.Int64)
+	yes := expression.NewLiteral(true, sql.Boolean)
+	no := expression.NewLiteral(false, sql.Boolean)
+	iIsOne := expression.NewEquals(i, one)
+
+	rand, err := function.NewRand(one)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		node     sql.Node
+		expected sql.Node
+	}{
+		{
+			"constant arithmetic",
+			plan.NewFilter(expression.NewEquals(i, expression.NewPlus(one, one)), table),
+			plan.NewFilter(expression.NewEquals(i, two), table),
+		},
+		{
+			"projections keep their names",
+			plan.NewProject([]sql.Expression{
+				i,
+				expression.NewPlus(i, expression.NewPlus(one, one)),
+				expression.NewAlias(expression.NewPlus(one, one), "x"),
+			}, table),
+			plan.NewProject([]sql.Expression{
+				i,
+				expression.NewAlias(expression.NewPlus(i, two), "mytable.i + 1 + 1"),
+				expression.NewAlias(two, "x"),
+			}, table),
+		},
+		{
+			"non-deterministic expressions and tuples",
+			plan.NewFilter(expression.NewAnd(
+				expression.NewLessThan(rand, one),
+				expression.NewIn(i, expression.NewTuple(one, two)),
+			), table),
+			plan.NewFilter(expression.NewAnd(
+				expression.NewLessThan(rand, one),
+				expression.NewIn(i, expression.NewTuple(one, two)),
+			), table),
+		},
+		{
+			"and true",
+			plan.NewFilter(expression.NewAnd(iIsOne, yes), table),
+			plan.NewFilter(iIsOne, table),
+		},
+		{
+			"or false",
+			plan.NewFilter(expression.NewOr(no, iIsOne), table),
+			plan.NewFilter(iIsOne, table),
+		},
+		{
+			"double negation",
+			plan.NewFilter(expression.NewNot(expression.NewNot(iIsOne)), table),
+			plan.NewFilter(iIsOne, table),
+		},
+		{
+			"non-boolean arguments are kept",
+			plan.NewFilter(expression.NewAnd(i, yes), table),
+			plan.NewFilter(expression.NewAnd(i, yes), table),
+		},
+		{
+			"always true filter",
+			plan.NewFilter(expression.NewOr(iIsOne, expression.NewEquals(one, one)), table),
+			table,
+		},
+		{
+			"always false filter",
+			plan.NewProject(
+				[]sql.Expression{i},
+				plan.NewFilter(expression.NewAnd(iIsOne, expression.NewEquals(one, two)), table),
+			),
+			plan.NewProject(
+				[]sql.Expression{i},
+				plan.NewEmptyTable(table.Schema()),
+			),
+		},
+		{
+			"null filter",
+			plan.NewFilter(expression.NewEquals(one, expression.NewLiteral(nil, sql.Null)), table),
+			plan.NewEmptyTable(table.Schema()),
+		},
+		{
+			"expressions that panic are not folded",
+			plan.NewFilter(expression.NewEquals(i, &panicExpression{expression.UnaryExpression{Child: one}}), table),
+			plan.NewFilter(expression.NewEquals(i, &panicExpression{expression.UnaryExpression{Child: one}}), table),
+		},
+		{
+			"sort fields are not folded",
+			plan.NewSort([]plan.SortField{{Column: expression.NewPlus(one, one)}}, table),
+			plan.NewSort([]plan.SortField{{Column: expression.NewPlus(one, one)}}, table),
+		},
+	}
+
+	rule := getRule("fold_constants")
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := rule.Apply(sql.NewEmptyContext(), nil, tt.node)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// panicExpression is a resolved deterministic expression that panics when
+// it's evaluated.
+type panicExpression struct {
+	expression.UnaryExpression
+}
+
+func (e *panicExpression) Type() sql.Type { return sql.Int64 }
+func (e *panicExpression) String() string { return "PANIC()" }
+
+func (e *panicExpression) Eval(*sql.Context, sql.Row) (interface{}, error) {
+	panic("evaluated")
+}
+
+func (e *panicExpression) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := e.Child.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+	return f(&panicExpression{expression.UnaryExpression{Child: child}})
+}
@@ -0,0 +1,160 @@
+package analyzer
+
+import (
+	"strings"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+)
+
+var (
+	// ErrForcedIndexNotUsable is returned when none of the indexes of a
+	// FORCE INDEX hint can be used to query the table.
+	ErrForcedIndexNotUsable = errors.NewKind("none of the forced indexes of table %q can be used: %s")
+
+	// ErrHintIndexNotFound is returned when an index hint names an index
+	// that the table does not have.
+	ErrHintIndexNotFound = errors.NewKind("key %q doesn't exist in table %q")
+)
+
+// indexHints are the index hints given for the tables of a query by the
+// name of the table. A table may appear several times in a query, with
+// different aliases and hints.
+type indexHints map[string][]*plan.IndexHint
+
+// getIndexHints returns the index hints of the tables in the given node.
+// Hints of tables without an alias are kept by the resolve_tables rule in
+// an alias with the name of the table.
+func getIndexHints(n sql.Node) indexHints {
+	var hints = make(indexHints)
+	plan.Inspect(n, func(n sql.Node) bool {
+		alias, ok := n.(*plan.TableAlias)
+		if !ok || alias.IndexHint == nil {
+			return true
+		}
+
+		if t := aliasedTable(alias); t != nil {
+			hints[t.Name()] = append(hints[t.Name()], alias.IndexHint)
+		}
+
+		return true
+	})
+	return hints
+}
+
+// aliasedTable returns the table behind the given alias, or nil if it's not
+// a table.
+func aliasedTable(alias *plan.TableAlias) sql.Table {
+	var table sql.Table
+	plan.Inspect(alias.Child, func(n sql.Node) bool {
+		if t, ok := n.(sql.Table); ok && table == nil {
+			table = t
+		}
+		return table == nil
+	})
+	return table
+}
+
+// allows returns whether the given index can be used according to the hints
+// of its table, if any. Indexes are looked up by the name of the table and
+// not by its alias, so when a table appears several times the index must
+// be allowed by all of its hints.
+func (h indexHints) allows(idx sql.Index) bool {
+	for _, hint := range h[idx.Table()] {
+		if hint.Type == plan.IgnoreIndex {
+			if hint.Contains(idx.ID()) {
+				return false
+			}
+			continue
+		}
+
+		if !hint.Contains(idx.ID()) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// indexByExpression returns the first index matching the given expressions
+// that can be used according to the hints.
+func (h indexHints) indexByExpression(a *Analyzer, exprs ...sql.Expression) sql.Index {
+	return a.Catalog.IndexByExpressionFunc(a.CurrentDatabase, h.allows, exprs...)
+}
+
+// validateIndexHints checks that all the indexes named in index hints exist
+// in their table and that the tables with a FORCE INDEX hint are queried
+// using an index, either because their rows are filtered with one or
+// because they are looked up with one in an index join.
+func validateIndexHints(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
+	span, _ := ctx.Span("validate_index_hints")
+	defer span.Finish()
+
+	var joined = make(map[*plan.TableAlias]struct{})
+	plan.Inspect(n, func(n sql.Node) bool {
+		if join, ok := n.(*plan.IndexJoin); ok {
+			plan.Inspect(join.Right, func(n sql.Node) bool {
+				if alias, ok := n.(*plan.TableAlias); ok {
+					joined[alias] = struct{}{}
+				}
+				return true
+			})
+		}
+		return true
+	})
+
+	var err error
+	plan.Inspect(n, func(n sql.Node) bool {
+		alias, ok := n.(*plan.TableAlias)
+		if !ok || alias.IndexHint == nil {
+			return true
+		}
+
+		table := aliasedTable(alias)
+		if table == nil {
+			return true
+		}
+
+		for _, id := range alias.IndexHint.Indexes {
+			if !a.Catalog.HasIndex(a.CurrentDatabase, table.Name(), id) {
+				err = ErrHintIndexNotFound.New(id, table.Name())
+				return false
+			}
+		}
+
+		if alias.IndexHint.Type != plan.ForceIndex {
+			return true
+		}
+
+		if _, ok := joined[alias]; ok || usesIndex(alias) {
+			return true
+		}
+
+		err = ErrForcedIndexNotUsable.New(
+			table.Name(),
+			strings.Join(alias.IndexHint.Indexes, ", "),
+		)
+		return false
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// usesIndex returns whether the rows of the given node are filtered using
+// an index.
+func usesIndex(n sql.Node) bool {
+	var result bool
+	plan.Inspect(n, func(n sql.Node) bool {
+		switch n.(type) {
+		case *plan.IndexableTable, *indexable:
+			result = true
+		}
+		return !result
+	})
+	return result
+}
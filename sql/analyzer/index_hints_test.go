@@ -0,0 +1,215 @@
+package analyzer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	errors "gopkg.in/src-d/go-errors.v1"
+	"gopkg.in/src-d/go-mysql-server.v0/mem"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+)
+
+func TestAssignIndexesHints(t *testing.T) {
+	foo := expression.NewGetFieldWithTable(0, sql.Int64, "t1", "foo", false)
+
+	// Both indexes have the same expression, but they are of different
+	// drivers.
+	catalog := sql.NewCatalog()
+	for _, idx := range []*hintedIndex{
+		{dummyIndex{"t1", []sql.Expression{foo}}, "bitmap"},
+		{dummyIndex{"t1", []sql.Expression{foo}}, "btree"},
+	} {
+		done, err := catalog.AddIndex(idx)
+		require.NoError(t, err)
+		close(done)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	a := NewDefault(catalog)
+
+	t1 := &indexableTable{
+		&pushdownProjectionAndFiltersTable{
+			mem.NewTable("t1", sql.Schema{
+				{Name: "foo", Type: sql.Int64, Source: "t1"},
+			}),
+		},
+	}
+
+	testCases := []struct {
+		name  string
+		hints []*plan.IndexHint
+		index string
+	}{
+		{"no hint", nil, "bitmap"},
+		{"use index", []*plan.IndexHint{plan.NewIndexHint(plan.UseIndex, "BTREE")}, "btree"},
+		{"use other index", []*plan.IndexHint{plan.NewIndexHint(plan.UseIndex, "other")}, ""},
+		{"ignore index", []*plan.IndexHint{plan.NewIndexHint(plan.IgnoreIndex, "bitmap")}, "btree"},
+		{"ignore all indexes", []*plan.IndexHint{plan.NewIndexHint(plan.IgnoreIndex, "bitmap", "btree")}, ""},
+		{"force index", []*plan.IndexHint{plan.NewIndexHint(plan.ForceIndex, "btree", "other")}, "btree"},
+		{
+			"hints of all the aliases",
+			[]*plan.IndexHint{
+				plan.NewIndexHint(plan.UseIndex, "bitmap", "btree"),
+				plan.NewIndexHint(plan.IgnoreIndex, "bitmap"),
+			},
+			"btree",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var node sql.Node = plan.NewTableAlias("t", t1)
+			for i, hint := range tt.hints {
+				node = plan.NewCrossJoin(
+					node,
+					plan.NewTableAlias(fmt.Sprintf("t%d", i), t1).WithIndexHint(hint),
+				)
+			}
+			node = plan.NewFilter(
+				expression.NewEquals(foo, expression.NewLiteral(int64(1), sql.Int64)),
+				node,
+			)
+
+			result, err := getRule("assign_indexes").Apply(sql.NewEmptyContext(), a, node)
+			require.NoError(err)
+
+			var used string
+			plan.Inspect(result, func(n sql.Node) bool {
+				if n, ok := n.(*indexable); ok {
+					used = n.index.indexes[0].ID()
+				}
+				return true
+			})
+			require.Equal(tt.index, used)
+		})
+	}
+}
+
+func TestIndexJoinHints(t *testing.T) {
+	require := require.New(t)
+
+	catalog := sql.NewCatalog()
+	done, err := catalog.AddIndex(&hintedIndex{
+		dummyIndex{
+			"t2",
+			[]sql.Expression{
+				expression.NewGetFieldWithTable(0, sql.Int64, "t2", "bar", false),
+			},
+		},
+		"bar_idx",
+	})
+	require.NoError(err)
+	close(done)
+
+	time.Sleep(50 * time.Millisecond)
+	a := NewDefault(catalog)
+
+	t1 := mem.NewTable("t1", sql.Schema{{Name: "foo", Type: sql.Int64, Source: "t1"}})
+	t2 := mem.NewTable("t2", sql.Schema{{Name: "bar", Type: sql.Int64, Source: "t2"}})
+
+	node := plan.NewInnerJoin(
+		t1,
+		plan.NewTableAlias("t2", t2).WithIndexHint(plan.NewIndexHint(plan.IgnoreIndex, "bar_idx")),
+		expression.NewEquals(
+			expression.NewGetFieldWithTable(0, sql.Int64, "t1", "foo", false),
+			expression.NewGetFieldWithTable(1, sql.Int64, "t2", "bar", false),
+		),
+	)
+
+	result, err := getRule("index_join").Apply(sql.NewEmptyContext(), a, node)
+	require.NoError(err)
+	require.Equal(node, result)
+}
+
+func TestValidateIndexHints(t *testing.T) {
+	foo := expression.NewGetFieldWithTable(0, sql.Int64, "t1", "foo", false)
+	bar := expression.NewGetFieldWithTable(1, sql.Int64, "t2", "bar", false)
+	fooIdx := &hintedIndex{dummyIndex{"t1", []sql.Expression{foo}}, "foo_idx"}
+
+	catalog := sql.NewCatalog()
+	done, err := catalog.AddIndex(fooIdx)
+	require.NoError(t, err)
+	close(done)
+
+	time.Sleep(50 * time.Millisecond)
+	a := NewDefault(catalog)
+
+	t1 := mem.NewTable("t1", sql.Schema{{Name: "foo", Type: sql.Int64, Source: "t1"}})
+	t2 := mem.NewTable("t2", sql.Schema{{Name: "bar", Type: sql.Int64, Source: "t2"}})
+	force := plan.NewIndexHint(plan.ForceIndex, "FOO_IDX")
+
+	testCases := []struct {
+		name string
+		node sql.Node
+		err  *errors.Kind
+	}{
+		{
+			"unknown index",
+			plan.NewTableAlias("t1", t1).WithIndexHint(plan.NewIndexHint(plan.IgnoreIndex, "other")),
+			ErrHintIndexNotFound,
+		},
+		{
+			"index of other table",
+			plan.NewTableAlias("t2", t2).WithIndexHint(plan.NewIndexHint(plan.UseIndex, "foo_idx")),
+			ErrHintIndexNotFound,
+		},
+		{
+			"forced index not used",
+			plan.NewTableAlias("t1", t1).WithIndexHint(force),
+			ErrForcedIndexNotUsable,
+		},
+		{
+			"forced index used",
+			plan.NewTableAlias("t1", &indexable{&indexLookup{nil, []sql.Index{fooIdx}}, t1}).
+				WithIndexHint(force),
+			nil,
+		},
+		{
+			"forced index used in an index join",
+			plan.NewIndexJoin(
+				t2,
+				plan.NewTableAlias("t1", t1).WithIndexHint(force),
+				expression.NewEquals(foo, bar),
+				fooIdx, foo, bar,
+			),
+			nil,
+		},
+		{
+			"forced index used only by one alias",
+			plan.NewCrossJoin(
+				plan.NewTableAlias("a", &indexable{&indexLookup{nil, []sql.Index{fooIdx}}, t1}).
+					WithIndexHint(force),
+				plan.NewTableAlias("b", t1).WithIndexHint(force),
+			),
+			ErrForcedIndexNotUsable,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			_, err := getValidationRule(validateIndexHintsRule).Apply(sql.NewEmptyContext(), a, tt.node)
+			if tt.err != nil {
+				require.Error(err)
+				require.True(tt.err.Is(err), "unexpected error: %s", err)
+			} else {
+				require.NoError(err)
+			}
+		})
+	}
+}
+
+type hintedIndex struct {
+	dummyIndex
+	id string
+}
+
+func (i *hintedIndex) ID() string     { return i.id }
+func (i *hintedIndex) Driver() string { return i.id }
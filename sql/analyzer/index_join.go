@@ -0,0 +1,115 @@
+package analyzer
+
+import (
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+)
+
+// indexJoin turns the inner joins whose condition compares an indexed
+// expression of the table in the right side with an expression of the left
+// side into index joins, so the rows of the right side are looked up in the
+// index for every row of the left side instead of being scanned. Index hints
+// of the tables are honored.
+func indexJoin(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
+	span, ctx := ctx.Span("index_join")
+	defer span.Finish()
+
+	a.Log("index join, node of type: %T", n)
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	hints := getIndexHints(n)
+	var indexes []sql.Index
+	release := func() {
+		for _, idx := range indexes {
+			a.Catalog.ReleaseIndex(idx)
+		}
+	}
+
+	node, err := n.TransformUp(func(node sql.Node) (sql.Node, error) {
+		join, ok := node.(*plan.InnerJoin)
+		if !ok {
+			return node, nil
+		}
+
+		table := indexJoinTable(join.Right)
+		if table == nil {
+			return node, nil
+		}
+
+		leftSources := nodeSources(join.Left)
+		rightSources := nodeSources(join.Right)
+		for _, e := range splitExpression(join.Cond) {
+			eq, ok := e.(*expression.Equals)
+			if !ok {
+				continue
+			}
+
+			indexExpr, key := eq.Left(), eq.Right()
+			if !containsSources(rightSources, expressionSources(indexExpr)) {
+				indexExpr, key = key, indexExpr
+			}
+
+			if isEvaluable(indexExpr) || isEvaluable(key) ||
+				!containsSources(rightSources, expressionSources(indexExpr)) ||
+				!containsSources(leftSources, expressionSources(key)) {
+				continue
+			}
+
+			idx := hints.indexByExpression(a, indexExpr)
+			if idx == nil {
+				continue
+			}
+
+			if idx.Table() != table.Name() {
+				a.Catalog.ReleaseIndex(idx)
+				continue
+			}
+
+			a.Log("join with table %q transformed into an index join using index %q", table.Name(), idx.ID())
+			indexes = append(indexes, idx)
+			return plan.NewIndexJoin(
+				join.Left, join.Right,
+				join.Cond,
+				idx, indexExpr, key,
+			), nil
+		}
+
+		return node, nil
+	})
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	if len(indexes) > 0 {
+		return &releaser{node, release}, nil
+	}
+
+	return node, nil
+}
+
+// indexJoinTable returns the table in the given side of a join if it can be
+// looked up with an index, that is, if it's an indexable table, optionally
+// behind an alias or filters, not already using an index.
+func indexJoinTable(n sql.Node) sql.Table {
+	switch n := n.(type) {
+	case *plan.Filter:
+		return indexJoinTable(n.Child)
+	case *plan.TableAlias:
+		return indexJoinTable(n.Child)
+	case *plan.IndexableTable:
+		return nil
+	case *plan.PushdownProjectionAndFiltersTable:
+		if _, ok := n.PushdownProjectionAndFiltersTable.(sql.Indexable); ok {
+			return n
+		}
+		return nil
+	case sql.Indexable:
+		return n
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/mem"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+)
+
+func TestIndexJoin(t *testing.T) {
+	require := require.New(t)
+
+	catalog := sql.NewCatalog()
+	idx := &dummyIndex{
+		"t2",
+		[]sql.Expression{
+			expression.NewGetFieldWithTable(0, sql.Int64, "t2", "bar", false),
+		},
+	}
+	done, err := catalog.AddIndex(idx)
+	require.NoError(err)
+	close(done)
+
+	time.Sleep(50 * time.Millisecond)
+	a := NewDefault(catalog)
+
+	t1 := plan.NewPushdownProjectionAndFiltersTable(nil, nil, mem.NewTable("t1", sql.Schema{
+		{Name: "foo", Type: sql.Int64, Source: "t1"},
+	}))
+	t2 := plan.NewPushdownProjectionAndFiltersTable(nil, nil, mem.NewTable("t2", sql.Schema{
+		{Name: "bar", Type: sql.Int64, Source: "t2"},
+		{Name: "baz", Type: sql.Int64, Source: "t2"},
+	}))
+
+	foo := expression.NewGetFieldWithTable(0, sql.Int64, "t1", "foo", false)
+	bar := expression.NewGetFieldWithTable(1, sql.Int64, "t2", "bar", false)
+	baz := expression.NewGetFieldWithTable(2, sql.Int64, "t2", "baz", false)
+	cond := expression.NewAnd(
+		expression.NewGreaterThan(baz, foo),
+		expression.NewEquals(bar, foo),
+	)
+
+	rule := getRule("index_join")
+	result, err := rule.Apply(sql.NewEmptyContext(), a, plan.NewInnerJoin(
+		t1,
+		plan.NewTableAlias("t", t2),
+		cond,
+	))
+	require.NoError(err)
+
+	r, ok := result.(*releaser)
+	require.True(ok)
+	require.Equal(plan.NewIndexJoin(
+		t1,
+		plan.NewTableAlias("t", t2),
+		cond,
+		idx, bar, foo,
+	), r.Child)
+
+	// Already transformed joins are left as they are.
+	result, err = rule.Apply(sql.NewEmptyContext(), a, r.Child)
+	require.NoError(err)
+	require.Equal(r.Child, result)
+
+	// The indexed table needs to be in the right side of the join.
+	node := plan.NewInnerJoin(
+		t2, t1,
+		expression.NewEquals(
+			expression.NewGetFieldWithTable(0, sql.Int64, "t2", "bar", false),
+			expression.NewGetFieldWithTable(2, sql.Int64, "t1", "foo", false),
+		),
+	)
+	result, err = rule.Apply(sql.NewEmptyContext(), a, node)
+	require.NoError(err)
+	require.Equal(node, result)
+
+	// Tables already using an index are not joined with another one.
+	node = plan.NewInnerJoin(
+		t1,
+		plan.NewIndexableTable(nil, nil, new(dummyIndexLookup), &indexableTable{t2}),
+		expression.NewEquals(bar, foo),
+	)
+	result, err = rule.Apply(sql.NewEmptyContext(), a, node)
+	require.NoError(err)
+	require.Equal(node, result)
+}
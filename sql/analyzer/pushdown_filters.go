@@ -0,0 +1,271 @@
+package analyzer
+
+import (
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+)
+
+// pushdownFilters moves the conditions of the filters as deep as possible in
+// the tree, so rows are discarded before being projected or joined and the
+// conditions get to the tables, where the pushdown rule can give them to
+// the tables that handle them. Filters are split in their conjunctions,
+// which are moved below projections, sorts, distincts and subquery aliases,
+// and to the side of the joins whose sources they use. Conditions that use
+// both sides of a join become part of its condition. As there are only inner
+// and cross joins, conditions can always be moved to any of their sides.
+func pushdownFilters(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
+	span, ctx := ctx.Span("pushdown_filters")
+	defer span.Finish()
+
+	a.Log("pushdown filters, node of type: %T", n)
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	switch n.(type) {
+	case *plan.InsertInto, *plan.CreateIndex:
+		return n, nil
+	}
+
+	return n.TransformUp(func(node sql.Node) (sql.Node, error) {
+		filter, ok := node.(*plan.Filter)
+		if !ok || !canPushFiltersBelow(filter.Child) {
+			return node, nil
+		}
+
+		a.Log("pushing down filter %s", filter.Expression)
+		return pushFilters(splitExpression(filter.Expression), filter.Child)
+	})
+}
+
+// canPushFiltersBelow returns whether the filters above the node can be
+// moved below it.
+func canPushFiltersBelow(node sql.Node) bool {
+	switch node.(type) {
+	case *plan.Filter,
+		*plan.Project,
+		*plan.Sort,
+		*plan.Distinct,
+		*plan.OrderedDistinct,
+		*plan.CrossJoin,
+		*plan.InnerJoin,
+		*plan.SubqueryAlias:
+		return true
+	default:
+		return false
+	}
+}
+
+// pushFilters returns a node equivalent to the given one with a filter of
+// all the given conditions above it, in which they have been moved as deep
+// as possible.
+func pushFilters(filters []sql.Expression, node sql.Node) (sql.Node, error) {
+	if len(filters) == 0 {
+		return node, nil
+	}
+
+	if !canPushFiltersBelow(node) {
+		return plan.NewFilter(expression.JoinAnd(filters...), node), nil
+	}
+
+	var above, below []sql.Expression
+	for _, f := range filters {
+		if isDeterministic(f) {
+			below = append(below, f)
+		} else {
+			above = append(above, f)
+		}
+	}
+
+	var result sql.Node
+	var err error
+	switch n := node.(type) {
+	case *plan.Filter:
+		// The conditions of the filter are already below the new ones.
+		filters := append(splitExpression(n.Expression), below...)
+		result, err = pushFilters(filters, n.Child)
+	case *plan.Project:
+		var pushed []sql.Expression
+		for _, f := range below {
+			e, ok := replaceProjectedFields(f, n.Projections)
+			if ok {
+				pushed = append(pushed, e)
+			} else {
+				above = append(above, f)
+			}
+		}
+
+		var child sql.Node
+		child, err = pushFilters(pushed, n.Child)
+		result = plan.NewProject(n.Projections, child)
+	case *plan.Sort:
+		var child sql.Node
+		child, err = pushFilters(below, n.Child)
+		result = plan.NewSort(n.SortFields, child)
+	case *plan.Distinct:
+		var child sql.Node
+		child, err = pushFilters(below, n.Child)
+		result = plan.NewDistinct(child)
+	case *plan.OrderedDistinct:
+		var child sql.Node
+		child, err = pushFilters(below, n.Child)
+		result = plan.NewOrderedDistinct(child)
+	case *plan.CrossJoin:
+		result, err = pushFiltersToJoin(below, n.Left, n.Right, nil)
+	case *plan.InnerJoin:
+		result, err = pushFiltersToJoin(below, n.Left, n.Right, n.Cond)
+	case *plan.SubqueryAlias:
+		var child sql.Node
+		child, err = pushFilters(subqueryFilters(below, n.Child.Schema()), n.Child)
+		result = plan.NewSubqueryAlias(n.Name(), child)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(above) > 0 {
+		return plan.NewFilter(expression.JoinAnd(above...), result), nil
+	}
+
+	return result, nil
+}
+
+// pushFiltersToJoin moves the conditions that use only one of the sides of a
+// join to that side, and the rest to the condition of the join.
+func pushFiltersToJoin(
+	filters []sql.Expression,
+	left, right sql.Node,
+	cond sql.Expression,
+) (sql.Node, error) {
+	leftSources := nodeSources(left)
+	rightSources := nodeSources(right)
+	offset := len(left.Schema())
+
+	var leftFilters, rightFilters, condFilters []sql.Expression
+	for _, f := range filters {
+		sources := expressionSources(f)
+		switch {
+		case containsSources(leftSources, sources):
+			leftFilters = append(leftFilters, f)
+		case containsSources(rightSources, sources):
+			f, err := shiftFieldIndexes(f, -offset)
+			if err != nil {
+				return nil, err
+			}
+			rightFilters = append(rightFilters, f)
+		default:
+			condFilters = append(condFilters, f)
+		}
+	}
+
+	left, err := pushFilters(leftFilters, left)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err = pushFilters(rightFilters, right)
+	if err != nil {
+		return nil, err
+	}
+
+	if cond != nil {
+		condFilters = append(splitExpression(cond), condFilters...)
+	}
+
+	if len(condFilters) == 0 {
+		return plan.NewCrossJoin(left, right), nil
+	}
+
+	return plan.NewInnerJoin(left, right, expression.JoinAnd(condFilters...)), nil
+}
+
+// replaceProjectedFields replaces the fields of an expression evaluated on
+// the rows of a projection with the projected expressions, so it can be
+// evaluated on the rows of its child. It returns false if some of them
+// cannot be evaluated on them.
+func replaceProjectedFields(e sql.Expression, projections []sql.Expression) (sql.Expression, bool) {
+	var ok = true
+	result, _ := e.TransformUp(func(e sql.Expression) (sql.Expression, error) {
+		f, isField := e.(*expression.GetField)
+		if !isField {
+			return e, nil
+		}
+
+		if f.Index() >= len(projections) {
+			ok = false
+			return e, nil
+		}
+
+		p := projections[f.Index()]
+		if alias, isAlias := p.(*expression.Alias); isAlias {
+			p = alias.Child
+		}
+
+		if !isDeterministic(p) {
+			ok = false
+		}
+
+		return p, nil
+	})
+
+	return result, ok
+}
+
+// subqueryFilters rewrites the fields of the filters above a subquery alias,
+// whose table is the alias, so they refer to the columns of the subquery.
+func subqueryFilters(filters []sql.Expression, schema sql.Schema) []sql.Expression {
+	var result = make([]sql.Expression, len(filters))
+	for i, f := range filters {
+		result[i], _ = f.TransformUp(func(e sql.Expression) (sql.Expression, error) {
+			field, ok := e.(*expression.GetField)
+			if !ok || field.Index() >= len(schema) {
+				return e, nil
+			}
+
+			col := schema[field.Index()]
+			return expression.NewGetFieldWithTable(
+				field.Index(),
+				col.Type,
+				col.Source,
+				col.Name,
+				col.Nullable,
+			), nil
+		})
+	}
+	return result
+}
+
+// shiftFieldIndexes adds the given offset to the indexes of the fields of
+// the expression.
+func shiftFieldIndexes(e sql.Expression, offset int) (sql.Expression, error) {
+	return e.TransformUp(func(e sql.Expression) (sql.Expression, error) {
+		f, ok := e.(*expression.GetField)
+		if !ok {
+			return e, nil
+		}
+
+		return expression.NewGetFieldWithTable(
+			f.Index()+offset,
+			f.Type(),
+			f.Table(),
+			f.Name(),
+			f.IsNullable(),
+		), nil
+	})
+}
+
+// isDeterministic returns whether the expression returns always the same
+// value for the same row, so the number of times and the order in which it's
+// evaluated do not matter.
+func isDeterministic(e sql.Expression) bool {
+	var result = true
+	expression.Inspect(e, func(e sql.Expression) bool {
+		if nd, ok := e.(sql.NonDeterministicExpression); ok && nd.IsNonDeterministic() {
+			result = false
+		}
+		return result
+	})
+	return result
+}
@@ -0,0 +1,157 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/mem"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression/function"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+)
+
+func TestPushdownFilters(t *testing.T) {
+	table := mem.NewTable("mytable", sql.Schema{
+		{Name: "i", Type: sql.Int64, Source: "mytable"},
+		{Name: "t", Type: sql.Text, Source: "mytable"},
+	})
+
+	table2 := mem.NewTable("mytable2", sql.Schema{
+		{Name: "i2", Type: sql.Int64, Source: "mytable2"},
+		{Name: "t2", Type: sql.Text, Source: "mytable2"},
+	})
+
+	i := expression.NewGetFieldWithTable(0, sql.Int64, "mytable", "i", false)
+	t1 := expression.NewGetFieldWithTable(1, sql.Text, "mytable", "t", false)
+	one := expression.NewLiteral(int64(1), sql.Int64)
+	foo := expression.NewLiteral("foo", sql.Text)
+
+	rand, err := function.NewRand(one)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		node     sql.Node
+		expected sql.Node
+	}{
+		{
+			"conditions are moved to the sides of a cross join",
+			plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(i, one),
+					expression.NewEquals(
+						expression.NewGetFieldWithTable(3, sql.Text, "mytable2", "t2", false),
+						foo,
+					),
+				),
+				plan.NewCrossJoin(table, table2),
+			),
+			plan.NewCrossJoin(
+				plan.NewFilter(expression.NewEquals(i, one), table),
+				plan.NewFilter(
+					expression.NewEquals(
+						expression.NewGetFieldWithTable(1, sql.Text, "mytable2", "t2", false),
+						foo,
+					),
+					table2,
+				),
+			),
+		},
+		{
+			"conditions on both sides become the join condition",
+			plan.NewFilter(
+				expression.NewAnd(
+					expression.NewEquals(
+						i,
+						expression.NewGetFieldWithTable(2, sql.Int64, "mytable2", "i2", false),
+					),
+					expression.NewEquals(t1, foo),
+				),
+				plan.NewCrossJoin(table, table2),
+			),
+			plan.NewInnerJoin(
+				plan.NewFilter(expression.NewEquals(t1, foo), table),
+				table2,
+				expression.NewEquals(
+					i,
+					expression.NewGetFieldWithTable(2, sql.Int64, "mytable2", "i2", false),
+				),
+			),
+		},
+		{
+			"conditions are moved below projections",
+			plan.NewFilter(
+				expression.NewEquals(
+					expression.NewGetField(0, sql.Int64, "x", false),
+					one,
+				),
+				plan.NewProject(
+					[]sql.Expression{expression.NewAlias(i, "x")},
+					table,
+				),
+			),
+			plan.NewProject(
+				[]sql.Expression{expression.NewAlias(i, "x")},
+				plan.NewFilter(expression.NewEquals(i, one), table),
+			),
+		},
+		{
+			"conditions are moved below subquery aliases",
+			plan.NewFilter(
+				expression.NewEquals(
+					expression.NewGetFieldWithTable(1, sql.Text, "sq", "t", false),
+					foo,
+				),
+				plan.NewSubqueryAlias("sq", plan.NewProject([]sql.Expression{i, t1}, table)),
+			),
+			plan.NewSubqueryAlias(
+				"sq",
+				plan.NewProject(
+					[]sql.Expression{i, t1},
+					plan.NewFilter(expression.NewEquals(t1, foo), table),
+				),
+			),
+		},
+		{
+			"non-deterministic conditions are not moved",
+			plan.NewFilter(
+				expression.NewAnd(
+					expression.NewLessThan(rand, one),
+					expression.NewEquals(i, one),
+				),
+				plan.NewSort(
+					[]plan.SortField{{Column: i}},
+					table,
+				),
+			),
+			plan.NewFilter(
+				expression.NewLessThan(rand, one),
+				plan.NewSort(
+					[]plan.SortField{{Column: i}},
+					plan.NewFilter(expression.NewEquals(i, one), table),
+				),
+			),
+		},
+		{
+			"filters are not moved below other nodes",
+			plan.NewFilter(
+				expression.NewEquals(i, one),
+				plan.NewLimit(1, table),
+			),
+			plan.NewFilter(
+				expression.NewEquals(i, one),
+				plan.NewLimit(1, table),
+			),
+		},
+	}
+
+	rule := getRule("pushdown_filters")
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := rule.Apply(sql.NewEmptyContext(), nil, tt.node)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+		})
+	}
+}
@@ -0,0 +1,629 @@
+package analyzer
+
+import (
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+)
+
+const (
+	// defaultRowCount is the number of rows assumed for the tables without
+	// statistics.
+	defaultRowCount = 1000
+	// defaultEqualsSelectivity is the fraction of rows assumed to satisfy an
+	// equality when the number of distinct values is unknown.
+	defaultEqualsSelectivity = 0.1
+	// defaultSelectivity is the fraction of rows assumed to satisfy any
+	// other condition.
+	defaultSelectivity = 1. / 3
+)
+
+// reorderJoins reorders the tables joined by inner and cross joins so the
+// ones expected to return less rows are joined first, which reduces the
+// number of rows read by the nested loops of the joins. Joins are built
+// greedily: the first table is the one with the least estimated rows, and
+// the next one is always the table joined with the ones before by some
+// condition that gives the least estimated rows. The estimates use the
+// statistics of the tables, either provided by them or computed with
+// ANALYZE TABLE. As reordering changes the order of the columns, the join
+// is wrapped in a projection that restores it.
+func reorderJoins(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
+	span, ctx := ctx.Span("reorder_joins")
+	defer span.Finish()
+
+	a.Log("reordering joins, node of type: %T", n)
+	if !n.Resolved() {
+		return n, nil
+	}
+
+	switch n.(type) {
+	case *plan.InsertInto, *plan.CreateIndex:
+		return n, nil
+	}
+
+	return n.TransformUp(func(node sql.Node) (sql.Node, error) {
+		switch node.(type) {
+		case *plan.InnerJoin, *plan.CrossJoin:
+		default:
+			return node, nil
+		}
+
+		// Joins are transformed from the inner to the outer ones, so the
+		// joins below have already been reordered. Their projections are
+		// looked through and all the tables are reordered again.
+		var t joinTree
+		columns := t.collect(node)
+
+		e := &cardinalityEstimator{ctx: ctx, a: a}
+		order, err := t.order(e)
+		if err != nil {
+			return nil, err
+		}
+
+		if order == nil || (!t.rebuild && isIdentityOrder(order)) {
+			return node, nil
+		}
+
+		a.Log("reordering join of %d tables", len(order))
+		return t.build(order, columns, node.Schema())
+	})
+}
+
+// joinColumn is a column of one of the nodes joined by a tree of joins.
+type joinColumn struct {
+	leaf, index int
+}
+
+// joinCondition is one of the conjunctions of the conditions of a tree of
+// joins. The indexes of its fields refer to the given columns.
+type joinCondition struct {
+	expr    sql.Expression
+	columns []joinColumn
+}
+
+// leaves returns the nodes whose columns are used in the condition, or nil
+// if some field does not refer to any of the columns.
+func (c joinCondition) leaves() map[int]bool {
+	var result = make(map[int]bool)
+	var ok = true
+	expression.Inspect(c.expr, func(e sql.Expression) bool {
+		if f, isField := e.(*expression.GetField); isField {
+			if f.Index() >= len(c.columns) {
+				ok = false
+			} else {
+				result[c.columns[f.Index()].leaf] = true
+			}
+		}
+		return ok
+	})
+
+	if !ok {
+		return nil
+	}
+
+	return result
+}
+
+// joinTree contains the nodes joined by a tree of inner and cross joins,
+// which are its leaves, and the conditions of the joins.
+type joinTree struct {
+	leaves []sql.Node
+	conds  []joinCondition
+	// rebuild is whether the tree must be rebuilt even if the order of the
+	// leaves does not change, because it contains reordered joins.
+	rebuild bool
+	// invalid is whether some condition could not be assigned to the
+	// leaves, in which case the tree is left as is.
+	invalid bool
+}
+
+// collect adds the leaves and conditions of the given node to the tree and
+// returns its columns.
+func (t *joinTree) collect(node sql.Node) []joinColumn {
+	switch n := node.(type) {
+	case *plan.CrossJoin:
+		return t.collectJoin(n.Left, n.Right)
+	case *plan.InnerJoin:
+		columns := t.collectJoin(n.Left, n.Right)
+		for _, e := range splitExpression(n.Cond) {
+			c := joinCondition{e, columns}
+			if c.leaves() == nil {
+				t.invalid = true
+			}
+			t.conds = append(t.conds, c)
+		}
+		return columns
+	case *plan.Project:
+		fields, ok := reorderedFields(n)
+		if !ok {
+			break
+		}
+
+		t.rebuild = true
+		child := t.collect(n.Child)
+		var columns = make([]joinColumn, len(fields))
+		for i, f := range fields {
+			if f.Index() >= len(child) {
+				t.invalid = true
+				return columns
+			}
+			columns[i] = child[f.Index()]
+		}
+		return columns
+	}
+
+	leaf := len(t.leaves)
+	t.leaves = append(t.leaves, node)
+	var columns = make([]joinColumn, len(node.Schema()))
+	for i := range columns {
+		columns[i] = joinColumn{leaf, i}
+	}
+	return columns
+}
+
+func (t *joinTree) collectJoin(left, right sql.Node) []joinColumn {
+	var columns []joinColumn
+	columns = append(columns, t.collect(left)...)
+	columns = append(columns, t.collect(right)...)
+	return columns
+}
+
+// reorderedFields returns the fields of a projection of a join that only
+// reorders its columns, like the ones added by this rule.
+func reorderedFields(p *plan.Project) ([]*expression.GetField, bool) {
+	switch p.Child.(type) {
+	case *plan.InnerJoin, *plan.CrossJoin:
+	default:
+		return nil, false
+	}
+
+	var fields = make([]*expression.GetField, len(p.Projections))
+	for i, e := range p.Projections {
+		f, ok := e.(*expression.GetField)
+		if !ok {
+			return nil, false
+		}
+		fields[i] = f
+	}
+
+	return fields, true
+}
+
+// order returns the order in which the leaves of the tree should be joined,
+// or nil if they can't be reordered.
+func (t *joinTree) order(e *cardinalityEstimator) ([]int, error) {
+	if t.invalid {
+		return nil, nil
+	}
+
+	var rows = make([]float64, len(t.leaves))
+	for i, leaf := range t.leaves {
+		var err error
+		rows[i], err = e.leafRows(leaf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var condLeaves = make([]map[int]bool, len(t.conds))
+	var condSelectivity = make([]float64, len(t.conds))
+	for i, c := range t.conds {
+		s, err := e.selectivity(c.expr, t.conditionColumn(c))
+		if err != nil {
+			return nil, err
+		}
+
+		// Conditions that only use one of the leaves will be moved to it,
+		// so they are part of the estimated rows of the leaf.
+		leaves := c.leaves()
+		if len(leaves) == 1 {
+			for leaf := range leaves {
+				rows[leaf] *= s
+			}
+			continue
+		}
+
+		condLeaves[i] = leaves
+		condSelectivity[i] = s
+	}
+
+	var placed = make(map[int]bool)
+	var order []int
+	var current float64
+	for len(order) < len(t.leaves) {
+		var best = -1
+		var bestRows float64
+		var bestConnected bool
+		for i := range t.leaves {
+			if placed[i] {
+				continue
+			}
+
+			estimated := rows[i]
+			connected := len(order) == 0
+			if len(order) > 0 {
+				estimated *= current
+				for j, leaves := range condLeaves {
+					if !leaves[i] || !containsLeaves(placed, leaves, i) {
+						continue
+					}
+
+					connected = true
+					estimated *= condSelectivity[j]
+				}
+			}
+
+			if best < 0 ||
+				(connected && !bestConnected) ||
+				(connected == bestConnected && lessRows(estimated, bestRows)) {
+				best, bestRows, bestConnected = i, estimated, connected
+			}
+		}
+
+		placed[best] = true
+		order = append(order, best)
+		current = bestRows
+	}
+
+	return order, nil
+}
+
+// conditionColumn returns a function that returns the name of the column
+// of a field of the condition and the leaf it belongs to.
+func (t *joinTree) conditionColumn(c joinCondition) columnFunc {
+	return func(f *expression.GetField) (sql.Node, string) {
+		if f.Index() >= len(c.columns) {
+			return nil, ""
+		}
+
+		col := c.columns[f.Index()]
+		leaf := t.leaves[col.leaf]
+		return leaf, leaf.Schema()[col.index].Name
+	}
+}
+
+// build returns the tree with the leaves joined in the given order, with a
+// projection above that returns the given columns, whose schema is the given
+// one.
+func (t *joinTree) build(order []int, columns []joinColumn, schema sql.Schema) (sql.Node, error) {
+	var offsets = make([]int, len(t.leaves))
+	var placed = make(map[int]bool)
+	var applied = make([]bool, len(t.conds))
+	var node sql.Node
+	var width int
+	for _, leaf := range order {
+		offsets[leaf] = width
+		width += len(t.leaves[leaf].Schema())
+		placed[leaf] = true
+
+		if node == nil {
+			node = t.leaves[leaf]
+			continue
+		}
+
+		var conds []sql.Expression
+		for i, c := range t.conds {
+			if applied[i] || !containsLeaves(placed, c.leaves(), -1) {
+				continue
+			}
+
+			applied[i] = true
+			cond, err := c.expr.TransformUp(func(e sql.Expression) (sql.Expression, error) {
+				f, ok := e.(*expression.GetField)
+				if !ok {
+					return e, nil
+				}
+
+				col := c.columns[f.Index()]
+				return expression.NewGetFieldWithTable(
+					offsets[col.leaf]+col.index,
+					f.Type(),
+					f.Table(),
+					f.Name(),
+					f.IsNullable(),
+				), nil
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			conds = append(conds, cond)
+		}
+
+		if len(conds) > 0 {
+			node = plan.NewInnerJoin(node, t.leaves[leaf], expression.JoinAnd(conds...))
+		} else {
+			node = plan.NewCrossJoin(node, t.leaves[leaf])
+		}
+	}
+
+	var projections = make([]sql.Expression, len(columns))
+	for i, col := range columns {
+		projections[i] = expression.NewGetFieldWithTable(
+			offsets[col.leaf]+col.index,
+			schema[i].Type,
+			schema[i].Source,
+			schema[i].Name,
+			schema[i].Nullable,
+		)
+	}
+
+	return plan.NewProject(projections, node), nil
+}
+
+// containsLeaves returns whether all the given leaves are placed or are the
+// given extra one.
+func containsLeaves(placed, leaves map[int]bool, extra int) bool {
+	for leaf := range leaves {
+		if !placed[leaf] && leaf != extra {
+			return false
+		}
+	}
+	return true
+}
+
+func isIdentityOrder(order []int) bool {
+	for i, leaf := range order {
+		if i != leaf {
+			return false
+		}
+	}
+	return true
+}
+
+// lessRows returns whether a is less than b, ignoring the tiny differences
+// that come from multiplying the same selectivities in different order, so
+// the order of the joins is stable.
+func lessRows(a, b float64) bool {
+	return a < b*(1-1e-9)
+}
+
+// columnFunc returns the node that returns the column of a field, and the
+// name of the column in its table.
+type columnFunc func(*expression.GetField) (sql.Node, string)
+
+// cardinalityEstimator estimates the number of rows returned by the nodes
+// using the statistics of the tables.
+type cardinalityEstimator struct {
+	ctx *sql.Context
+	a   *Analyzer
+}
+
+// leafRows returns the estimated number of rows returned by a node joined
+// by some join.
+func (e *cardinalityEstimator) leafRows(node sql.Node) (float64, error) {
+	table, filters := leafTable(node)
+	stats, err := e.statistics(table)
+	if err != nil {
+		return 0, err
+	}
+
+	var rows float64 = defaultRowCount
+	if stats != nil {
+		rows = float64(stats.RowCount)
+	}
+
+	column := func(f *expression.GetField) (sql.Node, string) {
+		return node, f.Name()
+	}
+
+	for _, f := range filters {
+		s, err := e.selectivity(f, column)
+		if err != nil {
+			return 0, err
+		}
+		rows *= s
+	}
+
+	return rows, nil
+}
+
+// leafTable returns the table read by a node joined by some join, or nil if
+// it's not a table, and the filters applied to its rows.
+func leafTable(node sql.Node) (sql.Table, []sql.Expression) {
+	var filters []sql.Expression
+	for {
+		switch n := node.(type) {
+		case *plan.Filter:
+			filters = append(filters, splitExpression(n.Expression)...)
+			node = n.Child
+		case *plan.TableAlias:
+			node = n.Child
+		case *plan.PushdownProjectionAndFiltersTable:
+			return n.PushdownProjectionAndFiltersTable, append(filters, n.Filters...)
+		case *plan.IndexableTable:
+			return n.Indexable, append(filters, n.Filters...)
+		case *plan.PushdownProjectionTable:
+			return n.PushdownProjectionTable, filters
+		case *plan.SubqueryAlias:
+			return nil, filters
+		case sql.Table:
+			return n, filters
+		default:
+			return nil, filters
+		}
+	}
+}
+
+// statistics returns the statistics of the table, or nil if there are none.
+func (e *cardinalityEstimator) statistics(table sql.Table) (*sql.TableStatistics, error) {
+	if table == nil {
+		return nil, nil
+	}
+
+	if t, ok := table.(sql.StatisticsTable); ok {
+		return t.Statistics(e.ctx)
+	}
+
+	if e.a == nil || e.a.Catalog == nil {
+		return nil, nil
+	}
+
+	return e.a.Catalog.Statistics(e.a.CurrentDatabase, table.Name()), nil
+}
+
+// columnStatistics returns the statistics of the column of the field and
+// the number of rows of its table, or nil if they are unknown.
+func (e *cardinalityEstimator) columnStatistics(
+	f *expression.GetField,
+	column columnFunc,
+) (*sql.ColumnStatistics, float64, error) {
+	node, name := column(f)
+	if node == nil {
+		return nil, 0, nil
+	}
+
+	table, _ := leafTable(node)
+	stats, err := e.statistics(table)
+	if err != nil || stats == nil {
+		return nil, 0, err
+	}
+
+	return stats.Column(name), float64(stats.RowCount), nil
+}
+
+// selectivity returns the estimated fraction of the rows that satisfy the
+// given condition.
+func (e *cardinalityEstimator) selectivity(cond sql.Expression, column columnFunc) (float64, error) {
+	switch c := cond.(type) {
+	case *expression.And:
+		l, err := e.selectivity(c.Left, column)
+		if err != nil {
+			return 0, err
+		}
+
+		r, err := e.selectivity(c.Right, column)
+		if err != nil {
+			return 0, err
+		}
+
+		return l * r, nil
+	case *expression.Or:
+		l, err := e.selectivity(c.Left, column)
+		if err != nil {
+			return 0, err
+		}
+
+		r, err := e.selectivity(c.Right, column)
+		if err != nil {
+			return 0, err
+		}
+
+		return l + r - l*r, nil
+	case *expression.Not:
+		s, err := e.selectivity(c.Child, column)
+		if err != nil {
+			return 0, err
+		}
+
+		return 1 - s, nil
+	case *expression.IsNull:
+		f, ok := c.Child.(*expression.GetField)
+		if !ok {
+			return defaultEqualsSelectivity, nil
+		}
+
+		stats, rows, err := e.columnStatistics(f, column)
+		if err != nil || stats == nil || rows == 0 {
+			return defaultEqualsSelectivity, err
+		}
+
+		return float64(stats.NullCount) / rows, nil
+	case *expression.Equals:
+		return e.equalsSelectivity(c.Left(), c.Right(), column)
+	case *expression.LessThan:
+		return e.rangeSelectivity(c.Left(), c.Right(), true, column)
+	case *expression.LessThanOrEqual:
+		return e.rangeSelectivity(c.Left(), c.Right(), true, column)
+	case *expression.GreaterThan:
+		return e.rangeSelectivity(c.Left(), c.Right(), false, column)
+	case *expression.GreaterThanOrEqual:
+		return e.rangeSelectivity(c.Left(), c.Right(), false, column)
+	default:
+		return defaultSelectivity, nil
+	}
+}
+
+// equalsSelectivity returns the estimated fraction of the rows in which
+// left is equal to right, assuming the values are uniformly distributed
+// among the distinct values of the columns.
+func (e *cardinalityEstimator) equalsSelectivity(
+	left, right sql.Expression,
+	column columnFunc,
+) (float64, error) {
+	var distinct uint64
+	for _, side := range []sql.Expression{left, right} {
+		f, ok := side.(*expression.GetField)
+		if !ok {
+			continue
+		}
+
+		stats, _, err := e.columnStatistics(f, column)
+		if err != nil {
+			return 0, err
+		}
+
+		if stats == nil {
+			return defaultEqualsSelectivity, nil
+		}
+
+		if stats.DistinctCount > distinct {
+			distinct = stats.DistinctCount
+		}
+	}
+
+	if distinct == 0 {
+		return defaultEqualsSelectivity, nil
+	}
+
+	return 1 / float64(distinct), nil
+}
+
+// rangeSelectivity returns the estimated fraction of the rows in which left
+// is less than right if less is true, or greater otherwise, using the
+// histogram of the column when one of the sides is a column and the other
+// a literal.
+func (e *cardinalityEstimator) rangeSelectivity(
+	left, right sql.Expression,
+	less bool,
+	column columnFunc,
+) (float64, error) {
+	f, isField := left.(*expression.GetField)
+	lit, isLiteral := right.(*expression.Literal)
+	if !isField || !isLiteral {
+		f, isField = right.(*expression.GetField)
+		lit, isLiteral = left.(*expression.Literal)
+		less = !less
+	}
+
+	if !isField || !isLiteral {
+		return defaultSelectivity, nil
+	}
+
+	stats, rows, err := e.columnStatistics(f, column)
+	if err != nil || stats == nil || rows == 0 {
+		return defaultSelectivity, err
+	}
+
+	v, err := lit.Eval(e.ctx, nil)
+	if err != nil || v == nil {
+		return defaultSelectivity, nil
+	}
+
+	v, err = f.Type().Convert(v)
+	if err != nil {
+		return defaultSelectivity, nil
+	}
+
+	fraction, ok := stats.Histogram.FractionLessThan(f.Type(), v)
+	if !ok {
+		return defaultSelectivity, nil
+	}
+
+	if !less {
+		fraction = 1 - fraction
+	}
+
+	// The histogram only contains the non-NULL values.
+	return fraction * (rows - float64(stats.NullCount)) / rows, nil
+}
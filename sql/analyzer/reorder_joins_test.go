@@ -0,0 +1,165 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/mem"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+)
+
+func TestReorderJoins(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	a := newTableWithRows(t, "a", 10, "x", "y")
+	b := newTableWithRows(t, "b", 2, "x")
+	c := newTableWithRows(t, "c", 5, "y")
+
+	node := plan.NewInnerJoin(
+		plan.NewInnerJoin(
+			a, b,
+			expression.NewEquals(
+				expression.NewGetFieldWithTable(0, sql.Int64, "a", "x", false),
+				expression.NewGetFieldWithTable(2, sql.Int64, "b", "x", false),
+			),
+		),
+		c,
+		expression.NewEquals(
+			expression.NewGetFieldWithTable(1, sql.Int64, "a", "y", false),
+			expression.NewGetFieldWithTable(3, sql.Int64, "c", "y", false),
+		),
+	)
+
+	// b is the smallest table, a is the only one joined with it and c is
+	// joined last.
+	expected := plan.NewProject(
+		[]sql.Expression{
+			expression.NewGetFieldWithTable(1, sql.Int64, "a", "x", false),
+			expression.NewGetFieldWithTable(2, sql.Int64, "a", "y", false),
+			expression.NewGetFieldWithTable(0, sql.Int64, "b", "x", false),
+			expression.NewGetFieldWithTable(3, sql.Int64, "c", "y", false),
+		},
+		plan.NewInnerJoin(
+			plan.NewInnerJoin(
+				b, a,
+				expression.NewEquals(
+					expression.NewGetFieldWithTable(1, sql.Int64, "a", "x", false),
+					expression.NewGetFieldWithTable(0, sql.Int64, "b", "x", false),
+				),
+			),
+			c,
+			expression.NewEquals(
+				expression.NewGetFieldWithTable(2, sql.Int64, "a", "y", false),
+				expression.NewGetFieldWithTable(3, sql.Int64, "c", "y", false),
+			),
+		),
+	)
+
+	rule := getRule("reorder_joins")
+	result, err := rule.Apply(ctx, nil, node)
+	require.NoError(err)
+	require.Equal(expected, result)
+
+	// Reordered joins are not reordered again.
+	result, err = rule.Apply(ctx, nil, result)
+	require.NoError(err)
+	require.Equal(expected, result)
+
+	rows, err := sql.NodeToRows(ctx, node)
+	require.NoError(err)
+	reordered, err := sql.NodeToRows(ctx, result)
+	require.NoError(err)
+	require.ElementsMatch(rows, reordered)
+}
+
+func TestReorderJoinsFilters(t *testing.T) {
+	require := require.New(t)
+
+	a := newTableWithRows(t, "a", 10, "x")
+	b := newTableWithRows(t, "b", 5, "x")
+
+	// The filter leaves only one row of a, so it's smaller than b.
+	filter := expression.NewEquals(
+		expression.NewGetFieldWithTable(0, sql.Int64, "a", "x", false),
+		expression.NewLiteral(int64(1), sql.Int64),
+	)
+
+	node := plan.NewCrossJoin(b, plan.NewFilter(filter, a))
+	expected := plan.NewProject(
+		[]sql.Expression{
+			expression.NewGetFieldWithTable(1, sql.Int64, "b", "x", false),
+			expression.NewGetFieldWithTable(0, sql.Int64, "a", "x", false),
+		},
+		plan.NewCrossJoin(plan.NewFilter(filter, a), b),
+	)
+
+	result, err := getRule("reorder_joins").Apply(sql.NewEmptyContext(), nil, node)
+	require.NoError(err)
+	require.Equal(expected, result)
+}
+
+func TestReorderJoinsCatalogStatistics(t *testing.T) {
+	require := require.New(t)
+
+	big := &tableWithoutStatistics{mem.NewTable("big", sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "big"},
+	})}
+	small := &tableWithoutStatistics{mem.NewTable("small", sql.Schema{
+		{Name: "y", Type: sql.Int64, Source: "small"},
+	})}
+
+	node := plan.NewCrossJoin(big, small)
+
+	// Without statistics, both tables are assumed to have the same number
+	// of rows and the join is left as is.
+	catalog := sql.NewCatalog()
+	a := NewDefault(catalog)
+	a.CurrentDatabase = "mydb"
+
+	rule := getRule("reorder_joins")
+	result, err := rule.Apply(sql.NewEmptyContext(), a, node)
+	require.NoError(err)
+	require.Equal(node, result)
+
+	catalog.SetStatistics("mydb", "big", &sql.TableStatistics{RowCount: 100})
+	catalog.SetStatistics("mydb", "small", &sql.TableStatistics{RowCount: 10})
+
+	result, err = rule.Apply(sql.NewEmptyContext(), a, node)
+	require.NoError(err)
+	require.Equal(plan.NewProject(
+		[]sql.Expression{
+			expression.NewGetFieldWithTable(1, sql.Int64, "big", "x", false),
+			expression.NewGetFieldWithTable(0, sql.Int64, "small", "y", false),
+		},
+		plan.NewCrossJoin(small, big),
+	), result)
+}
+
+type tableWithoutStatistics struct {
+	sql.Table
+}
+
+func (t *tableWithoutStatistics) TransformUp(f sql.TransformNodeFunc) (sql.Node, error) {
+	return f(t)
+}
+
+func newTableWithRows(t *testing.T, name string, rows int, columns ...string) *mem.Table {
+	var schema = make(sql.Schema, len(columns))
+	for i, col := range columns {
+		schema[i] = &sql.Column{Name: col, Type: sql.Int64, Source: name}
+	}
+
+	table := mem.NewTable(name, schema)
+	for i := 0; i < rows; i++ {
+		var row = make(sql.Row, len(columns))
+		for j := range row {
+			row[j] = int64(i)
+		}
+		require.NoError(t, table.Insert(row))
+	}
+
+	return table
+}
@@ -4,13 +4,16 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
+	"unicode/utf8"
 
 	errors "gopkg.in/src-d/go-errors.v1"
 	"gopkg.in/src-d/go-mysql-server.v0/mem"
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
 	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression/function/aggregation"
 	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
 )
 
@@ -26,10 +29,14 @@ var DefaultRules = []Rule{
 	{"resolve_database", resolveDatabase},
 	{"resolve_star", resolveStar},
 	{"resolve_functions", resolveFunctions},
+	{"fold_constants", foldConstants},
 	{"reorder_projection", reorderProjection},
 	{"assign_indexes", assignIndexes},
+	{"pushdown_filters", pushdownFilters},
+	{"reorder_joins", reorderJoins},
 	{"pushdown", pushdown},
 	{"move_join_conds_to_filter", moveJoinConditionsToFilter},
+	{"index_join", indexJoin},
 	{"optimize_distinct", optimizeDistinct},
 	{"erase_projection", eraseProjection},
 	{"index_catalog", indexCatalog},
@@ -122,6 +129,8 @@ func fixSortDependencies(sort *plan.Sort, missingCols []string) (sql.Node, error
 	switch child := sort.Child.(type) {
 	case *plan.Project:
 		expressions = child.Projections
+	case *plan.Window:
+		expressions = child.SelectExprs
 	case *plan.GroupBy:
 		expressions = child.Aggregate
 	default:
@@ -159,12 +168,20 @@ func fixSortDependencies(sort *plan.Sort, missingCols []string) (sql.Node, error
 				plan.NewProject(newExpressions, child.Child),
 			),
 		), nil
+	case *plan.Window:
+		return plan.NewProject(
+			expressions,
+			plan.NewSort(
+				sort.SortFields,
+				plan.NewWindow(newExpressions, child.Child),
+			),
+		), nil
 	case *plan.GroupBy:
 		return plan.NewProject(
 			expressions,
 			plan.NewSort(
 				sort.SortFields,
-				plan.NewGroupBy(newExpressions, child.Grouping, child.Child),
+				child.WithExpressions(newExpressions, child.Grouping, child.Child),
 			),
 		), nil
 	default:
@@ -173,12 +190,15 @@ func fixSortDependencies(sort *plan.Sort, missingCols []string) (sql.Node, error
 }
 
 // columnsDefinedInNode returns the columns that were defined in this node,
-// which, by definition, can only be plan.Project or plan.GroupBy.
+// which, by definition, can only be plan.Project, plan.Window or
+// plan.GroupBy.
 func columnsDefinedInNode(n sql.Node) []string {
 	var exprs []sql.Expression
 	switch n := n.(type) {
 	case *plan.Project:
 		exprs = n.Projections
+	case *plan.Window:
+		exprs = n.SelectExprs
 	case *plan.GroupBy:
 		exprs = n.Aggregate
 	}
@@ -203,15 +223,20 @@ func pushSortDown(sort *plan.Sort) (sql.Node, error) {
 			child.Projections,
 			plan.NewSort(sort.SortFields, child.Child),
 		), nil
+	case *plan.Window:
+		return plan.NewWindow(
+			child.SelectExprs,
+			plan.NewSort(sort.SortFields, child.Child),
+		), nil
 	case *plan.GroupBy:
-		return plan.NewGroupBy(
+		return child.WithExpressions(
 			child.Aggregate,
 			child.Grouping,
 			plan.NewSort(sort.SortFields, child.Child),
 		), nil
 	default:
-		// Can't do anything here, there should be either a project or a groupby
-		// below an order by.
+		// Can't do anything here, there should be either a project, a window
+		// or a groupby below an order by.
 		return nil, errSortPushdown.New(child)
 	}
 }
@@ -437,6 +462,12 @@ func resolveTables(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error)
 
 		a.Log("table resolved: %q", rt.Name())
 
+		if t.IndexHint != nil {
+			// tables have no place for the index hint, so it's kept in an
+			// alias with the name of the table
+			return plan.NewTableAlias(rt.Name(), rt).WithIndexHint(t.IndexHint), nil
+		}
+
 		return rt, nil
 	})
 }
@@ -565,6 +596,17 @@ func resolveStar(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
 			}
 
 			return plan.NewProject(expressions, n.Child), nil
+		case *plan.Window:
+			if !n.Child.Resolved() {
+				return n, nil
+			}
+
+			expressions, err := expandStars(n.SelectExprs, n.Child.Schema())
+			if err != nil {
+				return nil, err
+			}
+
+			return plan.NewWindow(expressions, n.Child), nil
 		case *plan.GroupBy:
 			if !n.Child.Resolved() {
 				return n, nil
@@ -575,7 +617,7 @@ func resolveStar(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
 				return nil, err
 			}
 
-			return plan.NewGroupBy(aggregate, n.Grouping, n.Child), nil
+			return n.WithExpressions(aggregate, n.Grouping, n.Child), nil
 		default:
 			return n, nil
 		}
@@ -729,7 +771,7 @@ func resolveColumns(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error)
 			// If expressioner and unary node we must take the
 			// child's schema to correctly select the indexes
 			// in the row is going to be evaluated in this node
-			case *plan.Project, *plan.Filter, *plan.GroupBy, *plan.Sort:
+			case *plan.Project, *plan.Window, *plan.Filter, *plan.GroupBy, *plan.Sort:
 				schema = n.Children()[0].Schema()
 			case *plan.CreateIndex:
 				schema = n.Table.Schema()
@@ -783,11 +825,26 @@ func resolveFunctions(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, erro
 				return nil, err
 			}
 
-			rf, err := f.Call(uf.Arguments...)
+			args := uf.Arguments
+			// Aggregations of distinct values can have several arguments,
+			// as in COUNT(DISTINCT a, b), which are aggregated as a tuple.
+			if uf.Distinct && len(args) > 1 {
+				args = []sql.Expression{expression.NewTuple(args...)}
+			}
+
+			rf, err := f.Call(args...)
 			if err != nil {
 				return nil, err
 			}
 
+			if uf.Distinct {
+				agg, ok := rf.(sql.Aggregation)
+				if !ok {
+					return nil, aggregation.ErrDistinctNotAggregation.New(n)
+				}
+				rf = aggregation.NewDistinct(agg)
+			}
+
 			a.Log("resolved function %q", n)
 
 			return rf, nil
@@ -998,6 +1055,11 @@ func indexCatalog(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
 		nc.Catalog = a.Catalog
 		nc.CurrentDatabase = a.CurrentDatabase
 		return &nc, nil
+	case *plan.AnalyzeTable:
+		nc := *node
+		nc.Catalog = a.Catalog
+		nc.CurrentDatabase = a.CurrentDatabase
+		return &nc, nil
 	default:
 		return n, nil
 	}
@@ -1014,7 +1076,7 @@ func pushdown(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
 
 	// don't do pushdown on certain queries
 	switch n.(type) {
-	case *plan.InsertInto, *plan.CreateIndex:
+	case *plan.InsertInto, *plan.CreateIndex, *plan.AnalyzeTable:
 		return n, nil
 	}
 
@@ -1227,6 +1289,7 @@ func assignIndexes(ctx *sql.Context, a *Analyzer, node sql.Node) (sql.Node, erro
 
 	a.Log("assigning indexes, node of type: %T", node)
 
+	hints := getIndexHints(node)
 	var indexes map[string]*indexLookup
 	// release all unused indexes
 	defer func() {
@@ -1249,7 +1312,7 @@ func assignIndexes(ctx *sql.Context, a *Analyzer, node sql.Node) (sql.Node, erro
 		}
 
 		var result map[string]*indexLookup
-		result, err = getIndexes(filter.Expression, a)
+		result, err = getIndexes(filter.Expression, hints, a)
 		if err != nil {
 			return false
 		}
@@ -1429,16 +1492,16 @@ type indexLookup struct {
 	indexes []sql.Index
 }
 
-func getIndexes(e sql.Expression, a *Analyzer) (map[string]*indexLookup, error) {
+func getIndexes(e sql.Expression, hints indexHints, a *Analyzer) (map[string]*indexLookup, error) {
 	var result = make(map[string]*indexLookup)
 	switch e := e.(type) {
 	case *expression.Or:
-		leftIndexes, err := getIndexes(e.Left, a)
+		leftIndexes, err := getIndexes(e.Left, hints, a)
 		if err != nil {
 			return nil, err
 		}
 
-		rightIndexes, err := getIndexes(e.Right, a)
+		rightIndexes, err := getIndexes(e.Right, hints, a)
 		if err != nil {
 			return nil, err
 		}
@@ -1466,7 +1529,7 @@ func getIndexes(e sql.Expression, a *Analyzer) (map[string]*indexLookup, error)
 		}
 
 		if !isEvaluable(left) && isEvaluable(right) {
-			idx := a.Catalog.IndexByExpression(a.CurrentDatabase, left)
+			idx := hints.indexByExpression(a, left)
 			if idx != nil {
 				// release the index if it was not used
 				defer func() {
@@ -1480,11 +1543,36 @@ func getIndexes(e sql.Expression, a *Analyzer) (map[string]*indexLookup, error)
 					return nil, err
 				}
 
-				lookup, err := idx.Get(value)
+				lookup, err := idx.Get(sql.NormalizeKey(left.Type(), value))
 				if err != nil {
 					return nil, err
 				}
 
+				result[idx.Table()] = &indexLookup{
+					lookup:  lookup,
+					indexes: []sql.Index{idx},
+				}
+			}
+		}
+	case *expression.Like:
+		// Take the index of a SOMETHING LIKE 'prefix%' expression only if the
+		// index is sorted, so the strings starting with the prefix of the
+		// pattern can be looked up as a range.
+		if !isEvaluable(e.Left) && isEvaluable(e.Right) {
+			idx := hints.indexByExpression(a, e.Left)
+			if idx != nil {
+				// release the index if it was not used
+				defer func() {
+					if _, ok := result[idx.Table()]; !ok {
+						a.Catalog.ReleaseIndex(idx)
+					}
+				}()
+
+				lookup, err := likeLookup(e, idx)
+				if err != nil || lookup == nil {
+					return result, err
+				}
+
 				result[idx.Table()] = &indexLookup{
 					lookup:  lookup,
 					indexes: []sql.Index{idx},
@@ -1496,7 +1584,7 @@ func getIndexes(e sql.Expression, a *Analyzer) (map[string]*indexLookup, error)
 		// the right branch is evaluable and the indexlookup supports set
 		// operations.
 		if !isEvaluable(e.Left()) && isEvaluable(e.Right()) {
-			idx := a.Catalog.IndexByExpression(a.CurrentDatabase, e.Left())
+			idx := hints.indexByExpression(a, e.Left())
 			if idx != nil {
 				// release the index if it was not used
 				defer func() {
@@ -1515,13 +1603,14 @@ func getIndexes(e sql.Expression, a *Analyzer) (map[string]*indexLookup, error)
 					return nil, errInvalidInRightEvaluation.New(value)
 				}
 
-				lookup, err := idx.Get(values[0])
+				typ := e.Left().Type()
+				lookup, err := idx.Get(sql.NormalizeKey(typ, values[0]))
 				if err != nil {
 					return nil, err
 				}
 
 				for _, v := range values[1:] {
-					lookup2, err := idx.Get(v)
+					lookup2, err := idx.Get(sql.NormalizeKey(typ, v))
 					if err != nil {
 						return nil, err
 					}
@@ -1544,7 +1633,7 @@ func getIndexes(e sql.Expression, a *Analyzer) (map[string]*indexLookup, error)
 		exprs := splitExpression(e)
 		used := make(map[sql.Expression]struct{})
 
-		result, err := getMultiColumnIndexes(exprs, a, used)
+		result, err := getMultiColumnIndexes(exprs, hints, a, used)
 		if err != nil {
 			return nil, err
 		}
@@ -1554,7 +1643,7 @@ func getIndexes(e sql.Expression, a *Analyzer) (map[string]*indexLookup, error)
 				continue
 			}
 
-			indexes, err := getIndexes(e, a)
+			indexes, err := getIndexes(e, hints, a)
 			if err != nil {
 				return nil, err
 			}
@@ -1568,6 +1657,55 @@ func getIndexes(e sql.Expression, a *Analyzer) (map[string]*indexLookup, error)
 	return result, nil
 }
 
+// likeLookup returns the lookup of the strings that start with the prefix of
+// the pattern of the given LIKE expression in the given index, or nil if the
+// index is not sorted or the pattern has no prefix.
+func likeLookup(like *expression.Like, idx sql.Index) (sql.IndexLookup, error) {
+	ascend, ok := idx.(sql.AscendIndex)
+	if !ok {
+		return nil, nil
+	}
+
+	pattern, err := like.Right.Eval(sql.NewEmptyContext(), nil)
+	if err != nil || pattern == nil {
+		return nil, err
+	}
+
+	pattern, err = sql.Text.Convert(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := like.Prefix(pattern.(string))
+	if prefix == "" {
+		return nil, nil
+	}
+
+	key, ok := sql.NormalizeKey(like.Left.Type(), prefix).(string)
+	if !ok || key == "" {
+		return nil, nil
+	}
+
+	if upper, ok := nextPrefix(key); ok {
+		return ascend.AscendRange([]interface{}{key}, []interface{}{upper})
+	}
+
+	return ascend.AscendGreaterOrEqual(key)
+}
+
+// nextPrefix returns the smallest string greater than all the strings that
+// start with the given prefix, if any.
+func nextPrefix(prefix string) (string, bool) {
+	runes := []rune(prefix)
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] < utf8.MaxRune {
+			runes[i]++
+			return string(runes[:i+1]), true
+		}
+	}
+	return "", false
+}
+
 func indexesIntersection(left, right map[string]*indexLookup) map[string]*indexLookup {
 	var result = make(map[string]*indexLookup)
 
@@ -1592,6 +1730,7 @@ func indexesIntersection(left, right map[string]*indexLookup) map[string]*indexL
 
 func getMultiColumnIndexes(
 	exprs []sql.Expression,
+	hints indexHints,
 	a *Analyzer,
 	used map[sql.Expression]struct{},
 ) (map[string]*indexLookup, error) {
@@ -1605,33 +1744,35 @@ func getMultiColumnIndexes(
 
 		exprList := a.Catalog.ExpressionsWithIndexes(a.CurrentDatabase, cols...)
 
-		var selected []sql.Expression
+		// Try first the indexes with more expressions.
+		sort.SliceStable(exprList, func(i, j int) bool {
+			return len(exprList[i]) > len(exprList[j])
+		})
+
+		var index sql.Index
 		for _, l := range exprList {
-			if len(l) > len(selected) {
-				selected = l
+			if index = hints.indexByExpression(a, l...); index != nil {
+				break
 			}
 		}
 
-		if len(selected) > 0 {
-			index := a.Catalog.IndexByExpression(a.CurrentDatabase, selected...)
-			if index != nil {
-				var values = make([]interface{}, len(index.ExpressionHashes()))
-				for i, e := range index.ExpressionHashes() {
-					col := findColumnByHash(exps, e)
-					used[col.expr] = struct{}{}
-					val, err := col.val.Eval(sql.NewEmptyContext(), nil)
-					if err != nil {
-						return nil, err
-					}
-					values[i] = val
-				}
-				lookup, err := index.Get(values...)
+		if index != nil {
+			var values = make([]interface{}, len(index.ExpressionHashes()))
+			for i, e := range index.ExpressionHashes() {
+				col := findColumnByHash(exps, e)
+				used[col.expr] = struct{}{}
+				val, err := col.val.Eval(sql.NewEmptyContext(), nil)
 				if err != nil {
 					return nil, err
 				}
-
-				result[table] = &indexLookup{lookup, []sql.Index{index}}
+				values[i] = sql.NormalizeKey(col.col.Type(), val)
 			}
+			lookup, err := index.Get(values...)
+			if err != nil {
+				return nil, err
+			}
+
+			result[table] = &indexLookup{lookup, []sql.Index{index}}
 		}
 	}
 
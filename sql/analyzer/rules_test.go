@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/require"
 
@@ -360,6 +361,13 @@ func TestResolveTables(t *testing.T) {
 	require.NoError(err)
 	require.Equal(table, analyzed)
 
+	// Index hints are kept in an alias with the name of the table.
+	hint := plan.NewIndexHint(plan.UseIndex, "idx")
+	notAnalyzed = &plan.UnresolvedTable{Name: "mytable", IndexHint: hint}
+	analyzed, err = f.Apply(sql.NewEmptyContext(), a, notAnalyzed)
+	require.NoError(err)
+	require.Equal(plan.NewTableAlias("mytable", table).WithIndexHint(hint), analyzed)
+
 	notAnalyzed = plan.NewUnresolvedTable("dual")
 	analyzed, err = f.Apply(sql.NewEmptyContext(), a, notAnalyzed)
 	require.NoError(err)
@@ -1095,17 +1103,12 @@ func TestPushdownProjectionAndFilters(t *testing.T) {
 		[]sql.Expression{
 			expression.NewGetFieldWithTable(0, sql.Int32, "mytable", "i", false),
 		},
-		plan.NewFilter(
-			expression.NewAnd(
+		plan.NewCrossJoin(
+			plan.NewFilter(
 				expression.NewGreaterThan(
 					expression.NewGetFieldWithTable(1, sql.Float64, "mytable", "f", false),
 					expression.NewLiteral(3., sql.Float64),
 				),
-				expression.NewIsNull(
-					expression.NewGetFieldWithTable(3, sql.Int32, "mytable2", "i2", false),
-				),
-			),
-			plan.NewCrossJoin(
 				plan.NewPushdownProjectionAndFiltersTable(
 					[]sql.Expression{
 						expression.NewGetFieldWithTable(0, sql.Int32, "mytable", "i", false),
@@ -1119,6 +1122,11 @@ func TestPushdownProjectionAndFilters(t *testing.T) {
 					},
 					table,
 				),
+			),
+			plan.NewFilter(
+				expression.NewIsNull(
+					expression.NewGetFieldWithTable(0, sql.Int32, "mytable2", "i2", false),
+				),
 				plan.NewPushdownProjectionAndFiltersTable(
 					[]sql.Expression{
 						expression.NewGetFieldWithTable(0, sql.Int32, "mytable2", "i2", false),
@@ -1297,17 +1305,12 @@ func TestPushdownIndexable(t *testing.T) {
 		[]sql.Expression{
 			expression.NewGetFieldWithTable(0, sql.Int32, "mytable", "i", false),
 		},
-		plan.NewFilter(
-			expression.NewAnd(
+		plan.NewCrossJoin(
+			plan.NewFilter(
 				expression.NewGreaterThan(
 					expression.NewGetFieldWithTable(1, sql.Float64, "mytable", "f", false),
 					expression.NewLiteral(3., sql.Float64),
 				),
-				expression.NewIsNull(
-					expression.NewGetFieldWithTable(3, sql.Int32, "mytable2", "i2", false),
-				),
-			),
-			plan.NewCrossJoin(
 				plan.NewIndexableTable(
 					[]sql.Expression{
 						expression.NewGetFieldWithTable(0, sql.Int32, "mytable", "i", false),
@@ -1322,6 +1325,11 @@ func TestPushdownIndexable(t *testing.T) {
 					lookup,
 					table.Indexable,
 				),
+			),
+			plan.NewFilter(
+				expression.NewIsNull(
+					expression.NewGetFieldWithTable(0, sql.Int32, "mytable2", "i2", false),
+				),
 				plan.NewIndexableTable(
 					[]sql.Expression{
 						expression.NewGetFieldWithTable(0, sql.Int32, "mytable2", "i2", false),
@@ -1911,7 +1919,7 @@ func TestGetIndexes(t *testing.T) {
 		t.Run(tt.expr.String(), func(t *testing.T) {
 			require := require.New(t)
 
-			result, err := getIndexes(tt.expr, a)
+			result, err := getIndexes(tt.expr, nil, a)
 			if tt.ok {
 				require.NoError(err)
 				require.Equal(tt.expected, result)
@@ -1922,6 +1930,98 @@ func TestGetIndexes(t *testing.T) {
 	}
 }
 
+func TestGetIndexesLike(t *testing.T) {
+	name := expression.NewGetFieldWithTable(0, sql.Text, "t1", "name", false)
+	code := expression.NewGetFieldWithTable(1, sql.Text, "t2", "code", false)
+
+	catalog := sql.NewCatalog()
+	indexes := []sql.Index{
+		&ascendIndex{dummyIndex{"t1", []sql.Expression{name}}},
+		&dummyIndex{"t2", []sql.Expression{code}},
+	}
+	for _, idx := range indexes {
+		done, err := catalog.AddIndex(idx)
+		require.NoError(t, err)
+		close(done)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	a := NewDefault(catalog)
+
+	testCases := []struct {
+		expr     sql.Expression
+		expected sql.IndexLookup
+	}{
+		{
+			expression.NewLike(name, expression.NewLiteral("jo%", sql.Text)),
+			&mergeableIndexLookup{id: "[JO, JP)"},
+		},
+		{
+			expression.NewLike(name, expression.NewLiteral(`a\%b%`, sql.Text)),
+			&mergeableIndexLookup{id: "[A%B, A%C)"},
+		},
+		{
+			expression.NewLike(name, expression.NewLiteral("%jo", sql.Text)),
+			nil,
+		},
+		{
+			expression.NewLike(code, expression.NewLiteral("jo%", sql.Text)),
+			nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.expr.String(), func(t *testing.T) {
+			require := require.New(t)
+
+			result, err := getIndexes(tt.expr, nil, a)
+			require.NoError(err)
+			if tt.expected == nil {
+				require.Len(result, 0)
+				return
+			}
+
+			require.Len(result, 1)
+			require.Equal(tt.expected, result["t1"].lookup)
+		})
+	}
+}
+
+type ascendIndex struct {
+	dummyIndex
+}
+
+var _ sql.AscendIndex = (*ascendIndex)(nil)
+
+func (i *ascendIndex) AscendGreaterOrEqual(keys ...interface{}) (sql.IndexLookup, error) {
+	return &mergeableIndexLookup{id: fmt.Sprintf("[%v, ...)", keys[0])}, nil
+}
+
+func (i *ascendIndex) AscendLessThan(keys ...interface{}) (sql.IndexLookup, error) {
+	return &mergeableIndexLookup{id: fmt.Sprintf("[..., %v)", keys[0])}, nil
+}
+
+func (i *ascendIndex) AscendRange(greaterOrEqual, lessThan []interface{}) (sql.IndexLookup, error) {
+	return &mergeableIndexLookup{
+		id: fmt.Sprintf("[%v, %v)", greaterOrEqual[0], lessThan[0]),
+	}, nil
+}
+
+func TestNextPrefix(t *testing.T) {
+	require := require.New(t)
+
+	next, ok := nextPrefix("ab")
+	require.True(ok)
+	require.Equal("ac", next)
+
+	next, ok = nextPrefix("a" + string(utf8.MaxRune))
+	require.True(ok)
+	require.Equal("b", next)
+
+	_, ok = nextPrefix(string(utf8.MaxRune))
+	require.False(ok)
+}
+
 func TestGetMultiColumnIndexes(t *testing.T) {
 	require := require.New(t)
 
@@ -1991,7 +2091,7 @@ func TestGetMultiColumnIndexes(t *testing.T) {
 			lit(6),
 		),
 	}
-	result, err := getMultiColumnIndexes(exprs, a, used)
+	result, err := getMultiColumnIndexes(exprs, nil, a, used)
 	require.NoError(err)
 
 	expected := map[string]*indexLookup{
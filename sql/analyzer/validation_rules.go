@@ -16,6 +16,7 @@ const (
 	validateSchemaSourceRule  = "validate_schema_source"
 	validateProjectTuplesRule = "validate_project_tuples"
 	validateIndexCreationRule = "validate_index_creation"
+	validateIndexHintsRule    = "validate_index_hints"
 )
 
 var (
@@ -46,6 +47,7 @@ var DefaultValidationRules = []Rule{
 	{validateSchemaSourceRule, validateSchemaSource},
 	{validateProjectTuplesRule, validateProjectTuples},
 	{validateIndexCreationRule, validateIndexCreation},
+	{validateIndexHintsRule, validateIndexHints},
 }
 
 func validateIsResolved(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
@@ -190,6 +192,12 @@ func validateProjectTuples(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node,
 				return nil, ErrProjectTuple.New(i+1, sql.NumColumns(e.Type()))
 			}
 		}
+	case *plan.Window:
+		for i, e := range n.SelectExprs {
+			if sql.IsTuple(e.Type()) {
+				return nil, ErrProjectTuple.New(i+1, sql.NumColumns(e.Type()))
+			}
+		}
 	case *plan.GroupBy:
 		for i, e := range n.Aggregate {
 			if sql.IsTuple(e.Type()) {
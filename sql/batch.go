@@ -0,0 +1,120 @@
+package sql
+
+import "io"
+
+// DefaultBatchSize is the number of rows requested in each batch by the
+// nodes that consume their children in batches.
+const DefaultBatchSize = 1024
+
+// BatchIter is an iterator that returns rows in batches, avoiding the cost of
+// a call per row.
+type BatchIter interface {
+	// NextBatch retrieves at most max rows. It never returns an empty batch
+	// without an error, and it will return io.EOF once there are no more
+	// rows. The returned slice is owned by the caller.
+	NextBatch(max int) ([]Row, error)
+	// Close the iterator.
+	Close() error
+}
+
+// RowBatchIter is an iterator that can return rows both one by one and in
+// batches. Calls to Next and NextBatch can be mixed.
+type RowBatchIter interface {
+	RowIter
+	BatchIter
+}
+
+// ToBatchIter returns the given iterator as a BatchIter. If the iterator does
+// not implement BatchIter, it's wrapped so that batches are built calling
+// Next.
+func ToBatchIter(iter RowIter) BatchIter {
+	if b, ok := iter.(BatchIter); ok {
+		return b
+	}
+
+	return &rowsToBatchIter{iter}
+}
+
+// ToRowIter returns the given iterator as a RowIter. If the iterator does not
+// implement RowIter, it's wrapped so that rows are returned one by one from
+// the batches returned by NextBatch.
+func ToRowIter(iter BatchIter) RowIter {
+	if r, ok := iter.(RowIter); ok {
+		return r
+	}
+
+	return &batchToRowsIter{iter: iter}
+}
+
+type rowsToBatchIter struct {
+	RowIter
+}
+
+func (i *rowsToBatchIter) NextBatch(max int) ([]Row, error) {
+	return nextBatch(i.RowIter, max)
+}
+
+// nextBatch builds a batch of at most max rows calling Next on the given
+// iterator.
+func nextBatch(iter RowIter, max int) ([]Row, error) {
+	var rows []Row
+	for len(rows) < max {
+		row, err := iter.Next()
+		if err == io.EOF {
+			if len(rows) > 0 {
+				return rows, nil
+			}
+			return nil, io.EOF
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+type batchToRowsIter struct {
+	iter  BatchIter
+	batch []Row
+	idx   int
+}
+
+func (i *batchToRowsIter) Next() (Row, error) {
+	if i.idx >= len(i.batch) {
+		batch, err := i.iter.NextBatch(DefaultBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		i.batch = batch
+		i.idx = 0
+	}
+
+	row := i.batch[i.idx]
+	i.idx++
+	return row, nil
+}
+
+func (i *batchToRowsIter) NextBatch(max int) ([]Row, error) {
+	if i.idx < len(i.batch) {
+		end := i.idx + max
+		if end > len(i.batch) {
+			end = len(i.batch)
+		}
+
+		rows := i.batch[i.idx:end:end]
+		i.idx = end
+		return rows, nil
+	}
+
+	return i.iter.NextBatch(max)
+}
+
+func (i *batchToRowsIter) Close() error {
+	i.batch = nil
+	return i.iter.Close()
+}
@@ -0,0 +1,101 @@
+package sql
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type sliceBatchIter struct {
+	rows   []Row
+	closed bool
+}
+
+func (i *sliceBatchIter) NextBatch(max int) ([]Row, error) {
+	if len(i.rows) == 0 {
+		return nil, io.EOF
+	}
+
+	if max > len(i.rows) {
+		max = len(i.rows)
+	}
+
+	rows := i.rows[:max]
+	i.rows = i.rows[max:]
+	return rows, nil
+}
+
+func (i *sliceBatchIter) Close() error {
+	i.closed = true
+	return nil
+}
+
+func TestToBatchIter(t *testing.T) {
+	require := require.New(t)
+
+	rows := []Row{NewRow(1), NewRow(2), NewRow(3), NewRow(4), NewRow(5)}
+	iter := ToBatchIter(RowsToRowIter(rows...))
+
+	batch, err := iter.NextBatch(2)
+	require.NoError(err)
+	require.Equal(rows[:2], batch)
+
+	batch, err = iter.NextBatch(2)
+	require.NoError(err)
+	require.Equal(rows[2:4], batch)
+
+	batch, err = iter.NextBatch(2)
+	require.NoError(err)
+	require.Equal(rows[4:], batch)
+
+	_, err = iter.NextBatch(2)
+	require.Equal(io.EOF, err)
+	require.NoError(iter.Close())
+
+	// Iterators that already implement BatchIter are not wrapped.
+	r := ToRowIter(&sliceBatchIter{})
+	require.True(r == ToBatchIter(r).(RowIter))
+}
+
+func TestToRowIter(t *testing.T) {
+	require := require.New(t)
+
+	rows := []Row{NewRow(1), NewRow(2), NewRow(3), NewRow(4), NewRow(5)}
+	b := &sliceBatchIter{rows: rows}
+	iter := ToRowIter(b)
+
+	row, err := iter.Next()
+	require.NoError(err)
+	require.Equal(rows[0], row)
+
+	// Rows already fetched from the batch iterator are returned first.
+	batch, err := ToBatchIter(iter).NextBatch(2)
+	require.NoError(err)
+	require.Equal(rows[1:3], batch)
+
+	result, err := RowIterToRows(iter)
+	require.NoError(err)
+	require.Equal(rows[3:], result)
+	require.True(b.closed)
+}
+
+func TestSpanIterNextBatch(t *testing.T) {
+	require := require.New(t)
+
+	rows := []Row{NewRow(1), NewRow(2), NewRow(3)}
+	span, _ := NewEmptyContext().Span("test")
+	iter := NewSpanIter(span, RowsToRowIter(rows...)).(RowBatchIter)
+
+	batch, err := iter.NextBatch(2)
+	require.NoError(err)
+	require.Equal(rows[:2], batch)
+
+	row, err := iter.Next()
+	require.NoError(err)
+	require.Equal(rows[2], row)
+
+	_, err = iter.NextBatch(2)
+	require.Equal(io.EOF, err)
+	require.NoError(iter.Close())
+}
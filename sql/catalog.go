@@ -1,6 +1,8 @@
 package sql
 
 import (
+	"sync/atomic"
+
 	"gopkg.in/src-d/go-errors.v1"
 )
 
@@ -12,17 +14,72 @@ type Catalog struct {
 	Databases
 	FunctionRegistry
 	*IndexRegistry
+	*StatisticsRegistry
+
+	version uint64
 }
 
 // NewCatalog returns a new empty Catalog.
 func NewCatalog() *Catalog {
 	return &Catalog{
-		Databases:        Databases{},
-		FunctionRegistry: NewFunctionRegistry(),
-		IndexRegistry:    NewIndexRegistry(),
+		Databases:          Databases{},
+		FunctionRegistry:   NewFunctionRegistry(),
+		IndexRegistry:      NewIndexRegistry(),
+		StatisticsRegistry: NewStatisticsRegistry(),
 	}
 }
 
+// AddDatabase adds a new database to the catalog.
+func (c *Catalog) AddDatabase(db Database) {
+	c.Databases.AddDatabase(db)
+	c.Invalidate()
+}
+
+// RegisterFunction registers a function with the given name.
+func (c *Catalog) RegisterFunction(name string, f Function) {
+	c.FunctionRegistry.RegisterFunction(name, f)
+	c.Invalidate()
+}
+
+// RegisterFunctions registers a map of functions.
+func (c *Catalog) RegisterFunctions(funcs Functions) {
+	c.FunctionRegistry.RegisterFunctions(funcs)
+	c.Invalidate()
+}
+
+// SetStatistics sets the statistics of the given table of the given
+// database. The catalog is invalidated, as the best plans of the queries
+// using the table may have changed.
+func (c *Catalog) SetStatistics(db, table string, stats *TableStatistics) {
+	c.StatisticsRegistry.SetStatistics(db, table, stats)
+	c.Invalidate()
+}
+
+// Invalidate reports that something in the catalog has changed, such as the
+// tables of a database, so anything computed from the previous state of the
+// catalog, like the analyzed plans of queries, must not be used anymore.
+func (c *Catalog) Invalidate() {
+	atomic.AddUint64(&c.version, 1)
+}
+
+// Version returns the current version of the catalog, which changes every
+// time databases, functions or indexes are added or removed, the tables of a
+// VersionedDatabase change, or the catalog is invalidated.
+func (c *Catalog) Version() uint64 {
+	v := atomic.LoadUint64(&c.version)
+	if c.IndexRegistry != nil {
+		v += c.IndexRegistry.Version()
+	}
+
+	for _, db := range c.Databases {
+		if db, ok := db.(VersionedDatabase); ok {
+			v += db.Version()
+		}
+	}
+
+	return v
+}
+
 // Databases is a collection of Database.
 type Databases []Database
 
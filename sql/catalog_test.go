@@ -47,3 +47,23 @@ func TestCatalog_Table(t *testing.T) {
 	require.NoError(err)
 	require.Equal(mytable, table)
 }
+
+func TestCatalog_Version(t *testing.T) {
+	require := require.New(t)
+
+	c := sql.NewCatalog()
+	mydb := mem.NewDatabase("foo")
+	c.AddDatabase(mydb)
+
+	version := c.Version()
+	mydb.AddTable("bar", mem.NewTable("bar", nil))
+	require.True(c.Version() > version)
+
+	version = c.Version()
+	require.NoError(mydb.Create("baz", nil))
+	require.True(c.Version() > version)
+
+	version = c.Version()
+	c.Invalidate()
+	require.True(c.Version() > version)
+}
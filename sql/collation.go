@@ -0,0 +1,132 @@
+package sql
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// Collation is the set of rules used to compare strings. Its value is the
+// id of the collation in MySQL.
+type Collation uint16
+
+const (
+	// CollationUtf8mb4GeneralCI is a case-insensitive collation of utf8mb4
+	// strings that ignores trailing spaces.
+	CollationUtf8mb4GeneralCI Collation = 45
+	// CollationUtf8mb4Bin is a collation of utf8mb4 strings that compares
+	// them by their code points, ignoring trailing spaces.
+	CollationUtf8mb4Bin Collation = 46
+	// CollationBinary is the collation of binary strings, which compares
+	// them byte by byte.
+	CollationBinary Collation = 63
+
+	// DefaultCollation is the collation of the string types that are defined
+	// without an explicit collation.
+	DefaultCollation = CollationUtf8mb4GeneralCI
+)
+
+var (
+	// ErrUnknownCollation is returned when a collation does not exist or
+	// is not supported.
+	ErrUnknownCollation = errors.NewKind("unknown collation: %s")
+
+	// ErrInvalidCollation is returned when a collation is applied to a type
+	// that is not a character string type.
+	ErrInvalidCollation = errors.NewKind("collation %s is not valid for type %v")
+
+	// ErrIllegalCollationMix is returned when strings with different
+	// collations are compared and none of them takes precedence.
+	ErrIllegalCollationMix = errors.NewKind("illegal mix of collations (%s) and (%s)")
+)
+
+var collationNames = map[Collation]string{
+	CollationUtf8mb4GeneralCI: "utf8mb4_general_ci",
+	CollationUtf8mb4Bin:       "utf8mb4_bin",
+	CollationBinary:           "binary",
+}
+
+// ParseCollation returns the collation with the given name.
+func ParseCollation(name string) (Collation, error) {
+	name = strings.ToLower(name)
+	for c, n := range collationNames {
+		if n == name {
+			return c, nil
+		}
+	}
+
+	return 0, ErrUnknownCollation.New(name)
+}
+
+// String returns the name of the collation.
+func (c Collation) String() string {
+	return collationNames[c]
+}
+
+// Compare compares two strings using the collation. The result will be 0 if
+// a==b, -1 if a < b, and +1 if a > b.
+func (c Collation) Compare(a, b string) int {
+	return strings.Compare(c.Key(a), c.Key(b))
+}
+
+// Key returns the string that is used in place of s to compare, hash or
+// index it, so that all the strings that are equal according to the
+// collation have the same key.
+func (c Collation) Key(s string) string {
+	switch c {
+	case CollationBinary:
+		return s
+	case CollationUtf8mb4Bin:
+		return strings.TrimRight(s, " ")
+	default:
+		return strings.ToUpper(strings.TrimRight(s, " "))
+	}
+}
+
+// WithCollation returns the given character string type with the given
+// collation.
+func WithCollation(t Type, c Collation) (Type, error) {
+	switch t := t.(type) {
+	case textT:
+		t.collation = c
+		return t, nil
+	case charT:
+		t.collation = c
+		return t, nil
+	default:
+		return nil, ErrInvalidCollation.New(c, t)
+	}
+}
+
+// CollationOf returns the collation of the given type. Character string
+// types that are not defined with an explicit collation have the default
+// one, and any other type has the binary collation.
+func CollationOf(t Type) Collation {
+	switch t := t.(type) {
+	case textT:
+		return t.collation
+	case charT:
+		return t.collation
+	case enumT, setT:
+		return DefaultCollation
+	default:
+		return CollationBinary
+	}
+}
+
+// NormalizeKey returns the value used as key for the value v of type t in
+// hashes, grouping keys and indexes, so that all values that are equal
+// according to the type have the same key. Strings are replaced with their
+// collation key and decimals with their string representation, because
+// their Go representation contains pointers.
+func NormalizeKey(t Type, v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return CollationOf(t).Key(v)
+	case decimal.Decimal:
+		return v.String()
+	default:
+		return v
+	}
+}
@@ -0,0 +1,88 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCollation(t *testing.T) {
+	require := require.New(t)
+
+	c, err := ParseCollation("utf8mb4_general_ci")
+	require.NoError(err)
+	require.Equal(CollationUtf8mb4GeneralCI, c)
+
+	c, err = ParseCollation("UTF8MB4_BIN")
+	require.NoError(err)
+	require.Equal(CollationUtf8mb4Bin, c)
+
+	c, err = ParseCollation("binary")
+	require.NoError(err)
+	require.Equal(CollationBinary, c)
+	require.Equal("binary", c.String())
+
+	_, err = ParseCollation("latin1_swedish_ci")
+	require.True(ErrUnknownCollation.Is(err))
+}
+
+func TestCollationCompare(t *testing.T) {
+	testCases := []struct {
+		collation Collation
+		a, b      string
+		expected  int
+	}{
+		{CollationUtf8mb4GeneralCI, "john", "John", 0},
+		{CollationUtf8mb4GeneralCI, "john ", "JOHN", 0},
+		{CollationUtf8mb4GeneralCI, "ana", "Bob", -1},
+		{CollationUtf8mb4Bin, "john", "John", 1},
+		{CollationUtf8mb4Bin, "john ", "john", 0},
+		{CollationBinary, "john ", "john", 1},
+		{CollationBinary, "A", "a", -1},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.collation.String()+" "+tt.a+" "+tt.b, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.collation.Compare(tt.a, tt.b))
+		})
+	}
+}
+
+func TestWithCollation(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(DefaultCollation, CollationOf(Text))
+	require.Equal(DefaultCollation, CollationOf(MustVarChar(10)))
+	require.Equal(CollationBinary, CollationOf(Blob))
+	require.Equal(CollationBinary, CollationOf(Int64))
+
+	typ, err := WithCollation(Text, CollationUtf8mb4Bin)
+	require.NoError(err)
+	require.True(IsText(typ))
+	require.Equal(CollationUtf8mb4Bin, CollationOf(typ))
+	require.NotEqual(Text, typ)
+	lt(t, typ, "A", "a")
+	eq(t, Text, "A", "a")
+
+	typ, err = WithCollation(MustChar(3), CollationBinary)
+	require.NoError(err)
+	require.Equal(CollationBinary, CollationOf(typ))
+	gt(t, typ, "a", "A")
+
+	_, err = WithCollation(Int64, CollationBinary)
+	require.True(ErrInvalidCollation.Is(err))
+}
+
+func TestNormalizeKey(t *testing.T) {
+	require := require.New(t)
+
+	bin, err := WithCollation(Text, CollationUtf8mb4Bin)
+	require.NoError(err)
+
+	require.Equal("JOHN", NormalizeKey(Text, "John "))
+	require.Equal("John", NormalizeKey(bin, "John "))
+	require.Equal("John ", NormalizeKey(Blob, "John "))
+	require.Equal("1.5", NormalizeKey(MustDecimal(5, 2), decimal.New(150, -2)))
+	require.Equal(int64(1), NormalizeKey(Int64, int64(1)))
+}
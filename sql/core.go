@@ -79,6 +79,16 @@ type Expression interface {
 	Children() []Expression
 }
 
+// NonDeterministicExpression is an expression which can return different
+// values each time it's evaluated, even with the same arguments, or whose
+// value depends on the execution of the query, such as the time at which it
+// started, so it cannot be replaced by its value during the analysis.
+type NonDeterministicExpression interface {
+	Expression
+	// IsNonDeterministic returns whether the expression is non-deterministic.
+	IsNonDeterministic() bool
+}
+
 // ExpressionHash is a SHA-1 checksum
 type ExpressionHash []byte
 
@@ -202,6 +212,16 @@ type Database interface {
 	Tables() map[string]Table
 }
 
+// VersionedDatabase should be implemented by databases whose tables can
+// change. The version must change every time a table is added or removed,
+// so anything computed from the previous tables, like the analyzed plans of
+// queries, is not used anymore.
+type VersionedDatabase interface {
+	Database
+	// Version returns the current version of the tables of the database.
+	Version() uint64
+}
+
 // Alterable should be implemented by databases that can handle DDL statements
 type Alterable interface {
 	Create(name string, schema Schema) error
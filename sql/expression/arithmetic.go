@@ -3,6 +3,7 @@ package expression
 import (
 	"fmt"
 
+	"github.com/shopspring/decimal"
 	errors "gopkg.in/src-d/go-errors.v1"
 	"gopkg.in/src-d/go-vitess.v0/vt/sqlparser"
 
@@ -87,6 +88,14 @@ func (a *Arithmetic) String() string {
 	return fmt.Sprintf("%s %s %s", a.Left, a.op, a.Right)
 }
 
+// divPrecisionIncrement is the number of digits by which the scale of the
+// dividend is increased in the result of a division between decimals.
+const divPrecisionIncrement = 4
+
+// decimalOperand is the type used to convert the operands of an operation
+// between decimals before computing it.
+var decimalOperand = sql.MustDecimal(sql.DecimalMaxPrecision, sql.DecimalMaxScale)
+
 // Type returns the greatest type for given operation.
 func (a *Arithmetic) Type() sql.Type {
 	switch a.op {
@@ -98,12 +107,22 @@ func (a *Arithmetic) Type() sql.Type {
 			return sql.Int64
 		}
 
+		if a.isFixedPoint() {
+			return a.decimalType()
+		}
+
 		return sql.Float64
 
 	case sqlparser.ShiftLeftStr, sqlparser.ShiftRightStr:
 		return sql.Uint64
 
-	case sqlparser.BitAndStr, sqlparser.BitOrStr, sqlparser.BitXorStr, sqlparser.IntDivStr, sqlparser.ModStr:
+	case sqlparser.ModStr:
+		if a.isFixedPoint() {
+			return a.decimalType()
+		}
+		fallthrough
+
+	case sqlparser.BitAndStr, sqlparser.BitOrStr, sqlparser.BitXorStr, sqlparser.IntDivStr:
 		if sql.IsUnsigned(a.Left.Type()) && sql.IsUnsigned(a.Right.Type()) {
 			return sql.Uint64
 		}
@@ -113,6 +132,56 @@ func (a *Arithmetic) Type() sql.Type {
 	return sql.Float64
 }
 
+// isFixedPoint returns whether the operation is between fixed-point decimals
+// or between a fixed-point decimal and an integer, in which case the result
+// is an exact decimal.
+func (a *Arithmetic) isFixedPoint() bool {
+	l, r := a.Left.Type(), a.Right.Type()
+	return (sql.IsFixedPoint(l) || sql.IsFixedPoint(r)) &&
+		(sql.IsFixedPoint(l) || sql.IsInteger(l)) &&
+		(sql.IsFixedPoint(r) || sql.IsInteger(r))
+}
+
+// decimalType returns the decimal type of the result of the operation, with
+// enough precision and scale to hold it, following the same rules as MySQL.
+func (a *Arithmetic) decimalType() sql.Type {
+	p1, s1 := sql.NumericPrecision(a.Left.Type())
+	p2, s2 := sql.NumericPrecision(a.Right.Type())
+
+	var precision, scale int
+	switch a.op {
+	case sqlparser.PlusStr, sqlparser.MinusStr:
+		scale = max(int(s1), int(s2))
+		precision = max(int(p1-s1), int(p2-s2)) + scale + 1
+	case sqlparser.MultStr:
+		scale = int(s1) + int(s2)
+		precision = int(p1) + int(p2)
+	case sqlparser.DivStr:
+		scale = int(s1) + divPrecisionIncrement
+		precision = int(p1-s1) + int(s2) + scale
+	case sqlparser.ModStr:
+		scale = max(int(s1), int(s2))
+		precision = max(int(p1-s1), int(p2-s2)) + scale
+	}
+
+	if scale > sql.DecimalMaxScale {
+		scale = sql.DecimalMaxScale
+	}
+
+	if precision > sql.DecimalMaxPrecision {
+		precision = sql.DecimalMaxPrecision
+	}
+
+	return sql.MustDecimal(uint8(precision), uint8(scale))
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 // TransformUp implements the Expression interface.
 func (a *Arithmetic) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
 	l, err := a.Left.TransformUp(f)
@@ -138,6 +207,10 @@ func (a *Arithmetic) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, err
 	}
 
+	if a.isFixedPoint() && a.isDecimalOp() {
+		return a.evalDecimal(lval, rval)
+	}
+
 	lval, rval, err = a.convertLeftRight(lval, rval)
 	if err != nil {
 		return nil, err
@@ -171,6 +244,59 @@ func (a *Arithmetic) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 	return nil, errUnableToEval.New(lval, a.op, rval)
 }
 
+// isDecimalOp returns whether the operation is computed between decimals
+// when its operands are fixed-point decimals. Bit operations are always
+// computed between integers.
+func (a *Arithmetic) isDecimalOp() bool {
+	switch a.op {
+	case sqlparser.PlusStr, sqlparser.MinusStr, sqlparser.MultStr,
+		sqlparser.DivStr, sqlparser.IntDivStr, sqlparser.ModStr:
+		return true
+	default:
+		return false
+	}
+}
+
+func (a *Arithmetic) evalDecimal(lval, rval interface{}) (interface{}, error) {
+	if lval == nil || rval == nil {
+		return nil, nil
+	}
+
+	l, err := decimalOperand.Convert(lval)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := decimalOperand.Convert(rval)
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+	switch a.op {
+	case sqlparser.PlusStr:
+		result, err = plus(l, r)
+	case sqlparser.MinusStr:
+		result, err = minus(l, r)
+	case sqlparser.MultStr:
+		result, err = mult(l, r)
+	case sqlparser.DivStr:
+		result, err = div(l, r)
+	case sqlparser.IntDivStr:
+		result, err = intDiv(l, r)
+	case sqlparser.ModStr:
+		result, err = mod(l, r)
+	default:
+		return nil, errUnableToEval.New(lval, a.op, rval)
+	}
+
+	if err != nil || result == nil {
+		return nil, err
+	}
+
+	return a.Type().Convert(result)
+}
+
 func (a *Arithmetic) evalLeftRight(ctx *sql.Context, row sql.Row) (interface{}, interface{}, error) {
 	lval, err := a.Left.Eval(ctx, row)
 	if err != nil {
@@ -203,6 +329,12 @@ func (a *Arithmetic) convertLeftRight(lval interface{}, rval interface{}) (inter
 
 func plus(lval, rval interface{}) (interface{}, error) {
 	switch l := lval.(type) {
+	case decimal.Decimal:
+		switch r := rval.(type) {
+		case decimal.Decimal:
+			return l.Add(r), nil
+		}
+
 	case uint64:
 		switch r := rval.(type) {
 		case uint64:
@@ -227,6 +359,12 @@ func plus(lval, rval interface{}) (interface{}, error) {
 
 func minus(lval, rval interface{}) (interface{}, error) {
 	switch l := lval.(type) {
+	case decimal.Decimal:
+		switch r := rval.(type) {
+		case decimal.Decimal:
+			return l.Sub(r), nil
+		}
+
 	case uint64:
 		switch r := rval.(type) {
 		case uint64:
@@ -251,6 +389,12 @@ func minus(lval, rval interface{}) (interface{}, error) {
 
 func mult(lval, rval interface{}) (interface{}, error) {
 	switch l := lval.(type) {
+	case decimal.Decimal:
+		switch r := rval.(type) {
+		case decimal.Decimal:
+			return l.Mul(r), nil
+		}
+
 	case uint64:
 		switch r := rval.(type) {
 		case uint64:
@@ -275,15 +419,31 @@ func mult(lval, rval interface{}) (interface{}, error) {
 
 func div(lval, rval interface{}) (interface{}, error) {
 	switch l := lval.(type) {
+	case decimal.Decimal:
+		switch r := rval.(type) {
+		case decimal.Decimal:
+			// As in MySQL, division by zero returns NULL.
+			if r.Sign() == 0 {
+				return nil, nil
+			}
+			return l.DivRound(r, sql.DecimalMaxScale), nil
+		}
+
 	case uint64:
 		switch r := rval.(type) {
 		case uint64:
+			if r == 0 {
+				return nil, nil
+			}
 			return l / r, nil
 		}
 
 	case int64:
 		switch r := rval.(type) {
 		case int64:
+			if r == 0 {
+				return nil, nil
+			}
 			return l / r, nil
 		}
 
@@ -377,15 +537,31 @@ func shiftRight(lval, rval interface{}) (interface{}, error) {
 
 func intDiv(lval, rval interface{}) (interface{}, error) {
 	switch l := lval.(type) {
+	case decimal.Decimal:
+		switch r := rval.(type) {
+		case decimal.Decimal:
+			if r.Sign() == 0 {
+				return nil, nil
+			}
+			q, _ := l.QuoRem(r, 0)
+			return q, nil
+		}
+
 	case uint64:
 		switch r := rval.(type) {
 		case uint64:
+			if r == 0 {
+				return nil, nil
+			}
 			return uint64(l / r), nil
 		}
 
 	case int64:
 		switch r := rval.(type) {
 		case int64:
+			if r == 0 {
+				return nil, nil
+			}
 			return int64(l / r), nil
 		}
 	}
@@ -395,15 +571,31 @@ func intDiv(lval, rval interface{}) (interface{}, error) {
 
 func mod(lval, rval interface{}) (interface{}, error) {
 	switch l := lval.(type) {
+	case decimal.Decimal:
+		switch r := rval.(type) {
+		case decimal.Decimal:
+			if r.Sign() == 0 {
+				return nil, nil
+			}
+			_, rem := l.QuoRem(r, 0)
+			return rem, nil
+		}
+
 	case uint64:
 		switch r := rval.(type) {
 		case uint64:
+			if r == 0 {
+				return nil, nil
+			}
 			return l % r, nil
 		}
 
 	case int64:
 		switch r := rval.(type) {
 		case int64:
+			if r == 0 {
+				return nil, nil
+			}
 			return l % r, nil
 		}
 	}
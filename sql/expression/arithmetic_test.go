@@ -3,6 +3,7 @@ package expression
 import (
 	"testing"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
 )
@@ -125,6 +126,84 @@ func TestDiv(t *testing.T) {
 	}
 }
 
+func TestDecimalArithmetic(t *testing.T) {
+	dec := func(s string) decimal.Decimal {
+		d, err := decimal.NewFromString(s)
+		require.NoError(t, err)
+		return d
+	}
+
+	price := NewLiteral(dec("10.25"), sql.MustDecimal(10, 2))
+	rate := NewLiteral(dec("0.1"), sql.MustDecimal(3, 1))
+	qty := NewLiteral(int64(3), sql.Int64)
+
+	var testCases = []struct {
+		name     string
+		expr     *Arithmetic
+		typ      sql.Type
+		expected interface{}
+	}{
+		{"decimal + decimal", NewPlus(price, rate), sql.MustDecimal(11, 2), dec("10.35")},
+		{"decimal - int", NewMinus(price, qty), sql.MustDecimal(23, 2), dec("7.25")},
+		{"decimal * decimal", NewMult(price, rate), sql.MustDecimal(13, 3), dec("1.025")},
+		{"decimal * int", NewMult(price, qty), sql.MustDecimal(30, 2), dec("30.75")},
+		{"decimal / int", NewDiv(price, qty), sql.MustDecimal(14, 6), dec("3.416667")},
+		{"int / decimal", NewDiv(qty, rate), sql.MustDecimal(25, 4), dec("30")},
+		{"decimal / 0", NewDiv(price, NewLiteral(int64(0), sql.Int64)), sql.MustDecimal(14, 6), nil},
+		{"decimal + float", NewPlus(price, NewLiteral(0.5, sql.Float64)), sql.Float64, float64(10.75)},
+		{"decimal % int", NewMod(price, qty), sql.MustDecimal(22, 2), dec("1.25")},
+		{"-decimal % int", NewMod(NewLiteral(dec("-10.25"), sql.MustDecimal(10, 2)), qty), sql.MustDecimal(22, 2), dec("-1.25")},
+		{"decimal % decimal", NewMod(price, rate), sql.MustDecimal(10, 2), dec("0.05")},
+		{"decimal % 0", NewMod(price, NewLiteral(int64(0), sql.Int64)), sql.MustDecimal(22, 2), nil},
+		{"decimal div int", NewIntDiv(price, qty), sql.Int64, int64(3)},
+		{"decimal div 0", NewIntDiv(price, NewLiteral(int64(0), sql.Int64)), sql.Int64, nil},
+		{"decimal & int", NewBitAnd(price, qty), sql.Int64, int64(2)},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			require.Equal(tt.typ, tt.expr.Type())
+
+			result, err := tt.expr.Eval(sql.NewEmptyContext(), sql.NewRow())
+			require.NoError(err)
+			if tt.expected == nil {
+				require.Nil(result)
+				return
+			}
+
+			if d, ok := tt.expected.(decimal.Decimal); ok {
+				require.True(d.Equal(result.(decimal.Decimal)), "expected %s, got %s", d, result)
+				return
+			}
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
+func TestIntegerDivisionByZero(t *testing.T) {
+	var testCases = []struct {
+		name string
+		expr *Arithmetic
+	}{
+		{"int / 0", NewDiv(NewLiteral(int64(1), sql.Int64), NewLiteral(int64(0), sql.Int64))},
+		{"uint / 0", NewDiv(NewLiteral(uint64(1), sql.Uint64), NewLiteral(uint64(0), sql.Uint64))},
+		{"int div 0", NewIntDiv(NewLiteral(int64(1), sql.Int64), NewLiteral(int64(0), sql.Int64))},
+		{"uint div 0", NewIntDiv(NewLiteral(uint64(1), sql.Uint64), NewLiteral(uint64(0), sql.Uint64))},
+		{"int % 0", NewMod(NewLiteral(int64(1), sql.Int64), NewLiteral(int64(0), sql.Int64))},
+		{"uint % 0", NewMod(NewLiteral(uint64(1), sql.Uint64), NewLiteral(uint64(0), sql.Uint64))},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.expr.Eval(sql.NewEmptyContext(), sql.NewRow())
+			require.NoError(err)
+			require.Nil(result)
+		})
+	}
+}
+
 func TestShiftLeft(t *testing.T) {
 	var testCases = []struct {
 		name        string
@@ -2,6 +2,10 @@ package expression
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cast"
 
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
 )
@@ -50,3 +54,24 @@ func (e *Not) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
 	}
 	return f(NewNot(child))
 }
+
+// IsTrue returns whether the given value satisfies a condition. NULL never
+// does, and any other value does if it is a number other than zero once
+// converted to one.
+func IsTrue(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case decimal.Decimal:
+		return !v.IsZero()
+	case time.Time:
+		return !v.IsZero()
+	case time.Duration:
+		return v != 0
+	default:
+		f, err := cast.ToFloat64E(v)
+		return err == nil && f != 0
+	}
+}
@@ -0,0 +1,217 @@
+package expression
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// CaseBranch is a WHEN ... THEN ... branch of a CASE expression.
+type CaseBranch struct {
+	Cond  sql.Expression
+	Value sql.Expression
+}
+
+// Case is an expression that returns the value of the first branch whose
+// condition is true, or the value of its ELSE clause if there is none. If
+// the CASE has an expression, a branch is taken when its condition is equal
+// to that expression. Only the conditions up to the first branch taken and
+// the value of that branch are evaluated.
+type Case struct {
+	Expr     sql.Expression
+	Branches []CaseBranch
+	Else     sql.Expression
+}
+
+// NewCase returns a new Case expression. Both expr and elseExpr may be nil.
+func NewCase(expr sql.Expression, branches []CaseBranch, elseExpr sql.Expression) *Case {
+	return &Case{expr, branches, elseExpr}
+}
+
+// Type implements the Expression interface.
+func (c *Case) Type() sql.Type {
+	var types = make([]sql.Type, 0, len(c.Branches)+1)
+	for _, b := range c.Branches {
+		types = append(types, b.Value.Type())
+	}
+
+	if c.Else != nil {
+		types = append(types, c.Else.Type())
+	}
+
+	return sql.CommonType(types...)
+}
+
+// IsNullable implements the Expression interface.
+func (c *Case) IsNullable() bool {
+	if c.Else == nil || c.Else.IsNullable() {
+		return true
+	}
+
+	for _, b := range c.Branches {
+		if b.Value.IsNullable() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Resolved implements the Expression interface.
+func (c *Case) Resolved() bool {
+	for _, e := range c.Children() {
+		if !e.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Children implements the Expression interface.
+func (c *Case) Children() []sql.Expression {
+	var children []sql.Expression
+	if c.Expr != nil {
+		children = append(children, c.Expr)
+	}
+
+	for _, b := range c.Branches {
+		children = append(children, b.Cond, b.Value)
+	}
+
+	if c.Else != nil {
+		children = append(children, c.Else)
+	}
+
+	return children
+}
+
+// Eval implements the Expression interface.
+func (c *Case) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("expression.Case")
+	defer span.Finish()
+
+	var expr interface{}
+	if c.Expr != nil {
+		var err error
+		expr, err = c.Expr.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, b := range c.Branches {
+		ok, err := c.matches(ctx, row, expr, b.Cond)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			return c.evalValue(ctx, row, b.Value)
+		}
+	}
+
+	if c.Else == nil {
+		return nil, nil
+	}
+
+	return c.evalValue(ctx, row, c.Else)
+}
+
+// matches returns whether the branch with the given condition must be
+// taken, given the value of the expression of the CASE.
+func (c *Case) matches(
+	ctx *sql.Context,
+	row sql.Row,
+	expr interface{},
+	cond sql.Expression,
+) (bool, error) {
+	v, err := cond.Eval(ctx, row)
+	if err != nil {
+		return false, err
+	}
+
+	if c.Expr == nil {
+		return IsTrue(v), nil
+	}
+
+	if expr == nil || v == nil {
+		return false, nil
+	}
+
+	cmp := newComparison(c.Expr, cond)
+	result, err := cmp.compareValues(expr, v)
+	if err != nil {
+		return false, err
+	}
+
+	return result == 0, nil
+}
+
+// evalValue evaluates the value of a branch and converts it to the type of
+// the CASE.
+func (c *Case) evalValue(ctx *sql.Context, row sql.Row, e sql.Expression) (interface{}, error) {
+	v, err := e.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	return sql.ConvertFrom(c.Type(), e.Type(), v)
+}
+
+// TransformUp implements the Expression interface.
+func (c *Case) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	var expr sql.Expression
+	if c.Expr != nil {
+		var err error
+		expr, err = c.Expr.TransformUp(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var branches = make([]CaseBranch, len(c.Branches))
+	for i, b := range c.Branches {
+		cond, err := b.Cond.TransformUp(f)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := b.Value.TransformUp(f)
+		if err != nil {
+			return nil, err
+		}
+
+		branches[i] = CaseBranch{cond, value}
+	}
+
+	var elseExpr sql.Expression
+	if c.Else != nil {
+		var err error
+		elseExpr, err = c.Else.TransformUp(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return f(NewCase(expr, branches, elseExpr))
+}
+
+func (c *Case) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("CASE")
+	if c.Expr != nil {
+		fmt.Fprintf(&buf, " %s", c.Expr)
+	}
+
+	for _, b := range c.Branches {
+		fmt.Fprintf(&buf, " WHEN %s THEN %s", b.Cond, b.Value)
+	}
+
+	if c.Else != nil {
+		fmt.Fprintf(&buf, " ELSE %s", c.Else)
+	}
+
+	buf.WriteString(" END")
+	return buf.String()
+}
@@ -0,0 +1,92 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+func TestCase(t *testing.T) {
+	simple := NewCase(
+		NewGetField(0, sql.Int64, "foo", true),
+		[]CaseBranch{
+			{NewLiteral(int64(1), sql.Int64), NewLiteral("one", sql.Text)},
+			{NewLiteral("2", sql.Text), NewLiteral(int64(2), sql.Int64)},
+		},
+		NewLiteral(float64(0.5), sql.Float64),
+	)
+
+	searched := NewCase(
+		nil,
+		[]CaseBranch{
+			{
+				NewGreaterThan(NewGetField(0, sql.Int64, "foo", true), NewLiteral(int64(10), sql.Int64)),
+				NewLiteral(int64(10), sql.Int64),
+			},
+			{NewGetField(0, sql.Int64, "foo", true), NewLiteral(uint8(1), sql.Uint8)},
+		},
+		nil,
+	)
+
+	testCases := []struct {
+		name     string
+		expr     *Case
+		row      sql.Row
+		expected interface{}
+	}{
+		{"simple first branch", simple, sql.NewRow(int64(1)), "one"},
+		{"simple second branch", simple, sql.NewRow(int64(2)), "2"},
+		{"simple else", simple, sql.NewRow(int64(3)), "0.5"},
+		{"simple null", simple, sql.NewRow(nil), "0.5"},
+		{"searched first branch", searched, sql.NewRow(int64(11)), int64(10)},
+		{"searched second branch", searched, sql.NewRow(int64(5)), int64(1)},
+		{"searched no branch", searched, sql.NewRow(int64(0)), nil},
+		{"searched null", searched, sql.NewRow(nil), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			result, err := tt.expr.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+
+	require.Equal(t, sql.Text, simple.Type())
+	require.Equal(t, sql.Int64, searched.Type())
+	require.True(t, searched.IsNullable())
+	require.Equal(t,
+		`CASE foo WHEN 1 THEN "one" WHEN "2" THEN 2 ELSE 0.5 END`,
+		simple.String(),
+	)
+}
+
+func TestCaseShortCircuit(t *testing.T) {
+	c := NewCase(
+		nil,
+		[]CaseBranch{
+			{NewLiteral(true, sql.Boolean), NewLiteral(int64(1), sql.Int64)},
+			{NewLiteral(true, sql.Boolean), NewGetField(5, sql.Int64, "missing", true)},
+		},
+		NewGetField(5, sql.Int64, "missing", true),
+	)
+
+	// Evaluating any other branch would fail, because the row does not
+	// have the field.
+	result, err := c.Eval(sql.NewEmptyContext(), sql.NewRow())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result)
+}
+
+func TestIsTrue(t *testing.T) {
+	require := require.New(t)
+	require.True(IsTrue(true))
+	require.True(IsTrue(int64(-1)))
+	require.True(IsTrue("1.5"))
+	require.False(IsTrue(nil))
+	require.False(IsTrue(false))
+	require.False(IsTrue(uint8(0)))
+	require.False(IsTrue("foo"))
+}
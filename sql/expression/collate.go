@@ -0,0 +1,59 @@
+package expression
+
+import (
+	"fmt"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// Collate is an expression that changes the collation used to compare the
+// string returned by its child.
+type Collate struct {
+	UnaryExpression
+	Collation sql.Collation
+}
+
+// NewCollate creates a new Collate expression.
+func NewCollate(child sql.Expression, collation sql.Collation) *Collate {
+	return &Collate{UnaryExpression{child}, collation}
+}
+
+// Type implements the Expression interface. Values of types other than
+// character strings are converted to text.
+func (e *Collate) Type() sql.Type {
+	t, err := sql.WithCollation(e.Child.Type(), e.Collation)
+	if err != nil {
+		t, _ = sql.WithCollation(sql.Text, e.Collation)
+	}
+	return t
+}
+
+// Eval implements the Expression interface.
+func (e *Collate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("expression.Collate")
+	defer span.Finish()
+
+	v, err := e.Child.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	if _, err := sql.WithCollation(e.Child.Type(), e.Collation); err != nil {
+		return sql.Text.Convert(v)
+	}
+
+	return v, nil
+}
+
+func (e *Collate) String() string {
+	return fmt.Sprintf("%s COLLATE %s", e.Child, e.Collation)
+}
+
+// TransformUp implements the Expression interface.
+func (e *Collate) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := e.Child.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+	return f(NewCollate(child, e.Collation))
+}
@@ -21,11 +21,10 @@ var ErrNilOperand = errors.NewKind("nil operand found in comparison")
 
 type comparison struct {
 	BinaryExpression
-	compareType sql.Type
 }
 
 func newComparison(left, right sql.Expression) comparison {
-	return comparison{BinaryExpression{left, right}, nil}
+	return comparison{BinaryExpression{left, right}}
 }
 
 // Compare the two given values using the types of the expressions in the comparison.
@@ -37,6 +36,12 @@ func (c *comparison) Compare(ctx *sql.Context, row sql.Row) (int, error) {
 		return 0, err
 	}
 
+	return c.compareValues(left, right)
+}
+
+// compareValues compares the given values of the left and right expressions
+// of the comparison.
+func (c *comparison) compareValues(left, right interface{}) (int, error) {
 	if left == nil || right == nil {
 		return 0, ErrNilOperand.New()
 	}
@@ -45,12 +50,12 @@ func (c *comparison) Compare(ctx *sql.Context, row sql.Row) (int, error) {
 		return c.Left().Type().Compare(left, right)
 	}
 
-	left, right, err = c.castLeftAndRight(left, right)
+	left, right, typ, err := c.castLeftAndRight(left, right)
 	if err != nil {
 		return 0, err
 	}
 
-	return c.compareType.Compare(left, right)
+	return typ.Compare(left, right)
 }
 
 func (c *comparison) evalLeftAndRight(ctx *sql.Context, row sql.Row) (interface{}, interface{}, error) {
@@ -67,44 +72,143 @@ func (c *comparison) evalLeftAndRight(ctx *sql.Context, row sql.Row) (interface{
 	return left, right, nil
 }
 
-func (c *comparison) castLeftAndRight(left, right interface{}) (interface{}, interface{}, error) {
+// castLeftAndRight converts the given values of the left and right
+// expressions of the comparison to a common type, which is also returned.
+func (c *comparison) castLeftAndRight(left, right interface{}) (interface{}, interface{}, sql.Type, error) {
+	if typ, ok := temporalType(c.Left().Type(), c.Right().Type()); ok {
+		left, err := typ.Convert(left)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		right, err := typ.Convert(right)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		return left, right, typ, nil
+	}
+
 	if sql.IsNumber(c.Left().Type()) || sql.IsNumber(c.Right().Type()) {
 		if sql.IsDecimal(c.Left().Type()) || sql.IsDecimal(c.Right().Type()) {
-			left, right, err := convertLeftAndRight(left, right, ConvertToDecimal)
+			left, right, err := convertLeftAndRight(left, right, ConvertToDouble)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			return left, right, sql.Float64, nil
+		}
+
+		if sql.IsFixedPoint(c.Left().Type()) || sql.IsFixedPoint(c.Right().Type()) {
+			left, err := decimalOperand.Convert(left)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			right, err := decimalOperand.Convert(right)
 			if err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 
-			c.compareType = sql.Float64
-			return left, right, nil
+			return left, right, decimalOperand, nil
 		}
 
 		if sql.IsSigned(c.Left().Type()) || sql.IsSigned(c.Right().Type()) {
 			left, right, err := convertLeftAndRight(left, right, ConvertToSigned)
 			if err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 
-			c.compareType = sql.Int64
-			return left, right, nil
+			return left, right, sql.Int64, nil
 		}
 
 		left, right, err := convertLeftAndRight(left, right, ConvertToUnsigned)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 
-		c.compareType = sql.Uint64
-		return left, right, nil
+		return left, right, sql.Uint64, nil
 	}
 
 	left, right, err := convertLeftAndRight(left, right, ConvertToChar)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	c.compareType = sql.Text
-	return left, right, nil
+	collation, err := comparisonCollation(c.Left(), c.Right())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	typ, err := sql.WithCollation(sql.Text, collation)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return left, right, typ, nil
+}
+
+// comparisonCollation returns the collation used to compare the strings
+// returned by the given expressions. An explicit COLLATE clause takes
+// precedence over the collation of any other expression, and the collation
+// of an expression takes precedence over the collation of a literal. If both
+// expressions have the same precedence but different collations, the binary
+// collation takes precedence, and otherwise they cannot be compared.
+func comparisonCollation(left, right sql.Expression) (sql.Collation, error) {
+	var explicit []sql.Collation
+	for _, e := range []sql.Expression{left, right} {
+		if c, ok := e.(*Collate); ok {
+			explicit = append(explicit, c.Collation)
+		}
+	}
+
+	if len(explicit) > 0 {
+		return mergeCollations(explicit...)
+	}
+
+	var implicit []sql.Collation
+	for _, e := range []sql.Expression{left, right} {
+		if _, ok := e.(*Literal); !ok && sql.IsText(e.Type()) {
+			implicit = append(implicit, sql.CollationOf(e.Type()))
+		}
+	}
+
+	if len(implicit) > 0 {
+		return mergeCollations(implicit...)
+	}
+
+	return sql.CollationOf(left.Type()), nil
+}
+
+// mergeCollations returns the collation used to compare strings with the
+// given collations, all of them with the same precedence.
+func mergeCollations(collations ...sql.Collation) (sql.Collation, error) {
+	result := collations[0]
+	for _, c := range collations[1:] {
+		switch {
+		case c == result:
+		case result == sql.CollationBinary:
+		case c == sql.CollationBinary:
+			result = c
+		default:
+			return 0, sql.ErrIllegalCollationMix.New(result, c)
+		}
+	}
+	return result, nil
+}
+
+// temporalType returns the type used to compare values of the given types
+// if any of them is a date or time type.
+func temporalType(left, right sql.Type) (sql.Type, bool) {
+	if sql.IsTemporal(left) {
+		return left, true
+	}
+
+	if sql.IsTemporal(right) {
+		return right, true
+	}
+
+	return nil, false
 }
 
 func convertLeftAndRight(left, right interface{}, convertTo string) (interface{}, interface{}, error) {
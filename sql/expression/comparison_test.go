@@ -2,6 +2,7 @@ package expression
 
 import (
 	"testing"
+	"time"
 
 	errors "gopkg.in/src-d/go-errors.v1"
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
@@ -172,6 +173,97 @@ func TestGreaterThan(t *testing.T) {
 	}
 }
 
+func TestTemporalComparison(t *testing.T) {
+	require := require.New(t)
+
+	date := time.Date(2018, time.March, 4, 12, 30, 15, 0, time.UTC)
+	testCases := []struct {
+		left     sql.Expression
+		right    sql.Expression
+		row      sql.Row
+		expected bool
+	}{
+		{
+			NewGetField(0, sql.Datetime, "foo", false),
+			NewLiteral("2018-03-04 12:30:15", sql.Text),
+			sql.NewRow(date),
+			true,
+		},
+		{
+			NewLiteral("12:30:15", sql.Text),
+			NewGetField(0, sql.Time, "foo", false),
+			sql.NewRow(12*time.Hour + 30*time.Minute + 15*time.Second),
+			true,
+		},
+		{
+			NewGetField(0, sql.Year, "foo", false),
+			NewLiteral("18", sql.Text),
+			sql.NewRow(int16(2018)),
+			true,
+		},
+		{
+			NewGetField(0, sql.Datetime, "foo", false),
+			NewLiteral("2018-03-04", sql.Text),
+			sql.NewRow(date),
+			false,
+		},
+	}
+
+	for _, tt := range testCases {
+		result, err := NewEquals(tt.left, tt.right).Eval(sql.NewEmptyContext(), tt.row)
+		require.NoError(err)
+		require.Equal(tt.expected, result)
+	}
+}
+
+func TestComparisonCollation(t *testing.T) {
+	bin, err := sql.WithCollation(sql.Text, sql.CollationUtf8mb4Bin)
+	require.NoError(t, err)
+
+	ci := NewGetField(0, sql.Text, "ci", true)
+	cs := NewGetField(1, bin, "cs", true)
+	blob := NewGetField(2, sql.Blob, "blob", true)
+	lit := NewLiteral("FOO", sql.Text)
+	row := sql.NewRow("foo", "foo", "foo")
+
+	testCases := []struct {
+		name     string
+		left     sql.Expression
+		right    sql.Expression
+		expected interface{}
+		err      bool
+	}{
+		{"column and literal", ci, lit, true, false},
+		{"literal and column", lit, cs, false, false},
+		{"explicit collation", NewCollate(cs, sql.CollationUtf8mb4GeneralCI), lit, true, false},
+		{"explicit and implicit collation", ci, NewCollate(lit, sql.CollationUtf8mb4Bin), false, false},
+		{"binary and other collation", ci, blob, true, false},
+		{"different implicit collations", ci, cs, nil, true},
+		{
+			"different explicit collations",
+			NewCollate(ci, sql.CollationUtf8mb4Bin),
+			NewCollate(cs, sql.CollationUtf8mb4GeneralCI),
+			nil, true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			result, err := NewEquals(tt.left, tt.right).Eval(sql.NewEmptyContext(), row)
+			if tt.err {
+				require.Error(err)
+				require.True(sql.ErrIllegalCollationMix.Is(err))
+				return
+			}
+
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
 func TestRegexp(t *testing.T) {
 	require := require.New(t)
 	for resultType, cmpCase := range likeComparisonCases {
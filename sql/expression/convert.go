@@ -29,6 +29,8 @@ const (
 	ConvertToDatetime = "datetime"
 	// ConvertToDecimal is a conversion to decimal.
 	ConvertToDecimal = "decimal"
+	// ConvertToDouble is a conversion to double.
+	ConvertToDouble = "double"
 	// ConvertToJSON is a conversion to json.
 	ConvertToJSON = "json"
 	// ConvertToSigned is a conversion to signed.
@@ -37,11 +39,17 @@ const (
 	ConvertToUnsigned = "unsigned"
 )
 
+// defaultDecimal is the type of the conversions to decimal without a
+// precision, which is DECIMAL(10, 0) as in MySQL.
+var defaultDecimal = sql.MustDecimal(sql.DecimalDefaultPrecision, 0)
+
 // Convert represent a CAST(x AS T) or CONVERT(x, T) operation that casts x expression to type T.
 type Convert struct {
 	UnaryExpression
 	// Type to cast
 	castToType string
+	// decimal is the type of the conversions to decimal.
+	decimal sql.Type
 }
 
 // NewConvert creates a new Convert expression.
@@ -49,9 +57,23 @@ func NewConvert(expr sql.Expression, castToType string) *Convert {
 	return &Convert{
 		UnaryExpression: UnaryExpression{Child: expr},
 		castToType:      castToType,
+		decimal:         defaultDecimal,
 	}
 }
 
+// NewDecimalConvert creates a new Convert expression to a decimal with the
+// given precision and scale.
+func NewDecimalConvert(expr sql.Expression, precision, scale uint8) (*Convert, error) {
+	typ, err := sql.Decimal(precision, scale)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewConvert(expr, ConvertToDecimal)
+	c.decimal = typ
+	return c, nil
+}
+
 // Type implements the Expression interface.
 func (c *Convert) Type() sql.Type {
 	switch c.castToType {
@@ -62,6 +84,8 @@ func (c *Convert) Type() sql.Type {
 	case ConvertToDate, ConvertToDatetime:
 		return sql.Date
 	case ConvertToDecimal:
+		return c.decimal
+	case ConvertToDouble:
 		return sql.Float64
 	case ConvertToJSON:
 		return sql.JSON
@@ -76,6 +100,9 @@ func (c *Convert) Type() sql.Type {
 
 // Name implements the Expression interface.
 func (c *Convert) String() string {
+	if c.castToType == ConvertToDecimal {
+		return fmt.Sprintf("convert(%v, %v)", c.Child, c.decimal)
+	}
 	return fmt.Sprintf("convert(%v, %v)", c.Child, c.castToType)
 }
 
@@ -86,7 +113,9 @@ func (c *Convert) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
 		return nil, err
 	}
 
-	return f(NewConvert(child, c.castToType))
+	nc := NewConvert(child, c.castToType)
+	nc.decimal = c.decimal
+	return f(nc)
 }
 
 // Eval implements the Expression interface.
@@ -103,7 +132,12 @@ func (c *Convert) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
-	casted, err := convertValue(val, c.castToType)
+	var casted interface{}
+	if c.castToType == ConvertToDecimal {
+		casted, err = convertToDecimal(val, c.decimal)
+	} else {
+		casted, err = convertValue(val, c.castToType)
+	}
 	if err != nil {
 		return nil, ErrConvertExpression.Wrap(err, c.String(), c.castToType)
 	}
@@ -149,7 +183,9 @@ func convertValue(val interface{}, castTo string) (interface{}, error) {
 
 		return d, nil
 	case ConvertToDecimal:
-		d, err := cast.ToFloat64E(val)
+		return convertToDecimal(val, defaultDecimal)
+	case ConvertToDouble:
+		d, err := sql.Float64.Convert(val)
 		if err != nil {
 			return float64(0), nil
 		}
@@ -187,6 +223,18 @@ func convertValue(val interface{}, castTo string) (interface{}, error) {
 	}
 }
 
+// convertToDecimal converts the given value to the given decimal type,
+// rounding it to the scale of the type. As in MySQL, values that are not
+// numbers are converted to zero.
+func convertToDecimal(val interface{}, typ sql.Type) (interface{}, error) {
+	d, err := typ.Convert(val)
+	if sql.ErrConvertingToDecimal.Is(err) {
+		return typ.Convert(0)
+	}
+
+	return d, err
+}
+
 func handleUnsignedErrors(err error, val interface{}) uint64 {
 	if err.Error() == "unable to cast negative value" {
 		return castSignedToUnsigned(val)
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
 )
@@ -145,6 +146,22 @@ func TestConvert(t *testing.T) {
 			expected:    int64(1),
 			expectedErr: false,
 		},
+		{
+			name:        "string to decimal",
+			row:         nil,
+			castTo:      ConvertToDecimal,
+			expression:  NewLiteral("2.5", sql.Text),
+			expected:    decimal.New(3, 0),
+			expectedErr: false,
+		},
+		{
+			name:        "float to double",
+			row:         nil,
+			castTo:      ConvertToDouble,
+			expression:  NewLiteral(float32(0.5), sql.Float32),
+			expected:    float64(0.5),
+			expectedErr: false,
+		},
 		{
 			name:        "bool to datetime",
 			row:         nil,
@@ -170,3 +187,28 @@ func TestConvert(t *testing.T) {
 		})
 	}
 }
+
+func TestDecimalConvert(t *testing.T) {
+	require := require.New(t)
+
+	c, err := NewDecimalConvert(NewLiteral("1.005", sql.Text), 10, 2)
+	require.NoError(err)
+	require.Equal(sql.MustDecimal(10, 2), c.Type())
+	require.Equal("convert(\"1.005\", DECIMAL(10,2))", c.String())
+
+	val, err := c.Eval(sql.NewEmptyContext(), nil)
+	require.NoError(err)
+	require.Equal("1.01", val.(decimal.Decimal).String())
+
+	val, err = NewConvert(NewLiteral("foo", sql.Text), ConvertToDecimal).Eval(sql.NewEmptyContext(), nil)
+	require.NoError(err)
+	require.Equal("0", val.(decimal.Decimal).String())
+
+	c, err = NewDecimalConvert(NewLiteral("123.4", sql.Text), 3, 1)
+	require.NoError(err)
+	_, err = c.Eval(sql.NewEmptyContext(), nil)
+	require.Error(err)
+
+	_, err = NewDecimalConvert(NewLiteral("1", sql.Text), 2, 3)
+	require.Error(err)
+}
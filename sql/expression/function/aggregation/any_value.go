@@ -0,0 +1,79 @@
+package aggregation
+
+import (
+	"fmt"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// AnyValue aggregation returns the value of the selected column in any of
+// the rows, which is the first one aggregated.
+type AnyValue struct {
+	expression.UnaryExpression
+}
+
+// NewAnyValue returns a new AnyValue node.
+func NewAnyValue(e sql.Expression) *AnyValue {
+	return &AnyValue{expression.UnaryExpression{Child: e}}
+}
+
+// Type returns the resultant type of the aggregation.
+func (a *AnyValue) Type() sql.Type {
+	return a.Child.Type()
+}
+
+// IsNullable returns whether the return value can be null.
+func (a *AnyValue) IsNullable() bool {
+	return a.Child.IsNullable()
+}
+
+func (a *AnyValue) String() string {
+	return fmt.Sprintf("ANY_VALUE(%s)", a.Child)
+}
+
+// TransformUp implements the Transformable interface.
+func (a *AnyValue) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := a.Child.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+	return f(NewAnyValue(child))
+}
+
+// NewBuffer creates a new buffer to compute the result. It keeps the value
+// and whether there is one.
+func (a *AnyValue) NewBuffer() sql.Row {
+	return sql.NewRow(nil, false)
+}
+
+// Update implements the Aggregation interface.
+func (a *AnyValue) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	if buffer[1].(bool) {
+		return nil
+	}
+
+	v, err := a.Child.Eval(ctx, row)
+	if err != nil {
+		return err
+	}
+
+	buffer[0], buffer[1] = v, true
+	return nil
+}
+
+// Merge implements the Aggregation interface.
+func (a *AnyValue) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	if !buffer[1].(bool) {
+		buffer[0], buffer[1] = partial[0], partial[1]
+	}
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (a *AnyValue) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("aggregation.AnyValue_Eval")
+	defer span.Finish()
+
+	return buffer[0], nil
+}
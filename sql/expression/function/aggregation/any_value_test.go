@@ -0,0 +1,33 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestAnyValue(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	a := NewAnyValue(expression.NewGetField(0, sql.Text, "field", true))
+	require.Equal("ANY_VALUE(field)", a.String())
+	require.Equal(sql.Text, a.Type())
+
+	b := a.NewBuffer()
+	v, err := a.Eval(ctx, b)
+	require.NoError(err)
+	require.Nil(v)
+
+	partial := a.NewBuffer()
+	require.NoError(a.Update(ctx, partial, sql.NewRow("a")))
+	require.NoError(a.Update(ctx, partial, sql.NewRow("b")))
+	require.NoError(a.Merge(ctx, b, partial))
+	require.NoError(a.Update(ctx, b, sql.NewRow("c")))
+
+	v, err = a.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal("a", v)
+}
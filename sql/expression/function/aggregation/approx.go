@@ -0,0 +1,204 @@
+package aggregation
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/hashstructure"
+	"gopkg.in/src-d/go-errors.v1"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// ErrInvalidPercentile is returned when the percentile of
+// APPROX_PERCENTILE is not between 0 and 1.
+var ErrInvalidPercentile = errors.NewKind("percentile must be a number between 0 and 1, got %v")
+
+// ApproxCountDistinct aggregation returns the approximate number of
+// distinct values of the selected column, which is estimated with a
+// HyperLogLog sketch of 4KB per group, instead of keeping all the values
+// as COUNT(DISTINCT) does. NULLs are not counted.
+type ApproxCountDistinct struct {
+	expression.UnaryExpression
+}
+
+// NewApproxCountDistinct returns a new ApproxCountDistinct node.
+func NewApproxCountDistinct(e sql.Expression) *ApproxCountDistinct {
+	return &ApproxCountDistinct{expression.UnaryExpression{Child: e}}
+}
+
+// Type returns the resultant type of the aggregation.
+func (a *ApproxCountDistinct) Type() sql.Type {
+	return sql.Int64
+}
+
+// IsNullable returns whether the return value can be null.
+func (a *ApproxCountDistinct) IsNullable() bool {
+	return false
+}
+
+func (a *ApproxCountDistinct) String() string {
+	return fmt.Sprintf("APPROX_COUNT_DISTINCT(%s)", a.Child)
+}
+
+// TransformUp implements the Transformable interface.
+func (a *ApproxCountDistinct) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := a.Child.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+	return f(NewApproxCountDistinct(child))
+}
+
+// NewBuffer creates a new buffer to compute the result.
+func (a *ApproxCountDistinct) NewBuffer() sql.Row {
+	return sql.NewRow(newHyperLogLog())
+}
+
+// Update implements the Aggregation interface.
+func (a *ApproxCountDistinct) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	v, err := a.Child.Eval(ctx, row)
+	if err != nil || v == nil {
+		return err
+	}
+
+	hash, err := hashstructure.Hash(sql.NormalizeKey(a.Child.Type(), v), nil)
+	if err != nil {
+		return err
+	}
+
+	buffer[0].(*hyperLogLog).add(mixHash(hash))
+	return nil
+}
+
+// Merge implements the Aggregation interface.
+func (a *ApproxCountDistinct) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	buffer[0].(*hyperLogLog).merge(partial[0].(*hyperLogLog))
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (a *ApproxCountDistinct) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("aggregation.ApproxCountDistinct_Eval")
+	defer span.Finish()
+
+	return buffer[0].(*hyperLogLog).count(), nil
+}
+
+// ApproxPercentile aggregation returns the approximate value of the
+// selected column at a percentile between 0 and 1, as a DOUBLE. The
+// percentile is evaluated in the first row of each group. It is estimated with a t-digest sketch, instead of sorting all
+// the values. It returns NULL if there are no values.
+type ApproxPercentile struct {
+	expression.BinaryExpression
+	name string
+}
+
+// NewApproxPercentile returns a new ApproxPercentile node.
+func NewApproxPercentile(e, percentile sql.Expression) *ApproxPercentile {
+	return &ApproxPercentile{
+		expression.BinaryExpression{Left: e, Right: percentile},
+		"APPROX_PERCENTILE",
+	}
+}
+
+// NewApproxMedian returns a new ApproxPercentile node of the median.
+func NewApproxMedian(e sql.Expression) *ApproxPercentile {
+	return &ApproxPercentile{
+		expression.BinaryExpression{
+			Left:  e,
+			Right: expression.NewLiteral(0.5, sql.Float64),
+		},
+		"APPROX_MEDIAN",
+	}
+}
+
+// Type returns the resultant type of the aggregation.
+func (a *ApproxPercentile) Type() sql.Type {
+	return sql.Float64
+}
+
+// IsNullable returns whether the return value can be null.
+func (a *ApproxPercentile) IsNullable() bool {
+	return true
+}
+
+func (a *ApproxPercentile) String() string {
+	if a.name == "APPROX_MEDIAN" {
+		return fmt.Sprintf("%s(%s)", a.name, a.Left)
+	}
+	return fmt.Sprintf("%s(%s, %s)", a.name, a.Left, a.Right)
+}
+
+// TransformUp implements the Transformable interface.
+func (a *ApproxPercentile) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	left, err := a.Left.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := a.Right.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	n := *a
+	n.Left, n.Right = left, right
+	return f(&n)
+}
+
+// NewBuffer creates a new buffer to compute the result. It keeps the
+// sketch and the percentile.
+func (a *ApproxPercentile) NewBuffer() sql.Row {
+	return sql.NewRow(newTDigest(), nil)
+}
+
+// Update implements the Aggregation interface.
+func (a *ApproxPercentile) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	if buffer[1] == nil {
+		p, err := a.Right.Eval(ctx, row)
+		if err != nil {
+			return err
+		}
+
+		percentile, err := sql.Float64.Convert(p)
+		if err != nil || p == nil || percentile.(float64) < 0 || percentile.(float64) > 1 {
+			return ErrInvalidPercentile.New(p)
+		}
+		buffer[1] = percentile
+	}
+
+	v, err := a.Left.Eval(ctx, row)
+	if err != nil || v == nil {
+		return err
+	}
+
+	x, err := sql.Float64.Convert(v)
+	if err != nil {
+		return err
+	}
+
+	buffer[0].(*tDigest).add(x.(float64))
+	return nil
+}
+
+// Merge implements the Aggregation interface.
+func (a *ApproxPercentile) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	buffer[0].(*tDigest).merge(partial[0].(*tDigest))
+	if buffer[1] == nil {
+		buffer[1] = partial[1]
+	}
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (a *ApproxPercentile) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("aggregation.ApproxPercentile_Eval")
+	defer span.Finish()
+
+	digest := buffer[0].(*tDigest)
+	if digest.count == 0 {
+		return nil, nil
+	}
+
+	return digest.quantile(buffer[1].(float64)), nil
+}
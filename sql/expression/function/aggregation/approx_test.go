@@ -0,0 +1,82 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestApproxCountDistinct(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	a := NewApproxCountDistinct(expression.NewGetField(0, sql.Text, "field", true))
+	require.Equal("APPROX_COUNT_DISTINCT(field)", a.String())
+
+	b := a.NewBuffer()
+	v, err := a.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(int64(0), v)
+
+	require.NoError(a.Update(ctx, b, sql.NewRow("a")))
+	require.NoError(a.Update(ctx, b, sql.NewRow("b")))
+	require.NoError(a.Update(ctx, b, sql.NewRow(nil)))
+
+	partial := a.NewBuffer()
+	require.NoError(a.Update(ctx, partial, sql.NewRow("b")))
+	require.NoError(a.Update(ctx, partial, sql.NewRow("c")))
+	require.NoError(a.Merge(ctx, b, partial))
+
+	v, err = a.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(int64(3), v)
+}
+
+func TestApproxPercentile(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+	field := expression.NewGetField(0, sql.Int64, "field", true)
+
+	p := NewApproxPercentile(field, expression.NewLiteral(0.25, sql.Float64))
+	m := NewApproxMedian(field)
+	require.Equal("APPROX_PERCENTILE(field, 0.25)", p.String())
+	require.Equal("APPROX_MEDIAN(field)", m.String())
+
+	for _, agg := range []*ApproxPercentile{p, m} {
+		b := agg.NewBuffer()
+		v, err := agg.Eval(ctx, b)
+		require.NoError(err)
+		require.Nil(v)
+
+		partial := agg.NewBuffer()
+		for i := int64(1); i <= 5; i++ {
+			require.NoError(agg.Update(ctx, partial, sql.NewRow(i)))
+		}
+		require.NoError(agg.Update(ctx, b, sql.NewRow(nil)))
+		require.NoError(agg.Merge(ctx, b, partial))
+	}
+
+	b := p.NewBuffer()
+	for i := int64(1); i <= 5; i++ {
+		require.NoError(p.Update(ctx, b, sql.NewRow(i)))
+	}
+	v, err := p.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(float64(2), v)
+
+	b = m.NewBuffer()
+	partial := m.NewBuffer()
+	require.NoError(m.Update(ctx, b, sql.NewRow(int64(1))))
+	require.NoError(m.Update(ctx, partial, sql.NewRow(int64(7))))
+	require.NoError(m.Update(ctx, partial, sql.NewRow(int64(3))))
+	require.NoError(m.Merge(ctx, b, partial))
+	v, err = m.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(float64(3), v)
+
+	invalid := NewApproxPercentile(field, expression.NewLiteral(1.5, sql.Float64))
+	err = invalid.Update(ctx, invalid.NewBuffer(), sql.NewRow(int64(1)))
+	require.True(ErrInvalidPercentile.Is(err))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/shopspring/decimal"
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
 	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
 )
@@ -27,9 +28,24 @@ func (a *Avg) Resolved() bool {
 	return true
 }
 
+// avgScaleIncrement is the number of digits by which the scale of the
+// averaged decimals is increased in the result.
+const avgScaleIncrement = 4
+
 // Type implements AggregationExpression interface. (AggregationExpression[Expression]])
+// As in MySQL, the average of integers or decimals is a decimal, and the
+// average of any other type is a DOUBLE.
 func (a *Avg) Type() sql.Type {
-	return sql.Float64
+	t := a.Child.Type()
+	if !sql.IsInteger(t) && !sql.IsFixedPoint(t) {
+		return sql.Float64
+	}
+
+	precision, scale := sql.NumericPrecision(t)
+	return sql.MustDecimal(
+		uint8(min(int(precision)+avgScaleIncrement, sql.DecimalMaxPrecision)),
+		uint8(min(int(scale)+avgScaleIncrement, sql.DecimalMaxScale)),
+	)
 }
 
 // IsNullable implements AggregationExpression interface. (AggregationExpression[Expression]])
@@ -52,6 +68,14 @@ func (a *Avg) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
 		return nil, nil
 	}
 
+	if typ := a.Type(); sql.IsFixedPoint(typ) {
+		_, scale := sql.NumericPrecision(typ)
+		sum := buffer[0].(decimal.Decimal)
+		avg := sum.DivRound(decimal.New(int64(noNullRows), 0), int32(scale))
+		span.LogKV("avg", avg)
+		return avg, nil
+	}
+
 	avg := buffer[0]
 	span.LogKV("avg", avg)
 	return avg, nil
@@ -74,6 +98,12 @@ func (a *Avg) NewBuffer() sql.Row {
 		noNum      = false
 	)
 
+	// The average of decimals is computed from their exact sum instead of
+	// updating the average with each row, which would lose precision.
+	if sql.IsFixedPoint(a.Type()) {
+		return sql.NewRow(decimal.New(0, 0), rowsCount, noNum)
+	}
+
 	return sql.NewRow(currentAvg, rowsCount, noNum)
 }
 
@@ -88,8 +118,19 @@ func (a *Avg) Update(ctx *sql.Context, buffer, row sql.Row) error {
 		return nil
 	}
 
+	if typ := a.Type(); sql.IsFixedPoint(typ) {
+		val, err := typ.Convert(v)
+		if err != nil {
+			return err
+		}
+
+		buffer[0] = buffer[0].(decimal.Decimal).Add(val.(decimal.Decimal))
+		buffer[1] = buffer[1].(float64) + 1
+		return nil
+	}
+
 	var num float64
-	switch n := row[0].(type) {
+	switch n := v.(type) {
 	case int, int16, int32, int64:
 		num = float64(reflect.ValueOf(n).Int())
 	case uint, uint8, uint16, uint32, uint64:
@@ -113,6 +154,12 @@ func (a *Avg) Update(ctx *sql.Context, buffer, row sql.Row) error {
 
 // Merge implements AggregationExpression interface. (AggregationExpression)
 func (a *Avg) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	if sql.IsFixedPoint(a.Type()) {
+		buffer[0] = buffer[0].(decimal.Decimal).Add(partial[0].(decimal.Decimal))
+		buffer[1] = buffer[1].(float64) + partial[1].(float64)
+		return nil
+	}
+
 	bufferAvg := buffer[0].(float64)
 	bufferRows := buffer[1].(float64)
 
@@ -3,6 +3,7 @@ package aggregation
 import (
 	"testing"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
 	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
@@ -24,10 +25,10 @@ func TestAvg_Eval_INT32(t *testing.T) {
 	require.Zero(avgNode.Eval(ctx, buffer))
 
 	avgNode.Update(ctx, buffer, sql.NewRow(int32(1)))
-	require.Equal(float64(1), eval(t, avgNode, buffer))
+	require.Equal(decimal.New(10000, -4), eval(t, avgNode, buffer))
 
 	avgNode.Update(ctx, buffer, sql.NewRow(int32(2)))
-	require.Equal(float64(1.5), eval(t, avgNode, buffer))
+	require.Equal(decimal.New(15000, -4), eval(t, avgNode, buffer))
 }
 
 func TestAvg_Eval_UINT64(t *testing.T) {
@@ -40,11 +41,11 @@ func TestAvg_Eval_UINT64(t *testing.T) {
 
 	err := avgNode.Update(ctx, buffer, sql.NewRow(uint64(1)))
 	require.NoError(err)
-	require.Equal(float64(1), eval(t, avgNode, buffer))
+	require.Equal(decimal.New(10000, -4), eval(t, avgNode, buffer))
 
 	err = avgNode.Update(ctx, buffer, sql.NewRow(uint64(2)))
 	require.NoError(err)
-	require.Equal(float64(1.5), eval(t, avgNode, buffer))
+	require.Equal(decimal.New(15000, -4), eval(t, avgNode, buffer))
 }
 
 func TestAvg_Eval_NoNum(t *testing.T) {
@@ -0,0 +1,121 @@
+package aggregation
+
+import (
+	"fmt"
+	"math"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// BitAggregation aggregation returns the bitwise AND, OR or XOR of the
+// values of the selected column as an unsigned 64-bit integer. If there are
+// no values, AND returns all bits set and OR and XOR return 0.
+type BitAggregation struct {
+	expression.UnaryExpression
+	name string
+	init uint64
+	op   func(a, b uint64) uint64
+}
+
+// NewBitAnd returns a new BitAggregation node of the bitwise AND.
+func NewBitAnd(e sql.Expression) *BitAggregation {
+	return &BitAggregation{
+		expression.UnaryExpression{Child: e},
+		"BIT_AND",
+		math.MaxUint64,
+		func(a, b uint64) uint64 { return a & b },
+	}
+}
+
+// NewBitOr returns a new BitAggregation node of the bitwise OR.
+func NewBitOr(e sql.Expression) *BitAggregation {
+	return &BitAggregation{
+		expression.UnaryExpression{Child: e},
+		"BIT_OR",
+		0,
+		func(a, b uint64) uint64 { return a | b },
+	}
+}
+
+// NewBitXor returns a new BitAggregation node of the bitwise XOR.
+func NewBitXor(e sql.Expression) *BitAggregation {
+	return &BitAggregation{
+		expression.UnaryExpression{Child: e},
+		"BIT_XOR",
+		0,
+		func(a, b uint64) uint64 { return a ^ b },
+	}
+}
+
+// Type returns the resultant type of the aggregation.
+func (b *BitAggregation) Type() sql.Type {
+	return sql.Uint64
+}
+
+// IsNullable returns whether the return value can be null.
+func (b *BitAggregation) IsNullable() bool {
+	return false
+}
+
+func (b *BitAggregation) String() string {
+	return fmt.Sprintf("%s(%s)", b.name, b.Child)
+}
+
+// TransformUp implements the Transformable interface.
+func (b *BitAggregation) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := b.Child.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	n := *b
+	n.Child = child
+	return f(&n)
+}
+
+// NewBuffer creates a new buffer to compute the result.
+func (b *BitAggregation) NewBuffer() sql.Row {
+	return sql.NewRow(b.init)
+}
+
+// Update implements the Aggregation interface.
+func (b *BitAggregation) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	v, err := b.Child.Eval(ctx, row)
+	if err != nil || v == nil {
+		return err
+	}
+
+	// Negative numbers are aggregated as their two's complement.
+	var n uint64
+	if sql.IsUnsigned(b.Child.Type()) {
+		u, err := sql.Uint64.Convert(v)
+		if err != nil {
+			return err
+		}
+		n = u.(uint64)
+	} else {
+		i, err := sql.Int64.Convert(v)
+		if err != nil {
+			return err
+		}
+		n = uint64(i.(int64))
+	}
+
+	buffer[0] = b.op(buffer[0].(uint64), n)
+	return nil
+}
+
+// Merge implements the Aggregation interface.
+func (b *BitAggregation) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	buffer[0] = b.op(buffer[0].(uint64), partial[0].(uint64))
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (b *BitAggregation) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("aggregation.BitAggregation_Eval")
+	defer span.Finish()
+
+	return buffer[0], nil
+}
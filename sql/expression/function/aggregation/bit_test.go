@@ -0,0 +1,48 @@
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestBitAggregation(t *testing.T) {
+	field := expression.NewGetField(0, sql.Int64, "field", true)
+	testCases := []struct {
+		agg      *BitAggregation
+		name     string
+		empty    uint64
+		expected uint64
+	}{
+		{NewBitAnd(field), "BIT_AND(field)", math.MaxUint64, 6},
+		{NewBitOr(field), "BIT_OR(field)", 0, 0xFFFFFFFFFFFFFFFE},
+		{NewBitXor(field), "BIT_XOR(field)", 0, 0xFFFFFFFFFFFFFFF8},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+			require.Equal(tt.name, tt.agg.String())
+
+			b := tt.agg.NewBuffer()
+			v, err := tt.agg.Eval(ctx, b)
+			require.NoError(err)
+			require.Equal(tt.empty, v)
+
+			require.NoError(tt.agg.Update(ctx, b, sql.NewRow(int64(6))))
+			require.NoError(tt.agg.Update(ctx, b, sql.NewRow(nil)))
+
+			partial := tt.agg.NewBuffer()
+			require.NoError(tt.agg.Update(ctx, partial, sql.NewRow(int64(-2))))
+			require.NoError(tt.agg.Merge(ctx, b, partial))
+
+			v, err = tt.agg.Eval(ctx, b)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
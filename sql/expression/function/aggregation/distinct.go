@@ -0,0 +1,136 @@
+package aggregation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/hashstructure"
+	"gopkg.in/src-d/go-errors.v1"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// ErrDistinctNotAggregation is returned when DISTINCT is used in the
+// arguments of a function that is not an aggregation.
+var ErrDistinctNotAggregation = errors.NewKind("DISTINCT can only be used in aggregations, %s is not one")
+
+// Distinct is an aggregation that only aggregates the rows with distinct
+// values of the arguments of another aggregation, as in COUNT(DISTINCT a).
+// Rows in which any of the arguments is NULL are ignored. Arguments given
+// as a tuple, as in COUNT(DISTINCT a, b), are distinct if any of the
+// values of the tuple is.
+//
+// The buffer keeps a row for each distinct value, so partial buffers can be
+// merged without aggregating the same value twice. The rows are aggregated
+// when the result is evaluated.
+type Distinct struct {
+	sql.Aggregation
+}
+
+// NewDistinct creates a new Distinct aggregation of the given one.
+func NewDistinct(agg sql.Aggregation) *Distinct {
+	return &Distinct{agg}
+}
+
+// distinctRows are the rows of each distinct value in the order they were
+// found.
+type distinctRows struct {
+	hashes []uint64
+	rows   map[uint64]sql.Row
+}
+
+func (r *distinctRows) add(hash uint64, row sql.Row) {
+	if _, ok := r.rows[hash]; !ok {
+		r.hashes = append(r.hashes, hash)
+		r.rows[hash] = row
+	}
+}
+
+// NewBuffer implements the Aggregation interface.
+func (d *Distinct) NewBuffer() sql.Row {
+	return sql.NewRow(&distinctRows{rows: make(map[uint64]sql.Row)})
+}
+
+func (d *Distinct) String() string {
+	return strings.Replace(d.Aggregation.String(), "(", "(DISTINCT ", 1)
+}
+
+// TransformUp implements the Expression interface.
+func (d *Distinct) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	e, err := d.Aggregation.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	agg, ok := e.(sql.Aggregation)
+	if !ok {
+		return nil, ErrDistinctNotAggregation.New(e)
+	}
+
+	return f(NewDistinct(agg))
+}
+
+// Update implements the Aggregation interface.
+func (d *Distinct) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	var values []interface{}
+	for _, e := range distinctArgs(d.Aggregation) {
+		v, err := e.Eval(ctx, row)
+		if err != nil {
+			return err
+		}
+
+		if v == nil {
+			return nil
+		}
+
+		values = append(values, sql.NormalizeKey(e.Type(), v))
+	}
+
+	hash, err := hashstructure.Hash(values, nil)
+	if err != nil {
+		return fmt.Errorf("unable to hash row: %s", err)
+	}
+
+	buffer[0].(*distinctRows).add(hash, row)
+	return nil
+}
+
+// distinctArgs returns the arguments of the aggregation whose values must
+// be distinct.
+func distinctArgs(agg sql.Aggregation) []sql.Expression {
+	var args []sql.Expression
+	for _, e := range agg.Children() {
+		if t, ok := e.(expression.Tuple); ok {
+			args = append(args, t...)
+		} else {
+			args = append(args, e)
+		}
+	}
+	return args
+}
+
+// Merge implements the Aggregation interface.
+func (d *Distinct) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	rows := buffer[0].(*distinctRows)
+	p := partial[0].(*distinctRows)
+	for _, hash := range p.hashes {
+		rows.add(hash, p.rows[hash])
+	}
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (d *Distinct) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("aggregation.Distinct_Eval")
+	defer span.Finish()
+
+	rows := buffer[0].(*distinctRows)
+	b := d.Aggregation.NewBuffer()
+	for _, hash := range rows.hashes {
+		if err := d.Aggregation.Update(ctx, b, rows.rows[hash]); err != nil {
+			return nil, err
+		}
+	}
+
+	return d.Aggregation.Eval(ctx, b)
+}
@@ -0,0 +1,58 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestDistinct(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	d := NewDistinct(NewSum(expression.NewGetField(0, sql.Int64, "field", true)))
+	require.Equal("SUM(DISTINCT field)", d.String())
+
+	b := d.NewBuffer()
+	require.NoError(d.Update(ctx, b, sql.NewRow(int64(1))))
+	require.NoError(d.Update(ctx, b, sql.NewRow(int64(2))))
+	require.NoError(d.Update(ctx, b, sql.NewRow(nil)))
+	require.NoError(d.Update(ctx, b, sql.NewRow(int64(1))))
+
+	partial := d.NewBuffer()
+	require.NoError(d.Update(ctx, partial, sql.NewRow(int64(2))))
+	require.NoError(d.Update(ctx, partial, sql.NewRow(int64(3))))
+	require.NoError(d.Merge(ctx, b, partial))
+
+	v, err := d.Eval(ctx, b)
+	require.NoError(err)
+	require.True(decimal.New(6, 0).Equal(v.(decimal.Decimal)))
+
+	v, err = d.Eval(ctx, d.NewBuffer())
+	require.NoError(err)
+	require.Nil(v)
+}
+
+func TestDistinctTuple(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	d := NewDistinct(NewCount(expression.NewTuple(
+		expression.NewGetField(0, sql.Int64, "a", true),
+		expression.NewGetField(1, sql.Text, "b", true),
+	)))
+	require.Equal("COUNT(DISTINCT (a, b))", d.String())
+
+	b := d.NewBuffer()
+	require.NoError(d.Update(ctx, b, sql.NewRow(int64(1), "x")))
+	require.NoError(d.Update(ctx, b, sql.NewRow(int64(1), "y")))
+	require.NoError(d.Update(ctx, b, sql.NewRow(int64(1), "x")))
+	require.NoError(d.Update(ctx, b, sql.NewRow(int64(2), nil)))
+
+	v, err := d.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(int32(2), v)
+}
@@ -0,0 +1,290 @@
+package aggregation
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// GroupConcat aggregation returns the concatenation of the values of the
+// selected columns in each row, separated by a separator, or NULL if there
+// are no rows. Rows in which any of the values is NULL are ignored. The rows
+// can be sorted and only distinct values can be concatenated.
+type GroupConcat struct {
+	distinct  bool
+	args      []sql.Expression
+	orderBy   []OrderByField
+	separator string
+}
+
+// OrderByField is an expression by which the rows aggregated by
+// GroupConcat are sorted. NULLs are the lowest values.
+type OrderByField struct {
+	Column     sql.Expression
+	Descending bool
+}
+
+func (f OrderByField) String() string {
+	if f.Descending {
+		return fmt.Sprintf("%s DESC", f.Column)
+	}
+	return fmt.Sprintf("%s ASC", f.Column)
+}
+
+// DefaultGroupConcatSeparator is the separator of GroupConcat if none is
+// given.
+const DefaultGroupConcatSeparator = ","
+
+// NewGroupConcat returns a new GroupConcat node.
+func NewGroupConcat(
+	distinct bool,
+	args []sql.Expression,
+	orderBy []OrderByField,
+	separator string,
+) *GroupConcat {
+	return &GroupConcat{distinct, args, orderBy, separator}
+}
+
+// Type returns the resultant type of the aggregation.
+func (g *GroupConcat) Type() sql.Type {
+	return sql.Text
+}
+
+// IsNullable returns whether the return value can be null.
+func (g *GroupConcat) IsNullable() bool {
+	return true
+}
+
+// Resolved implements the Expression interface.
+func (g *GroupConcat) Resolved() bool {
+	for _, e := range g.Children() {
+		if !e.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Children implements the Expression interface. They are the concatenated
+// expressions followed by the ones the rows are sorted by.
+func (g *GroupConcat) Children() []sql.Expression {
+	var children = make([]sql.Expression, 0, len(g.args)+len(g.orderBy))
+	children = append(children, g.args...)
+	for _, f := range g.orderBy {
+		children = append(children, f.Column)
+	}
+	return children
+}
+
+func (g *GroupConcat) String() string {
+	var buf bytes.Buffer
+	buf.WriteString("GROUP_CONCAT(")
+	if g.distinct {
+		buf.WriteString("DISTINCT ")
+	}
+
+	var args = make([]string, len(g.args))
+	for i, e := range g.args {
+		args[i] = e.String()
+	}
+	buf.WriteString(strings.Join(args, ", "))
+
+	if len(g.orderBy) > 0 {
+		var fields = make([]string, len(g.orderBy))
+		for i, f := range g.orderBy {
+			fields[i] = f.String()
+		}
+		buf.WriteString(" ORDER BY ")
+		buf.WriteString(strings.Join(fields, ", "))
+	}
+
+	if g.separator != DefaultGroupConcatSeparator {
+		buf.WriteString(" SEPARATOR ")
+		buf.WriteString(strconv.Quote(g.separator))
+	}
+
+	buf.WriteString(")")
+	return buf.String()
+}
+
+// TransformUp implements the Transformable interface.
+func (g *GroupConcat) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	var args = make([]sql.Expression, len(g.args))
+	for i, e := range g.args {
+		e, err := e.TransformUp(f)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = e
+	}
+
+	var orderBy = make([]OrderByField, len(g.orderBy))
+	for i, field := range g.orderBy {
+		col, err := field.Column.TransformUp(f)
+		if err != nil {
+			return nil, err
+		}
+		orderBy[i] = OrderByField{col, field.Descending}
+	}
+
+	return f(NewGroupConcat(g.distinct, args, orderBy, g.separator))
+}
+
+// NewBuffer creates a new buffer to compute the result. It keeps the rows
+// with values, which are concatenated once they are all sorted.
+func (g *GroupConcat) NewBuffer() sql.Row {
+	return sql.NewRow([]sql.Row(nil))
+}
+
+// Update implements the Aggregation interface.
+func (g *GroupConcat) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	for _, e := range g.args {
+		v, err := e.Eval(ctx, row)
+		if err != nil || v == nil {
+			return err
+		}
+	}
+
+	buffer[0] = append(buffer[0].([]sql.Row), row)
+	return nil
+}
+
+// Merge implements the Aggregation interface.
+func (g *GroupConcat) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	buffer[0] = append(buffer[0].([]sql.Row), partial[0].([]sql.Row)...)
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (g *GroupConcat) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("aggregation.GroupConcat_Eval")
+	defer span.Finish()
+
+	rows := buffer[0].([]sql.Row)
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	rows, err := g.sort(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	var seen = make(map[string]bool)
+	var concatenated int
+	for _, row := range rows {
+		var values = make([]string, len(g.args))
+		for j, e := range g.args {
+			v, err := e.Eval(ctx, row)
+			if err != nil {
+				return nil, err
+			}
+
+			s, err := sql.ConvertFrom(sql.Text, e.Type(), v)
+			if err != nil {
+				return nil, err
+			}
+			values[j] = s.(string)
+		}
+
+		if g.distinct {
+			var keys = make([]string, len(values))
+			for j, v := range values {
+				keys[j] = sql.CollationOf(g.args[j].Type()).Key(v)
+			}
+
+			key := strings.Join(keys, "\x00")
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+
+		if concatenated > 0 {
+			buf.WriteString(g.separator)
+		}
+		concatenated++
+
+		for _, v := range values {
+			buf.WriteString(v)
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// sort returns the given rows sorted by the fields of the ORDER BY clause.
+// Rows that are equal keep their order.
+func (g *GroupConcat) sort(ctx *sql.Context, rows []sql.Row) ([]sql.Row, error) {
+	if len(g.orderBy) == 0 {
+		return rows, nil
+	}
+
+	var sorted = make([]sql.Row, len(rows))
+	var keys = make([]sql.Row, len(rows))
+	for i, row := range rows {
+		key := make(sql.Row, len(g.orderBy))
+		for j, f := range g.orderBy {
+			v, err := f.Column.Eval(ctx, row)
+			if err != nil {
+				return nil, err
+			}
+			key[j] = v
+		}
+		sorted[i], keys[i] = row, key
+	}
+
+	var sortErr error
+	sort.Stable(&rowsByKey{sorted, keys, func(a, b sql.Row) bool {
+		for i, f := range g.orderBy {
+			cmp, err := compareNullsFirst(f.Column.Type(), a[i], b[i])
+			if err != nil {
+				sortErr = err
+				return false
+			}
+
+			if f.Descending {
+				cmp = -cmp
+			}
+
+			if cmp != 0 {
+				return cmp < 0
+			}
+		}
+		return false
+	}})
+
+	return sorted, sortErr
+}
+
+func compareNullsFirst(t sql.Type, a, b interface{}) (int, error) {
+	switch {
+	case a == nil && b == nil:
+		return 0, nil
+	case a == nil:
+		return -1, nil
+	case b == nil:
+		return 1, nil
+	default:
+		return t.Compare(a, b)
+	}
+}
+
+// rowsByKey sorts rows by a key of each row.
+type rowsByKey struct {
+	rows []sql.Row
+	keys []sql.Row
+	less func(a, b sql.Row) bool
+}
+
+func (r *rowsByKey) Len() int           { return len(r.rows) }
+func (r *rowsByKey) Less(i, j int) bool { return r.less(r.keys[i], r.keys[j]) }
+func (r *rowsByKey) Swap(i, j int) {
+	r.rows[i], r.rows[j] = r.rows[j], r.rows[i]
+	r.keys[i], r.keys[j] = r.keys[j], r.keys[i]
+}
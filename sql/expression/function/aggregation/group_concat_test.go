@@ -0,0 +1,69 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestGroupConcat(t *testing.T) {
+	name := expression.NewGetField(0, sql.Text, "name", true)
+	n := expression.NewGetField(1, sql.Int64, "n", true)
+	rows := []sql.Row{
+		sql.NewRow("b", int64(2)),
+		sql.NewRow("a", int64(1)),
+		sql.NewRow(nil, int64(3)),
+		sql.NewRow("c", nil),
+		sql.NewRow("a", int64(1)),
+	}
+
+	testCases := []struct {
+		name     string
+		agg      *GroupConcat
+		expected interface{}
+	}{
+		{
+			"GROUP_CONCAT(name)",
+			NewGroupConcat(false, []sql.Expression{name}, nil, ","),
+			"b,a,c,a",
+		},
+		{
+			"GROUP_CONCAT(name, n ORDER BY n DESC SEPARATOR \"; \")",
+			NewGroupConcat(false, []sql.Expression{name, n}, []OrderByField{{n, true}}, "; "),
+			"b2; a1; a1",
+		},
+		{
+			"GROUP_CONCAT(DISTINCT name ORDER BY n ASC, name DESC)",
+			NewGroupConcat(true, []sql.Expression{name}, []OrderByField{{n, false}, {name, true}}, ","),
+			"c,a,b",
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+			require.Equal(tt.name, tt.agg.String())
+
+			b := tt.agg.NewBuffer()
+			v, err := tt.agg.Eval(ctx, b)
+			require.NoError(err)
+			require.Nil(v)
+
+			partial := tt.agg.NewBuffer()
+			for _, row := range rows[:2] {
+				require.NoError(tt.agg.Update(ctx, b, row))
+			}
+			for _, row := range rows[2:] {
+				require.NoError(tt.agg.Update(ctx, partial, row))
+			}
+			require.NoError(tt.agg.Merge(ctx, b, partial))
+
+			v, err = tt.agg.Eval(ctx, b)
+			require.NoError(err)
+			require.Equal(tt.expected, v)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package aggregation
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// Grouping aggregation returns a bitmask of which of its arguments, which
+// must be grouping expressions, are NULL because the row is a
+// super-aggregate row of a GROUP BY WITH ROLLUP. The leftmost argument is
+// the highest bit. It's 0 in the rest of rows, and the super-aggregate rows
+// are computed by the GroupBy node.
+type Grouping struct {
+	args []sql.Expression
+}
+
+// NewGrouping returns a new Grouping node.
+func NewGrouping(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) == 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New("1 or more", 0)
+	}
+
+	return &Grouping{args}, nil
+}
+
+// Type returns the resultant type of the aggregation.
+func (g *Grouping) Type() sql.Type {
+	return sql.Int64
+}
+
+// IsNullable returns whether the return value can be null.
+func (g *Grouping) IsNullable() bool {
+	return false
+}
+
+// Resolved implements the Expression interface.
+func (g *Grouping) Resolved() bool {
+	for _, e := range g.args {
+		if !e.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Children implements the Expression interface.
+func (g *Grouping) Children() []sql.Expression {
+	return g.args
+}
+
+func (g *Grouping) String() string {
+	var args = make([]string, len(g.args))
+	for i, e := range g.args {
+		args[i] = e.String()
+	}
+	return fmt.Sprintf("GROUPING(%s)", strings.Join(args, ", "))
+}
+
+// TransformUp implements the Transformable interface.
+func (g *Grouping) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	var args = make([]sql.Expression, len(g.args))
+	for i, e := range g.args {
+		e, err := e.TransformUp(f)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = e
+	}
+
+	return f(&Grouping{args})
+}
+
+// NewBuffer creates a new buffer to compute the result.
+func (g *Grouping) NewBuffer() sql.Row {
+	return sql.NewRow(int64(0))
+}
+
+// Update implements the Aggregation interface.
+func (g *Grouping) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	return nil
+}
+
+// Merge implements the Aggregation interface.
+func (g *Grouping) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (g *Grouping) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	return buffer[0], nil
+}
@@ -0,0 +1,34 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestGrouping(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	g, err := NewGrouping(
+		expression.NewGetField(0, sql.Text, "a", true),
+		expression.NewGetField(1, sql.Text, "b", true),
+	)
+	require.NoError(err)
+	require.Equal("GROUPING(a, b)", g.String())
+	require.Equal(sql.Int64, g.Type())
+
+	agg := g.(sql.Aggregation)
+	b := agg.NewBuffer()
+	require.NoError(agg.Update(ctx, b, sql.NewRow("x", "y")))
+	require.NoError(agg.Merge(ctx, b, agg.NewBuffer()))
+
+	v, err := agg.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(int64(0), v)
+
+	_, err = NewGrouping()
+	require.True(sql.ErrInvalidArgumentNumber.Is(err))
+}
@@ -0,0 +1,121 @@
+package aggregation
+
+import (
+	"math"
+	"math/bits"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrInvalidSketch is returned when a serialized sketch cannot be decoded.
+var ErrInvalidSketch = errors.NewKind("invalid %s sketch")
+
+// hllPrecision is the number of bits of the hashes used to choose a
+// register of a HyperLogLog sketch. With 2^12 registers of a byte, the
+// sketch takes 4KB and its standard error is about 1.6%.
+const hllPrecision = 12
+
+const hllRegisters = 1 << hllPrecision
+
+// hyperLogLog is a sketch that estimates the number of distinct values
+// added to it using a fixed amount of memory. The registers are allocated
+// when the first value is added, so sketches of empty groups are small.
+type hyperLogLog struct {
+	registers []uint8
+}
+
+func newHyperLogLog() *hyperLogLog {
+	return &hyperLogLog{}
+}
+
+// add adds the value with the given 64-bit hash to the sketch. The hash
+// must be evenly distributed.
+func (h *hyperLogLog) add(hash uint64) {
+	if h.registers == nil {
+		h.registers = make([]uint8, hllRegisters)
+	}
+
+	idx := hash >> (64 - hllPrecision)
+	// The rank is the position of the first set bit of the rest of the hash.
+	// A sentinel bit bounds it when all of them are zero.
+	rest := hash<<hllPrecision | 1<<(hllPrecision-1)
+	rank := uint8(bits.LeadingZeros64(rest)) + 1
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+// merge merges other into the sketch, which then estimates the number of
+// distinct values added to any of them.
+func (h *hyperLogLog) merge(other *hyperLogLog) {
+	if other.registers == nil {
+		return
+	}
+
+	if h.registers == nil {
+		h.registers = make([]uint8, hllRegisters)
+	}
+
+	for i, r := range other.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+}
+
+// count returns the estimated number of distinct values.
+func (h *hyperLogLog) count() int64 {
+	if h.registers == nil {
+		return 0
+	}
+
+	const m = float64(hllRegisters)
+	var sum float64
+	var zeros int
+	for _, r := range h.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+	// Linear counting is more accurate for small cardinalities.
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return int64(estimate + 0.5)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (h *hyperLogLog) MarshalBinary() ([]byte, error) {
+	var data = []byte{hllPrecision}
+	return append(data, h.registers...), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (h *hyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 || data[0] != hllPrecision ||
+		(len(data) != 1 && len(data) != hllRegisters+1) {
+		return ErrInvalidSketch.New("HyperLogLog")
+	}
+
+	h.registers = nil
+	if len(data) > 1 {
+		h.registers = append([]uint8(nil), data[1:]...)
+	}
+	return nil
+}
+
+// mixHash returns an evenly distributed hash of the given one, using the
+// finalizer of SplitMix64.
+func mixHash(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
@@ -0,0 +1,56 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	require := require.New(t)
+
+	a, b := newHyperLogLog(), newHyperLogLog()
+	require.Equal(int64(0), a.count())
+
+	for i := uint64(0); i < 100000; i++ {
+		a.add(mixHash(i))
+		// Half of the values of b are also in a.
+		b.add(mixHash(i + 50000))
+	}
+
+	require.InEpsilon(100000, a.count(), 0.05)
+
+	a.merge(b)
+	require.InEpsilon(150000, a.count(), 0.05)
+
+	small := newHyperLogLog()
+	for i := uint64(0); i < 100; i++ {
+		small.add(mixHash(i % 10))
+	}
+	require.Equal(int64(10), small.count())
+}
+
+func TestHyperLogLogMarshal(t *testing.T) {
+	require := require.New(t)
+
+	h := newHyperLogLog()
+	data, err := h.MarshalBinary()
+	require.NoError(err)
+	require.Len(data, 1)
+
+	var decoded hyperLogLog
+	require.NoError(decoded.UnmarshalBinary(data))
+	require.Equal(int64(0), decoded.count())
+
+	for i := uint64(0); i < 1000; i++ {
+		h.add(mixHash(i))
+	}
+
+	data, err = h.MarshalBinary()
+	require.NoError(err)
+	require.NoError(decoded.UnmarshalBinary(data))
+	require.Equal(h.count(), decoded.count())
+
+	err = decoded.UnmarshalBinary(data[:10])
+	require.True(ErrInvalidSketch.Is(err))
+}
@@ -0,0 +1,199 @@
+package aggregation
+
+import (
+	"fmt"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// JSONArrayAgg aggregation returns a JSON array with the values of the
+// selected column, including NULLs, or NULL if there are no rows.
+type JSONArrayAgg struct {
+	expression.UnaryExpression
+}
+
+// NewJSONArrayAgg creates a new JSONArrayAgg node.
+func NewJSONArrayAgg(e sql.Expression) *JSONArrayAgg {
+	return &JSONArrayAgg{expression.UnaryExpression{Child: e}}
+}
+
+// NewBuffer creates a new buffer for the aggregation.
+func (j *JSONArrayAgg) NewBuffer() sql.Row {
+	return sql.NewRow(nil)
+}
+
+// Type returns the type of the result.
+func (j *JSONArrayAgg) Type() sql.Type {
+	return sql.JSON
+}
+
+// IsNullable returns whether the return value can be null.
+func (j *JSONArrayAgg) IsNullable() bool {
+	return true
+}
+
+func (j *JSONArrayAgg) String() string {
+	return fmt.Sprintf("JSON_ARRAYAGG(%s)", j.Child)
+}
+
+// TransformUp implements the Expression interface.
+func (j *JSONArrayAgg) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := j.Child.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+	return f(NewJSONArrayAgg(child))
+}
+
+// Update implements the Aggregation interface.
+func (j *JSONArrayAgg) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	v, err := j.Child.Eval(ctx, row)
+	if err != nil {
+		return err
+	}
+
+	v, err = sql.ToJSON(j.Child.Type(), v)
+	if err != nil {
+		return err
+	}
+
+	values, _ := buffer[0].([]interface{})
+	buffer[0] = append(values, v)
+	return nil
+}
+
+// Merge implements the Aggregation interface.
+func (j *JSONArrayAgg) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	if partial[0] == nil {
+		return nil
+	}
+
+	values, _ := buffer[0].([]interface{})
+	buffer[0] = append(values, partial[0].([]interface{})...)
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (j *JSONArrayAgg) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("aggregation.JSONArrayAgg_Eval")
+	defer span.Finish()
+
+	if buffer[0] == nil {
+		return nil, nil
+	}
+
+	return sql.MarshalJSON(buffer[0])
+}
+
+// JSONObjectAgg aggregation returns a JSON object with the keys and values
+// of the selected columns, or NULL if there are no rows. If a key appears
+// more than once, the last value is kept.
+type JSONObjectAgg struct {
+	expression.BinaryExpression
+}
+
+// NewJSONObjectAgg creates a new JSONObjectAgg node.
+func NewJSONObjectAgg(key, value sql.Expression) *JSONObjectAgg {
+	return &JSONObjectAgg{expression.BinaryExpression{Left: key, Right: value}}
+}
+
+// NewBuffer creates a new buffer for the aggregation.
+func (j *JSONObjectAgg) NewBuffer() sql.Row {
+	return sql.NewRow(nil)
+}
+
+// Type returns the type of the result.
+func (j *JSONObjectAgg) Type() sql.Type {
+	return sql.JSON
+}
+
+// IsNullable returns whether the return value can be null.
+func (j *JSONObjectAgg) IsNullable() bool {
+	return true
+}
+
+func (j *JSONObjectAgg) String() string {
+	return fmt.Sprintf("JSON_OBJECTAGG(%s, %s)", j.Left, j.Right)
+}
+
+// TransformUp implements the Expression interface.
+func (j *JSONObjectAgg) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	key, err := j.Left.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := j.Right.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return f(NewJSONObjectAgg(key, value))
+}
+
+// Update implements the Aggregation interface.
+func (j *JSONObjectAgg) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	k, err := j.Left.Eval(ctx, row)
+	if err != nil {
+		return err
+	}
+
+	if k == nil {
+		return sql.ErrJSONNullKey.New()
+	}
+
+	key, err := sql.ConvertFrom(sql.Text, j.Left.Type(), k)
+	if err != nil {
+		return err
+	}
+
+	v, err := j.Right.Eval(ctx, row)
+	if err != nil {
+		return err
+	}
+
+	v, err = sql.ToJSON(j.Right.Type(), v)
+	if err != nil {
+		return err
+	}
+
+	object, ok := buffer[0].(map[string]interface{})
+	if !ok {
+		object = make(map[string]interface{})
+		buffer[0] = object
+	}
+
+	object[key.(string)] = v
+	return nil
+}
+
+// Merge implements the Aggregation interface.
+func (j *JSONObjectAgg) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	if partial[0] == nil {
+		return nil
+	}
+
+	object, ok := buffer[0].(map[string]interface{})
+	if !ok {
+		object = make(map[string]interface{})
+		buffer[0] = object
+	}
+
+	for k, v := range partial[0].(map[string]interface{}) {
+		object[k] = v
+	}
+	return nil
+}
+
+// Eval implements the Aggregation interface.
+func (j *JSONObjectAgg) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("aggregation.JSONObjectAgg_Eval")
+	defer span.Finish()
+
+	if buffer[0] == nil {
+		return nil, nil
+	}
+
+	return sql.MarshalJSON(buffer[0])
+}
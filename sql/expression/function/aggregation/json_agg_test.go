@@ -0,0 +1,64 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestJSONArrayAgg(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	j := NewJSONArrayAgg(expression.NewGetField(0, sql.Text, "field", true))
+	require.Equal("JSON_ARRAYAGG(field)", j.String())
+
+	b := j.NewBuffer()
+	v, err := j.Eval(ctx, b)
+	require.NoError(err)
+	require.Nil(v)
+
+	require.NoError(j.Update(ctx, b, sql.NewRow("a")))
+	require.NoError(j.Update(ctx, b, sql.NewRow(nil)))
+
+	partial := j.NewBuffer()
+	require.NoError(j.Update(ctx, partial, sql.NewRow("b")))
+	require.NoError(j.Merge(ctx, b, partial))
+	require.NoError(j.Merge(ctx, b, j.NewBuffer()))
+
+	v, err = j.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(`["a", null, "b"]`, string(v.([]byte)))
+}
+
+func TestJSONObjectAgg(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	j := NewJSONObjectAgg(
+		expression.NewGetField(0, sql.Text, "key", true),
+		expression.NewGetField(1, sql.Int64, "value", true),
+	)
+	require.Equal("JSON_OBJECTAGG(key, value)", j.String())
+
+	b := j.NewBuffer()
+	v, err := j.Eval(ctx, b)
+	require.NoError(err)
+	require.Nil(v)
+
+	require.NoError(j.Update(ctx, b, sql.NewRow("b", int64(1))))
+	require.NoError(j.Update(ctx, b, sql.NewRow("a", nil)))
+
+	partial := j.NewBuffer()
+	require.NoError(j.Update(ctx, partial, sql.NewRow("b", int64(2))))
+	require.NoError(j.Merge(ctx, b, partial))
+
+	v, err = j.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(`{"a": null, "b": 2}`, string(v.([]byte)))
+
+	err = j.Update(ctx, b, sql.NewRow(nil, int64(1)))
+	require.True(sql.ErrJSONNullKey.Is(err))
+}
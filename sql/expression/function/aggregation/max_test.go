@@ -93,3 +93,23 @@ func TestMax_Eval_Empty(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(nil, v)
 }
+
+func TestMax_Merge(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	m := NewMax(expression.NewGetField(0, sql.Int32, "field", true))
+	b := m.NewBuffer()
+	partial := m.NewBuffer()
+
+	require.NoError(m.Merge(ctx, b, partial))
+	require.NoError(m.Update(ctx, b, sql.NewRow(int32(5))))
+	require.NoError(m.Update(ctx, partial, sql.NewRow(int32(7))))
+	require.NoError(m.Update(ctx, partial, sql.NewRow(int32(2))))
+	require.NoError(m.Merge(ctx, b, partial))
+	require.NoError(m.Merge(ctx, b, m.NewBuffer()))
+
+	v, err := m.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(int32(7), v)
+}
@@ -43,8 +43,23 @@ func TestMin_Eval_Text(t *testing.T) {
 	m.Update(ctx, b, sql.NewRow("A"))
 	m.Update(ctx, b, sql.NewRow("b"))
 
+	// "a" and "A" are equal in the default collation.
 	v, err := m.Eval(ctx, b)
 	assert.NoError(err)
+	assert.Equal("a", v)
+
+	typ, err := sql.WithCollation(sql.Text, sql.CollationUtf8mb4Bin)
+	assert.NoError(err)
+
+	m = NewMin(expression.NewGetField(0, typ, "field", true))
+	b = m.NewBuffer()
+
+	m.Update(ctx, b, sql.NewRow("a"))
+	m.Update(ctx, b, sql.NewRow("A"))
+	m.Update(ctx, b, sql.NewRow("b"))
+
+	v, err = m.Eval(ctx, b)
+	assert.NoError(err)
 	assert.Equal("A", v)
 }
 
@@ -95,3 +110,23 @@ func TestMin_Eval_Empty(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(nil, v)
 }
+
+func TestMin_Merge(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	m := NewMin(expression.NewGetField(0, sql.Int32, "field", true))
+	b := m.NewBuffer()
+	partial := m.NewBuffer()
+
+	require.NoError(m.Merge(ctx, b, partial))
+	require.NoError(m.Update(ctx, b, sql.NewRow(int32(5))))
+	require.NoError(m.Update(ctx, partial, sql.NewRow(int32(7))))
+	require.NoError(m.Update(ctx, partial, sql.NewRow(int32(2))))
+	require.NoError(m.Merge(ctx, b, partial))
+	require.NoError(m.Merge(ctx, b, m.NewBuffer()))
+
+	v, err := m.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(int32(2), v)
+}
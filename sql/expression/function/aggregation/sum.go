@@ -3,6 +3,7 @@ package aggregation
 import (
 	"fmt"
 
+	"github.com/shopspring/decimal"
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
 	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
 )
@@ -18,9 +19,31 @@ func NewSum(e sql.Expression) *Sum {
 	return &Sum{expression.UnaryExpression{Child: e}}
 }
 
-// Type returns the resultant type of the aggregation.
+// sumPrecisionIncrement is the number of digits by which the precision of
+// the summed decimals is increased in the result.
+const sumPrecisionIncrement = 22
+
+// Type returns the resultant type of the aggregation. As in MySQL, the sum of
+// integers or decimals is a decimal, and the sum of any other type is a
+// DOUBLE.
 func (m *Sum) Type() sql.Type {
-	return sql.Float64
+	t := m.Child.Type()
+	if !sql.IsInteger(t) && !sql.IsFixedPoint(t) {
+		return sql.Float64
+	}
+
+	precision, scale := sql.NumericPrecision(t)
+	return sql.MustDecimal(
+		uint8(min(int(precision)+sumPrecisionIncrement, sql.DecimalMaxPrecision)),
+		scale,
+	)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
 }
 
 func (m *Sum) String() string {
@@ -52,6 +75,21 @@ func (m *Sum) Update(ctx *sql.Context, buffer, row sql.Row) error {
 		return nil
 	}
 
+	typ := m.Type()
+	if sql.IsFixedPoint(typ) {
+		val, err := typ.Convert(v)
+		if err != nil {
+			return err
+		}
+
+		if buffer[0] == nil {
+			buffer[0] = decimal.New(0, 0)
+		}
+
+		buffer[0] = buffer[0].(decimal.Decimal).Add(val.(decimal.Decimal))
+		return nil
+	}
+
 	val, err := sql.Float64.Convert(v)
 	if err != nil {
 		val = float64(0)
@@ -68,7 +106,21 @@ func (m *Sum) Update(ctx *sql.Context, buffer, row sql.Row) error {
 
 // Merge implements the Aggregation interface.
 func (m *Sum) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
-	return m.Update(ctx, buffer, partial)
+	switch v := partial[0].(type) {
+	case nil:
+	case decimal.Decimal:
+		if buffer[0] == nil {
+			buffer[0] = decimal.New(0, 0)
+		}
+		buffer[0] = buffer[0].(decimal.Decimal).Add(v)
+	case float64:
+		if buffer[0] == nil {
+			buffer[0] = float64(0)
+		}
+		buffer[0] = buffer[0].(float64) + v
+	}
+
+	return nil
 }
 
 // Eval implements the Aggregation interface.
@@ -3,6 +3,7 @@ package aggregation
 import (
 	"testing"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
 	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
@@ -73,3 +74,52 @@ func TestSum(t *testing.T) {
 		})
 	}
 }
+
+func TestSumDecimal(t *testing.T) {
+	require := require.New(t)
+
+	sum := NewSum(expression.NewGetField(0, sql.MustDecimal(10, 2), "", true))
+	require.Equal(sql.MustDecimal(32, 2), sum.Type())
+
+	buf := sum.NewBuffer()
+	for i := 0; i < 10; i++ {
+		require.NoError(sum.Update(sql.NewEmptyContext(), buf, sql.NewRow("0.10")))
+	}
+	require.NoError(sum.Update(sql.NewEmptyContext(), buf, sql.NewRow(nil)))
+
+	result, err := sum.Eval(sql.NewEmptyContext(), buf)
+	require.NoError(err)
+	require.True(decimal.New(1, 0).Equal(result.(decimal.Decimal)))
+
+	sum = NewSum(expression.NewGetField(0, sql.Int64, "", true))
+	require.Equal(sql.MustDecimal(42, 0), sum.Type())
+}
+
+func TestSum_Merge(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	sum := NewSum(expression.NewGetField(0, sql.Int64, "", true))
+	b := sum.NewBuffer()
+	partial := sum.NewBuffer()
+
+	require.NoError(sum.Update(ctx, b, sql.NewRow(int64(1))))
+	require.NoError(sum.Update(ctx, partial, sql.NewRow(int64(2))))
+	require.NoError(sum.Update(ctx, partial, sql.NewRow(int64(3))))
+	require.NoError(sum.Merge(ctx, b, partial))
+	require.NoError(sum.Merge(ctx, b, sum.NewBuffer()))
+
+	result, err := sum.Eval(ctx, b)
+	require.NoError(err)
+	require.True(decimal.New(6, 0).Equal(result.(decimal.Decimal)))
+
+	sum = NewSum(expression.NewGetField(0, sql.Float64, "", true))
+	b = sum.NewBuffer()
+	partial = sum.NewBuffer()
+	require.NoError(sum.Update(ctx, partial, sql.NewRow(float64(1.5))))
+	require.NoError(sum.Merge(ctx, b, partial))
+
+	result, err = sum.Eval(ctx, b)
+	require.NoError(err)
+	require.Equal(float64(1.5), result)
+}
@@ -0,0 +1,181 @@
+package aggregation
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+)
+
+// tDigestCompression bounds the number of centroids of a t-digest, which
+// is about tDigestCompression/2 once it is compressed.
+const tDigestCompression = 100
+
+// tDigestBufferSize is the number of values that are buffered before they
+// are merged into the centroids.
+const tDigestBufferSize = 5 * tDigestCompression
+
+type centroid struct {
+	mean  float64
+	count float64
+}
+
+// tDigest is a sketch that estimates the quantiles of the values added to
+// it using a bounded amount of memory. Values are summarized in centroids,
+// which are smaller near the extremes, so extreme quantiles are more
+// accurate than central ones. Quantiles are exact while there are fewer
+// values than half the compression.
+type tDigest struct {
+	centroids []centroid
+	unmerged  []centroid
+	count     float64
+	min, max  float64
+}
+
+func newTDigest() *tDigest {
+	return &tDigest{}
+}
+
+// add adds a value to the sketch.
+func (t *tDigest) add(x float64) {
+	t.addCentroid(centroid{x, 1}, x, x)
+}
+
+func (t *tDigest) addCentroid(c centroid, min, max float64) {
+	if t.count == 0 || min < t.min {
+		t.min = min
+	}
+	if t.count == 0 || max > t.max {
+		t.max = max
+	}
+
+	t.unmerged = append(t.unmerged, c)
+	t.count += c.count
+	if len(t.unmerged) >= tDigestBufferSize {
+		t.compress()
+	}
+}
+
+// merge merges other into the sketch, which then estimates the quantiles
+// of the values added to any of them.
+func (t *tDigest) merge(other *tDigest) {
+	if other.count == 0 {
+		return
+	}
+
+	min, max := other.min, other.max
+	for _, cs := range [][]centroid{other.centroids, other.unmerged} {
+		for _, c := range cs {
+			t.addCentroid(c, min, max)
+		}
+	}
+}
+
+// tDigestScale is the scale function that bounds the size of the centroids
+// depending on their quantile.
+func tDigestScale(q float64) float64 {
+	return tDigestCompression / (2 * math.Pi) * math.Asin(2*q-1)
+}
+
+// compress merges the buffered values into the centroids.
+func (t *tDigest) compress() {
+	if len(t.unmerged) == 0 {
+		return
+	}
+
+	all := append(t.centroids, t.unmerged...)
+	sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+
+	var merged = make([]centroid, 0, len(t.centroids))
+	var soFar float64
+	cur := all[0]
+	for _, c := range all[1:] {
+		proposed := cur.count + c.count
+		if tDigestScale((soFar+proposed)/t.count)-tDigestScale(soFar/t.count) <= 1 {
+			cur.mean += (c.mean - cur.mean) * c.count / proposed
+			cur.count = proposed
+			continue
+		}
+
+		merged = append(merged, cur)
+		soFar += cur.count
+		cur = c
+	}
+
+	t.centroids = append(merged, cur)
+	t.unmerged = nil
+}
+
+// quantile returns the estimated value at the quantile q, which is between
+// 0 and 1. Values between centroids are interpolated, so that quantiles of
+// values that are not merged in centroids are interpolated between the
+// closest values as PERCENTILE_CONT does.
+func (t *tDigest) quantile(q float64) float64 {
+	t.compress()
+
+	c := t.centroids
+	switch {
+	case t.count == 0:
+		return math.NaN()
+	case q <= 0:
+		return t.min
+	case q >= 1:
+		return t.max
+	case len(c) == 1:
+		return c[0].mean
+	}
+
+	// Each centroid is centered in its weight, so the values are at 0.5,
+	// 1.5, ..., count-0.5.
+	index := q*(t.count-1) + 0.5
+	if index < c[0].count/2 {
+		return t.min + index/(c[0].count/2)*(c[0].mean-t.min)
+	}
+
+	weight := c[0].count / 2
+	for i := 0; i < len(c)-1; i++ {
+		dw := (c[i].count + c[i+1].count) / 2
+		if weight+dw > index {
+			z := (index - weight) / dw
+			return c[i].mean + z*(c[i+1].mean-c[i].mean)
+		}
+		weight += dw
+	}
+
+	last := c[len(c)-1]
+	z := (index - weight) / (last.count / 2)
+	return last.mean + math.Min(z, 1)*(t.max-last.mean)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The
+// sketch is encoded as its minimum, maximum and centroids.
+func (t *tDigest) MarshalBinary() ([]byte, error) {
+	t.compress()
+
+	var data = make([]byte, 8*(2+2*len(t.centroids)))
+	binary.LittleEndian.PutUint64(data, math.Float64bits(t.min))
+	binary.LittleEndian.PutUint64(data[8:], math.Float64bits(t.max))
+	for i, c := range t.centroids {
+		binary.LittleEndian.PutUint64(data[16+16*i:], math.Float64bits(c.mean))
+		binary.LittleEndian.PutUint64(data[24+16*i:], math.Float64bits(c.count))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (t *tDigest) UnmarshalBinary(data []byte) error {
+	if len(data) < 16 || len(data)%16 != 0 {
+		return ErrInvalidSketch.New("t-digest")
+	}
+
+	float := func(i int) float64 {
+		return math.Float64frombits(binary.LittleEndian.Uint64(data[i:]))
+	}
+
+	*t = tDigest{min: float(0), max: float(8)}
+	for i := 16; i < len(data); i += 16 {
+		c := centroid{float(i), float(i + 8)}
+		t.centroids = append(t.centroids, c)
+		t.count += c.count
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package aggregation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTDigest(t *testing.T) {
+	require := require.New(t)
+
+	a, b := newTDigest(), newTDigest()
+	require.True(math.IsNaN(a.quantile(0.5)))
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, i := range rnd.Perm(100000) {
+		if i%2 == 0 {
+			a.add(float64(i))
+		} else {
+			b.add(float64(i))
+		}
+	}
+	a.merge(b)
+
+	require.Equal(float64(0), a.quantile(0))
+	require.Equal(float64(99999), a.quantile(1))
+	for _, q := range []float64{0.001, 0.01, 0.25, 0.5, 0.75, 0.99, 0.999} {
+		require.InDelta(q*100000, a.quantile(q), 500, "quantile %v", q)
+	}
+	require.True(len(a.centroids) <= tDigestCompression)
+}
+
+func TestTDigestSmall(t *testing.T) {
+	require := require.New(t)
+
+	d := newTDigest()
+	for _, x := range []float64{4, 1, 3, 2} {
+		d.add(x)
+	}
+
+	require.Equal(float64(1), d.quantile(0))
+	require.Equal(2.5, d.quantile(0.5))
+	require.Equal(float64(4), d.quantile(1))
+
+	d.add(5)
+	require.Equal(float64(3), d.quantile(0.5))
+}
+
+func TestTDigestMarshal(t *testing.T) {
+	require := require.New(t)
+
+	d := newTDigest()
+	for i := 0; i < 10000; i++ {
+		d.add(float64(i))
+	}
+
+	data, err := d.MarshalBinary()
+	require.NoError(err)
+
+	var decoded tDigest
+	require.NoError(decoded.UnmarshalBinary(data))
+	require.Equal(d.count, decoded.count)
+	require.Equal(d.quantile(0.3), decoded.quantile(0.3))
+
+	err = decoded.UnmarshalBinary(data[:20])
+	require.True(ErrInvalidSketch.Is(err))
+}
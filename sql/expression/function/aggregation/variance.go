@@ -0,0 +1,133 @@
+package aggregation
+
+import (
+	"fmt"
+	"math"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// Variance aggregation returns the population or sample variance, or
+// standard deviation, of the values of the selected column as a DOUBLE. It
+// returns NULL if there are no values, or only one for the sample ones.
+type Variance struct {
+	expression.UnaryExpression
+	name   string
+	sample bool
+	stddev bool
+}
+
+// NewVarPop returns a new Variance node of the population variance.
+func NewVarPop(e sql.Expression) *Variance {
+	return &Variance{expression.UnaryExpression{Child: e}, "VAR_POP", false, false}
+}
+
+// NewVarSamp returns a new Variance node of the sample variance.
+func NewVarSamp(e sql.Expression) *Variance {
+	return &Variance{expression.UnaryExpression{Child: e}, "VAR_SAMP", true, false}
+}
+
+// NewStdDevPop returns a new Variance node of the population standard
+// deviation.
+func NewStdDevPop(e sql.Expression) *Variance {
+	return &Variance{expression.UnaryExpression{Child: e}, "STDDEV_POP", false, true}
+}
+
+// NewStdDevSamp returns a new Variance node of the sample standard
+// deviation.
+func NewStdDevSamp(e sql.Expression) *Variance {
+	return &Variance{expression.UnaryExpression{Child: e}, "STDDEV_SAMP", true, true}
+}
+
+// Type returns the resultant type of the aggregation.
+func (v *Variance) Type() sql.Type {
+	return sql.Float64
+}
+
+// IsNullable returns whether the return value can be null.
+func (v *Variance) IsNullable() bool {
+	return true
+}
+
+func (v *Variance) String() string {
+	return fmt.Sprintf("%s(%s)", v.name, v.Child)
+}
+
+// TransformUp implements the Transformable interface.
+func (v *Variance) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := v.Child.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	n := *v
+	n.Child = child
+	return f(&n)
+}
+
+// NewBuffer creates a new buffer to compute the result. It keeps the
+// number of values, their mean and the sum of the squares of their
+// differences from the mean.
+func (v *Variance) NewBuffer() sql.Row {
+	return sql.NewRow(int64(0), float64(0), float64(0))
+}
+
+// Update implements the Aggregation interface.
+func (v *Variance) Update(ctx *sql.Context, buffer, row sql.Row) error {
+	val, err := v.Child.Eval(ctx, row)
+	if err != nil || val == nil {
+		return err
+	}
+
+	x, err := sql.Float64.Convert(val)
+	if err != nil {
+		return err
+	}
+
+	mergeVariance(buffer, 1, x.(float64), 0)
+	return nil
+}
+
+// Merge implements the Aggregation interface.
+func (v *Variance) Merge(ctx *sql.Context, buffer, partial sql.Row) error {
+	mergeVariance(buffer, partial[0].(int64), partial[1].(float64), partial[2].(float64))
+	return nil
+}
+
+// mergeVariance merges the values with the given count, mean and sum of
+// squared differences into the buffer.
+func mergeVariance(buffer sql.Row, count int64, mean, m2 float64) {
+	if count == 0 {
+		return
+	}
+
+	n := buffer[0].(int64)
+	total := n + count
+	delta := mean - buffer[1].(float64)
+
+	buffer[0] = total
+	buffer[1] = buffer[1].(float64) + delta*float64(count)/float64(total)
+	buffer[2] = buffer[2].(float64) + m2 + delta*delta*float64(n)*float64(count)/float64(total)
+}
+
+// Eval implements the Aggregation interface.
+func (v *Variance) Eval(ctx *sql.Context, buffer sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("aggregation.Variance_Eval")
+	defer span.Finish()
+
+	n := buffer[0].(int64)
+	if v.sample {
+		n--
+	}
+
+	if n <= 0 {
+		return nil, nil
+	}
+
+	variance := buffer[2].(float64) / float64(n)
+	if v.stddev {
+		return math.Sqrt(variance), nil
+	}
+	return variance, nil
+}
@@ -0,0 +1,66 @@
+package aggregation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestVariance(t *testing.T) {
+	field := expression.NewGetField(0, sql.Int64, "field", true)
+	testCases := []struct {
+		agg      *Variance
+		name     string
+		expected interface{}
+	}{
+		{NewVarPop(field), "VAR_POP(field)", 4.0},
+		{NewVarSamp(field), "VAR_SAMP(field)", 32.0 / 7},
+		{NewStdDevPop(field), "STDDEV_POP(field)", 2.0},
+		{NewStdDevSamp(field), "STDDEV_SAMP(field)", math.Sqrt(32.0 / 7)},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := sql.NewEmptyContext()
+			require.Equal(tt.name, tt.agg.String())
+
+			b := tt.agg.NewBuffer()
+			v, err := tt.agg.Eval(ctx, b)
+			require.NoError(err)
+			require.Nil(v)
+
+			// The values are split in two partial buffers to check that
+			// merging them gives the same result.
+			partial := tt.agg.NewBuffer()
+			for i, n := range []interface{}{int64(2), int64(4), nil, int64(4), int64(4), int64(5), int64(5), int64(7), int64(9)} {
+				buffer := b
+				if i%2 == 0 {
+					buffer = partial
+				}
+				require.NoError(tt.agg.Update(ctx, buffer, sql.NewRow(n)))
+			}
+			require.NoError(tt.agg.Merge(ctx, b, partial))
+
+			v, err = tt.agg.Eval(ctx, b)
+			require.NoError(err)
+			require.InDelta(tt.expected, v, 1e-9)
+		})
+	}
+}
+
+func TestVarianceSampleOfOne(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	v := NewVarSamp(expression.NewGetField(0, sql.Float64, "field", true))
+	b := v.NewBuffer()
+	require.NoError(v.Update(ctx, b, sql.NewRow(1.5)))
+
+	result, err := v.Eval(ctx, b)
+	require.NoError(err)
+	require.Nil(result)
+}
@@ -0,0 +1,124 @@
+package function
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shopspring/decimal"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// integralType returns the type of the result of rounding a number of type
+// t to an integer. Fixed-point decimals are rounded to decimals without
+// fractional digits, so they are still exact.
+func integralType(t sql.Type) sql.Type {
+	if !sql.IsFixedPoint(t) {
+		return numberType(t)
+	}
+
+	precision, scale := sql.NumericPrecision(t)
+	digits := precision - scale + 1
+	if digits > sql.DecimalMaxPrecision {
+		digits = sql.DecimalMaxPrecision
+	}
+
+	return sql.MustDecimal(digits, 0)
+}
+
+// Ceil returns the smallest integer that is not less than a number.
+type Ceil struct {
+	expression.UnaryExpression
+}
+
+// NewCeil creates a new Ceil function.
+func NewCeil(e sql.Expression) sql.Expression {
+	return &Ceil{expression.UnaryExpression{Child: e}}
+}
+
+// Type implements the Expression interface.
+func (f *Ceil) Type() sql.Type { return integralType(f.Child.Type()) }
+
+// Eval implements the Expression interface.
+func (f *Ceil) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Ceil")
+	defer span.Finish()
+
+	return evalIntegral(ctx, row, f.Child, f.Type(), decimal.Decimal.Ceil, math.Ceil)
+}
+
+// TransformUp implements the Expression interface.
+func (f *Ceil) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := f.Child.TransformUp(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewCeil(child))
+}
+
+func (f *Ceil) String() string { return fmt.Sprintf("ceil(%s)", f.Child) }
+
+// Floor returns the largest integer that is not greater than a number.
+type Floor struct {
+	expression.UnaryExpression
+}
+
+// NewFloor creates a new Floor function.
+func NewFloor(e sql.Expression) sql.Expression {
+	return &Floor{expression.UnaryExpression{Child: e}}
+}
+
+// Type implements the Expression interface.
+func (f *Floor) Type() sql.Type { return integralType(f.Child.Type()) }
+
+// Eval implements the Expression interface.
+func (f *Floor) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Floor")
+	defer span.Finish()
+
+	return evalIntegral(ctx, row, f.Child, f.Type(), decimal.Decimal.Floor, math.Floor)
+}
+
+// TransformUp implements the Expression interface.
+func (f *Floor) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := f.Child.TransformUp(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewFloor(child))
+}
+
+func (f *Floor) String() string { return fmt.Sprintf("floor(%s)", f.Child) }
+
+// evalIntegral evaluates e and rounds its value to an integer of type t with
+// the given functions for decimals and floats.
+func evalIntegral(
+	ctx *sql.Context,
+	row sql.Row,
+	e sql.Expression,
+	t sql.Type,
+	decimalFn func(decimal.Decimal) decimal.Decimal,
+	floatFn func(float64) float64,
+) (interface{}, error) {
+	v, err := e.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	n, err := toNumber(e.Type(), v)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n := n.(type) {
+	case decimal.Decimal:
+		return t.Convert(decimalFn(n))
+	case float64:
+		return floatFn(n), nil
+	default:
+		return n, nil
+	}
+}
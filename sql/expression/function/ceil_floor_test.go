@@ -0,0 +1,43 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestCeilFloor(t *testing.T) {
+	testCases := []struct {
+		name         string
+		typ          sql.Type
+		value        interface{}
+		expectedType sql.Type
+		ceil         interface{}
+		floor        interface{}
+	}{
+		{"int", sql.Int32, int32(-3), sql.Int64, int64(-3), int64(-3)},
+		{
+			"decimal", sql.MustDecimal(5, 2), decimal.RequireFromString("-1.25"),
+			sql.MustDecimal(4, 0), decimal.RequireFromString("-1"), decimal.RequireFromString("-2"),
+		},
+		{"float", sql.Float64, 1.25, sql.Float64, float64(2), float64(1)},
+		{"text", sql.Text, "-1.5", sql.Float64, float64(-1), float64(-2)},
+		{"null", sql.Float64, nil, sql.Float64, nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ceil := NewCeil(expression.NewLiteral(tt.value, tt.typ))
+			floor := NewFloor(expression.NewLiteral(tt.value, tt.typ))
+
+			require.Equal(tt.expectedType, ceil.Type())
+			require.Equal(tt.expectedType, floor.Type())
+			requireEqualValue(t, tt.ceil, eval(t, ceil, nil))
+			requireEqualValue(t, tt.floor, eval(t, floor, nil))
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// maxStringLength is the maximum length in bytes of the strings built by
+// functions such as REPEAT or LPAD, which return NULL instead of a longer
+// string. It is the default maximum size of a packet in MySQL.
+const maxStringLength = 64 << 20
+
+// nullPropagating is the base of the functions that return NULL if any of
+// their arguments is NULL.
+type nullPropagating struct {
+	name string
+	args []sql.Expression
+}
+
+func newNullPropagating(name string, args ...sql.Expression) nullPropagating {
+	return nullPropagating{name, args}
+}
+
+// Children implements the Expression interface.
+func (f nullPropagating) Children() []sql.Expression { return f.args }
+
+// Resolved implements the Expression interface.
+func (f nullPropagating) Resolved() bool {
+	for _, arg := range f.args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNullable implements the Expression interface.
+func (f nullPropagating) IsNullable() bool {
+	for _, arg := range f.args {
+		if arg.IsNullable() {
+			return true
+		}
+	}
+	return false
+}
+
+func (f nullPropagating) String() string {
+	var args = make([]string, len(f.args))
+	for i, arg := range f.args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("%s(%s)", f.name, strings.Join(args, ", "))
+}
+
+// evalArgs evaluates the arguments of the function. If any of them is NULL,
+// the arguments after it are not evaluated and nil is returned.
+func (f nullPropagating) evalArgs(ctx *sql.Context, row sql.Row) ([]interface{}, error) {
+	var values = make([]interface{}, len(f.args))
+	for i, arg := range f.args {
+		v, err := arg.Eval(ctx, row)
+		if err != nil || v == nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// toText converts the value v of the expression e to a string.
+func toText(e sql.Expression, v interface{}) (string, error) {
+	s, err := sql.ConvertFrom(sql.Text, e.Type(), v)
+	if err != nil {
+		return "", err
+	}
+	return s.(string), nil
+}
+
+// toInt64 converts the value v to an int64.
+func toInt64(v interface{}) (int64, error) {
+	n, err := sql.Int64.Convert(v)
+	if err != nil {
+		return 0, err
+	}
+	return n.(int64), nil
+}
+
+// transformArgs transforms the given arguments of a function with fn.
+func transformArgs(fn sql.TransformExprFunc, args ...sql.Expression) ([]sql.Expression, error) {
+	var result = make([]sql.Expression, len(args))
+	for i, arg := range args {
+		arg, err := arg.TransformUp(fn)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = arg
+	}
+	return result, nil
+}
+
+// evalText evaluates the expression e and returns the result of applying fn
+// to its value converted to a string, or NULL if the value is NULL.
+func evalText(
+	ctx *sql.Context,
+	row sql.Row,
+	e sql.Expression,
+	fn func(string) string,
+) (interface{}, error) {
+	v, err := e.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	s, err := toText(e, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(s), nil
+}
+
+// toTexts converts the values of the given expressions to strings.
+func toTexts(exprs []sql.Expression, values []interface{}) ([]string, error) {
+	var result = make([]string, len(values))
+	for i, v := range values {
+		s, err := toText(exprs[i], v)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = s
+	}
+	return result, nil
+}
@@ -3,6 +3,7 @@ package function
 import (
 	"testing"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
 )
@@ -15,3 +16,15 @@ func eval(t *testing.T, e sql.Expression, row sql.Row) interface{} {
 	require.NoError(t, err)
 	return v
 }
+
+// requireEqualValue is like require.Equal, but decimals are equal if they
+// have the same value, whatever their number of digits.
+func requireEqualValue(t *testing.T, expected, actual interface{}) {
+	t.Helper()
+	if d, ok := expected.(decimal.Decimal); ok {
+		a, ok := actual.(decimal.Decimal)
+		require.True(t, ok && d.Equal(a), "expected %s, got %v", d, actual)
+		return
+	}
+	require.Equal(t, expected, actual)
+}
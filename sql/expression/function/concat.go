@@ -24,6 +24,11 @@ func NewConcat(args ...sql.Expression) (sql.Expression, error) {
 	}
 
 	for _, arg := range args {
+		// Arguments may not be resolved yet, so their type is unknown.
+		if !arg.Resolved() {
+			continue
+		}
+
 		if len(args) > 1 && sql.IsArray(arg.Type()) {
 			return nil, ErrConcatArrayWithOthers.New()
 		}
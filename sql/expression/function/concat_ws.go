@@ -0,0 +1,113 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// ConcatWS joins several strings together with a separator. Unlike Concat,
+// NULL arguments are skipped, and the result is only NULL if the separator
+// is NULL.
+type ConcatWS struct {
+	args []sql.Expression
+}
+
+// NewConcatWS creates a new ConcatWS UDF.
+func NewConcatWS(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("2 or more", len(args))
+	}
+
+	return &ConcatWS{args}, nil
+}
+
+// Type implements the Expression interface.
+func (f *ConcatWS) Type() sql.Type { return sql.Text }
+
+// IsNullable implements the Expression interface.
+func (f *ConcatWS) IsNullable() bool {
+	return f.args[0].IsNullable()
+}
+
+func (f *ConcatWS) String() string {
+	var args = make([]string, len(f.args))
+	for i, arg := range f.args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("concat_ws(%s)", strings.Join(args, ", "))
+}
+
+// TransformUp implements the Expression interface.
+func (f *ConcatWS) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(&ConcatWS{args})
+}
+
+// Resolved implements the Expression interface.
+func (f *ConcatWS) Resolved() bool {
+	for _, arg := range f.args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Children implements the Expression interface.
+func (f *ConcatWS) Children() []sql.Expression { return f.args }
+
+// Eval implements the Expression interface.
+func (f *ConcatWS) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.ConcatWS")
+	defer span.Finish()
+
+	sep, err := f.args[0].Eval(ctx, row)
+	if err != nil || sep == nil {
+		return nil, err
+	}
+
+	separator, err := toText(f.args[0], sep)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []string
+	for _, arg := range f.args[1:] {
+		val, err := arg.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		if val == nil {
+			continue
+		}
+
+		if sql.IsArray(arg.Type()) {
+			val, err = sql.Array(sql.Text).Convert(val)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, v := range val.([]interface{}) {
+				if v != nil {
+					parts = append(parts, v.(string))
+				}
+			}
+			continue
+		}
+
+		s, err := toText(arg, val)
+		if err != nil {
+			return nil, err
+		}
+
+		parts = append(parts, s)
+	}
+
+	return strings.Join(parts, separator), nil
+}
@@ -0,0 +1,39 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestConcatWS(t *testing.T) {
+	_, err := NewConcatWS(expression.NewLiteral(",", sql.Text))
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	f, err := NewConcatWS(
+		expression.NewGetField(0, sql.Text, "sep", true),
+		expression.NewGetField(1, sql.Text, "a", true),
+		expression.NewGetField(2, sql.Int64, "b", true),
+		expression.NewGetField(3, sql.Array(sql.Text), "c", true),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"all values", sql.NewRow(", ", "a", int64(1), []interface{}{"x", "y"}), "a, 1, x, y"},
+		{"nulls are skipped", sql.NewRow("-", nil, int64(1), nil), "1"},
+		{"all nulls", sql.NewRow("-", nil, nil, nil), ""},
+		{"null separator", sql.NewRow(nil, "a", int64(1), nil), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, f, tt.row))
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package function
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// If returns its second argument if the first one is true and the third one
+// otherwise. Only the argument returned is evaluated.
+type If struct {
+	Cond  sql.Expression
+	True  sql.Expression
+	False sql.Expression
+}
+
+// NewIf creates a new If expression.
+func NewIf(cond, ifTrue, ifFalse sql.Expression) sql.Expression {
+	return &If{cond, ifTrue, ifFalse}
+}
+
+// Type implements the Expression interface.
+func (f *If) Type() sql.Type {
+	return sql.CommonType(f.True.Type(), f.False.Type())
+}
+
+// IsNullable implements the Expression interface.
+func (f *If) IsNullable() bool {
+	return f.True.IsNullable() || f.False.IsNullable()
+}
+
+// Resolved implements the Expression interface.
+func (f *If) Resolved() bool {
+	return f.Cond.Resolved() && f.True.Resolved() && f.False.Resolved()
+}
+
+// Children implements the Expression interface.
+func (f *If) Children() []sql.Expression {
+	return []sql.Expression{f.Cond, f.True, f.False}
+}
+
+// Eval implements the Expression interface.
+func (f *If) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.If")
+	defer span.Finish()
+
+	cond, err := f.Cond.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	e := f.False
+	if expression.IsTrue(cond) {
+		e = f.True
+	}
+
+	v, err := e.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.ConvertFrom(f.Type(), e.Type(), v)
+}
+
+// TransformUp implements the Expression interface.
+func (f *If) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.Cond, f.True, f.False)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewIf(args[0], args[1], args[2]))
+}
+
+func (f *If) String() string {
+	return fmt.Sprintf("if(%s, %s, %s)", f.Cond, f.True, f.False)
+}
+
+// IfNull returns its first argument if it is not NULL and the second one
+// otherwise, which is only evaluated in that case.
+type IfNull struct {
+	expression.BinaryExpression
+}
+
+// NewIfNull creates a new IfNull expression.
+func NewIfNull(e, alternative sql.Expression) sql.Expression {
+	return &IfNull{expression.BinaryExpression{Left: e, Right: alternative}}
+}
+
+// Type implements the Expression interface.
+func (f *IfNull) Type() sql.Type {
+	return sql.CommonType(f.Left.Type(), f.Right.Type())
+}
+
+// IsNullable implements the Expression interface.
+func (f *IfNull) IsNullable() bool {
+	return f.Left.IsNullable() && f.Right.IsNullable()
+}
+
+// Eval implements the Expression interface.
+func (f *IfNull) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.IfNull")
+	defer span.Finish()
+
+	return evalFirstNotNull(ctx, row, f.Type(), f.Left, f.Right)
+}
+
+// TransformUp implements the Expression interface.
+func (f *IfNull) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.Left, f.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewIfNull(args[0], args[1]))
+}
+
+func (f *IfNull) String() string {
+	return fmt.Sprintf("ifnull(%s, %s)", f.Left, f.Right)
+}
+
+// Coalesce returns the first of its arguments that is not NULL. The
+// arguments after it are not evaluated.
+type Coalesce struct {
+	args []sql.Expression
+}
+
+// NewCoalesce creates a new Coalesce expression.
+func NewCoalesce(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) == 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New("1 or more", 0)
+	}
+
+	return &Coalesce{args}, nil
+}
+
+// Type implements the Expression interface.
+func (f *Coalesce) Type() sql.Type {
+	var types = make([]sql.Type, len(f.args))
+	for i, arg := range f.args {
+		types[i] = arg.Type()
+	}
+
+	return sql.CommonType(types...)
+}
+
+// IsNullable implements the Expression interface.
+func (f *Coalesce) IsNullable() bool {
+	for _, arg := range f.args {
+		if !arg.IsNullable() {
+			return false
+		}
+	}
+	return true
+}
+
+// Resolved implements the Expression interface.
+func (f *Coalesce) Resolved() bool {
+	for _, arg := range f.args {
+		if !arg.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Children implements the Expression interface.
+func (f *Coalesce) Children() []sql.Expression { return f.args }
+
+// Eval implements the Expression interface.
+func (f *Coalesce) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Coalesce")
+	defer span.Finish()
+
+	return evalFirstNotNull(ctx, row, f.Type(), f.args...)
+}
+
+// TransformUp implements the Expression interface.
+func (f *Coalesce) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&Coalesce{args})
+}
+
+func (f *Coalesce) String() string {
+	var args = make([]string, len(f.args))
+	for i, arg := range f.args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("coalesce(%s)", strings.Join(args, ", "))
+}
+
+// NullIf returns NULL if its two arguments are equal and the first one
+// otherwise.
+type NullIf struct {
+	expression.BinaryExpression
+}
+
+// NewNullIf creates a new NullIf expression.
+func NewNullIf(e1, e2 sql.Expression) sql.Expression {
+	return &NullIf{expression.BinaryExpression{Left: e1, Right: e2}}
+}
+
+// Type implements the Expression interface.
+func (f *NullIf) Type() sql.Type {
+	return f.Left.Type()
+}
+
+// IsNullable implements the Expression interface.
+func (f *NullIf) IsNullable() bool {
+	return true
+}
+
+// Eval implements the Expression interface.
+func (f *NullIf) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.NullIf")
+	defer span.Finish()
+
+	v, err := f.Left.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	equal, err := expression.NewEquals(f.Left, f.Right).Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if equal == true {
+		return nil, nil
+	}
+
+	return v, nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *NullIf) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.Left, f.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewNullIf(args[0], args[1]))
+}
+
+func (f *NullIf) String() string {
+	return fmt.Sprintf("nullif(%s, %s)", f.Left, f.Right)
+}
+
+// evalFirstNotNull returns the value of the first of the given expressions
+// that is not NULL, converted to the type t. The expressions after it are
+// not evaluated.
+func evalFirstNotNull(
+	ctx *sql.Context,
+	row sql.Row,
+	t sql.Type,
+	exprs ...sql.Expression,
+) (interface{}, error) {
+	for _, e := range exprs {
+		v, err := e.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		if v != nil {
+			return sql.ConvertFrom(t, e.Type(), v)
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,126 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestIf(t *testing.T) {
+	f := NewIf(
+		expression.NewGetField(0, sql.Int64, "cond", true),
+		expression.NewGetField(1, sql.Int32, "a", true),
+		expression.NewGetField(2, sql.Text, "b", true),
+	)
+	require.Equal(t, sql.Text, f.Type())
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"true", sql.NewRow(int64(2), int32(1), "foo"), "1"},
+		{"false", sql.NewRow(int64(0), int32(1), "foo"), "foo"},
+		{"null condition", sql.NewRow(nil, int32(1), "foo"), "foo"},
+		{"null value", sql.NewRow(int64(1), nil, "foo"), nil},
+		// The branch not taken is not evaluated, so the missing field does
+		// not make it fail.
+		{"short circuit", sql.NewRow(int64(1), int32(1)), "1"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
+
+func TestIfNull(t *testing.T) {
+	f := NewIfNull(
+		expression.NewGetField(0, sql.Int32, "a", true),
+		expression.NewGetField(1, sql.Int64, "b", false),
+	)
+	require.Equal(t, sql.Int64, f.Type())
+	require.False(t, f.IsNullable())
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"not null", sql.NewRow(int32(1)), int64(1)},
+		{"null", sql.NewRow(nil, int64(2)), int64(2)},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	_, err := NewCoalesce()
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	f, err := NewCoalesce(
+		expression.NewGetField(0, sql.Uint8, "a", true),
+		expression.NewGetField(1, sql.Uint32, "b", true),
+		expression.NewLiteral(nil, sql.Null),
+	)
+	require.NoError(t, err)
+	require.Equal(t, sql.Uint32, f.Type())
+	require.True(t, f.IsNullable())
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"first", sql.NewRow(uint8(1)), uint32(1)},
+		{"second", sql.NewRow(nil, uint32(2)), uint32(2)},
+		{"all null", sql.NewRow(nil, nil), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
+
+func TestNullIf(t *testing.T) {
+	f := NewNullIf(
+		expression.NewGetField(0, sql.Text, "a", true),
+		expression.NewGetField(1, sql.Int64, "b", true),
+	)
+	require.Equal(t, sql.Text, f.Type())
+	require.True(t, f.IsNullable())
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"equal", sql.NewRow("1", int64(1)), nil},
+		{"not equal", sql.NewRow("2", int64(1)), "2"},
+		{"first null", sql.NewRow(nil, int64(1)), nil},
+		{"second null", sql.NewRow("1", nil), "1"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := f.Eval(sql.NewEmptyContext(), tt.row)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, v)
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package function
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// Conv converts a number from a base to another, such as
+// CONV('ff', 16, 10). Bases go from 2 to 36, and a negative base means the
+// number is signed. The number is read up to its first character that is
+// not a digit in its base, and it is NULL if any base is not valid.
+type Conv struct {
+	nullPropagating
+}
+
+// NewConv creates a new Conv function.
+func NewConv(n, from, to sql.Expression) sql.Expression {
+	return &Conv{newNullPropagating("conv", n, from, to)}
+}
+
+// NewBin creates a new Conv function that returns the binary representation
+// of a number, as in CONV(n, 10, 2).
+func NewBin(n sql.Expression) sql.Expression {
+	return &Conv{newNullPropagating("bin", n)}
+}
+
+// NewOct creates a new Conv function that returns the octal representation
+// of a number, as in CONV(n, 10, 8).
+func NewOct(n sql.Expression) sql.Expression {
+	return &Conv{newNullPropagating("oct", n)}
+}
+
+// Type implements the Expression interface.
+func (f *Conv) Type() sql.Type { return sql.Text }
+
+// IsNullable implements the Expression interface.
+func (f *Conv) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *Conv) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Conv")
+	defer span.Finish()
+
+	values, err := f.evalArgs(ctx, row)
+	if err != nil || values == nil {
+		return nil, err
+	}
+
+	n, err := toText(f.args[0], values[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var from, to int64 = 10, 2
+	switch f.name {
+	case "oct":
+		to = 8
+	case "conv":
+		if from, err = toInt64(values[1]); err != nil {
+			return nil, err
+		}
+
+		if to, err = toInt64(values[2]); err != nil {
+			return nil, err
+		}
+	}
+
+	result, ok := conv(n, from, to)
+	if !ok {
+		return nil, nil
+	}
+
+	return result, nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *Conv) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(&Conv{newNullPropagating(f.name, args...)})
+}
+
+// conv converts the number n from a base to another. It returns false if any
+// of the bases is not valid.
+func conv(n string, from, to int64) (string, bool) {
+	if !validBase(from) || !validBase(to) {
+		return "", false
+	}
+
+	n = strings.TrimSpace(n)
+	negative := strings.HasPrefix(n, "-")
+	if negative {
+		n = n[1:]
+	}
+
+	base := uint64(from)
+	if from < 0 {
+		base = uint64(-from)
+	}
+
+	var value uint64
+	for _, c := range strings.ToLower(n) {
+		var digit uint64
+		switch {
+		case c >= '0' && c <= '9':
+			digit = uint64(c - '0')
+		case c >= 'a' && c <= 'z':
+			digit = uint64(c-'a') + 10
+		default:
+			digit = base
+		}
+
+		if digit >= base {
+			break
+		}
+
+		if value > (math.MaxUint64-digit)/base {
+			value = math.MaxUint64
+		} else {
+			value = value*base + digit
+		}
+	}
+
+	if negative {
+		value = -value
+	}
+
+	var prefix string
+	if to < 0 {
+		to = -to
+		if int64(value) < 0 {
+			prefix, value = "-", uint64(-int64(value))
+		}
+	}
+
+	return prefix + strings.ToUpper(strconv.FormatUint(value, int(to))), true
+}
+
+func validBase(base int64) bool {
+	if base < 0 {
+		base = -base
+	}
+	return base >= 2 && base <= 36
+}
+
+// Hex returns the hexadecimal representation of a number, or of the bytes of
+// a string.
+type Hex struct {
+	expression.UnaryExpression
+}
+
+// NewHex creates a new Hex function.
+func NewHex(e sql.Expression) sql.Expression {
+	return &Hex{expression.UnaryExpression{Child: e}}
+}
+
+// Type implements the Expression interface.
+func (f *Hex) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (f *Hex) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Hex")
+	defer span.Finish()
+
+	v, err := f.Child.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	if b, ok := v.([]byte); ok {
+		return strings.ToUpper(hex.EncodeToString(b)), nil
+	}
+
+	s, err := toText(f.Child, v)
+	if err != nil {
+		return nil, err
+	}
+
+	if sql.IsNumber(f.Child.Type()) {
+		result, _ := conv(s, 10, 16)
+		return result, nil
+	}
+
+	return strings.ToUpper(hex.EncodeToString([]byte(s))), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *Hex) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := f.Child.TransformUp(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewHex(child))
+}
+
+func (f *Hex) String() string { return fmt.Sprintf("hex(%s)", f.Child) }
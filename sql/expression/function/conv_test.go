@@ -0,0 +1,52 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestConv(t *testing.T) {
+	f := NewConv(
+		expression.NewGetField(0, sql.Text, "n", true),
+		expression.NewGetField(1, sql.Int64, "from", true),
+		expression.NewGetField(2, sql.Int64, "to", true),
+	)
+
+	testCases := []struct {
+		n        interface{}
+		from, to int64
+		expected interface{}
+	}{
+		{"a", 16, 2, "1010"},
+		{"6E", 18, 8, "172"},
+		{"-17", 10, -18, "-H"},
+		{"-1", 10, 16, "FFFFFFFFFFFFFFFF"},
+		{"12abc", 10, 10, "12"},
+		{"z", 10, 10, "0"},
+		{"99999999999999999999", 10, 16, "FFFFFFFFFFFFFFFF"},
+		{"10", 1, 10, nil},
+		{"10", 10, 37, nil},
+		{nil, 10, 2, nil},
+	}
+
+	for _, tt := range testCases {
+		require.Equal(t, tt.expected, eval(t, f, sql.NewRow(tt.n, tt.from, tt.to)), "CONV(%v, %d, %d)", tt.n, tt.from, tt.to)
+	}
+}
+
+func TestBinOctHex(t *testing.T) {
+	require := require.New(t)
+	n := expression.NewGetField(0, sql.Int64, "n", true)
+	s := expression.NewGetField(0, sql.Text, "s", true)
+
+	require.Equal("1100", eval(t, NewBin(n), sql.NewRow(int64(12))))
+	require.Equal("14", eval(t, NewOct(n), sql.NewRow(int64(12))))
+	require.Equal("FF", eval(t, NewHex(n), sql.NewRow(int64(255))))
+	require.Equal("FFFFFFFFFFFFFFFF", eval(t, NewHex(n), sql.NewRow(int64(-1))))
+	require.Equal("616263", eval(t, NewHex(s), sql.NewRow("abc")))
+	require.Equal("00FF", eval(t, NewHex(s), sql.NewRow([]byte{0, 255})))
+	require.Nil(eval(t, NewHex(s), sql.NewRow(nil)))
+}
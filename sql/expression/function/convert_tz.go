@@ -0,0 +1,122 @@
+package function
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// ConvertTz converts a date from a time zone to another, such as
+// CONVERT_TZ(d, '+00:00', 'Europe/Madrid'). Time zones are given as an
+// offset from UTC, as a name of the IANA time zone database or as SYSTEM for
+// the time zone of the server. The result is NULL if any of the time zones
+// is not valid.
+type ConvertTz struct {
+	nullPropagating
+}
+
+// NewConvertTz creates a new ConvertTz function.
+func NewConvertTz(date, from, to sql.Expression) sql.Expression {
+	return &ConvertTz{newNullPropagating("convert_tz", date, from, to)}
+}
+
+// Type implements the Expression interface.
+func (f *ConvertTz) Type() sql.Type {
+	typ, _ := sql.DatetimeWithPrecision(fractionalDigits(f.args[0].Type()))
+	return typ
+}
+
+// IsNullable implements the Expression interface.
+func (f *ConvertTz) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *ConvertTz) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.ConvertTz")
+	defer span.Finish()
+
+	values, err := f.evalArgs(ctx, row)
+	if err != nil || values == nil {
+		return nil, err
+	}
+
+	date, err := toDatetime(values[0])
+	if err != nil {
+		return nil, nil
+	}
+
+	var locations [2]*time.Location
+	for i, v := range values[1:] {
+		name, err := toText(f.args[i+1], v)
+		if err != nil {
+			return nil, err
+		}
+
+		locations[i] = loadTimeZone(name)
+		if locations[i] == nil {
+			return nil, nil
+		}
+	}
+
+	from, to := locations[0], locations[1]
+	t := time.Date(
+		date.Year(), date.Month(), date.Day(),
+		date.Hour(), date.Minute(), date.Second(), date.Nanosecond(),
+		from,
+	).In(to)
+
+	return f.Type().Convert(time.Date(
+		t.Year(), t.Month(), t.Day(),
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(),
+		time.UTC,
+	))
+}
+
+// TransformUp implements the Expression interface.
+func (f *ConvertTz) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewConvertTz(args[0], args[1], args[2]))
+}
+
+var timeZoneOffset = regexp.MustCompile(`^([+-])(\d{1,2}):(\d{2})$`)
+
+// loadTimeZone returns the time zone with the given name, or nil if there
+// is no such time zone.
+func loadTimeZone(name string) *time.Location {
+	name = strings.TrimSpace(name)
+	if m := timeZoneOffset.FindStringSubmatch(name); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi(m[3])
+		offset := hours*60 + minutes
+		if m[1] == "-" {
+			offset = -offset
+		}
+
+		if minutes > 59 || offset < -13*60-59 || offset > 14*60 {
+			return nil
+		}
+
+		return time.FixedZone(name, offset*60)
+	}
+
+	if strings.EqualFold(name, "SYSTEM") {
+		return time.Local
+	}
+
+	if name == "" || strings.EqualFold(name, "Local") {
+		return nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil
+	}
+
+	return loc
+}
@@ -0,0 +1,49 @@
+package function
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestConvertTz(t *testing.T) {
+	f := NewConvertTz(
+		expression.NewGetField(0, sql.Text, "d", true),
+		expression.NewGetField(1, sql.Text, "from", true),
+		expression.NewGetField(2, sql.Text, "to", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{
+			"offsets",
+			sql.NewRow("2018-01-01 12:00:00", "+00:00", "+10:30"),
+			time.Date(2018, 1, 1, 22, 30, 0, 0, time.UTC),
+		},
+		{
+			"negative offset",
+			sql.NewRow("2018-01-01 12:00:00", "-05:00", "+00:00"),
+			time.Date(2018, 1, 1, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			"named",
+			sql.NewRow("2018-07-01 12:00:00", "UTC", "Europe/Madrid"),
+			time.Date(2018, 7, 1, 14, 0, 0, 0, time.UTC),
+		},
+		{"invalid offset", sql.NewRow("2018-01-01 12:00:00", "+15:00", "UTC"), nil},
+		{"unknown zone", sql.NewRow("2018-01-01 12:00:00", "UTC", "Mars/Olympus"), nil},
+		{"null", sql.NewRow(nil, "UTC", "UTC"), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, f, tt.row))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package function
+
+import (
+	"fmt"
+	"hash/crc32"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// CRC32 returns the cyclic redundancy check value of a string as an
+// unsigned 32-bit integer.
+type CRC32 struct {
+	expression.UnaryExpression
+}
+
+// NewCRC32 creates a new CRC32 function.
+func NewCRC32(str sql.Expression) sql.Expression {
+	return &CRC32{expression.UnaryExpression{Child: str}}
+}
+
+// Type implements the Expression interface.
+func (f *CRC32) Type() sql.Type { return sql.Uint32 }
+
+// Eval implements the Expression interface.
+func (f *CRC32) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.CRC32")
+	defer span.Finish()
+
+	v, err := f.Child.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	var data []byte
+	if b, ok := v.([]byte); ok {
+		data = b
+	} else {
+		s, err := toText(f.Child, v)
+		if err != nil {
+			return nil, err
+		}
+		data = []byte(s)
+	}
+
+	return crc32.ChecksumIEEE(data), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *CRC32) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := f.Child.TransformUp(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewCRC32(child))
+}
+
+func (f *CRC32) String() string { return fmt.Sprintf("crc32(%s)", f.Child) }
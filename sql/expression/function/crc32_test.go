@@ -0,0 +1,19 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestCRC32(t *testing.T) {
+	f := NewCRC32(expression.NewGetField(0, sql.Text, "s", true))
+	require.Equal(t, uint32(3259397556), eval(t, f, sql.NewRow("MySQL")))
+	require.Equal(t, uint32(0), eval(t, f, sql.NewRow("")))
+	require.Nil(t, eval(t, f, sql.NewRow(nil)))
+
+	f = NewCRC32(expression.NewGetField(0, sql.Int64, "n", true))
+	require.Equal(t, uint32(841265288), eval(t, f, sql.NewRow(int64(42))))
+}
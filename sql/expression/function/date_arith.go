@@ -0,0 +1,113 @@
+package function
+
+import (
+	"time"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// ErrIntervalExpected is returned when the second argument of DATE_ADD or
+// DATE_SUB is not an INTERVAL.
+var ErrIntervalExpected = errors.NewKind("%s expects an INTERVAL as its second argument, got %s")
+
+// DateAdd adds an interval to a date.
+type DateAdd struct {
+	nullPropagating
+	sub bool
+}
+
+// NewDateAdd creates a new DateAdd function.
+func NewDateAdd(args ...sql.Expression) (sql.Expression, error) {
+	return newDateArith("date_add", false, args)
+}
+
+// NewDateSub creates a new DateAdd function that subtracts the interval
+// from the date instead of adding it.
+func NewDateSub(args ...sql.Expression) (sql.Expression, error) {
+	return newDateArith("date_sub", true, args)
+}
+
+func newDateArith(name string, sub bool, args []sql.Expression) (sql.Expression, error) {
+	if len(args) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New(2, len(args))
+	}
+
+	if _, ok := args[1].(*expression.Interval); !ok {
+		return nil, ErrIntervalExpected.New(name, args[1])
+	}
+
+	return &DateAdd{newNullPropagating(name, args...), sub}, nil
+}
+
+func (f *DateAdd) interval() *expression.Interval {
+	return f.args[1].(*expression.Interval)
+}
+
+// Type implements the Expression interface. Adding an interval without
+// clock units to a DATE results in a DATE, and in a DATETIME otherwise.
+func (f *DateAdd) Type() sql.Type {
+	interval := f.interval()
+	t := f.args[0].Type()
+	if t == sql.Date && !interval.HasTime() {
+		return sql.Date
+	}
+
+	precision := datetimePrecision(t)
+	if interval.HasMicroseconds() {
+		precision = sql.FractionalMaxPrecision
+	}
+
+	typ, _ := sql.DatetimeWithPrecision(precision)
+	return typ
+}
+
+// IsNullable implements the Expression interface. The result is NULL if it
+// is not a valid date.
+func (f *DateAdd) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *DateAdd) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.DateAdd")
+	defer span.Finish()
+
+	v, err := f.args[0].Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	date, err := toDatetime(v)
+	if err != nil {
+		return nil, nil
+	}
+
+	delta, err := f.interval().EvalDelta(ctx, row)
+	if err != nil || delta == nil {
+		return nil, err
+	}
+
+	var result time.Time
+	if f.sub {
+		result = delta.Sub(date)
+	} else {
+		result = delta.Add(date)
+	}
+
+	if result.Year() < 0 || result.Year() > 9999 {
+		return nil, nil
+	}
+
+	return f.Type().Convert(result)
+}
+
+// TransformUp implements the Expression interface.
+func (f *DateAdd) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&DateAdd{newNullPropagating(f.name, args...), f.sub})
+}
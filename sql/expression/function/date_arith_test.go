@@ -0,0 +1,108 @@
+package function
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func newInterval(t *testing.T, value interface{}, typ sql.Type, unit string) *expression.Interval {
+	t.Helper()
+	i, err := expression.NewInterval(expression.NewLiteral(value, typ), unit)
+	require.NoError(t, err)
+	return i
+}
+
+func TestDateAdd(t *testing.T) {
+	datetime6, err := sql.DatetimeWithPrecision(6)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name         string
+		typ          sql.Type
+		date         interface{}
+		interval     *expression.Interval
+		sub          bool
+		expectedType sql.Type
+		expected     interface{}
+	}{
+		{
+			"date plus days",
+			sql.Date, time.Date(2018, 1, 30, 0, 0, 0, 0, time.UTC),
+			newInterval(t, int64(3), sql.Int64, "DAY"), false,
+			sql.Date, time.Date(2018, 2, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			"date plus month at end of month",
+			sql.Date, time.Date(2018, 1, 31, 0, 0, 0, 0, time.UTC),
+			newInterval(t, int64(1), sql.Int64, "MONTH"), false,
+			sql.Date, time.Date(2018, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			"date plus hours",
+			sql.Date, time.Date(2018, 1, 31, 0, 0, 0, 0, time.UTC),
+			newInterval(t, int64(25), sql.Int64, "HOUR"), false,
+			sql.Datetime, time.Date(2018, 2, 1, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			"string minus composite interval",
+			sql.Text, "2018-01-01 00:00:00",
+			newInterval(t, "1 1:30", sql.Text, "DAY_MINUTE"), true,
+			sql.Datetime, time.Date(2017, 12, 30, 22, 30, 0, 0, time.UTC),
+		},
+		{
+			"microseconds",
+			sql.Datetime, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+			newInterval(t, int64(5), sql.Int64, "MICROSECOND"), false,
+			datetime6, time.Date(2018, 1, 1, 0, 0, 0, 5000, time.UTC),
+		},
+		{
+			"out of range",
+			sql.Date, time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC),
+			newInterval(t, int64(1), sql.Int64, "DAY"), false,
+			sql.Date, nil,
+		},
+		{
+			"invalid date",
+			sql.Text, "foo",
+			newInterval(t, int64(1), sql.Int64, "DAY"), false,
+			sql.Datetime, nil,
+		},
+		{
+			"null interval",
+			sql.Date, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+			newInterval(t, nil, sql.Null, "DAY"), false,
+			sql.Date, nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			args := []sql.Expression{expression.NewLiteral(tt.date, tt.typ), tt.interval}
+
+			var f sql.Expression
+			var err error
+			if tt.sub {
+				f, err = NewDateSub(args...)
+			} else {
+				f, err = NewDateAdd(args...)
+			}
+			require.NoError(err)
+
+			require.Equal(tt.expectedType, f.Type())
+			require.Equal(tt.expected, eval(t, f, nil))
+		})
+	}
+}
+
+func TestDateAddRequiresInterval(t *testing.T) {
+	_, err := NewDateAdd(
+		expression.NewLiteral("2018-01-01", sql.Text),
+		expression.NewLiteral(int64(1), sql.Int64),
+	)
+	require.True(t, ErrIntervalExpected.Is(err))
+}
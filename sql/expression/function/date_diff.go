@@ -0,0 +1,210 @@
+package function
+
+import (
+	"strings"
+	"time"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// DateDiff returns the number of days from its second argument to its
+// first one. Only the date part of the arguments is used.
+type DateDiff struct {
+	nullPropagating
+}
+
+// NewDateDiff creates a new DateDiff function.
+func NewDateDiff(date1, date2 sql.Expression) sql.Expression {
+	return &DateDiff{newNullPropagating("datediff", date1, date2)}
+}
+
+// Type implements the Expression interface.
+func (f *DateDiff) Type() sql.Type { return sql.Int64 }
+
+// IsNullable implements the Expression interface.
+func (f *DateDiff) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *DateDiff) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.DateDiff")
+	defer span.Finish()
+
+	dates, err := evalDatetimes(ctx, row, f.args...)
+	if err != nil || dates == nil {
+		return nil, err
+	}
+
+	return daysSinceEpoch(dates[0]) - daysSinceEpoch(dates[1]), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *DateDiff) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewDateDiff(args[0], args[1]))
+}
+
+// timestampDiffUnits are the units supported by TIMESTAMPDIFF, with their
+// duration for the units with a fixed one.
+var timestampDiffUnits = map[string]time.Duration{
+	"MICROSECOND": time.Microsecond,
+	"SECOND":      time.Second,
+	"MINUTE":      time.Minute,
+	"HOUR":        time.Hour,
+	"DAY":         24 * time.Hour,
+	"WEEK":        7 * 24 * time.Hour,
+	"MONTH":       0,
+	"QUARTER":     0,
+	"YEAR":        0,
+}
+
+// TimestampDiff returns the number of whole units from its second argument
+// to its third one, such as TIMESTAMPDIFF(MONTH, '2018-01-31', '2018-03-01').
+type TimestampDiff struct {
+	nullPropagating
+}
+
+// NewTimestampDiff creates a new TimestampDiff function.
+func NewTimestampDiff(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New(3, len(args))
+	}
+
+	if lit, ok := args[0].(*expression.Literal); ok {
+		v, err := lit.Eval(nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		s, _ := v.(string)
+		if _, ok := timestampDiffUnits[strings.ToUpper(s)]; !ok {
+			return nil, expression.ErrUnknownIntervalUnit.New(v)
+		}
+	}
+
+	return &TimestampDiff{newNullPropagating("timestampdiff", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *TimestampDiff) Type() sql.Type { return sql.Int64 }
+
+// IsNullable implements the Expression interface.
+func (f *TimestampDiff) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *TimestampDiff) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.TimestampDiff")
+	defer span.Finish()
+
+	v, err := f.args[0].Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	unit, err := toText(f.args[0], v)
+	if err != nil {
+		return nil, err
+	}
+
+	unit = strings.ToUpper(unit)
+	duration, ok := timestampDiffUnits[unit]
+	if !ok {
+		return nil, expression.ErrUnknownIntervalUnit.New(unit)
+	}
+
+	dates, err := evalDatetimes(ctx, row, f.args[1:]...)
+	if err != nil || dates == nil {
+		return nil, err
+	}
+
+	from, to := dates[0], dates[1]
+	if duration != 0 {
+		micros := daysSinceEpoch(to)*microsPerDay + microsOfDay(to) -
+			daysSinceEpoch(from)*microsPerDay - microsOfDay(from)
+		return micros / int64(duration/time.Microsecond), nil
+	}
+
+	months := monthsBetween(from, to)
+	switch unit {
+	case "QUARTER":
+		return months / 3, nil
+	case "YEAR":
+		return months / 12, nil
+	default:
+		return months, nil
+	}
+}
+
+// monthsBetween returns the number of whole months from one time to the
+// other, which is negative if to is before from. A month has passed when the
+// same day and time of the next month is reached.
+func monthsBetween(from, to time.Time) int64 {
+	var sign int64 = 1
+	if to.Before(from) {
+		sign, from, to = -1, to, from
+	}
+
+	months := int64(to.Year()-from.Year())*12 + int64(to.Month()-from.Month())
+	if to.Day() < from.Day() ||
+		(to.Day() == from.Day() && microsOfDay(to) < microsOfDay(from)) {
+		months--
+	}
+
+	return sign * months
+}
+
+// TransformUp implements the Expression interface.
+func (f *TimestampDiff) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&TimestampDiff{newNullPropagating(f.name, args...)})
+}
+
+const microsPerDay = int64(24 * time.Hour / time.Microsecond)
+
+// daysSinceEpoch returns the number of days from 1970-01-01 to the date of
+// the given time.
+func daysSinceEpoch(t time.Time) int64 {
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return floorDiv(date.Unix(), 24*60*60)
+}
+
+// microsOfDay returns the number of microseconds from the start of the day
+// of the given time.
+func microsOfDay(t time.Time) int64 {
+	return (int64(t.Hour())*3600+int64(t.Minute())*60+int64(t.Second()))*1e6 +
+		int64(t.Nanosecond()/1e3)
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// evalDatetimes evaluates the given date arguments. If any of them is NULL or
+// not a valid date, nil is returned.
+func evalDatetimes(ctx *sql.Context, row sql.Row, args ...sql.Expression) ([]time.Time, error) {
+	var dates = make([]time.Time, len(args))
+	for i, arg := range args {
+		v, err := arg.Eval(ctx, row)
+		if err != nil || v == nil {
+			return nil, err
+		}
+
+		dates[i], err = toDatetime(v)
+		if err != nil {
+			return nil, nil
+		}
+	}
+	return dates, nil
+}
@@ -0,0 +1,75 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestDateDiff(t *testing.T) {
+	f := NewDateDiff(
+		expression.NewGetField(0, sql.Text, "d1", true),
+		expression.NewGetField(1, sql.Text, "d2", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"later", sql.NewRow("2018-03-01 00:00:01", "2018-02-28 23:59:59"), int64(1)},
+		{"earlier", sql.NewRow("2017-12-31", "2018-01-02"), int64(-2)},
+		{"before epoch", sql.NewRow("1969-12-31", "1970-01-01"), int64(-1)},
+		{"invalid", sql.NewRow("foo", "2018-01-01"), nil},
+		{"null", sql.NewRow(nil, "2018-01-01"), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, f, tt.row))
+		})
+	}
+}
+
+func TestTimestampDiff(t *testing.T) {
+	testCases := []struct {
+		unit     string
+		from     string
+		to       string
+		expected interface{}
+	}{
+		{"MONTH", "2018-01-31", "2018-02-28", int64(0)},
+		{"MONTH", "2018-01-31", "2018-03-01", int64(1)},
+		{"MONTH", "2018-01-15 10:00:00", "2018-02-15 09:59:59", int64(0)},
+		{"MONTH", "2018-03-01", "2018-01-31", int64(-1)},
+		{"QUARTER", "2018-01-01", "2018-12-31", int64(3)},
+		{"YEAR", "2016-02-29", "2017-02-28", int64(0)},
+		{"WEEK", "2018-01-01", "2018-01-14", int64(1)},
+		{"DAY", "2018-01-02", "2018-01-01 00:00:01", int64(0)},
+		{"HOUR", "2018-01-01 10:00:00", "2018-01-01 07:30:00", int64(-2)},
+		{"SECOND", "2018-01-01 00:00:00", "2018-01-01 00:01:01.9", int64(61)},
+		{"MICROSECOND", "2018-01-01 00:00:00", "2018-01-01 00:00:00.000012", int64(12)},
+		{"DAY", "foo", "2018-01-01", nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.unit+" "+tt.from+" "+tt.to, func(t *testing.T) {
+			f, err := NewTimestampDiff(
+				expression.NewLiteral(tt.unit, sql.Text),
+				expression.NewLiteral(tt.from, sql.Text),
+				expression.NewLiteral(tt.to, sql.Text),
+			)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, eval(t, f, nil))
+		})
+	}
+
+	_, err := NewTimestampDiff(
+		expression.NewLiteral("FORTNIGHT", sql.Text),
+		expression.NewLiteral("2018-01-01", sql.Text),
+		expression.NewLiteral("2018-01-01", sql.Text),
+	)
+	require.True(t, expression.ErrUnknownIntervalUnit.Is(err))
+}
@@ -0,0 +1,516 @@
+package function
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// DateFormat formats a date with a MySQL format string, such as
+// DATE_FORMAT(d, '%Y-%m-%d %H:%i:%s').
+type DateFormat struct {
+	nullPropagating
+}
+
+// NewDateFormat creates a new DateFormat function.
+func NewDateFormat(date, format sql.Expression) sql.Expression {
+	return &DateFormat{newNullPropagating("date_format", date, format)}
+}
+
+// Type implements the Expression interface.
+func (f *DateFormat) Type() sql.Type { return sql.Text }
+
+// IsNullable implements the Expression interface.
+func (f *DateFormat) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *DateFormat) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.DateFormat")
+	defer span.Finish()
+
+	values, err := f.evalArgs(ctx, row)
+	if err != nil || values == nil {
+		return nil, err
+	}
+
+	date, err := toDatetime(values[0])
+	if err != nil {
+		return nil, nil
+	}
+
+	format, err := toText(f.args[1], values[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return formatDate(date, format), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *DateFormat) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewDateFormat(args[0], args[1]))
+}
+
+// formatDate formats the time t with the given MySQL format string.
+// Unknown specifiers are written without the percent sign.
+func formatDate(t time.Time, format string) string {
+	var buf bytes.Buffer
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i == len(format)-1 {
+			buf.WriteByte(format[i])
+			continue
+		}
+
+		i++
+		switch format[i] {
+		case 'a':
+			buf.WriteString(t.Weekday().String()[:3])
+		case 'b':
+			buf.WriteString(t.Month().String()[:3])
+		case 'c':
+			fmt.Fprintf(&buf, "%d", t.Month())
+		case 'D':
+			fmt.Fprintf(&buf, "%d%s", t.Day(), ordinalSuffix(t.Day()))
+		case 'd':
+			fmt.Fprintf(&buf, "%02d", t.Day())
+		case 'e':
+			fmt.Fprintf(&buf, "%d", t.Day())
+		case 'f':
+			fmt.Fprintf(&buf, "%06d", t.Nanosecond()/1e3)
+		case 'H':
+			fmt.Fprintf(&buf, "%02d", t.Hour())
+		case 'h', 'I':
+			fmt.Fprintf(&buf, "%02d", hour12(t))
+		case 'i':
+			fmt.Fprintf(&buf, "%02d", t.Minute())
+		case 'j':
+			fmt.Fprintf(&buf, "%03d", t.YearDay())
+		case 'k':
+			fmt.Fprintf(&buf, "%d", t.Hour())
+		case 'l':
+			fmt.Fprintf(&buf, "%d", hour12(t))
+		case 'M':
+			buf.WriteString(t.Month().String())
+		case 'm':
+			fmt.Fprintf(&buf, "%02d", t.Month())
+		case 'p':
+			buf.WriteString(meridiem(t))
+		case 'r':
+			fmt.Fprintf(&buf, "%02d:%02d:%02d %s", hour12(t), t.Minute(), t.Second(), meridiem(t))
+		case 'S', 's':
+			fmt.Fprintf(&buf, "%02d", t.Second())
+		case 'T':
+			fmt.Fprintf(&buf, "%02d:%02d:%02d", t.Hour(), t.Minute(), t.Second())
+		case 'U':
+			_, week := calcWeek(t, weekFirstWeekday)
+			fmt.Fprintf(&buf, "%02d", week)
+		case 'u':
+			_, week := calcWeek(t, weekMondayFirst)
+			fmt.Fprintf(&buf, "%02d", week)
+		case 'V':
+			_, week := calcWeek(t, weekYear|weekFirstWeekday)
+			fmt.Fprintf(&buf, "%02d", week)
+		case 'v':
+			_, week := calcWeek(t, weekYear|weekMondayFirst)
+			fmt.Fprintf(&buf, "%02d", week)
+		case 'W':
+			buf.WriteString(t.Weekday().String())
+		case 'w':
+			fmt.Fprintf(&buf, "%d", t.Weekday())
+		case 'X':
+			year, _ := calcWeek(t, weekYear|weekFirstWeekday)
+			fmt.Fprintf(&buf, "%04d", year)
+		case 'x':
+			year, _ := calcWeek(t, weekYear|weekMondayFirst)
+			fmt.Fprintf(&buf, "%04d", year)
+		case 'Y':
+			fmt.Fprintf(&buf, "%04d", t.Year())
+		case 'y':
+			fmt.Fprintf(&buf, "%02d", t.Year()%100)
+		default:
+			buf.WriteByte(format[i])
+		}
+	}
+
+	return buf.String()
+}
+
+func hour12(t time.Time) int {
+	if h := t.Hour() % 12; h != 0 {
+		return h
+	}
+	return 12
+}
+
+func meridiem(t time.Time) string {
+	if t.Hour() < 12 {
+		return "AM"
+	}
+	return "PM"
+}
+
+func ordinalSuffix(n int) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	default:
+		return "th"
+	}
+}
+
+// StrToDate parses a date with a MySQL format string, such as
+// STR_TO_DATE('01/02/2018', '%d/%m/%Y'). It returns NULL if the string does
+// not match the format or is not a valid date.
+type StrToDate struct {
+	nullPropagating
+}
+
+// NewStrToDate creates a new StrToDate function.
+func NewStrToDate(str, format sql.Expression) sql.Expression {
+	return &StrToDate{newNullPropagating("str_to_date", str, format)}
+}
+
+// Type implements the Expression interface. It is a DATE if the format is a
+// literal without time specifiers, and a DATETIME otherwise.
+func (f *StrToDate) Type() sql.Type {
+	lit, ok := f.args[1].(*expression.Literal)
+	if !ok {
+		return datetimeArg
+	}
+
+	v, err := lit.Eval(nil, nil)
+	if err != nil || v == nil {
+		return datetimeArg
+	}
+
+	format, err := toText(lit, v)
+	if err != nil {
+		return datetimeArg
+	}
+
+	switch {
+	case hasSpecifier(format, "f"):
+		return datetimeArg
+	case hasSpecifier(format, "HkhIlisSpTr"):
+		return sql.Datetime
+	default:
+		return sql.Date
+	}
+}
+
+// hasSpecifier returns whether the given format string has any of the given
+// specifiers.
+func hasSpecifier(format string, specifiers string) bool {
+	for i := 0; i < len(format)-1; i++ {
+		if format[i] != '%' {
+			continue
+		}
+
+		i++
+		if strings.IndexByte(specifiers, format[i]) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNullable implements the Expression interface.
+func (f *StrToDate) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *StrToDate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.StrToDate")
+	defer span.Finish()
+
+	values, err := f.evalArgs(ctx, row)
+	if err != nil || values == nil {
+		return nil, err
+	}
+
+	str, err := toText(f.args[0], values[0])
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := toText(f.args[1], values[1])
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := parseDate(str, format)
+	if !ok {
+		return nil, nil
+	}
+
+	return f.Type().Convert(t)
+}
+
+// TransformUp implements the Expression interface.
+func (f *StrToDate) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewStrToDate(args[0], args[1]))
+}
+
+// dateParser reads the parts of a date from a string.
+type dateParser struct {
+	s string
+	// parts of the date read so far.
+	year, month, day, yearDay    int
+	hour, minute, second, micros int
+	pm, hour12                   bool
+}
+
+// parseDate parses the string s with the given MySQL format string. It
+// returns false if s does not match the format or is not a valid date.
+// Spaces are ignored both in s and in the format.
+func parseDate(s, format string) (time.Time, bool) {
+	p := &dateParser{s: s}
+	if !p.parse(format) {
+		return time.Time{}, false
+	}
+
+	if p.hour12 {
+		if p.hour < 1 || p.hour > 12 {
+			return time.Time{}, false
+		}
+
+		p.hour %= 12
+		if p.pm {
+			p.hour += 12
+		}
+	}
+
+	if p.yearDay > 0 {
+		if p.yearDay > daysInYear(p.year) {
+			return time.Time{}, false
+		}
+
+		t := time.Date(p.year, 1, p.yearDay, 0, 0, 0, 0, time.UTC)
+		p.month, p.day = int(t.Month()), t.Day()
+	}
+
+	if p.month < 1 || p.month > 12 || p.day < 1 ||
+		p.day > daysIn(p.year, time.Month(p.month)) ||
+		p.hour > 23 || p.minute > 59 || p.second > 59 {
+		return time.Time{}, false
+	}
+
+	return time.Date(
+		p.year, time.Month(p.month), p.day,
+		p.hour, p.minute, p.second, p.micros*1e3,
+		time.UTC,
+	), true
+}
+
+func (p *dateParser) parse(format string) bool {
+	for i := 0; i < len(format); i++ {
+		if unicode.IsSpace(rune(format[i])) {
+			continue
+		}
+
+		p.skipSpaces()
+		if format[i] != '%' || i == len(format)-1 {
+			if !p.literal(format[i]) {
+				return false
+			}
+			continue
+		}
+
+		i++
+		var ok bool
+		switch format[i] {
+		case 'Y':
+			p.year, ok = p.number(4)
+		case 'y':
+			p.year, ok = p.number(2)
+			if p.year < 70 {
+				p.year += 2000
+			} else {
+				p.year += 1900
+			}
+		case 'm', 'c':
+			p.month, ok = p.number(2)
+		case 'M':
+			p.month, ok = p.name(monthNames, false)
+		case 'b':
+			p.month, ok = p.name(monthNames, true)
+		case 'd', 'e':
+			p.day, ok = p.number(2)
+		case 'D':
+			p.day, ok = p.number(2)
+			ok = ok && p.suffix()
+		case 'j':
+			p.yearDay, ok = p.number(3)
+		case 'H', 'k':
+			p.hour, ok = p.number(2)
+		case 'h', 'I', 'l':
+			p.hour, ok = p.number(2)
+			p.hour12 = true
+		case 'i':
+			p.minute, ok = p.number(2)
+		case 'S', 's':
+			p.second, ok = p.number(2)
+		case 'f':
+			ok = p.fraction()
+		case 'p':
+			ok = p.meridiem()
+		case 'T':
+			ok = p.parse("%H:%i:%s")
+		case 'r':
+			ok = p.parse("%h:%i:%s %p")
+		case 'W':
+			_, ok = p.name(weekdayNames, false)
+		case 'a':
+			_, ok = p.name(weekdayNames, true)
+		case 'w':
+			_, ok = p.number(1)
+		default:
+			// Week specifiers are not supported, and any other specifier
+			// is the character after the percent sign.
+			if strings.IndexByte("UuVvXx", format[i]) >= 0 {
+				return false
+			}
+			ok = p.literal(format[i])
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *dateParser) skipSpaces() {
+	p.s = strings.TrimLeftFunc(p.s, unicode.IsSpace)
+}
+
+func (p *dateParser) literal(c byte) bool {
+	if len(p.s) == 0 || p.s[0] != c {
+		return false
+	}
+	p.s = p.s[1:]
+	return true
+}
+
+// number reads a number of at most the given number of digits.
+func (p *dateParser) number(digits int) (int, bool) {
+	var i int
+	for i < digits && i < len(p.s) && p.s[i] >= '0' && p.s[i] <= '9' {
+		i++
+	}
+
+	if i == 0 {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(p.s[:i])
+	p.s = p.s[i:]
+	return n, err == nil
+}
+
+// fraction reads the microseconds of the fractional seconds.
+func (p *dateParser) fraction() bool {
+	var i int
+	for i < len(p.s) && p.s[i] >= '0' && p.s[i] <= '9' {
+		i++
+	}
+
+	if i == 0 {
+		return false
+	}
+
+	digits := p.s[:i]
+	p.s = p.s[i:]
+	if len(digits) > 6 {
+		digits = digits[:6]
+	}
+
+	n, err := strconv.Atoi(digits + strings.Repeat("0", 6-len(digits)))
+	p.micros = n
+	return err == nil
+}
+
+func (p *dateParser) meridiem() bool {
+	if len(p.s) < 2 {
+		return false
+	}
+
+	switch strings.ToUpper(p.s[:2]) {
+	case "AM":
+		p.pm = false
+	case "PM":
+		p.pm = true
+	default:
+		return false
+	}
+
+	p.s = p.s[2:]
+	return true
+}
+
+func (p *dateParser) suffix() bool {
+	if len(p.s) < 2 {
+		return false
+	}
+
+	switch strings.ToLower(p.s[:2]) {
+	case "st", "nd", "rd", "th":
+		p.s = p.s[2:]
+		return true
+	default:
+		return false
+	}
+}
+
+// name reads one of the given names, or their first three letters if abbr
+// is true, ignoring case. It returns the position of the name plus one.
+func (p *dateParser) name(names []string, abbr bool) (int, bool) {
+	for i, name := range names {
+		if abbr {
+			name = name[:3]
+		}
+
+		if len(p.s) >= len(name) && strings.EqualFold(p.s[:len(name)], name) {
+			p.s = p.s[len(name):]
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+var monthNames = []string{
+	"January", "February", "March", "April", "May", "June", "July",
+	"August", "September", "October", "November", "December",
+}
+
+var weekdayNames = []string{
+	"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
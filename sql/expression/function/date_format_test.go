@@ -0,0 +1,90 @@
+package function
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestDateFormat(t *testing.T) {
+	date := time.Date(2018, time.January, 2, 15, 4, 5, 67000, time.UTC)
+	f := NewDateFormat(
+		expression.NewGetField(0, sql.Datetime, "d", true),
+		expression.NewGetField(1, sql.Text, "format", true),
+	)
+
+	testCases := []struct {
+		format   string
+		expected interface{}
+	}{
+		{"%Y-%m-%d %H:%i:%s", "2018-01-02 15:04:05"},
+		{"%a %b %e %D %y", "Tue Jan 2 2nd 18"},
+		{"%W %M %c %j", "Tuesday January 1 002"},
+		{"%h %I %l %k %p", "03 03 3 15 PM"},
+		{"%r|%T|%f", "03:04:05 PM|15:04:05|000067"},
+		{"%U %u %V %v %X %x %w", "00 01 53 01 2017 2018 2"},
+		{"100%% %q", "100% q"},
+		{"%", "%"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.format, func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, f, sql.NewRow(date, tt.format)))
+		})
+	}
+
+	require.Nil(t, eval(t, f, sql.NewRow(nil, "%Y")))
+}
+
+func TestOrdinalSuffix(t *testing.T) {
+	for n, expected := range map[int]string{
+		1: "st", 2: "nd", 3: "rd", 4: "th", 11: "th", 12: "th", 13: "th", 21: "st", 22: "nd", 31: "st",
+	} {
+		require.Equal(t, expected, ordinalSuffix(n), "%d", n)
+	}
+}
+
+func TestStrToDate(t *testing.T) {
+	testCases := []struct {
+		str          string
+		format       string
+		expectedType sql.Type
+		expected     interface{}
+	}{
+		{"01/02/2018", "%d/%m/%Y", sql.Date, time.Date(2018, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"  2018 - 2 -  1", "%Y-%c-%e", sql.Date, time.Date(2018, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"March 4th, 18", "%M %D, %y", sql.Date, time.Date(2018, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"Sun, 04 mar 1973", "%a, %d %b %Y", sql.Date, time.Date(1973, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{"2018 060", "%Y %j", sql.Date, time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{
+			"2018-01-02 03:04:05 pm", "%Y-%m-%d %r",
+			sql.Datetime, time.Date(2018, 1, 2, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			"2018-01-02 12:04:05 AM", "%Y-%m-%d %h:%i:%s %p",
+			sql.Datetime, time.Date(2018, 1, 2, 0, 4, 5, 0, time.UTC),
+		},
+		{
+			"20180102 030405.25", "%Y%m%d %H%i%s.%f",
+			datetimeArg, time.Date(2018, 1, 2, 3, 4, 5, 250000000, time.UTC),
+		},
+		{"2018-02-30", "%Y-%m-%d", sql.Date, nil},
+		{"2018-01-02", "%d/%m/%Y", sql.Date, nil},
+		{"2018-01", "%Y-%m-%d", sql.Date, nil},
+		{"13:00 PM", "%h:%i %p", sql.Datetime, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.str, func(t *testing.T) {
+			f := NewStrToDate(
+				expression.NewLiteral(tt.str, sql.Text),
+				expression.NewLiteral(tt.format, sql.Text),
+			)
+			require.Equal(t, tt.expectedType, f.Type())
+			require.Equal(t, tt.expected, eval(t, f, nil))
+		})
+	}
+}
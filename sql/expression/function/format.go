@@ -0,0 +1,134 @@
+package function
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// formatMaxDecimals is the maximum number of decimals of the result of
+// FORMAT.
+const formatMaxDecimals = 30
+
+// Format returns a number formatted like "#,###,###.##", rounded to the
+// given number of decimals. The optional locale argument is accepted for
+// compatibility, but the en_US format is always used.
+type Format struct {
+	nullPropagating
+}
+
+// NewFormat creates a new Format UDF.
+func NewFormat(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("2 or 3", len(args))
+	}
+
+	return &Format{newNullPropagating("format", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *Format) Type() sql.Type { return sql.Text }
+
+// IsNullable implements the Expression interface.
+func (f *Format) IsNullable() bool {
+	return f.args[0].IsNullable() || f.args[1].IsNullable()
+}
+
+// Eval implements the Expression interface.
+func (f *Format) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Format")
+	defer span.Finish()
+
+	args, err := newNullPropagating(f.name, f.args[:2]...).evalArgs(ctx, row)
+	if err != nil || args == nil {
+		return nil, err
+	}
+
+	d, err := toDecimal(f.args[0], args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	decimals, err := toInt64(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case decimals < 0:
+		decimals = 0
+	case decimals > formatMaxDecimals:
+		decimals = formatMaxDecimals
+	}
+
+	return formatNumber(d.StringFixed(int32(decimals))), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *Format) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := NewFormat(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(format)
+}
+
+// toDecimal converts the value v of the expression e to a decimal. Strings
+// that are not numbers are converted to zero, as MySQL does.
+func toDecimal(e sql.Expression, v interface{}) (decimal.Decimal, error) {
+	switch v := v.(type) {
+	case decimal.Decimal:
+		return v, nil
+	case float32:
+		return decimal.NewFromFloat(float64(v)), nil
+	case float64:
+		return decimal.NewFromFloat(v), nil
+	}
+
+	s, err := toText(e, v)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+
+	d, err := decimal.NewFromString(strings.TrimSpace(s))
+	if err != nil {
+		return decimal.Zero, nil
+	}
+
+	return d, nil
+}
+
+// formatNumber inserts thousands separators in the integer part of the
+// given number.
+func formatNumber(n string) string {
+	var sign string
+	if strings.HasPrefix(n, "-") {
+		sign, n = "-", n[1:]
+	}
+
+	integer, fraction := n, ""
+	if i := strings.IndexByte(n, '.'); i >= 0 {
+		integer, fraction = n[:i], n[i:]
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(sign)
+	for i, c := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteRune(c)
+	}
+	buf.WriteString(fraction)
+
+	return buf.String()
+}
@@ -0,0 +1,41 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestFormat(t *testing.T) {
+	f, err := NewFormat(
+		expression.NewGetField(0, sql.Float64, "n", true),
+		expression.NewGetField(1, sql.Int64, "d", true),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"float", sql.NewRow(12332.123456, int64(4)), "12,332.1235"},
+		{"rounded", sql.NewRow(12332.2, int64(0)), "12,332"},
+		{"padded", sql.NewRow(float64(1234567), int64(2)), "1,234,567.00"},
+		{"negative", sql.NewRow(-1234.5, int64(0)), "-1,235"},
+		{"small", sql.NewRow(0.5, int64(2)), "0.50"},
+		{"negative decimals", sql.NewRow(1234.5, int64(-2)), "1,235"},
+		{"decimal", sql.NewRow(decimal.RequireFromString("9999.995"), int64(2)), "10,000.00"},
+		{"string", sql.NewRow("1000", int64(1)), "1,000.0"},
+		{"not a number", sql.NewRow("foo", int64(1)), "0.0"},
+		{"null", sql.NewRow(nil, int64(1)), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, f, tt.row))
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package function
+
+import (
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// Greatest returns the greatest of its arguments, or NULL if any of them is
+// NULL. The arguments are compared as values of their common type.
+type Greatest struct {
+	nullPropagating
+}
+
+// NewGreatest creates a new Greatest function.
+func NewGreatest(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("2 or more", len(args))
+	}
+
+	return &Greatest{newNullPropagating("greatest", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *Greatest) Type() sql.Type { return argsCommonType(f.args) }
+
+// Eval implements the Expression interface.
+func (f *Greatest) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Greatest")
+	defer span.Finish()
+
+	return evalExtreme(ctx, row, f.nullPropagating, 1)
+}
+
+// TransformUp implements the Expression interface.
+func (f *Greatest) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(&Greatest{newNullPropagating(f.name, args...)})
+}
+
+// Least returns the least of its arguments, or NULL if any of them is NULL.
+// The arguments are compared as values of their common type.
+type Least struct {
+	nullPropagating
+}
+
+// NewLeast creates a new Least function.
+func NewLeast(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("2 or more", len(args))
+	}
+
+	return &Least{newNullPropagating("least", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *Least) Type() sql.Type { return argsCommonType(f.args) }
+
+// Eval implements the Expression interface.
+func (f *Least) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Least")
+	defer span.Finish()
+
+	return evalExtreme(ctx, row, f.nullPropagating, -1)
+}
+
+// TransformUp implements the Expression interface.
+func (f *Least) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(&Least{newNullPropagating(f.name, args...)})
+}
+
+func argsCommonType(args []sql.Expression) sql.Type {
+	var types = make([]sql.Type, len(args))
+	for i, arg := range args {
+		types[i] = arg.Type()
+	}
+	return sql.CommonType(types...)
+}
+
+// evalExtreme returns the greatest of the arguments of f if direction is 1,
+// or the least one if it is -1.
+func evalExtreme(
+	ctx *sql.Context,
+	row sql.Row,
+	f nullPropagating,
+	direction int,
+) (interface{}, error) {
+	values, err := f.evalArgs(ctx, row)
+	if err != nil || values == nil {
+		return nil, err
+	}
+
+	typ := argsCommonType(f.args)
+	var result interface{}
+	for i, v := range values {
+		v, err = sql.ConvertFrom(typ, f.args[i].Type(), v)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			result = v
+			continue
+		}
+
+		cmp, err := typ.Compare(v, result)
+		if err != nil {
+			return nil, err
+		}
+
+		if cmp*direction > 0 {
+			result = v
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package function
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestGreatestLeast(t *testing.T) {
+	testCases := []struct {
+		name         string
+		args         []sql.Expression
+		expectedType sql.Type
+		greatest     interface{}
+		least        interface{}
+	}{
+		{
+			"ints",
+			[]sql.Expression{
+				expression.NewLiteral(int32(2), sql.Int32),
+				expression.NewLiteral(int64(-1), sql.Int64),
+				expression.NewLiteral(int8(7), sql.Int8),
+			},
+			sql.Int64, int64(7), int64(-1),
+		},
+		{
+			"numbers",
+			[]sql.Expression{
+				expression.NewLiteral(int64(2), sql.Int64),
+				expression.NewLiteral(2.5, sql.Float64),
+			},
+			sql.Float64, 2.5, float64(2),
+		},
+		{
+			"strings",
+			[]sql.Expression{
+				expression.NewLiteral("b", sql.Text),
+				expression.NewLiteral("A", sql.Text),
+				expression.NewLiteral("c", sql.Text),
+			},
+			sql.Text, "c", "A",
+		},
+		{
+			"dates",
+			[]sql.Expression{
+				expression.NewLiteral(time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC), sql.Date),
+				expression.NewLiteral(time.Date(2018, 1, 1, 10, 0, 0, 0, time.UTC), sql.Datetime),
+			},
+			sql.Datetime,
+			time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC),
+			time.Date(2018, 1, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			"null",
+			[]sql.Expression{
+				expression.NewLiteral(int64(1), sql.Int64),
+				expression.NewLiteral(nil, sql.Null),
+			},
+			sql.Int64, nil, nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			greatest, err := NewGreatest(tt.args...)
+			require.NoError(err)
+			least, err := NewLeast(tt.args...)
+			require.NoError(err)
+
+			require.Equal(tt.expectedType, greatest.Type())
+			require.Equal(tt.greatest, eval(t, greatest, nil))
+			require.Equal(tt.least, eval(t, least, nil))
+		})
+	}
+
+	_, err := NewGreatest(expression.NewLiteral(int64(1), sql.Int64))
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+}
@@ -0,0 +1,550 @@
+package function
+
+import (
+	"strings"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// jsonDocument returns the JSON value of the JSON document v of the
+// expression e. Strings are parsed as JSON documents.
+func jsonDocument(e sql.Expression, v interface{}) (interface{}, error) {
+	if e.Type() == sql.JSON {
+		return sql.JSONValue(v)
+	}
+
+	if sql.IsText(e.Type()) {
+		s, err := toText(e, v)
+		if err != nil {
+			return nil, err
+		}
+		return sql.ParseJSON([]byte(s))
+	}
+
+	return sql.ToJSON(e.Type(), v)
+}
+
+// evalJSONPath evaluates the JSON path expression e.
+func evalJSONPath(e sql.Expression, v interface{}) (jsonPath, error) {
+	s, err := toText(e, v)
+	if err != nil {
+		return jsonPath{}, err
+	}
+	return parseJSONPath(s)
+}
+
+// findJSONValue returns the value selected by the JSON path expression e
+// in the document doc, or nil if there is none. The path cannot have
+// wildcards.
+func findJSONValue(doc interface{}, e sql.Expression, v interface{}) (interface{}, bool, error) {
+	path, err := evalJSONPath(e, v)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if path.hasWildcard() {
+		return nil, false, ErrJSONPathWildcard.New(path.text)
+	}
+
+	values := path.find(doc)
+	if len(values) == 0 {
+		return nil, false, nil
+	}
+	return values[0], true, nil
+}
+
+// evalJSONDocument returns the JSON document of the first argument, or the
+// value selected in it by the JSON path expression of the second one. It
+// returns false if any argument is NULL or the path selects no value.
+func evalJSONDocument(ctx *sql.Context, row sql.Row, f nullPropagating) (interface{}, bool, error) {
+	values, err := f.evalArgs(ctx, row)
+	if err != nil || values == nil {
+		return nil, false, err
+	}
+
+	doc, err := jsonDocument(f.args[0], values[0])
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(values) == 1 {
+		return doc, true, nil
+	}
+	return findJSONValue(doc, f.args[1], values[1])
+}
+
+// JSONExtract returns the values selected by one or more JSON path
+// expressions in a JSON document. If there is a single path without
+// wildcards, the value selected by it is returned. Otherwise, all the
+// selected values are returned in an array. It returns NULL if no value is
+// selected.
+type JSONExtract struct {
+	nullPropagating
+}
+
+// NewJSONExtract creates a new JSONExtract function.
+func NewJSONExtract(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("2 or more", len(args))
+	}
+
+	return &JSONExtract{newNullPropagating("json_extract", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *JSONExtract) Type() sql.Type { return sql.JSON }
+
+// IsNullable implements the Expression interface.
+func (f *JSONExtract) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *JSONExtract) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.JSONExtract")
+	defer span.Finish()
+
+	values, err := f.evalArgs(ctx, row)
+	if err != nil || values == nil {
+		return nil, err
+	}
+
+	doc, err := jsonDocument(f.args[0], values[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var found []interface{}
+	var wrap = len(values) > 2
+	for i := 1; i < len(values); i++ {
+		path, err := evalJSONPath(f.args[i], values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		wrap = wrap || path.hasWildcard()
+		found = append(found, path.find(doc)...)
+	}
+
+	switch {
+	case len(found) == 0:
+		return nil, nil
+	case wrap:
+		return sql.MarshalJSON(found)
+	default:
+		return sql.MarshalJSON(found[0])
+	}
+}
+
+// TransformUp implements the Expression interface.
+func (f *JSONExtract) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&JSONExtract{newNullPropagating(f.name, args...)})
+}
+
+// JSONUnquote returns the string of a JSON string without quotes and with
+// its escape sequences decoded. Other JSON values are returned as their
+// JSON document.
+type JSONUnquote struct {
+	nullPropagating
+}
+
+// NewJSONUnquote creates a new JSONUnquote function.
+func NewJSONUnquote(json sql.Expression) sql.Expression {
+	return &JSONUnquote{newNullPropagating("json_unquote", json)}
+}
+
+// Type implements the Expression interface.
+func (f *JSONUnquote) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (f *JSONUnquote) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.JSONUnquote")
+	defer span.Finish()
+
+	values, err := f.evalArgs(ctx, row)
+	if err != nil || values == nil {
+		return nil, err
+	}
+
+	if f.args[0].Type() == sql.JSON {
+		v, err := sql.JSONValue(values[0])
+		if err != nil {
+			return nil, err
+		}
+
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+
+		data, err := sql.MarshalJSON(v)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	}
+
+	s, err := toText(f.args[0], values[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// Only strings that are quoted are unquoted, other strings are returned
+	// as they are.
+	if len(s) < 2 || !strings.HasPrefix(s, `"`) || !strings.HasSuffix(s, `"`) {
+		return s, nil
+	}
+
+	v, err := sql.ParseJSON([]byte(s))
+	if err != nil {
+		return nil, err
+	}
+	return v.(string), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *JSONUnquote) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewJSONUnquote(args[0]))
+}
+
+// JSONContains returns whether a JSON document contains another one,
+// optionally at the value selected by a JSON path expression. A scalar
+// contains the scalars equal to it, an array contains the values that any
+// of its elements contains and the arrays whose elements are all contained
+// in it, and an object contains the objects whose members are all
+// contained in its members with the same key.
+type JSONContains struct {
+	nullPropagating
+}
+
+// NewJSONContains creates a new JSONContains function.
+func NewJSONContains(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("2 or 3", len(args))
+	}
+
+	return &JSONContains{newNullPropagating("json_contains", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *JSONContains) Type() sql.Type { return sql.Boolean }
+
+// IsNullable implements the Expression interface.
+func (f *JSONContains) IsNullable() bool {
+	return len(f.args) == 3 || f.nullPropagating.IsNullable()
+}
+
+// Eval implements the Expression interface.
+func (f *JSONContains) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.JSONContains")
+	defer span.Finish()
+
+	values, err := f.evalArgs(ctx, row)
+	if err != nil || values == nil {
+		return nil, err
+	}
+
+	target, err := jsonDocument(f.args[0], values[0])
+	if err != nil {
+		return nil, err
+	}
+
+	candidate, err := jsonDocument(f.args[1], values[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) == 3 {
+		var ok bool
+		target, ok, err = findJSONValue(target, f.args[2], values[2])
+		if err != nil || !ok {
+			return nil, err
+		}
+	}
+
+	return jsonContains(target, candidate)
+}
+
+func jsonContains(target, candidate interface{}) (bool, error) {
+	switch target := target.(type) {
+	case []interface{}:
+		if candidate, ok := candidate.([]interface{}); ok {
+			for _, c := range candidate {
+				if ok, err := jsonContains(target, c); err != nil || !ok {
+					return false, err
+				}
+			}
+			return true, nil
+		}
+
+		for _, t := range target {
+			if ok, err := jsonContains(t, candidate); err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case map[string]interface{}:
+		candidate, ok := candidate.(map[string]interface{})
+		if !ok {
+			return false, nil
+		}
+
+		for k, c := range candidate {
+			t, ok := target[k]
+			if !ok {
+				return false, nil
+			}
+
+			if ok, err := jsonContains(t, c); err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	default:
+		switch candidate.(type) {
+		case []interface{}, map[string]interface{}:
+			return false, nil
+		}
+
+		cmp, err := sql.CompareJSON(target, candidate)
+		return cmp == 0, err
+	}
+}
+
+// TransformUp implements the Expression interface.
+func (f *JSONContains) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&JSONContains{newNullPropagating(f.name, args...)})
+}
+
+// JSONLength returns the length of a JSON document, optionally of the
+// value selected by a JSON path expression, which is the number of
+// elements of an array, the number of members of an object and 1 for
+// scalars.
+type JSONLength struct {
+	nullPropagating
+}
+
+// NewJSONLength creates a new JSONLength function.
+func NewJSONLength(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("1 or 2", len(args))
+	}
+
+	return &JSONLength{newNullPropagating("json_length", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *JSONLength) Type() sql.Type { return sql.Int64 }
+
+// IsNullable implements the Expression interface.
+func (f *JSONLength) IsNullable() bool {
+	return len(f.args) == 2 || f.nullPropagating.IsNullable()
+}
+
+// Eval implements the Expression interface.
+func (f *JSONLength) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.JSONLength")
+	defer span.Finish()
+
+	doc, ok, err := evalJSONDocument(ctx, row, f.nullPropagating)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	switch doc := doc.(type) {
+	case []interface{}:
+		return int64(len(doc)), nil
+	case map[string]interface{}:
+		return int64(len(doc)), nil
+	default:
+		return int64(1), nil
+	}
+}
+
+// TransformUp implements the Expression interface.
+func (f *JSONLength) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&JSONLength{newNullPropagating(f.name, args...)})
+}
+
+// JSONKeys returns the keys of a JSON object, optionally of the value
+// selected by a JSON path expression, as a JSON array. It returns NULL if
+// the value is not an object.
+type JSONKeys struct {
+	nullPropagating
+}
+
+// NewJSONKeys creates a new JSONKeys function.
+func NewJSONKeys(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("1 or 2", len(args))
+	}
+
+	return &JSONKeys{newNullPropagating("json_keys", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *JSONKeys) Type() sql.Type { return sql.JSON }
+
+// IsNullable implements the Expression interface.
+func (f *JSONKeys) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *JSONKeys) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.JSONKeys")
+	defer span.Finish()
+
+	doc, ok, err := evalJSONDocument(ctx, row, f.nullPropagating)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	o, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	var keys []interface{}
+	for _, k := range sql.JSONKeys(o) {
+		keys = append(keys, k)
+	}
+	return sql.MarshalJSON(keys)
+}
+
+// TransformUp implements the Expression interface.
+func (f *JSONKeys) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&JSONKeys{newNullPropagating(f.name, args...)})
+}
+
+// JSONObject returns a JSON object with the given keys and values, which
+// are given as alternate arguments. NULL values are JSON nulls.
+type JSONObject struct {
+	nullPropagating
+}
+
+// NewJSONObject creates a new JSONObject function.
+func NewJSONObject(args ...sql.Expression) (sql.Expression, error) {
+	if len(args)%2 != 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New("an even number of", len(args))
+	}
+
+	return &JSONObject{newNullPropagating("json_object", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *JSONObject) Type() sql.Type { return sql.JSON }
+
+// IsNullable implements the Expression interface.
+func (f *JSONObject) IsNullable() bool { return false }
+
+// Eval implements the Expression interface.
+func (f *JSONObject) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.JSONObject")
+	defer span.Finish()
+
+	var o = make(map[string]interface{}, len(f.args)/2)
+	for i := 0; i < len(f.args); i += 2 {
+		k, err := f.args[i].Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		if k == nil {
+			return nil, sql.ErrJSONNullKey.New()
+		}
+
+		key, err := toText(f.args[i], k)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := f.args[i+1].Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		if o[key], err = sql.ToJSON(f.args[i+1].Type(), v); err != nil {
+			return nil, err
+		}
+	}
+
+	return sql.MarshalJSON(o)
+}
+
+// TransformUp implements the Expression interface.
+func (f *JSONObject) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&JSONObject{newNullPropagating(f.name, args...)})
+}
+
+// JSONArray returns a JSON array with the given values. NULL values are
+// JSON nulls.
+type JSONArray struct {
+	nullPropagating
+}
+
+// NewJSONArray creates a new JSONArray function.
+func NewJSONArray(args ...sql.Expression) (sql.Expression, error) {
+	return &JSONArray{newNullPropagating("json_array", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *JSONArray) Type() sql.Type { return sql.JSON }
+
+// IsNullable implements the Expression interface.
+func (f *JSONArray) IsNullable() bool { return false }
+
+// Eval implements the Expression interface.
+func (f *JSONArray) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.JSONArray")
+	defer span.Finish()
+
+	var a = make([]interface{}, len(f.args))
+	for i, arg := range f.args {
+		v, err := arg.Eval(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		if a[i], err = sql.ToJSON(arg.Type(), v); err != nil {
+			return nil, err
+		}
+	}
+
+	return sql.MarshalJSON(a)
+}
+
+// TransformUp implements the Expression interface.
+func (f *JSONArray) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&JSONArray{newNullPropagating(f.name, args...)})
+}
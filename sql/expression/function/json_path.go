@@ -0,0 +1,332 @@
+package function
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/src-d/go-errors.v1"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// ErrInvalidJSONPath is returned when a JSON path expression is not valid.
+var ErrInvalidJSONPath = errors.NewKind("invalid JSON path expression %q at character %d")
+
+// ErrJSONPathWildcard is returned when a JSON path expression with
+// wildcards is used where only a single value can be selected.
+var ErrJSONPathWildcard = errors.NewKind("JSON path expression %q may not contain the * and ** tokens")
+
+type jsonPathLegKind byte
+
+const (
+	// legMember selects the member of an object with a key.
+	legMember jsonPathLegKind = iota
+	// legMemberWildcard selects all the members of an object.
+	legMemberWildcard
+	// legArrayCell selects the element of an array at an index.
+	legArrayCell
+	// legArrayRange selects the elements of an array in a range of indexes.
+	legArrayRange
+	// legArrayWildcard selects all the elements of an array.
+	legArrayWildcard
+	// legDoubleWildcard selects a value and all the values nested in it.
+	legDoubleWildcard
+)
+
+// jsonArrayIndex is an index of an array, which can be relative to its
+// last element, as in [last] or [last-2].
+type jsonArrayIndex struct {
+	n    int
+	last bool
+}
+
+// resolve returns the index in an array of the given length.
+func (i jsonArrayIndex) resolve(length int) int {
+	if i.last {
+		return length - 1 - i.n
+	}
+	return i.n
+}
+
+type jsonPathLeg struct {
+	kind     jsonPathLegKind
+	key      string
+	from, to jsonArrayIndex
+}
+
+// jsonPath is a MySQL JSON path expression, such as $.a[1].b, $[*] or
+// $**.c, which selects values of a JSON document.
+type jsonPath struct {
+	text string
+	legs []jsonPathLeg
+}
+
+// hasWildcard returns whether the path can select more than one value.
+func (p jsonPath) hasWildcard() bool {
+	for _, leg := range p.legs {
+		switch leg.kind {
+		case legMemberWildcard, legArrayRange, legArrayWildcard, legDoubleWildcard:
+			return true
+		}
+	}
+	return false
+}
+
+// find returns the values selected by the path in the given JSON value, in
+// document order.
+func (p jsonPath) find(v interface{}) []interface{} {
+	var result []interface{}
+	findJSONPath(v, p.legs, &result)
+	return result
+}
+
+func findJSONPath(v interface{}, legs []jsonPathLeg, result *[]interface{}) {
+	if len(legs) == 0 {
+		*result = append(*result, v)
+		return
+	}
+
+	leg, rest := legs[0], legs[1:]
+	switch leg.kind {
+	case legMember:
+		if o, ok := v.(map[string]interface{}); ok {
+			if e, ok := o[leg.key]; ok {
+				findJSONPath(e, rest, result)
+			}
+		}
+	case legMemberWildcard:
+		if o, ok := v.(map[string]interface{}); ok {
+			for _, k := range sql.JSONKeys(o) {
+				findJSONPath(o[k], rest, result)
+			}
+		}
+	case legArrayCell, legArrayRange:
+		// Values that are not arrays are treated as arrays with a single
+		// element when selecting an index.
+		a, ok := v.([]interface{})
+		if !ok {
+			a = []interface{}{v}
+		}
+
+		from, to := leg.from.resolve(len(a)), leg.from.resolve(len(a))
+		if leg.kind == legArrayRange {
+			to = leg.to.resolve(len(a))
+		}
+		if from < 0 {
+			from = 0
+		}
+		for i := from; i <= to && i < len(a); i++ {
+			findJSONPath(a[i], rest, result)
+		}
+	case legArrayWildcard:
+		if a, ok := v.([]interface{}); ok {
+			for _, e := range a {
+				findJSONPath(e, rest, result)
+			}
+		}
+	case legDoubleWildcard:
+		findJSONPath(v, rest, result)
+		switch v := v.(type) {
+		case []interface{}:
+			for _, e := range v {
+				findJSONPath(e, legs, result)
+			}
+		case map[string]interface{}:
+			for _, k := range sql.JSONKeys(v) {
+				findJSONPath(v[k], legs, result)
+			}
+		}
+	}
+}
+
+// parseJSONPath parses a JSON path expression.
+func parseJSONPath(text string) (jsonPath, error) {
+	p := &jsonPathParser{text: text}
+	legs, err := p.parse()
+	if err != nil {
+		return jsonPath{}, err
+	}
+	return jsonPath{text, legs}, nil
+}
+
+type jsonPathParser struct {
+	text string
+	pos  int
+}
+
+func (p *jsonPathParser) parse() ([]jsonPathLeg, error) {
+	p.skipSpaces()
+	if !p.consume("$") {
+		return nil, p.errorf()
+	}
+
+	var legs []jsonPathLeg
+	for {
+		p.skipSpaces()
+		if p.pos == len(p.text) {
+			break
+		}
+
+		var leg jsonPathLeg
+		var err error
+		switch {
+		case p.consume("**"):
+			leg.kind = legDoubleWildcard
+		case p.consume("."):
+			leg, err = p.parseMember()
+		case p.consume("["):
+			leg, err = p.parseArrayLeg()
+		default:
+			err = p.errorf()
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		legs = append(legs, leg)
+	}
+
+	// A path cannot end with ** nor have two of them in a row.
+	for i, leg := range legs {
+		if leg.kind == legDoubleWildcard &&
+			(i == len(legs)-1 || legs[i+1].kind == legDoubleWildcard) {
+			return nil, ErrInvalidJSONPath.New(p.text, len(p.text))
+		}
+	}
+
+	return legs, nil
+}
+
+func (p *jsonPathParser) parseMember() (jsonPathLeg, error) {
+	p.skipSpaces()
+	if p.consume("*") {
+		return jsonPathLeg{kind: legMemberWildcard}, nil
+	}
+
+	if strings.HasPrefix(p.text[p.pos:], `"`) {
+		end := p.pos + 1
+		for ; end < len(p.text) && p.text[end] != '"'; end++ {
+			if p.text[end] == '\\' {
+				end++
+			}
+		}
+		if end >= len(p.text) {
+			return jsonPathLeg{}, p.errorf()
+		}
+
+		var key string
+		if err := json.Unmarshal([]byte(p.text[p.pos:end+1]), &key); err != nil {
+			return jsonPathLeg{}, p.errorf()
+		}
+		p.pos = end + 1
+		return jsonPathLeg{kind: legMember, key: key}, nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.text) && isJSONPathIdentifierChar(rune(p.text[p.pos])) {
+		p.pos++
+	}
+	if p.pos == start || unicode.IsDigit(rune(p.text[start])) {
+		return jsonPathLeg{}, p.errorf()
+	}
+
+	return jsonPathLeg{kind: legMember, key: p.text[start:p.pos]}, nil
+}
+
+func isJSONPathIdentifierChar(r rune) bool {
+	return r == '_' || r == '$' || r >= 0x80 || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func (p *jsonPathParser) parseArrayLeg() (jsonPathLeg, error) {
+	p.skipSpaces()
+
+	var leg jsonPathLeg
+	if p.consume("*") {
+		leg.kind = legArrayWildcard
+	} else {
+		from, err := p.parseArrayIndex()
+		if err != nil {
+			return leg, err
+		}
+		leg.kind = legArrayCell
+		leg.from = from
+
+		p.skipSpaces()
+		if p.consumeWord("to") {
+			to, err := p.parseArrayIndex()
+			if err != nil {
+				return leg, err
+			}
+			leg.kind = legArrayRange
+			leg.to = to
+		}
+	}
+
+	p.skipSpaces()
+	if !p.consume("]") {
+		return leg, p.errorf()
+	}
+	return leg, nil
+}
+
+func (p *jsonPathParser) parseArrayIndex() (jsonArrayIndex, error) {
+	p.skipSpaces()
+	if !p.consumeWord("last") {
+		n, err := p.parseNumber()
+		return jsonArrayIndex{n: n}, err
+	}
+
+	p.skipSpaces()
+	if !p.consume("-") {
+		return jsonArrayIndex{last: true}, nil
+	}
+
+	p.skipSpaces()
+	n, err := p.parseNumber()
+	return jsonArrayIndex{n: n, last: true}, err
+}
+
+func (p *jsonPathParser) parseNumber() (int, error) {
+	start := p.pos
+	for p.pos < len(p.text) && p.text[p.pos] >= '0' && p.text[p.pos] <= '9' {
+		p.pos++
+	}
+
+	n, err := strconv.Atoi(p.text[start:p.pos])
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf()
+	}
+	return n, nil
+}
+
+func (p *jsonPathParser) skipSpaces() {
+	for p.pos < len(p.text) && unicode.IsSpace(rune(p.text[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *jsonPathParser) consume(s string) bool {
+	if strings.HasPrefix(p.text[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+// consumeWord is like consume, but s must not be followed by a character
+// of an identifier.
+func (p *jsonPathParser) consumeWord(s string) bool {
+	end := p.pos + len(s)
+	if !strings.HasPrefix(p.text[p.pos:], s) ||
+		(end < len(p.text) && isJSONPathIdentifierChar(rune(p.text[end]))) {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *jsonPathParser) errorf() error {
+	return ErrInvalidJSONPath.New(p.text, p.pos+1)
+}
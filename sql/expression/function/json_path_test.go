@@ -0,0 +1,87 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+func TestJSONPath(t *testing.T) {
+	doc, err := sql.ParseJSON([]byte(`{
+		"a": [1, [2, 3], {"b": 4}],
+		"b": {"c": {"b": 5}, "d e": 6},
+		"c": "x"
+	}`))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		path     string
+		wildcard bool
+		expected []interface{}
+	}{
+		{"$", false, []interface{}{doc}},
+		{"$.c", false, []interface{}{"x"}},
+		{"  $ . c ", false, []interface{}{"x"}},
+		{`$."c"`, false, []interface{}{"x"}},
+		{`$.b."d e"`, false, []interface{}{int64(6)}},
+		{"$.z", false, nil},
+		{"$.a[0]", false, []interface{}{int64(1)}},
+		{"$.a[1][1]", false, []interface{}{int64(3)}},
+		{"$.a[3]", false, nil},
+		{"$.a[last]", false, []interface{}{map[string]interface{}{"b": int64(4)}}},
+		{"$.a[last - 2]", false, []interface{}{int64(1)}},
+		{"$.a[0].b", false, nil},
+		{"$.c[0]", false, []interface{}{"x"}},
+		{"$.a[1 to last]", true, []interface{}{
+			[]interface{}{int64(2), int64(3)},
+			map[string]interface{}{"b": int64(4)},
+		}},
+		{"$.a[1][*]", true, []interface{}{int64(2), int64(3)}},
+		{"$.b.*", true, []interface{}{
+			map[string]interface{}{"b": int64(5)},
+			int64(6),
+		}},
+		{"$**.b", true, []interface{}{
+			map[string]interface{}{"c": map[string]interface{}{"b": int64(5)}, "d e": int64(6)},
+			int64(4),
+			int64(5),
+		}},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.path, func(t *testing.T) {
+			require := require.New(t)
+
+			path, err := parseJSONPath(tt.path)
+			require.NoError(err)
+			require.Equal(tt.wildcard, path.hasWildcard())
+			require.Equal(tt.expected, path.find(doc))
+		})
+	}
+}
+
+func TestJSONPathInvalid(t *testing.T) {
+	paths := []string{
+		"",
+		"a",
+		"$.",
+		"$.1a",
+		"$[",
+		"$[a]",
+		"$[1",
+		`$."a`,
+		"$**",
+		"$.a**",
+		"$***.a",
+		"$[last-]",
+	}
+
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			_, err := parseJSONPath(path)
+			require.Error(t, err)
+			require.True(t, ErrInvalidJSONPath.Is(err))
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+const testJSONDocument = `{"a": [1, 2, {"b": "x"}], "c": {"d": null, "e": true}}`
+
+func jsonLiteral(doc string) sql.Expression {
+	return expression.NewLiteral([]byte(doc), sql.JSON)
+}
+
+func textLiteral(s string) sql.Expression {
+	return expression.NewLiteral(s, sql.Text)
+}
+
+func requireJSON(t *testing.T, expected string, actual interface{}) {
+	t.Helper()
+	if expected == "" {
+		require.Nil(t, actual)
+		return
+	}
+	require.Equal(t, expected, string(actual.([]byte)))
+}
+
+func TestJSONExtract(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []sql.Expression
+		expected string
+	}{
+		{"member", []sql.Expression{jsonLiteral(testJSONDocument), textLiteral("$.c")}, `{"d": null, "e": true}`},
+		{"element", []sql.Expression{jsonLiteral(testJSONDocument), textLiteral("$.a[2].b")}, `"x"`},
+		{"null value", []sql.Expression{jsonLiteral(testJSONDocument), textLiteral("$.c.d")}, `null`},
+		{"not found", []sql.Expression{jsonLiteral(testJSONDocument), textLiteral("$.z")}, ""},
+		{"wildcard", []sql.Expression{jsonLiteral(testJSONDocument), textLiteral("$.a[0 to 1]")}, `[1, 2]`},
+		{"several paths", []sql.Expression{jsonLiteral(testJSONDocument), textLiteral("$.a[0]"), textLiteral("$.z"), textLiteral("$.c.e")}, `[1, true]`},
+		{"text document", []sql.Expression{textLiteral(`[1, "a"]`), textLiteral("$[1]")}, `"a"`},
+		{"null document", []sql.Expression{expression.NewLiteral(nil, sql.Null), textLiteral("$")}, ""},
+		{"null path", []sql.Expression{jsonLiteral(testJSONDocument), expression.NewLiteral(nil, sql.Null)}, ""},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewJSONExtract(tt.args...)
+			require.NoError(t, err)
+			requireJSON(t, tt.expected, eval(t, f, nil))
+		})
+	}
+
+	_, err := NewJSONExtract(jsonLiteral(testJSONDocument))
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	f, err := NewJSONExtract(jsonLiteral(testJSONDocument), textLiteral("$."))
+	require.NoError(t, err)
+	_, err = f.Eval(sql.NewEmptyContext(), nil)
+	require.True(t, ErrInvalidJSONPath.Is(err))
+
+	f, err = NewJSONExtract(textLiteral("{"), textLiteral("$"))
+	require.NoError(t, err)
+	_, err = f.Eval(sql.NewEmptyContext(), nil)
+	require.True(t, sql.ErrInvalidJSONText.Is(err))
+}
+
+func TestJSONUnquote(t *testing.T) {
+	testCases := []struct {
+		name     string
+		arg      sql.Expression
+		expected interface{}
+	}{
+		{"json string", jsonLiteral(`"a\tb"`), "a\tb"},
+		{"json object", jsonLiteral(`{"b":1,"a":[2]}`), `{"a": [2], "b": 1}`},
+		{"quoted text", textLiteral(`"aé"`), "aé"},
+		{"unquoted text", textLiteral(`abc`), "abc"},
+		{"null", expression.NewLiteral(nil, sql.Null), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, NewJSONUnquote(tt.arg), nil))
+		})
+	}
+}
+
+func TestJSONContains(t *testing.T) {
+	testCases := []struct {
+		target, candidate string
+		path              string
+		expected          interface{}
+	}{
+		{`1`, `1`, "", true},
+		{`1`, `1.0`, "", true},
+		{`1`, `"1"`, "", false},
+		{`[1, 2, [3, 4]]`, `2`, "", true},
+		{`[1, 2, [3, 4]]`, `[1, 3]`, "", true},
+		{`[1, 2, [3, 4]]`, `[1, 5]`, "", false},
+		{`[1, 2]`, `{"a": 1}`, "", false},
+		{`{"a": 1, "b": {"c": [1, 2]}}`, `{"b": {"c": 2}}`, "", true},
+		{`{"a": 1, "b": {"c": [1, 2]}}`, `{"a": 1, "d": 1}`, "", false},
+		{`{"a": 1}`, `1`, "", false},
+		{`{"a": 1}`, `1`, "$.a", true},
+		{`{"a": 1}`, `1`, "$.b", nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.target+" "+tt.candidate+" "+tt.path, func(t *testing.T) {
+			args := []sql.Expression{jsonLiteral(tt.target), jsonLiteral(tt.candidate)}
+			if tt.path != "" {
+				args = append(args, textLiteral(tt.path))
+			}
+
+			f, err := NewJSONContains(args...)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, eval(t, f, nil))
+		})
+	}
+
+	f, err := NewJSONContains(jsonLiteral(`[1]`), jsonLiteral(`1`), textLiteral("$[*]"))
+	require.NoError(t, err)
+	_, err = f.Eval(sql.NewEmptyContext(), nil)
+	require.True(t, ErrJSONPathWildcard.Is(err))
+}
+
+func TestJSONLengthAndKeys(t *testing.T) {
+	testCases := []struct {
+		path   string
+		length interface{}
+		keys   string
+	}{
+		{"", int64(2), `["a", "c"]`},
+		{"$.a", int64(3), ""},
+		{"$.a[0]", int64(1), ""},
+		{"$.c", int64(2), `["d", "e"]`},
+		{"$.z", nil, ""},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.path, func(t *testing.T) {
+			args := []sql.Expression{jsonLiteral(testJSONDocument)}
+			if tt.path != "" {
+				args = append(args, textLiteral(tt.path))
+			}
+
+			f, err := NewJSONLength(args...)
+			require.NoError(t, err)
+			require.Equal(t, tt.length, eval(t, f, nil))
+
+			f, err = NewJSONKeys(args...)
+			require.NoError(t, err)
+			requireJSON(t, tt.keys, eval(t, f, nil))
+		})
+	}
+}
+
+func TestJSONObjectAndArray(t *testing.T) {
+	require := require.New(t)
+
+	args := []sql.Expression{
+		textLiteral("a"),
+		expression.NewLiteral(int64(1), sql.Int64),
+		textLiteral("b"),
+		expression.NewLiteral(nil, sql.Null),
+		textLiteral("c"),
+		expression.NewLiteral(true, sql.Boolean),
+		textLiteral("d"),
+		jsonLiteral(`{"e": [1.5]}`),
+		textLiteral("f"),
+		textLiteral(`{"g": 1}`),
+	}
+
+	f, err := NewJSONObject(args...)
+	require.NoError(err)
+	require.False(f.IsNullable())
+	requireJSON(t, `{"a": 1, "b": null, "c": true, "d": {"e": [1.5]}, "f": "{\"g\": 1}"}`, eval(t, f, nil))
+
+	f, err = NewJSONArray(args[:6]...)
+	require.NoError(err)
+	requireJSON(t, `["a", 1, "b", null, "c", true]`, eval(t, f, nil))
+
+	f, err = NewJSONArray()
+	require.NoError(err)
+	requireJSON(t, `[]`, eval(t, f, nil))
+
+	_, err = NewJSONObject(args[:3]...)
+	require.True(sql.ErrInvalidArgumentNumber.Is(err))
+
+	f, err = NewJSONObject(expression.NewLiteral(nil, sql.Null), textLiteral("a"))
+	require.NoError(err)
+	_, err = f.Eval(sql.NewEmptyContext(), nil)
+	require.True(sql.ErrJSONNullKey.Is(err))
+}
@@ -0,0 +1,106 @@
+package function
+
+import (
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// Left returns the given number of characters from the start of a string.
+type Left struct {
+	nullPropagating
+}
+
+// NewLeft creates a new Left function.
+func NewLeft(str, length sql.Expression) sql.Expression {
+	return &Left{newNullPropagating("left", str, length)}
+}
+
+// Type implements the Expression interface.
+func (f *Left) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (f *Left) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Left")
+	defer span.Finish()
+
+	runes, length, ok, err := evalRunesAndLength(ctx, row, f.nullPropagating)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return string(runes[:length]), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *Left) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewLeft(args[0], args[1]))
+}
+
+// Right returns the given number of characters from the end of a string.
+type Right struct {
+	nullPropagating
+}
+
+// NewRight creates a new Right function.
+func NewRight(str, length sql.Expression) sql.Expression {
+	return &Right{newNullPropagating("right", str, length)}
+}
+
+// Type implements the Expression interface.
+func (f *Right) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (f *Right) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Right")
+	defer span.Finish()
+
+	runes, length, ok, err := evalRunesAndLength(ctx, row, f.nullPropagating)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return string(runes[len(runes)-length:]), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *Right) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewRight(args[0], args[1]))
+}
+
+// evalRunesAndLength evaluates the string and length arguments of LEFT or
+// RIGHT. It returns the characters of the string and the length clamped to
+// the number of characters. The returned bool is false if any argument is
+// NULL.
+func evalRunesAndLength(ctx *sql.Context, row sql.Row, f nullPropagating) ([]rune, int, bool, error) {
+	args, err := f.evalArgs(ctx, row)
+	if err != nil || args == nil {
+		return nil, 0, false, err
+	}
+
+	str, err := toText(f.args[0], args[0])
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	length, err := toInt64(args[1])
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	runes := []rune(str)
+	switch {
+	case length < 0:
+		length = 0
+	case length > int64(len(runes)):
+		length = int64(len(runes))
+	}
+
+	return runes, int(length), true, nil
+}
@@ -0,0 +1,37 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestLeftRight(t *testing.T) {
+	str := expression.NewGetField(0, sql.Text, "s", true)
+	length := expression.NewGetField(1, sql.Int64, "len", true)
+	left := NewLeft(str, length)
+	right := NewRight(str, length)
+
+	testCases := []struct {
+		name  string
+		row   sql.Row
+		left  interface{}
+		right interface{}
+	}{
+		{"ascii", sql.NewRow("foobar", int64(2)), "fo", "ar"},
+		{"unicode", sql.NewRow("ñandú", int64(2)), "ña", "dú"},
+		{"longer", sql.NewRow("foo", int64(5)), "foo", "foo"},
+		{"negative", sql.NewRow("foo", int64(-1)), "", ""},
+		{"empty", sql.NewRow("", int64(1)), "", ""},
+		{"null", sql.NewRow(nil, int64(1)), nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.left, eval(t, left, tt.row))
+			require.Equal(t, tt.right, eval(t, right, tt.row))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package function
+
+import (
+	"unicode/utf8"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// Length returns the length of a string in bytes.
+type Length struct {
+	nullPropagating
+}
+
+// NewLength creates a new Length function.
+func NewLength(str sql.Expression) sql.Expression {
+	return &Length{newNullPropagating("length", str)}
+}
+
+// Type implements the Expression interface.
+func (f *Length) Type() sql.Type { return sql.Int32 }
+
+// Eval implements the Expression interface.
+func (f *Length) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Length")
+	defer span.Finish()
+
+	v, err := f.args[0].Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	if b, ok := v.([]byte); ok {
+		return int32(len(b)), nil
+	}
+
+	s, err := toText(f.args[0], v)
+	if err != nil {
+		return nil, err
+	}
+
+	return int32(len(s)), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *Length) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewLength(args[0]))
+}
+
+// CharLength returns the length of a string in characters.
+type CharLength struct {
+	nullPropagating
+}
+
+// NewCharLength creates a new CharLength function.
+func NewCharLength(str sql.Expression) sql.Expression {
+	return &CharLength{newNullPropagating("char_length", str)}
+}
+
+// Type implements the Expression interface.
+func (f *CharLength) Type() sql.Type { return sql.Int32 }
+
+// Eval implements the Expression interface.
+func (f *CharLength) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.CharLength")
+	defer span.Finish()
+
+	v, err := f.args[0].Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	s, err := toText(f.args[0], v)
+	if err != nil {
+		return nil, err
+	}
+
+	return int32(utf8.RuneCountInString(s)), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *CharLength) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewCharLength(args[0]))
+}
@@ -0,0 +1,34 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestLength(t *testing.T) {
+	length := NewLength(expression.NewGetField(0, sql.Text, "s", true))
+	charLength := NewCharLength(expression.NewGetField(0, sql.Text, "s", true))
+
+	testCases := []struct {
+		name       string
+		input      interface{}
+		length     interface{}
+		charLength interface{}
+	}{
+		{"ascii", "foo", int32(3), int32(3)},
+		{"unicode", "señor", int32(6), int32(5)},
+		{"empty", "", int32(0), int32(0)},
+		{"blob", []byte{0, 1}, int32(2), int32(2)},
+		{"null", nil, nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.length, eval(t, length, sql.NewRow(tt.input)))
+			require.Equal(t, tt.charLength, eval(t, charLength, sql.NewRow(tt.input)))
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package function
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// Instr returns the position of the first occurrence of a substring in a
+// string, starting at 1, or 0 if there is none.
+type Instr struct {
+	nullPropagating
+}
+
+// NewInstr creates a new Instr function.
+func NewInstr(str, substr sql.Expression) sql.Expression {
+	return &Instr{newNullPropagating("instr", str, substr)}
+}
+
+// Type implements the Expression interface.
+func (f *Instr) Type() sql.Type { return sql.Int32 }
+
+// Eval implements the Expression interface.
+func (f *Instr) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Instr")
+	defer span.Finish()
+
+	args, err := f.evalArgs(ctx, row)
+	if err != nil || args == nil {
+		return nil, err
+	}
+
+	strs, err := toTexts(f.args, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return locate(strs[0], strs[1], 1, caseInsensitive(f.args...)), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *Instr) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewInstr(args[0], args[1]))
+}
+
+// Locate returns the position of the first occurrence of a substring in a
+// string, starting at 1, or 0 if there is none. The search can start at a
+// given position of the string.
+type Locate struct {
+	nullPropagating
+}
+
+// NewLocate creates a new Locate function.
+func NewLocate(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 2 && len(args) != 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("2 or 3", len(args))
+	}
+
+	return &Locate{newNullPropagating("locate", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *Locate) Type() sql.Type { return sql.Int32 }
+
+// Eval implements the Expression interface.
+func (f *Locate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Locate")
+	defer span.Finish()
+
+	args, err := f.evalArgs(ctx, row)
+	if err != nil || args == nil {
+		return nil, err
+	}
+
+	strs, err := toTexts(f.args[:2], args[:2])
+	if err != nil {
+		return nil, err
+	}
+
+	var pos int64 = 1
+	if len(args) == 3 {
+		pos, err = toInt64(args[2])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return locate(strs[1], strs[0], pos, caseInsensitive(f.args[:2]...)), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *Locate) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	locate, err := NewLocate(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(locate)
+}
+
+// locate returns the position in characters of the first occurrence of
+// substr in str at or after the position pos, starting at 1, or 0 if there
+// is none.
+func locate(str, substr string, pos int64, ci bool) int32 {
+	if ci {
+		str, substr = strings.ToUpper(str), strings.ToUpper(substr)
+	}
+
+	runes := []rune(str)
+	if pos < 1 || pos > int64(len(runes))+1 {
+		return 0
+	}
+
+	rest := string(runes[pos-1:])
+	idx := strings.Index(rest, substr)
+	if idx < 0 {
+		return 0
+	}
+
+	return int32(pos) + int32(utf8.RuneCountInString(rest[:idx]))
+}
+
+// caseInsensitive returns whether the strings returned by the given
+// expressions are compared case-insensitively, which is the case unless any
+// of them is a string with a case-sensitive collation.
+func caseInsensitive(exprs ...sql.Expression) bool {
+	for _, e := range exprs {
+		if sql.IsText(e.Type()) && sql.CollationOf(e.Type()) != sql.CollationUtf8mb4GeneralCI {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestInstr(t *testing.T) {
+	bin, err := sql.WithCollation(sql.Text, sql.CollationUtf8mb4Bin)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		typ      sql.Type
+		row      sql.Row
+		expected interface{}
+	}{
+		{"found", sql.Text, sql.NewRow("foobar", "bar"), int32(4)},
+		{"unicode", sql.Text, sql.NewRow("ñandú", "dú"), int32(4)},
+		{"case-insensitive", sql.Text, sql.NewRow("fooBAR", "bar"), int32(4)},
+		{"case-sensitive", bin, sql.NewRow("fooBAR", "bar"), int32(0)},
+		{"empty substring", sql.Text, sql.NewRow("foo", ""), int32(1)},
+		{"not found", sql.Text, sql.NewRow("foo", "x"), int32(0)},
+		{"null", sql.Text, sql.NewRow(nil, "x"), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewInstr(
+				expression.NewGetField(0, tt.typ, "s", true),
+				expression.NewGetField(1, sql.Text, "sub", true),
+			)
+			require.Equal(t, tt.expected, eval(t, f, tt.row))
+		})
+	}
+}
+
+func TestLocate(t *testing.T) {
+	_, err := NewLocate(expression.NewLiteral("a", sql.Text))
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+
+	f, err := NewLocate(
+		expression.NewGetField(0, sql.Text, "sub", true),
+		expression.NewGetField(1, sql.Text, "s", true),
+		expression.NewGetField(2, sql.Int64, "pos", true),
+	)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"from start", sql.NewRow("bar", "foobarbar", int64(1)), int32(4)},
+		{"from position", sql.NewRow("bar", "foobarbar", int64(5)), int32(7)},
+		{"unicode", sql.NewRow("r", "ñañar", int64(2)), int32(5)},
+		{"zero position", sql.NewRow("bar", "foobarbar", int64(0)), int32(0)},
+		{"past the end", sql.NewRow("bar", "foobarbar", int64(20)), int32(0)},
+		{"null", sql.NewRow("bar", "foobarbar", nil), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, f, tt.row))
+		})
+	}
+}
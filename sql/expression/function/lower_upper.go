@@ -0,0 +1,67 @@
+package function
+
+import (
+	"strings"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// Lower returns the given string with all its characters in lowercase.
+type Lower struct {
+	nullPropagating
+}
+
+// NewLower creates a new Lower function.
+func NewLower(str sql.Expression) sql.Expression {
+	return &Lower{newNullPropagating("lower", str)}
+}
+
+// Type implements the Expression interface.
+func (f *Lower) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (f *Lower) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Lower")
+	defer span.Finish()
+
+	return evalText(ctx, row, f.args[0], strings.ToLower)
+}
+
+// TransformUp implements the Expression interface.
+func (f *Lower) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewLower(args[0]))
+}
+
+// Upper returns the given string with all its characters in uppercase.
+type Upper struct {
+	nullPropagating
+}
+
+// NewUpper creates a new Upper function.
+func NewUpper(str sql.Expression) sql.Expression {
+	return &Upper{newNullPropagating("upper", str)}
+}
+
+// Type implements the Expression interface.
+func (f *Upper) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (f *Upper) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Upper")
+	defer span.Finish()
+
+	return evalText(ctx, row, f.args[0], strings.ToUpper)
+}
+
+// TransformUp implements the Expression interface.
+func (f *Upper) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewUpper(args[0]))
+}
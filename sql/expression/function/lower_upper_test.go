@@ -0,0 +1,35 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestLowerUpper(t *testing.T) {
+	lower := NewLower(expression.NewGetField(0, sql.Text, "s", true))
+	upper := NewUpper(expression.NewGetField(0, sql.Text, "s", true))
+
+	testCases := []struct {
+		name  string
+		input interface{}
+		lower interface{}
+		upper interface{}
+	}{
+		{"ascii", "FooBar", "foobar", "FOOBAR"},
+		{"unicode", "ÁrBoL", "árbol", "ÁRBOL"},
+		{"number", int64(12), "12", "12"},
+		{"null", nil, nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.lower, eval(t, lower, sql.NewRow(tt.input)))
+			require.Equal(t, tt.upper, eval(t, upper, sql.NewRow(tt.input)))
+		})
+	}
+
+	require.Equal(t, "lower(s)", lower.String())
+}
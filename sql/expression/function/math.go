@@ -0,0 +1,157 @@
+package function
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shopspring/decimal"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// numberType returns the type of the result of the math functions that keep
+// the kind of number of their argument of type t: integers are BIGINT,
+// fixed-point decimals keep their type and any other type is a DOUBLE.
+func numberType(t sql.Type) sql.Type {
+	switch {
+	case sql.IsUnsigned(t):
+		return sql.Uint64
+	case sql.IsInteger(t):
+		return sql.Int64
+	case sql.IsFixedPoint(t):
+		return t
+	default:
+		return sql.Float64
+	}
+}
+
+// toNumber converts the value v of an argument of type t to the number the
+// math functions operate on, which is an int64, a uint64, a decimal or a
+// float64 depending on numberType(t).
+func toNumber(t sql.Type, v interface{}) (interface{}, error) {
+	return numberType(t).Convert(v)
+}
+
+// Abs returns the absolute value of a number.
+type Abs struct {
+	expression.UnaryExpression
+}
+
+// NewAbs creates a new Abs function.
+func NewAbs(e sql.Expression) sql.Expression {
+	return &Abs{expression.UnaryExpression{Child: e}}
+}
+
+// Type implements the Expression interface.
+func (f *Abs) Type() sql.Type { return numberType(f.Child.Type()) }
+
+// Eval implements the Expression interface.
+func (f *Abs) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Abs")
+	defer span.Finish()
+
+	v, err := f.Child.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	n, err := toNumber(f.Child.Type(), v)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n := n.(type) {
+	case int64:
+		if n == math.MinInt64 {
+			return nil, sql.ErrValueOutOfRange.New(f, "BIGINT")
+		}
+
+		if n < 0 {
+			return -n, nil
+		}
+		return n, nil
+	case decimal.Decimal:
+		return f.Type().Convert(n.Abs())
+	case float64:
+		return math.Abs(n), nil
+	default:
+		return n, nil
+	}
+}
+
+// TransformUp implements the Expression interface.
+func (f *Abs) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := f.Child.TransformUp(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewAbs(child))
+}
+
+func (f *Abs) String() string { return fmt.Sprintf("abs(%s)", f.Child) }
+
+// Sign returns -1, 0 or 1 if a number is negative, zero or positive.
+type Sign struct {
+	expression.UnaryExpression
+}
+
+// NewSign creates a new Sign function.
+func NewSign(e sql.Expression) sql.Expression {
+	return &Sign{expression.UnaryExpression{Child: e}}
+}
+
+// Type implements the Expression interface.
+func (f *Sign) Type() sql.Type { return sql.Int32 }
+
+// Eval implements the Expression interface.
+func (f *Sign) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Sign")
+	defer span.Finish()
+
+	v, err := f.Child.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	n, err := toNumber(f.Child.Type(), v)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n := n.(type) {
+	case int64:
+		return sign(n > 0, n < 0), nil
+	case uint64:
+		return sign(n > 0, false), nil
+	case decimal.Decimal:
+		return int32(n.Sign()), nil
+	default:
+		f := n.(float64)
+		return sign(f > 0, f < 0), nil
+	}
+}
+
+func sign(positive, negative bool) int32 {
+	switch {
+	case positive:
+		return 1
+	case negative:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// TransformUp implements the Expression interface.
+func (f *Sign) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := f.Child.TransformUp(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewSign(child))
+}
+
+func (f *Sign) String() string { return fmt.Sprintf("sign(%s)", f.Child) }
@@ -0,0 +1,58 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestAbs(t *testing.T) {
+	dec := sql.MustDecimal(5, 2)
+	testCases := []struct {
+		name         string
+		typ          sql.Type
+		value        interface{}
+		expectedType sql.Type
+		expected     interface{}
+	}{
+		{"int", sql.Int32, int32(-3), sql.Int64, int64(3)},
+		{"unsigned", sql.Uint8, uint8(3), sql.Uint64, uint64(3)},
+		{"decimal", dec, decimal.RequireFromString("-1.50"), dec, decimal.RequireFromString("1.50")},
+		{"float", sql.Float32, float32(-1.5), sql.Float64, float64(1.5)},
+		{"text", sql.Text, "-2.5", sql.Float64, float64(2.5)},
+		{"null", sql.Int64, nil, sql.Int64, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewAbs(expression.NewLiteral(tt.value, tt.typ))
+			require.Equal(t, tt.expectedType, f.Type())
+			requireEqualValue(t, tt.expected, eval(t, f, nil))
+		})
+	}
+
+	_, err := NewAbs(expression.NewLiteral(int64(-1<<63), sql.Int64)).Eval(sql.NewEmptyContext(), nil)
+	require.True(t, sql.ErrValueOutOfRange.Is(err))
+}
+
+func TestSign(t *testing.T) {
+	testCases := []struct {
+		typ      sql.Type
+		value    interface{}
+		expected interface{}
+	}{
+		{sql.Int64, int64(-3), int32(-1)},
+		{sql.Uint64, uint64(0), int32(0)},
+		{sql.MustDecimal(5, 2), decimal.RequireFromString("0.01"), int32(1)},
+		{sql.Float64, -0.5, int32(-1)},
+		{sql.Int64, nil, nil},
+	}
+
+	for _, tt := range testCases {
+		f := NewSign(expression.NewLiteral(tt.value, tt.typ))
+		requireEqualValue(t, tt.expected, eval(t, f, nil))
+	}
+}
@@ -0,0 +1,120 @@
+package function
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/shopspring/decimal"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// Mod returns the remainder of dividing a number by another one, which has
+// the sign of the dividend. It is NULL if the divisor is zero.
+type Mod struct {
+	expression.BinaryExpression
+}
+
+// NewMod creates a new Mod function.
+func NewMod(dividend, divisor sql.Expression) sql.Expression {
+	return &Mod{expression.BinaryExpression{Left: dividend, Right: divisor}}
+}
+
+// Type implements the Expression interface. It is an integer if both
+// arguments are integers, a fixed-point decimal if they are integers or
+// fixed-point decimals and a DOUBLE otherwise.
+func (f *Mod) Type() sql.Type {
+	l, r := f.Left.Type(), f.Right.Type()
+	switch {
+	case sql.IsUnsigned(l) && sql.IsUnsigned(r):
+		return sql.Uint64
+	case sql.IsInteger(l) && sql.IsInteger(r):
+		return sql.Int64
+	case (sql.IsInteger(l) || sql.IsFixedPoint(l)) && (sql.IsInteger(r) || sql.IsFixedPoint(r)):
+		p1, s1 := sql.NumericPrecision(l)
+		p2, s2 := sql.NumericPrecision(r)
+		scale := s1
+		if s2 > scale {
+			scale = s2
+		}
+
+		intDigits := p1 - s1
+		if p2-s2 > intDigits {
+			intDigits = p2 - s2
+		}
+
+		if intDigits+scale > sql.DecimalMaxPrecision {
+			intDigits = sql.DecimalMaxPrecision - scale
+		}
+
+		return sql.MustDecimal(intDigits+scale, scale)
+	default:
+		return sql.Float64
+	}
+}
+
+// IsNullable implements the Expression interface.
+func (f *Mod) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *Mod) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Mod")
+	defer span.Finish()
+
+	l, err := f.Left.Eval(ctx, row)
+	if err != nil || l == nil {
+		return nil, err
+	}
+
+	r, err := f.Right.Eval(ctx, row)
+	if err != nil || r == nil {
+		return nil, err
+	}
+
+	typ := f.Type()
+	l, err = typ.Convert(l)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err = typ.Convert(r)
+	if err != nil {
+		return nil, err
+	}
+
+	switch l := l.(type) {
+	case int64:
+		if r.(int64) == 0 {
+			return nil, nil
+		}
+		return l % r.(int64), nil
+	case uint64:
+		if r.(uint64) == 0 {
+			return nil, nil
+		}
+		return l % r.(uint64), nil
+	case decimal.Decimal:
+		if r.(decimal.Decimal).Sign() == 0 {
+			return nil, nil
+		}
+		return typ.Convert(l.Mod(r.(decimal.Decimal)))
+	default:
+		if r.(float64) == 0 {
+			return nil, nil
+		}
+		return math.Mod(l.(float64), r.(float64)), nil
+	}
+}
+
+// TransformUp implements the Expression interface.
+func (f *Mod) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.Left, f.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewMod(args[0], args[1]))
+}
+
+func (f *Mod) String() string { return fmt.Sprintf("mod(%s, %s)", f.Left, f.Right) }
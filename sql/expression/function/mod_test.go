@@ -0,0 +1,38 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestMod(t *testing.T) {
+	testCases := []struct {
+		name         string
+		ltyp, rtyp   sql.Type
+		l, r         interface{}
+		expectedType sql.Type
+		expected     interface{}
+	}{
+		{"ints", sql.Int64, sql.Int32, int64(-7), int32(3), sql.Int64, int64(-1)},
+		{"unsigned", sql.Uint64, sql.Uint8, uint64(7), uint8(3), sql.Uint64, uint64(1)},
+		{
+			"decimal", sql.MustDecimal(4, 2), sql.Int64, decimal.RequireFromString("7.50"), int64(2),
+			sql.MustDecimal(22, 2), decimal.RequireFromString("1.5"),
+		},
+		{"float", sql.Float64, sql.Int64, -7.5, int64(2), sql.Float64, -1.5},
+		{"zero divisor", sql.Int64, sql.Int64, int64(7), int64(0), sql.Int64, nil},
+		{"null", sql.Int64, sql.Int64, nil, int64(2), sql.Int64, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewMod(expression.NewLiteral(tt.l, tt.ltyp), expression.NewLiteral(tt.r, tt.rtyp))
+			require.Equal(t, tt.expectedType, f.Type())
+			requireEqualValue(t, tt.expected, eval(t, f, nil))
+		})
+	}
+}
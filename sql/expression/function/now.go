@@ -0,0 +1,141 @@
+package function
+
+import (
+	"fmt"
+	"time"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// ErrInvalidPrecisionArgument is returned when the fractional seconds
+// precision given to a function is not a constant integer.
+var ErrInvalidPrecisionArgument = errors.NewKind("the precision of %s must be a constant integer, got %s")
+
+// Now returns the time at which the query started, which is the same for
+// all the rows of the query. It can be given a fractional seconds
+// precision.
+type Now struct {
+	name      string
+	precision sql.Expression
+	typ       sql.Type
+}
+
+// NewNow returns a function that creates a new Now function with the given
+// name, such as NOW or CURRENT_TIMESTAMP.
+func NewNow(name string) sql.FunctionN {
+	return func(args ...sql.Expression) (sql.Expression, error) {
+		switch len(args) {
+		case 0:
+			return &Now{name, nil, sql.Datetime}, nil
+		case 1:
+			lit, ok := args[0].(*expression.Literal)
+			if !ok {
+				return nil, ErrInvalidPrecisionArgument.New(name, args[0])
+			}
+
+			v, err := lit.Eval(nil, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			precision, err := toInt64(v)
+			if err != nil || precision < 0 || precision > sql.FractionalMaxPrecision {
+				return nil, sql.ErrInvalidFractionalPrecision.New(v, sql.FractionalMaxPrecision)
+			}
+
+			typ, err := sql.DatetimeWithPrecision(uint8(precision))
+			if err != nil {
+				return nil, err
+			}
+
+			return &Now{name, lit, typ}, nil
+		default:
+			return nil, sql.ErrInvalidArgumentNumber.New("0 or 1", len(args))
+		}
+	}
+}
+
+// Type implements the Expression interface.
+func (f *Now) Type() sql.Type { return f.typ }
+
+// IsNullable implements the Expression interface.
+func (f *Now) IsNullable() bool { return false }
+
+// IsNonDeterministic implements the NonDeterministicExpression interface.
+func (f *Now) IsNonDeterministic() bool { return true }
+
+// Resolved implements the Expression interface.
+func (f *Now) Resolved() bool { return true }
+
+// Children implements the Expression interface.
+func (f *Now) Children() []sql.Expression {
+	if f.precision == nil {
+		return nil
+	}
+	return []sql.Expression{f.precision}
+}
+
+// Eval implements the Expression interface.
+func (f *Now) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return f.typ.Convert(ctx.QueryTime())
+}
+
+// TransformUp implements the Expression interface.
+func (f *Now) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	n := *f
+	return fn(&n)
+}
+
+func (f *Now) String() string {
+	if f.precision == nil {
+		return fmt.Sprintf("%s()", f.name)
+	}
+	return fmt.Sprintf("%s(%s)", f.name, f.precision)
+}
+
+// CurDate returns the date at which the query started.
+type CurDate struct {
+	name string
+}
+
+// NewCurDate returns a function that creates a new CurDate function with the
+// given name, such as CURDATE or CURRENT_DATE.
+func NewCurDate(name string) sql.FunctionN {
+	return func(args ...sql.Expression) (sql.Expression, error) {
+		if len(args) != 0 {
+			return nil, sql.ErrInvalidArgumentNumber.New(0, len(args))
+		}
+		return &CurDate{name}, nil
+	}
+}
+
+// Type implements the Expression interface.
+func (f *CurDate) Type() sql.Type { return sql.Date }
+
+// IsNullable implements the Expression interface.
+func (f *CurDate) IsNullable() bool { return false }
+
+// IsNonDeterministic implements the NonDeterministicExpression interface.
+func (f *CurDate) IsNonDeterministic() bool { return true }
+
+// Resolved implements the Expression interface.
+func (f *CurDate) Resolved() bool { return true }
+
+// Children implements the Expression interface.
+func (f *CurDate) Children() []sql.Expression { return nil }
+
+// Eval implements the Expression interface.
+func (f *CurDate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	t := ctx.QueryTime().UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *CurDate) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	return fn(&CurDate{f.name})
+}
+
+func (f *CurDate) String() string { return fmt.Sprintf("%s()", f.name) }
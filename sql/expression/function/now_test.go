@@ -0,0 +1,70 @@
+package function
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+var queryTime = time.Date(2018, time.March, 4, 15, 16, 17, 123456789, time.UTC)
+
+func TestNow(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewContext(context.TODO(), sql.WithQueryTime(queryTime))
+
+	now, err := NewNow("now")()
+	require.NoError(err)
+	require.Equal(sql.Datetime, now.Type())
+	require.Equal("now()", now.String())
+
+	v, err := now.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(time.Date(2018, time.March, 4, 15, 16, 17, 0, time.UTC), v)
+
+	now, err = NewNow("now")(expression.NewLiteral(int64(3), sql.Int64))
+	require.NoError(err)
+
+	v, err = now.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(time.Date(2018, time.March, 4, 15, 16, 17, 123000000, time.UTC), v)
+
+	_, err = NewNow("now")(expression.NewLiteral(int64(7), sql.Int64))
+	require.True(sql.ErrInvalidFractionalPrecision.Is(err))
+
+	_, err = NewNow("now")(expression.NewGetField(0, sql.Int64, "p", false))
+	require.True(ErrInvalidPrecisionArgument.Is(err))
+}
+
+func TestNowIsStablePerQuery(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	now, err := NewNow("now")()
+	require.NoError(err)
+
+	first, err := now.Eval(ctx, nil)
+	require.NoError(err)
+
+	time.Sleep(time.Second)
+
+	second, err := now.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(first, second)
+}
+
+func TestCurDate(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewContext(context.TODO(), sql.WithQueryTime(queryTime))
+
+	f, err := NewCurDate("curdate")()
+	require.NoError(err)
+	require.Equal(sql.Date, f.Type())
+
+	v, err := f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(time.Date(2018, time.March, 4, 0, 0, 0, 0, time.UTC), v)
+}
@@ -0,0 +1,124 @@
+package function
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// LPad returns the given string left-padded with another one up to a number
+// of characters, or truncated to that number if it is longer.
+type LPad struct {
+	nullPropagating
+}
+
+// NewLPad creates a new LPad function.
+func NewLPad(str, length, pad sql.Expression) sql.Expression {
+	return &LPad{newNullPropagating("lpad", str, length, pad)}
+}
+
+// Type implements the Expression interface.
+func (f *LPad) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (f *LPad) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.LPad")
+	defer span.Finish()
+
+	return evalPad(ctx, row, f.nullPropagating, true)
+}
+
+// TransformUp implements the Expression interface.
+func (f *LPad) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewLPad(args[0], args[1], args[2]))
+}
+
+// RPad returns the given string right-padded with another one up to a
+// number of characters, or truncated to that number if it is longer.
+type RPad struct {
+	nullPropagating
+}
+
+// NewRPad creates a new RPad function.
+func NewRPad(str, length, pad sql.Expression) sql.Expression {
+	return &RPad{newNullPropagating("rpad", str, length, pad)}
+}
+
+// Type implements the Expression interface.
+func (f *RPad) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (f *RPad) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.RPad")
+	defer span.Finish()
+
+	return evalPad(ctx, row, f.nullPropagating, false)
+}
+
+// TransformUp implements the Expression interface.
+func (f *RPad) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewRPad(args[0], args[1], args[2]))
+}
+
+// evalPad evaluates the arguments of LPAD or RPAD and pads the string.
+// The result is NULL if the length is negative or if padding is needed and
+// the padding string is empty.
+func evalPad(ctx *sql.Context, row sql.Row, f nullPropagating, left bool) (interface{}, error) {
+	args, err := f.evalArgs(ctx, row)
+	if err != nil || args == nil {
+		return nil, err
+	}
+
+	str, err := toText(f.args[0], args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	length, err := toInt64(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	pad, err := toText(f.args[2], args[2])
+	if err != nil {
+		return nil, err
+	}
+
+	if length < 0 || length > maxStringLength/utf8.UTFMax {
+		return nil, nil
+	}
+
+	runes := []rune(str)
+	if int64(len(runes)) >= length {
+		return string(runes[:length]), nil
+	}
+
+	if pad == "" {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	if !left {
+		buf.WriteString(str)
+	}
+
+	padRunes := []rune(pad)
+	for i := int64(0); i < length-int64(len(runes)); i++ {
+		buf.WriteRune(padRunes[i%int64(len(padRunes))])
+	}
+
+	if left {
+		buf.WriteString(str)
+	}
+
+	return buf.String(), nil
+}
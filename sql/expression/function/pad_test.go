@@ -0,0 +1,40 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestPad(t *testing.T) {
+	args := []sql.Expression{
+		expression.NewGetField(0, sql.Text, "s", true),
+		expression.NewGetField(1, sql.Int64, "len", true),
+		expression.NewGetField(2, sql.Text, "pad", true),
+	}
+	lpad := NewLPad(args[0], args[1], args[2])
+	rpad := NewRPad(args[0], args[1], args[2])
+
+	testCases := []struct {
+		name string
+		row  sql.Row
+		lpad interface{}
+		rpad interface{}
+	}{
+		{"padded", sql.NewRow("hi", int64(5), "ñ?"), "ñ?ñhi", "hiñ?ñ"},
+		{"truncated", sql.NewRow("héllo", int64(2), "?"), "hé", "hé"},
+		{"same length", sql.NewRow("hi", int64(2), ""), "hi", "hi"},
+		{"empty pad", sql.NewRow("hi", int64(3), ""), nil, nil},
+		{"negative length", sql.NewRow("hi", int64(-1), "?"), nil, nil},
+		{"null", sql.NewRow("hi", nil, "?"), nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.lpad, eval(t, lpad, tt.row))
+			require.Equal(t, tt.rpad, eval(t, rpad, tt.row))
+		})
+	}
+}
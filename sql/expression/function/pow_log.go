@@ -0,0 +1,234 @@
+package function
+
+import (
+	"math"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// Pow returns a number raised to the power of another one. It is NULL if
+// zero is raised to a negative power.
+type Pow struct {
+	nullPropagating
+}
+
+// NewPow creates a new Pow function.
+func NewPow(x, y sql.Expression) sql.Expression {
+	return &Pow{newNullPropagating("pow", x, y)}
+}
+
+// Type implements the Expression interface.
+func (f *Pow) Type() sql.Type { return sql.Float64 }
+
+// IsNullable implements the Expression interface.
+func (f *Pow) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *Pow) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Pow")
+	defer span.Finish()
+
+	return evalFloats(ctx, row, f.nullPropagating, func(x ...float64) (float64, bool) {
+		if x[0] == 0 && x[1] < 0 {
+			return 0, false
+		}
+
+		return math.Pow(x[0], x[1]), true
+	})
+}
+
+// TransformUp implements the Expression interface.
+func (f *Pow) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewPow(args[0], args[1]))
+}
+
+// Sqrt returns the square root of a number, or NULL if it is negative.
+type Sqrt struct {
+	nullPropagating
+}
+
+// NewSqrt creates a new Sqrt function.
+func NewSqrt(x sql.Expression) sql.Expression {
+	return &Sqrt{newNullPropagating("sqrt", x)}
+}
+
+// Type implements the Expression interface.
+func (f *Sqrt) Type() sql.Type { return sql.Float64 }
+
+// IsNullable implements the Expression interface.
+func (f *Sqrt) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *Sqrt) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Sqrt")
+	defer span.Finish()
+
+	return evalFloats(ctx, row, f.nullPropagating, func(x ...float64) (float64, bool) {
+		return math.Sqrt(x[0]), x[0] >= 0
+	})
+}
+
+// TransformUp implements the Expression interface.
+func (f *Sqrt) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewSqrt(args[0]))
+}
+
+// Exp returns e raised to the power of a number.
+type Exp struct {
+	nullPropagating
+}
+
+// NewExp creates a new Exp function.
+func NewExp(x sql.Expression) sql.Expression {
+	return &Exp{newNullPropagating("exp", x)}
+}
+
+// Type implements the Expression interface.
+func (f *Exp) Type() sql.Type { return sql.Float64 }
+
+// Eval implements the Expression interface.
+func (f *Exp) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Exp")
+	defer span.Finish()
+
+	return evalFloats(ctx, row, f.nullPropagating, func(x ...float64) (float64, bool) {
+		return math.Exp(x[0]), true
+	})
+}
+
+// TransformUp implements the Expression interface.
+func (f *Exp) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewExp(args[0]))
+}
+
+// Log returns the logarithm of a number. With a single argument it is the
+// natural logarithm, and with two arguments the first one is the base. It
+// is NULL if the number is not positive or the base is not valid.
+type Log struct {
+	nullPropagating
+}
+
+// NewLog creates a new Log function.
+func NewLog(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("1 or 2", len(args))
+	}
+
+	return &Log{newNullPropagating("log", args...)}, nil
+}
+
+// NewLn creates a new Log function that returns the natural logarithm of a
+// number.
+func NewLn(x sql.Expression) sql.Expression {
+	return &Log{newNullPropagating("ln", x)}
+}
+
+// NewLog2 creates a new Log function that returns the base-2 logarithm of a
+// number.
+func NewLog2(x sql.Expression) sql.Expression {
+	return &Log{newNullPropagating("log2", x)}
+}
+
+// NewLog10 creates a new Log function that returns the base-10 logarithm of
+// a number.
+func NewLog10(x sql.Expression) sql.Expression {
+	return &Log{newNullPropagating("log10", x)}
+}
+
+// Type implements the Expression interface.
+func (f *Log) Type() sql.Type { return sql.Float64 }
+
+// IsNullable implements the Expression interface.
+func (f *Log) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *Log) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Log")
+	defer span.Finish()
+
+	return evalFloats(ctx, row, f.nullPropagating, func(x ...float64) (float64, bool) {
+		var base float64
+		switch {
+		case len(x) == 2:
+			base, x = x[0], x[1:]
+			if base <= 0 || base == 1 {
+				return 0, false
+			}
+		case f.name == "log2":
+			base = 2
+		case f.name == "log10":
+			base = 10
+		}
+
+		if x[0] <= 0 {
+			return 0, false
+		}
+
+		switch base {
+		case 0:
+			return math.Log(x[0]), true
+		case 2:
+			return math.Log2(x[0]), true
+		case 10:
+			return math.Log10(x[0]), true
+		default:
+			return math.Log(x[0]) / math.Log(base), true
+		}
+	})
+}
+
+// TransformUp implements the Expression interface.
+func (f *Log) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(&Log{newNullPropagating(f.name, args...)})
+}
+
+// evalFloats evaluates the arguments of f as floating point numbers and
+// returns the result of applying fn to them, or NULL if fn returns false.
+// Results that can't be represented are an error.
+func evalFloats(
+	ctx *sql.Context,
+	row sql.Row,
+	f nullPropagating,
+	fn func(...float64) (float64, bool),
+) (interface{}, error) {
+	values, err := f.evalArgs(ctx, row)
+	if err != nil || values == nil {
+		return nil, err
+	}
+
+	var args = make([]float64, len(values))
+	for i, v := range values {
+		n, err := sql.Float64.Convert(v)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = n.(float64)
+	}
+
+	result, ok := fn(args...)
+	if !ok || math.IsNaN(result) {
+		return nil, nil
+	}
+
+	if math.IsInf(result, 0) {
+		return nil, sql.ErrValueOutOfRange.New(f, "DOUBLE")
+	}
+
+	return result, nil
+}
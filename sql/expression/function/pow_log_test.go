@@ -0,0 +1,55 @@
+package function
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestPowLog(t *testing.T) {
+	lit := func(v interface{}) sql.Expression {
+		return expression.NewLiteral(v, sql.Float64)
+	}
+
+	log, err := NewLog(lit(2.0), lit(8.0))
+	require.NoError(t, err)
+
+	naturalLog, err := NewLog(lit(math.E))
+	require.NoError(t, err)
+
+	invalidBase, err := NewLog(lit(1.0), lit(8.0))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		f        sql.Expression
+		expected interface{}
+	}{
+		{NewPow(lit(2.0), lit(10.0)), float64(1024)},
+		{NewPow(lit(4.0), lit(-0.5)), float64(0.5)},
+		{NewPow(lit(0.0), lit(-1.0)), nil},
+		{NewPow(lit(-8.0), lit(1.0/3)), nil},
+		{NewSqrt(lit(16.0)), float64(4)},
+		{NewSqrt(lit(-1.0)), nil},
+		{NewExp(lit(0.0)), float64(1)},
+		{NewLn(lit(1.0)), float64(0)},
+		{NewLn(lit(0.0)), nil},
+		{naturalLog, float64(1)},
+		{log, float64(3)},
+		{invalidBase, nil},
+		{NewLog2(lit(1024.0)), float64(10)},
+		{NewLog10(lit(0.001)), float64(-3)},
+		{NewLog10(lit(nil)), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.f.String(), func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, tt.f, nil))
+		})
+	}
+
+	_, err = NewPow(lit(10.0), lit(1000.0)).Eval(sql.NewEmptyContext(), nil)
+	require.True(t, sql.ErrValueOutOfRange.Is(err))
+}
@@ -0,0 +1,130 @@
+package function
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// Rand returns a random floating point number v such that 0 <= v < 1. It can
+// be given a seed: if it is a constant, the numbers of all the rows are a
+// repeatable sequence, and otherwise each row gets the first number of the
+// sequence of its seed. The sequence of a constant seed starts again in every
+// execution of the query.
+type Rand struct {
+	seed     sql.Expression
+	constant bool
+}
+
+// seededRand is the sequence of random numbers of a constant seed during the
+// execution of a query.
+type seededRand struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewRand creates a new Rand function.
+func NewRand(args ...sql.Expression) (sql.Expression, error) {
+	switch len(args) {
+	case 0:
+		return &Rand{}, nil
+	case 1:
+		f := &Rand{seed: args[0]}
+		if lit, ok := args[0].(*expression.Literal); ok {
+			if _, err := randSeed(lit, nil, nil); err != nil {
+				return nil, err
+			}
+			f.constant = true
+		}
+		return f, nil
+	default:
+		return nil, sql.ErrInvalidArgumentNumber.New("0 or 1", len(args))
+	}
+}
+
+// Type implements the Expression interface.
+func (f *Rand) Type() sql.Type { return sql.Float64 }
+
+// IsNullable implements the Expression interface.
+func (f *Rand) IsNullable() bool { return false }
+
+// IsNonDeterministic implements the NonDeterministicExpression interface.
+func (f *Rand) IsNonDeterministic() bool { return true }
+
+// Resolved implements the Expression interface.
+func (f *Rand) Resolved() bool {
+	return f.seed == nil || f.seed.Resolved()
+}
+
+// Children implements the Expression interface.
+func (f *Rand) Children() []sql.Expression {
+	if f.seed == nil {
+		return nil
+	}
+	return []sql.Expression{f.seed}
+}
+
+// Eval implements the Expression interface.
+func (f *Rand) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Rand")
+	defer span.Finish()
+
+	switch {
+	case f.seed == nil:
+		return rand.Float64(), nil
+	case f.constant:
+		r := ctx.QueryValue(f, func() interface{} {
+			// The seed was already checked when the function was created.
+			seed, _ := randSeed(f.seed, nil, nil)
+			return &seededRand{rnd: rand.New(rand.NewSource(seed))}
+		}).(*seededRand)
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return r.rnd.Float64(), nil
+	default:
+		seed, err := randSeed(f.seed, ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		return rand.New(rand.NewSource(seed)).Float64(), nil
+	}
+}
+
+// randSeed evaluates the seed of RAND. A NULL seed is the same as zero.
+func randSeed(e sql.Expression, ctx *sql.Context, row sql.Row) (int64, error) {
+	v, err := e.Eval(ctx, row)
+	if err != nil || v == nil {
+		return 0, err
+	}
+	return toInt64(v)
+}
+
+// TransformUp implements the Expression interface.
+func (f *Rand) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	if f.seed == nil {
+		return fn(&Rand{})
+	}
+
+	seed, err := f.seed.TransformUp(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := NewRand(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(r)
+}
+
+func (f *Rand) String() string {
+	if f.seed == nil {
+		return "rand()"
+	}
+	return fmt.Sprintf("rand(%s)", f.seed)
+}
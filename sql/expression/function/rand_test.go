@@ -0,0 +1,45 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestRand(t *testing.T) {
+	require := require.New(t)
+
+	f, err := NewRand()
+	require.NoError(err)
+	for i := 0; i < 10; i++ {
+		v := eval(t, f, nil).(float64)
+		require.True(v >= 0 && v < 1)
+	}
+
+	seeded, err := NewRand(expression.NewLiteral(int64(3), sql.Int64))
+	require.NoError(err)
+
+	// Every execution of the same expression gets the same sequence.
+	sequence := func() []interface{} {
+		ctx := sql.NewEmptyContext()
+
+		var values []interface{}
+		for i := 0; i < 3; i++ {
+			v, err := seeded.Eval(ctx, nil)
+			require.NoError(err)
+			values = append(values, v)
+		}
+		return values
+	}
+
+	first := sequence()
+	require.Equal(first, sequence())
+	require.NotEqual(first[0], first[1])
+
+	f, err = NewRand(expression.NewGetField(0, sql.Int64, "seed", true))
+	require.NoError(err)
+	require.Equal(first[0], eval(t, f, sql.NewRow(int64(3))))
+	require.Equal(first[0], eval(t, f, sql.NewRow(int64(3))))
+}
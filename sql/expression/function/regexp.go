@@ -0,0 +1,282 @@
+package function
+
+import (
+	"bytes"
+	"regexp"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+var (
+	// ErrInvalidMatchType is returned when the match type of a regular
+	// expression function has an unknown flag.
+	ErrInvalidMatchType = errors.NewKind("invalid match type for regular expression: %q")
+
+	// ErrRegexpPositionOutOfRange is returned when the position at which a
+	// regular expression function starts to search is outside the string.
+	ErrRegexpPositionOutOfRange = errors.NewKind("position %d is out of range in regular expression search")
+)
+
+// RegexpReplace returns a string with the occurrences of a regular
+// expression replaced by another string, in which $N is replaced by the
+// text of the Nth group of the match. Optionally, it can start to search at
+// a given position, replace only the Nth occurrence and take a match type.
+type RegexpReplace struct {
+	nullPropagating
+}
+
+// NewRegexpReplace creates a new RegexpReplace UDF.
+func NewRegexpReplace(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 3 || len(args) > 6 {
+		return nil, sql.ErrInvalidArgumentNumber.New("3 to 6", len(args))
+	}
+
+	return &RegexpReplace{newNullPropagating("regexp_replace", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *RegexpReplace) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (f *RegexpReplace) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.RegexpReplace")
+	defer span.Finish()
+
+	args, err := f.evalArgs(ctx, row)
+	if err != nil || args == nil {
+		return nil, err
+	}
+
+	strs, err := toTexts(f.args[:3], args[:3])
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := newRegexpSearch(f.args, args, 3, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, text, err := search.split(strs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	re, err := search.compile(strs[1])
+	if err != nil {
+		return nil, err
+	}
+
+	repl := strs[2]
+	if search.occurrence == 0 {
+		return prefix + re.ReplaceAllString(text, repl), nil
+	}
+
+	matches := re.FindAllStringSubmatchIndex(text, int(search.occurrence))
+	if int64(len(matches)) < search.occurrence {
+		return prefix + text, nil
+	}
+
+	m := matches[search.occurrence-1]
+	var buf bytes.Buffer
+	buf.WriteString(prefix)
+	buf.WriteString(text[:m[0]])
+	buf.Write(re.ExpandString(nil, repl, text, m))
+	buf.WriteString(text[m[1]:])
+	return buf.String(), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *RegexpReplace) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := NewRegexpReplace(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(e)
+}
+
+// RegexpSubstr returns the part of a string that matches a regular
+// expression, or NULL if there is no match. Optionally, it can start to
+// search at a given position, return the Nth match and take a match type.
+type RegexpSubstr struct {
+	nullPropagating
+}
+
+// NewRegexpSubstr creates a new RegexpSubstr UDF.
+func NewRegexpSubstr(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) < 2 || len(args) > 5 {
+		return nil, sql.ErrInvalidArgumentNumber.New("2 to 5", len(args))
+	}
+
+	return &RegexpSubstr{newNullPropagating("regexp_substr", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *RegexpSubstr) Type() sql.Type { return sql.Text }
+
+// IsNullable implements the Expression interface.
+func (f *RegexpSubstr) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *RegexpSubstr) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.RegexpSubstr")
+	defer span.Finish()
+
+	args, err := f.evalArgs(ctx, row)
+	if err != nil || args == nil {
+		return nil, err
+	}
+
+	strs, err := toTexts(f.args[:2], args[:2])
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := newRegexpSearch(f.args, args, 2, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if search.occurrence < 1 {
+		search.occurrence = 1
+	}
+
+	_, text, err := search.split(strs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	re, err := search.compile(strs[1])
+	if err != nil {
+		return nil, err
+	}
+
+	matches := re.FindAllStringIndex(text, int(search.occurrence))
+	if int64(len(matches)) < search.occurrence {
+		return nil, nil
+	}
+
+	m := matches[search.occurrence-1]
+	return text[m[0]:m[1]], nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *RegexpSubstr) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := NewRegexpSubstr(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(e)
+}
+
+// regexpSearch holds the optional position, occurrence and match type
+// arguments of the regular expression functions.
+type regexpSearch struct {
+	pos        int64
+	occurrence int64
+	ci         bool
+	multiline  bool
+	dotAll     bool
+}
+
+// newRegexpSearch reads the optional arguments of a regular expression
+// function, which start at the index first of the given arguments. The
+// expression and the pattern are always the first two arguments.
+func newRegexpSearch(
+	exprs []sql.Expression,
+	args []interface{},
+	first int,
+	occurrence int64,
+) (*regexpSearch, error) {
+	s := &regexpSearch{
+		pos:        1,
+		occurrence: occurrence,
+		ci:         caseInsensitive(exprs[:2]...),
+	}
+
+	var err error
+	if len(args) > first {
+		if s.pos, err = toInt64(args[first]); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(args) > first+1 {
+		if s.occurrence, err = toInt64(args[first+1]); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(args) > first+2 {
+		matchType, err := toText(exprs[first+2], args[first+2])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range matchType {
+			switch c {
+			case 'c':
+				s.ci = false
+			case 'i':
+				s.ci = true
+			case 'm':
+				s.multiline = true
+			case 'n':
+				s.dotAll = true
+			case 'u':
+				// Only \n is recognized as line terminator anyway.
+			default:
+				return nil, ErrInvalidMatchType.New(matchType)
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// split splits the given string into the part before the position at which
+// the search starts and the part that is searched.
+func (s *regexpSearch) split(str string) (string, string, error) {
+	runes := []rune(str)
+	if s.pos < 1 || s.pos > int64(len(runes))+1 {
+		return "", "", ErrRegexpPositionOutOfRange.New(s.pos)
+	}
+
+	return string(runes[:s.pos-1]), string(runes[s.pos-1:]), nil
+}
+
+// compile compiles the given pattern with the flags of the match type.
+func (s *regexpSearch) compile(pattern string) (*regexp.Regexp, error) {
+	var flags string
+	if s.ci {
+		flags += "i"
+	}
+
+	if s.multiline {
+		flags += "m"
+	}
+
+	if s.dotAll {
+		flags += "s"
+	}
+
+	if flags != "" {
+		pattern = "(?" + flags + ")" + pattern
+	}
+
+	return regexp.Compile(pattern)
+}
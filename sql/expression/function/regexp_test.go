@@ -0,0 +1,88 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func literals(values ...interface{}) []sql.Expression {
+	var exprs = make([]sql.Expression, len(values))
+	for i, v := range values {
+		switch v.(type) {
+		case int64:
+			exprs[i] = expression.NewLiteral(v, sql.Int64)
+		case nil:
+			exprs[i] = expression.NewLiteral(v, sql.Null)
+		default:
+			exprs[i] = expression.NewLiteral(v, sql.Text)
+		}
+	}
+	return exprs
+}
+
+func TestRegexpReplace(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []interface{}
+		expected interface{}
+		err      bool
+	}{
+		{"all", []interface{}{"a b c", "[a-c]", "x"}, "x x x", false},
+		{"groups", []interface{}{"john smith", `(\w+) (\w+)`, "${2}, $1"}, "smith, john", false},
+		{"case-insensitive", []interface{}{"aAa", "a", "b"}, "bbb", false},
+		{"case-sensitive", []interface{}{"aAa", "a", "b", int64(1), int64(0), "c"}, "bAb", false},
+		{"position", []interface{}{"ñaña", "a", "o", int64(3)}, "ñaño", false},
+		{"occurrence", []interface{}{"a a a", "a", "b", int64(1), int64(2)}, "a b a", false},
+		{"missing occurrence", []interface{}{"a a a", "a", "b", int64(1), int64(4)}, "a a a", false},
+		{"null", []interface{}{"a", nil, "b"}, nil, false},
+		{"invalid position", []interface{}{"a", "a", "b", int64(3)}, nil, true},
+		{"invalid match type", []interface{}{"a", "a", "b", int64(1), int64(0), "x"}, nil, true},
+		{"invalid pattern", []interface{}{"a", "(", "b"}, nil, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			f, err := NewRegexpReplace(literals(tt.args...)...)
+			require.NoError(err)
+
+			v, err := f.Eval(sql.NewEmptyContext(), nil)
+			if tt.err {
+				require.Error(err)
+			} else {
+				require.NoError(err)
+				require.Equal(tt.expected, v)
+			}
+		})
+	}
+
+	_, err := NewRegexpReplace(literals("a", "b")...)
+	require.True(t, sql.ErrInvalidArgumentNumber.Is(err))
+}
+
+func TestRegexpSubstr(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []interface{}
+		expected interface{}
+	}{
+		{"first", []interface{}{"abc def ghi", "[a-z]+"}, "abc"},
+		{"position", []interface{}{"abc def ghi", "[a-z]+", int64(2)}, "bc"},
+		{"occurrence", []interface{}{"abc def ghi", "[a-z]+", int64(1), int64(3)}, "ghi"},
+		{"no match", []interface{}{"abc", "[0-9]+"}, nil},
+		{"multiline", []interface{}{"a\nb", "^b$", int64(1), int64(1), "m"}, "b"},
+		{"dot all", []interface{}{"a\nb", "a.b", int64(1), int64(1), "n"}, "a\nb"},
+		{"null", []interface{}{nil, "a"}, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewRegexpSubstr(literals(tt.args...)...)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, eval(t, f, nil))
+		})
+	}
+}
@@ -22,15 +22,154 @@ var Defaults = sql.Functions{
 	"sum": sql.Function1(func(e sql.Expression) sql.Expression {
 		return aggregation.NewSum(e)
 	}),
-	"is_binary":    sql.Function1(NewIsBinary),
-	"substring":    sql.FunctionN(NewSubstring),
-	"year":         sql.Function1(NewYear),
-	"month":        sql.Function1(NewMonth),
-	"day":          sql.Function1(NewDay),
-	"hour":         sql.Function1(NewHour),
-	"minute":       sql.Function1(NewMinute),
-	"second":       sql.Function1(NewSecond),
-	"dayofyear":    sql.Function1(NewDayOfYear),
-	"array_length": sql.Function1(NewArrayLength),
-	"split":        sql.Function2(NewSplit),
+	"std": sql.Function1(func(e sql.Expression) sql.Expression {
+		return aggregation.NewStdDevPop(e)
+	}),
+	"stddev": sql.Function1(func(e sql.Expression) sql.Expression {
+		return aggregation.NewStdDevPop(e)
+	}),
+	"stddev_pop": sql.Function1(func(e sql.Expression) sql.Expression {
+		return aggregation.NewStdDevPop(e)
+	}),
+	"stddev_samp": sql.Function1(func(e sql.Expression) sql.Expression {
+		return aggregation.NewStdDevSamp(e)
+	}),
+	"variance": sql.Function1(func(e sql.Expression) sql.Expression {
+		return aggregation.NewVarPop(e)
+	}),
+	"var_pop": sql.Function1(func(e sql.Expression) sql.Expression {
+		return aggregation.NewVarPop(e)
+	}),
+	"var_samp": sql.Function1(func(e sql.Expression) sql.Expression {
+		return aggregation.NewVarSamp(e)
+	}),
+	"bit_and": sql.Function1(func(e sql.Expression) sql.Expression {
+		return aggregation.NewBitAnd(e)
+	}),
+	"bit_or": sql.Function1(func(e sql.Expression) sql.Expression {
+		return aggregation.NewBitOr(e)
+	}),
+	"bit_xor": sql.Function1(func(e sql.Expression) sql.Expression {
+		return aggregation.NewBitXor(e)
+	}),
+	"any_value": sql.Function1(func(e sql.Expression) sql.Expression {
+		return aggregation.NewAnyValue(e)
+	}),
+	"approx_count_distinct": sql.Function1(func(e sql.Expression) sql.Expression {
+		return aggregation.NewApproxCountDistinct(e)
+	}),
+	"approx_percentile": sql.Function2(func(e, p sql.Expression) sql.Expression {
+		return aggregation.NewApproxPercentile(e, p)
+	}),
+	"approx_median": sql.Function1(func(e sql.Expression) sql.Expression {
+		return aggregation.NewApproxMedian(e)
+	}),
+	"json_arrayagg": sql.Function1(func(e sql.Expression) sql.Expression {
+		return aggregation.NewJSONArrayAgg(e)
+	}),
+	"json_objectagg": sql.Function2(func(k, v sql.Expression) sql.Expression {
+		return aggregation.NewJSONObjectAgg(k, v)
+	}),
+	"grouping": sql.FunctionN(func(args ...sql.Expression) (sql.Expression, error) {
+		return aggregation.NewGrouping(args...)
+	}),
+	"is_binary":         sql.Function1(NewIsBinary),
+	"substring":         sql.FunctionN(NewSubstring),
+	"year":              sql.Function1(NewYear),
+	"month":             sql.Function1(NewMonth),
+	"day":               sql.Function1(NewDay),
+	"hour":              sql.Function1(NewHour),
+	"minute":            sql.Function1(NewMinute),
+	"second":            sql.Function1(NewSecond),
+	"dayofyear":         sql.Function1(NewDayOfYear),
+	"array_length":      sql.Function1(NewArrayLength),
+	"split":             sql.Function2(NewSplit),
+	"if":                sql.Function3(NewIf),
+	"ifnull":            sql.Function2(NewIfNull),
+	"coalesce":          sql.FunctionN(NewCoalesce),
+	"nullif":            sql.Function2(NewNullIf),
+	"lower":             sql.Function1(NewLower),
+	"lcase":             sql.Function1(NewLower),
+	"upper":             sql.Function1(NewUpper),
+	"ucase":             sql.Function1(NewUpper),
+	"length":            sql.Function1(NewLength),
+	"octet_length":      sql.Function1(NewLength),
+	"char_length":       sql.Function1(NewCharLength),
+	"character_length":  sql.Function1(NewCharLength),
+	"trim":              sql.Function1(NewTrim),
+	"ltrim":             sql.Function1(NewLTrim),
+	"rtrim":             sql.Function1(NewRTrim),
+	"replace":           sql.Function3(NewReplace),
+	"reverse":           sql.Function1(NewReverse),
+	"lpad":              sql.Function3(NewLPad),
+	"rpad":              sql.Function3(NewRPad),
+	"instr":             sql.Function2(NewInstr),
+	"locate":            sql.FunctionN(NewLocate),
+	"left":              sql.Function2(NewLeft),
+	"right":             sql.Function2(NewRight),
+	"repeat":            sql.Function2(NewRepeat),
+	"concat":            sql.FunctionN(NewConcat),
+	"concat_ws":         sql.FunctionN(NewConcatWS),
+	"substring_index":   sql.Function3(NewSubstringIndex),
+	"format":            sql.FunctionN(NewFormat),
+	"regexp_replace":    sql.FunctionN(NewRegexpReplace),
+	"regexp_substr":     sql.FunctionN(NewRegexpSubstr),
+	"now":               sql.FunctionN(NewNow("now")),
+	"current_timestamp": sql.FunctionN(NewNow("current_timestamp")),
+	"localtime":         sql.FunctionN(NewNow("localtime")),
+	"localtimestamp":    sql.FunctionN(NewNow("localtimestamp")),
+	"utc_timestamp":     sql.FunctionN(NewNow("utc_timestamp")),
+	"curdate":           sql.FunctionN(NewCurDate("curdate")),
+	"current_date":      sql.FunctionN(NewCurDate("current_date")),
+	"date_add":          sql.FunctionN(NewDateAdd),
+	"date_sub":          sql.FunctionN(NewDateSub),
+	"datediff":          sql.Function2(NewDateDiff),
+	"timestampdiff":     sql.FunctionN(NewTimestampDiff),
+	"date_format":       sql.Function2(NewDateFormat),
+	"str_to_date":       sql.Function2(NewStrToDate),
+	"unix_timestamp":    sql.FunctionN(NewUnixTimestamp),
+	"from_unixtime":     sql.FunctionN(NewFromUnixtime),
+	"week":              sql.FunctionN(NewWeek),
+	"weekday":           sql.Function1(NewWeekDay),
+	"dayofweek":         sql.Function1(NewDayOfWeek),
+	"convert_tz":        sql.Function3(NewConvertTz),
+	"abs":               sql.Function1(NewAbs),
+	"sign":              sql.Function1(NewSign),
+	"ceil":              sql.Function1(NewCeil),
+	"ceiling":           sql.Function1(NewCeil),
+	"floor":             sql.Function1(NewFloor),
+	"round":             sql.FunctionN(NewRound),
+	"truncate":          sql.Function2(NewTruncate),
+	"mod":               sql.Function2(NewMod),
+	"pow":               sql.Function2(NewPow),
+	"power":             sql.Function2(NewPow),
+	"sqrt":              sql.Function1(NewSqrt),
+	"exp":               sql.Function1(NewExp),
+	"ln":                sql.Function1(NewLn),
+	"log":               sql.FunctionN(NewLog),
+	"log2":              sql.Function1(NewLog2),
+	"log10":             sql.Function1(NewLog10),
+	"greatest":          sql.FunctionN(NewGreatest),
+	"least":             sql.FunctionN(NewLeast),
+	"rand":              sql.FunctionN(NewRand),
+	"crc32":             sql.Function1(NewCRC32),
+	"conv":              sql.Function3(NewConv),
+	"bin":               sql.Function1(NewBin),
+	"oct":               sql.Function1(NewOct),
+	"hex":               sql.Function1(NewHex),
+	"json_extract":      sql.FunctionN(NewJSONExtract),
+	"json_unquote":      sql.Function1(NewJSONUnquote),
+	"json_contains":     sql.FunctionN(NewJSONContains),
+	"json_length":       sql.FunctionN(NewJSONLength),
+	"json_keys":         sql.FunctionN(NewJSONKeys),
+	"json_object":       sql.FunctionN(NewJSONObject),
+	"json_array":        sql.FunctionN(NewJSONArray),
+	"row_number":        sql.FunctionN(NewRowNumber),
+	"rank":              sql.FunctionN(NewRank),
+	"dense_rank":        sql.FunctionN(NewDenseRank),
+	"ntile":             sql.Function1(NewNtile),
+	"lag":               sql.FunctionN(NewLag),
+	"lead":              sql.FunctionN(NewLead),
+	"first_value":       sql.Function1(NewFirstValue),
+	"last_value":        sql.Function1(NewLastValue),
 }
@@ -0,0 +1,145 @@
+package function
+
+import (
+	"strings"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// Reverse returns the characters of the given string in reverse order.
+type Reverse struct {
+	nullPropagating
+}
+
+// NewReverse creates a new Reverse function.
+func NewReverse(str sql.Expression) sql.Expression {
+	return &Reverse{newNullPropagating("reverse", str)}
+}
+
+// Type implements the Expression interface.
+func (f *Reverse) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (f *Reverse) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Reverse")
+	defer span.Finish()
+
+	return evalText(ctx, row, f.args[0], reverseString)
+}
+
+func reverseString(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+// TransformUp implements the Expression interface.
+func (f *Reverse) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewReverse(args[0]))
+}
+
+// Repeat returns a string made of the given string repeated a number of
+// times.
+type Repeat struct {
+	nullPropagating
+}
+
+// NewRepeat creates a new Repeat function.
+func NewRepeat(str, count sql.Expression) sql.Expression {
+	return &Repeat{newNullPropagating("repeat", str, count)}
+}
+
+// Type implements the Expression interface.
+func (f *Repeat) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (f *Repeat) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Repeat")
+	defer span.Finish()
+
+	args, err := f.evalArgs(ctx, row)
+	if err != nil || args == nil {
+		return nil, err
+	}
+
+	str, err := toText(f.args[0], args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := toInt64(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if count <= 0 || str == "" {
+		return "", nil
+	}
+
+	if count > maxStringLength/int64(len(str)) {
+		return nil, nil
+	}
+
+	return strings.Repeat(str, int(count)), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *Repeat) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewRepeat(args[0], args[1]))
+}
+
+// Replace returns the given string with all the occurrences of a substring
+// replaced by another one. Substrings are matched case-sensitively.
+type Replace struct {
+	nullPropagating
+}
+
+// NewReplace creates a new Replace function.
+func NewReplace(str, from, to sql.Expression) sql.Expression {
+	return &Replace{newNullPropagating("replace", str, from, to)}
+}
+
+// Type implements the Expression interface.
+func (f *Replace) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (f *Replace) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Replace")
+	defer span.Finish()
+
+	args, err := f.evalArgs(ctx, row)
+	if err != nil || args == nil {
+		return nil, err
+	}
+
+	strs, err := toTexts(f.args, args)
+	if err != nil {
+		return nil, err
+	}
+
+	str, from, to := strs[0], strs[1], strs[2]
+	if from == "" {
+		return str, nil
+	}
+
+	return strings.Replace(str, from, to, -1), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *Replace) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewReplace(args[0], args[1], args[2]))
+}
@@ -0,0 +1,70 @@
+package function
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestReverse(t *testing.T) {
+	f := NewReverse(expression.NewGetField(0, sql.Text, "s", true))
+	require.Equal(t, "ñeña", eval(t, f, sql.NewRow("añeñ")))
+	require.Equal(t, "", eval(t, f, sql.NewRow("")))
+	require.Nil(t, eval(t, f, sql.NewRow(nil)))
+}
+
+func TestRepeat(t *testing.T) {
+	f := NewRepeat(
+		expression.NewGetField(0, sql.Text, "s", true),
+		expression.NewGetField(1, sql.Int64, "n", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"repeated", sql.NewRow("ab", int64(3)), "ababab"},
+		{"zero times", sql.NewRow("ab", int64(0)), ""},
+		{"negative times", sql.NewRow("ab", int64(-1)), ""},
+		{"too long", sql.NewRow("ab", int64(maxStringLength)), nil},
+		{"null string", sql.NewRow(nil, int64(1)), nil},
+		{"null count", sql.NewRow("ab", nil), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, f, tt.row))
+		})
+	}
+}
+
+func TestReplace(t *testing.T) {
+	f := NewReplace(
+		expression.NewGetField(0, sql.Text, "s", true),
+		expression.NewGetField(1, sql.Text, "from", true),
+		expression.NewGetField(2, sql.Text, "to", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"replaced", sql.NewRow("a-b-c", "-", "+"), "a+b+c"},
+		{"case-sensitive", sql.NewRow("aAa", "a", "b"), "bAb"},
+		{"empty from", sql.NewRow("abc", "", "x"), "abc"},
+		{"null", sql.NewRow("abc", "a", nil), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, f, tt.row))
+		})
+	}
+
+	require.True(t, strings.HasPrefix(f.String(), "replace("))
+}
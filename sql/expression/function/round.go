@@ -0,0 +1,244 @@
+package function
+
+import (
+	"math"
+
+	"github.com/shopspring/decimal"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// Round rounds a number to a number of digits after the decimal point,
+// which is zero by default and can be negative to round the integer part.
+// Exact numbers are rounded half away from zero and floating point numbers
+// to the nearest even.
+type Round struct {
+	nullPropagating
+}
+
+// NewRound creates a new Round function.
+func NewRound(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("1 or 2", len(args))
+	}
+
+	return &Round{newNullPropagating("round", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *Round) Type() sql.Type { return roundedType(f.args) }
+
+// Eval implements the Expression interface.
+func (f *Round) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Round")
+	defer span.Finish()
+
+	return evalRounded(ctx, row, f.nullPropagating, f.Type(), false)
+}
+
+// TransformUp implements the Expression interface.
+func (f *Round) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&Round{newNullPropagating(f.name, args...)})
+}
+
+// Truncate truncates a number to a number of digits after the decimal
+// point, which can be negative to truncate the integer part.
+type Truncate struct {
+	nullPropagating
+}
+
+// NewTruncate creates a new Truncate function.
+func NewTruncate(x, digits sql.Expression) sql.Expression {
+	return &Truncate{newNullPropagating("truncate", x, digits)}
+}
+
+// Type implements the Expression interface.
+func (f *Truncate) Type() sql.Type { return roundedType(f.args) }
+
+// Eval implements the Expression interface.
+func (f *Truncate) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Truncate")
+	defer span.Finish()
+
+	return evalRounded(ctx, row, f.nullPropagating, f.Type(), true)
+}
+
+// TransformUp implements the Expression interface.
+func (f *Truncate) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewTruncate(args[0], args[1]))
+}
+
+// roundedType returns the type of the result of rounding or truncating the
+// first of the given arguments to the number of digits given by the second
+// one, if any. Fixed-point decimals have as many fractional digits as the
+// number of digits if it is constant.
+func roundedType(args []sql.Expression) sql.Type {
+	t := args[0].Type()
+	if !sql.IsFixedPoint(t) {
+		return numberType(t)
+	}
+
+	precision, scale := sql.NumericPrecision(t)
+	intDigits := int64(precision-scale) + 1
+	digits := int64(scale)
+	if len(args) == 1 {
+		digits = 0
+	} else if lit, ok := args[1].(*expression.Literal); ok {
+		v, err := lit.Eval(nil, nil)
+		if err != nil || v == nil {
+			return t
+		}
+
+		if digits, err = toInt64(v); err != nil {
+			return t
+		}
+	}
+
+	if digits < 0 {
+		digits = 0
+	} else if digits > sql.DecimalMaxScale {
+		digits = sql.DecimalMaxScale
+	}
+
+	if intDigits+digits > sql.DecimalMaxPrecision {
+		intDigits = sql.DecimalMaxPrecision - digits
+	}
+
+	return sql.MustDecimal(uint8(intDigits+digits), uint8(digits))
+}
+
+func evalRounded(
+	ctx *sql.Context,
+	row sql.Row,
+	f nullPropagating,
+	t sql.Type,
+	truncate bool,
+) (interface{}, error) {
+	values, err := f.evalArgs(ctx, row)
+	if err != nil || values == nil {
+		return nil, err
+	}
+
+	n, err := toNumber(f.args[0].Type(), values[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var digits int64
+	if len(values) > 1 {
+		digits, err = toInt64(values[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch n := n.(type) {
+	case int64:
+		return roundInt(n, digits, truncate), nil
+	case uint64:
+		return roundUint(n, digits, truncate), nil
+	case decimal.Decimal:
+		if digits > sql.DecimalMaxScale {
+			digits = sql.DecimalMaxScale
+		}
+
+		if truncate {
+			n = n.Shift(int32(digits)).Truncate(0).Shift(-int32(digits))
+		} else {
+			n = n.Round(int32(digits))
+		}
+		return t.Convert(n)
+	default:
+		return roundFloat(n.(float64), digits, truncate), nil
+	}
+}
+
+// roundInt rounds or truncates n to the given number of digits after the
+// decimal point, which only changes n if it is negative.
+func roundInt(n, digits int64, truncate bool) int64 {
+	if digits >= 0 {
+		return n
+	}
+
+	if digits < -18 {
+		return 0
+	}
+
+	p := int64(1)
+	for i := int64(0); i < -digits; i++ {
+		p *= 10
+	}
+
+	q, r := n/p, n%p
+	if !truncate {
+		if r >= (p+1)/2 {
+			q++
+		} else if r <= -(p+1)/2 {
+			q--
+		}
+	}
+
+	return q * p
+}
+
+// roundUint is like roundInt for unsigned numbers.
+func roundUint(n uint64, digits int64, truncate bool) uint64 {
+	if digits >= 0 {
+		return n
+	}
+
+	if digits < -19 {
+		return 0
+	}
+
+	p := uint64(1)
+	for i := int64(0); i < -digits; i++ {
+		p *= 10
+	}
+
+	q, r := n/p, n%p
+	if !truncate && r >= (p+1)/2 {
+		q++
+	}
+
+	return q * p
+}
+
+// roundFloat rounds or truncates f to the given number of digits after the
+// decimal point. Halfway values are rounded to the nearest even.
+func roundFloat(f float64, digits int64, truncate bool) float64 {
+	if digits > 308 {
+		return f
+	}
+
+	if digits < -308 {
+		return 0
+	}
+
+	p := math.Pow(10, float64(digits))
+	if truncate {
+		return math.Trunc(f*p) / p
+	}
+
+	return roundHalfEven(f*p) / p
+}
+
+func roundHalfEven(f float64) float64 {
+	t := math.Trunc(f)
+	diff := math.Abs(f - t)
+	if diff > 0.5 || (diff == 0.5 && math.Mod(t, 2) != 0) {
+		t += math.Copysign(1, f)
+	}
+	return t
+}
@@ -0,0 +1,72 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestRoundTruncate(t *testing.T) {
+	dec := sql.MustDecimal(6, 3)
+	testCases := []struct {
+		name         string
+		typ          sql.Type
+		value        interface{}
+		digits       interface{}
+		expectedType sql.Type
+		round        interface{}
+		truncate     interface{}
+	}{
+		{"int", sql.Int64, int64(15), int64(0), sql.Int64, int64(15), int64(15)},
+		{"negative int", sql.Int64, int64(-155), int64(-1), sql.Int64, int64(-160), int64(-150)},
+		{"big unsigned", sql.Uint64, uint64(18446744073709551605), int64(-1), sql.Uint64, uint64(18446744073709551610), uint64(18446744073709551600)},
+		{
+			"decimal", dec, decimal.RequireFromString("-2.345"), int64(2),
+			sql.MustDecimal(6, 2), decimal.RequireFromString("-2.35"), decimal.RequireFromString("-2.34"),
+		},
+		{
+			"decimal with more digits", dec, decimal.RequireFromString("2.5"), int64(4),
+			sql.MustDecimal(8, 4), decimal.RequireFromString("2.5"), decimal.RequireFromString("2.5"),
+		},
+		{
+			"decimal integer part", dec, decimal.RequireFromString("155.5"), int64(-1),
+			sql.MustDecimal(4, 0), decimal.RequireFromString("160"), decimal.RequireFromString("150"),
+		},
+		{"float half to even", sql.Float64, 2.5, int64(0), sql.Float64, float64(2), float64(2)},
+		{"float digits", sql.Float64, -1.2567, int64(2), sql.Float64, -1.26, -1.25},
+		{"float integer part", sql.Float64, 1250.0, int64(-2), sql.Float64, float64(1200), float64(1200)},
+		{"null digits", sql.Float64, 1.5, nil, sql.Float64, nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			x := expression.NewLiteral(tt.value, tt.typ)
+			digits := expression.NewLiteral(tt.digits, sql.Int64)
+
+			round, err := NewRound(x, digits)
+			require.NoError(err)
+			truncate := NewTruncate(x, digits)
+
+			require.Equal(tt.expectedType, round.Type())
+			require.Equal(tt.expectedType, truncate.Type())
+			requireEqualValue(t, tt.round, eval(t, round, nil))
+			requireEqualValue(t, tt.truncate, eval(t, truncate, nil))
+		})
+	}
+}
+
+func TestRoundWithoutDigits(t *testing.T) {
+	require := require.New(t)
+
+	f, err := NewRound(expression.NewLiteral(decimal.RequireFromString("-2.5"), sql.MustDecimal(2, 1)))
+	require.NoError(err)
+	require.Equal(sql.MustDecimal(2, 0), f.Type())
+	requireEqualValue(t, decimal.RequireFromString("-3"), eval(t, f, nil))
+
+	_, err = NewRound()
+	require.True(sql.ErrInvalidArgumentNumber.Is(err))
+}
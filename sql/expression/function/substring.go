@@ -3,6 +3,7 @@ package function
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
 )
@@ -171,3 +172,67 @@ func (s *Substring) TransformUp(f sql.TransformExprFunc) (sql.Expression, error)
 	}
 	return f(sub)
 }
+
+// SubstringIndex returns the part of a string before the given number of
+// occurrences of a delimiter. If the number is negative, it returns the part
+// after that number of occurrences counting from the end of the string.
+// The delimiter is matched case-sensitively.
+type SubstringIndex struct {
+	nullPropagating
+}
+
+// NewSubstringIndex creates a new SubstringIndex UDF.
+func NewSubstringIndex(str, delim, count sql.Expression) sql.Expression {
+	return &SubstringIndex{newNullPropagating("substring_index", str, delim, count)}
+}
+
+// Type implements the Expression interface.
+func (*SubstringIndex) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (s *SubstringIndex) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.SubstringIndex")
+	defer span.Finish()
+
+	args, err := s.evalArgs(ctx, row)
+	if err != nil || args == nil {
+		return nil, err
+	}
+
+	strs, err := toTexts(s.args[:2], args[:2])
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := toInt64(args[2])
+	if err != nil {
+		return nil, err
+	}
+
+	str, delim := strs[0], strs[1]
+	if count == 0 || delim == "" {
+		return "", nil
+	}
+
+	parts := strings.Split(str, delim)
+	if count > 0 {
+		if count >= int64(len(parts)) {
+			return str, nil
+		}
+		return strings.Join(parts[:count], delim), nil
+	}
+
+	if -count >= int64(len(parts)) {
+		return str, nil
+	}
+	return strings.Join(parts[int64(len(parts))+count:], delim), nil
+}
+
+// TransformUp implements the Expression interface.
+func (s *SubstringIndex) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(f, s.args...)
+	if err != nil {
+		return nil, err
+	}
+	return f(NewSubstringIndex(args[0], args[1], args[2]))
+}
@@ -51,3 +51,32 @@ func TestSubstring(t *testing.T) {
 		})
 	}
 }
+
+func TestSubstringIndex(t *testing.T) {
+	f := NewSubstringIndex(
+		expression.NewGetField(0, sql.Text, "str", true),
+		expression.NewGetField(1, sql.Text, "delim", true),
+		expression.NewGetField(2, sql.Int64, "count", true),
+	)
+
+	testCases := []struct {
+		name     string
+		row      sql.Row
+		expected interface{}
+	}{
+		{"positive", sql.NewRow("www.mysql.com", ".", int64(2)), "www.mysql"},
+		{"negative", sql.NewRow("www.mysql.com", ".", int64(-2)), "mysql.com"},
+		{"more than occurrences", sql.NewRow("www.mysql.com", ".", int64(5)), "www.mysql.com"},
+		{"less than occurrences", sql.NewRow("www.mysql.com", ".", int64(-5)), "www.mysql.com"},
+		{"zero", sql.NewRow("www.mysql.com", ".", int64(0)), ""},
+		{"multi-character delimiter", sql.NewRow("añbbañbbc", "bb", int64(-1)), "c"},
+		{"case-sensitive", sql.NewRow("aXbxc", "x", int64(1)), "aXb"},
+		{"null", sql.NewRow("a.b", nil, int64(1)), nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, f, tt.row))
+		})
+	}
+}
@@ -262,3 +262,27 @@ func (d *DayOfYear) TransformUp(f sql.TransformExprFunc) (sql.Expression, error)
 
 	return f(NewDayOfYear(child))
 }
+
+// datetimeArg is the type used to convert the date arguments of the date
+// and time functions, which keeps their fractional seconds.
+var datetimeArg, _ = sql.DatetimeWithPrecision(sql.FractionalMaxPrecision)
+
+// toDatetime converts the value v of a date argument to a time.
+func toDatetime(v interface{}) (time.Time, error) {
+	t, err := datetimeArg.Convert(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.(time.Time), nil
+}
+
+// datetimePrecision returns the fractional seconds precision of the given
+// date or time type, or zero if it has none.
+func datetimePrecision(t sql.Type) uint8 {
+	if p, ok := t.(interface {
+		Precision() uint8
+	}); ok && sql.IsTemporal(t) {
+		return p.Precision()
+	}
+	return 0
+}
@@ -0,0 +1,103 @@
+package function
+
+import (
+	"strings"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// Trim returns the given string without its leading and trailing spaces.
+type Trim struct {
+	nullPropagating
+}
+
+// NewTrim creates a new Trim function.
+func NewTrim(str sql.Expression) sql.Expression {
+	return &Trim{newNullPropagating("trim", str)}
+}
+
+// Type implements the Expression interface.
+func (f *Trim) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (f *Trim) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Trim")
+	defer span.Finish()
+
+	return evalText(ctx, row, f.args[0], func(s string) string {
+		return strings.Trim(s, " ")
+	})
+}
+
+// TransformUp implements the Expression interface.
+func (f *Trim) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewTrim(args[0]))
+}
+
+// LTrim returns the given string without its leading spaces.
+type LTrim struct {
+	nullPropagating
+}
+
+// NewLTrim creates a new LTrim function.
+func NewLTrim(str sql.Expression) sql.Expression {
+	return &LTrim{newNullPropagating("ltrim", str)}
+}
+
+// Type implements the Expression interface.
+func (f *LTrim) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (f *LTrim) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.LTrim")
+	defer span.Finish()
+
+	return evalText(ctx, row, f.args[0], func(s string) string {
+		return strings.TrimLeft(s, " ")
+	})
+}
+
+// TransformUp implements the Expression interface.
+func (f *LTrim) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewLTrim(args[0]))
+}
+
+// RTrim returns the given string without its trailing spaces.
+type RTrim struct {
+	nullPropagating
+}
+
+// NewRTrim creates a new RTrim function.
+func NewRTrim(str sql.Expression) sql.Expression {
+	return &RTrim{newNullPropagating("rtrim", str)}
+}
+
+// Type implements the Expression interface.
+func (f *RTrim) Type() sql.Type { return sql.Text }
+
+// Eval implements the Expression interface.
+func (f *RTrim) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.RTrim")
+	defer span.Finish()
+
+	return evalText(ctx, row, f.args[0], func(s string) string {
+		return strings.TrimRight(s, " ")
+	})
+}
+
+// TransformUp implements the Expression interface.
+func (f *RTrim) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	return fn(NewRTrim(args[0]))
+}
@@ -0,0 +1,32 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestTrim(t *testing.T) {
+	field := expression.NewGetField(0, sql.Text, "s", true)
+	testCases := []struct {
+		name     string
+		f        sql.Expression
+		input    interface{}
+		expected interface{}
+	}{
+		{"trim", NewTrim(field), "  foo bar \t ", "foo bar \t"},
+		{"ltrim", NewLTrim(field), "  foo  ", "foo  "},
+		{"rtrim", NewRTrim(field), "  foo  ", "  foo"},
+		{"trim null", NewTrim(field), nil, nil},
+		{"ltrim null", NewLTrim(field), nil, nil},
+		{"rtrim null", NewRTrim(field), nil, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, eval(t, tt.f, sql.NewRow(tt.input)))
+		})
+	}
+}
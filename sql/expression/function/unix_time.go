@@ -0,0 +1,178 @@
+package function
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// fractionalDigits returns the number of digits of the fractional seconds
+// kept by the date and time functions for arguments of type t.
+func fractionalDigits(t sql.Type) uint8 {
+	if d := sql.Decimals(t); d < sql.FractionalMaxPrecision {
+		return d
+	}
+	return sql.FractionalMaxPrecision
+}
+
+// UnixTimestamp returns the number of seconds since 1970-01-01 00:00:00 UTC
+// of a date, or of the time the query started if it has no argument. The
+// result has the fractional seconds of the date, if any, and it is zero for
+// dates before 1970.
+type UnixTimestamp struct {
+	nullPropagating
+}
+
+// NewUnixTimestamp creates a new UnixTimestamp function.
+func NewUnixTimestamp(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) > 1 {
+		return nil, sql.ErrInvalidArgumentNumber.New("0 or 1", len(args))
+	}
+
+	return &UnixTimestamp{newNullPropagating("unix_timestamp", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *UnixTimestamp) Type() sql.Type {
+	if len(f.args) == 0 {
+		return sql.Int64
+	}
+
+	if digits := fractionalDigits(f.args[0].Type()); digits > 0 {
+		return sql.MustDecimal(11+digits, digits)
+	}
+
+	return sql.Int64
+}
+
+// IsNullable implements the Expression interface.
+func (f *UnixTimestamp) IsNullable() bool {
+	return len(f.args) > 0
+}
+
+// IsNonDeterministic implements the NonDeterministicExpression interface.
+// Without arguments, the result is the time at which the query started, and
+// the value of dates given as arguments depends on the time zone of the
+// session in MySQL, so it's never replaced by its value.
+func (f *UnixTimestamp) IsNonDeterministic() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *UnixTimestamp) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.UnixTimestamp")
+	defer span.Finish()
+
+	if len(f.args) == 0 {
+		return ctx.QueryTime().Unix(), nil
+	}
+
+	v, err := f.args[0].Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	date, err := toDatetime(v)
+	if err != nil {
+		return nil, nil
+	}
+
+	if date.Unix() < 0 {
+		date = time.Unix(0, 0)
+	}
+
+	typ := f.Type()
+	if typ == sql.Int64 {
+		return date.Unix(), nil
+	}
+
+	micros := date.Unix()*1e6 + int64(date.Nanosecond()/1e3)
+	return typ.Convert(decimal.New(micros, -6))
+}
+
+// TransformUp implements the Expression interface.
+func (f *UnixTimestamp) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&UnixTimestamp{newNullPropagating(f.name, args...)})
+}
+
+// FromUnixtime returns the date of a number of seconds since 1970-01-01
+// 00:00:00 UTC. If it is given a format, the date is formatted as in
+// DATE_FORMAT.
+type FromUnixtime struct {
+	nullPropagating
+}
+
+// NewFromUnixtime creates a new FromUnixtime function.
+func NewFromUnixtime(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("1 or 2", len(args))
+	}
+
+	return &FromUnixtime{newNullPropagating("from_unixtime", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *FromUnixtime) Type() sql.Type {
+	if len(f.args) > 1 {
+		return sql.Text
+	}
+
+	typ, _ := sql.DatetimeWithPrecision(fractionalDigits(f.args[0].Type()))
+	return typ
+}
+
+// IsNullable implements the Expression interface. The result is NULL for
+// negative numbers.
+func (f *FromUnixtime) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *FromUnixtime) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.FromUnixtime")
+	defer span.Finish()
+
+	values, err := f.evalArgs(ctx, row)
+	if err != nil || values == nil {
+		return nil, err
+	}
+
+	seconds, err := toDecimal(f.args[0], values[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if seconds.Sign() < 0 {
+		return nil, nil
+	}
+
+	micros := seconds.Shift(6).Round(0).IntPart()
+	date := time.Unix(micros/1e6, micros%1e6*1e3).UTC()
+	if date.Year() > 9999 {
+		return nil, nil
+	}
+
+	if len(values) == 1 {
+		return f.Type().Convert(date)
+	}
+
+	format, err := toText(f.args[1], values[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return formatDate(date, format), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *FromUnixtime) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&FromUnixtime{newNullPropagating(f.name, args...)})
+}
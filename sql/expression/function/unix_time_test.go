@@ -0,0 +1,72 @@
+package function
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestUnixTimestamp(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewContext(context.TODO(), sql.WithQueryTime(queryTime))
+
+	f, err := NewUnixTimestamp()
+	require.NoError(err)
+	require.Equal(sql.Int64, f.Type())
+
+	v, err := f.Eval(ctx, nil)
+	require.NoError(err)
+	require.Equal(queryTime.Unix(), v)
+
+	f, err = NewUnixTimestamp(expression.NewGetField(0, sql.Text, "d", true))
+	require.NoError(err)
+	require.Equal(sql.Int64, f.Type())
+	require.Equal(int64(1514768461), eval(t, f, sql.NewRow("2018-01-01 01:01:01")))
+	require.Equal(int64(0), eval(t, f, sql.NewRow("1960-01-01")))
+	require.Nil(eval(t, f, sql.NewRow("foo")))
+	require.Nil(eval(t, f, sql.NewRow(nil)))
+
+	datetime3, err := sql.DatetimeWithPrecision(3)
+	require.NoError(err)
+
+	f, err = NewUnixTimestamp(expression.NewGetField(0, datetime3, "d", true))
+	require.NoError(err)
+	require.Equal(sql.MustDecimal(14, 3), f.Type())
+
+	v = eval(t, f, sql.NewRow(time.Date(2018, 1, 1, 1, 1, 1, 500000000, time.UTC)))
+	require.Equal("1514768461.5", v.(decimal.Decimal).String())
+
+	_, err = NewUnixTimestamp(expression.NewLiteral(1, sql.Int64), expression.NewLiteral(1, sql.Int64))
+	require.True(sql.ErrInvalidArgumentNumber.Is(err))
+}
+
+func TestFromUnixtime(t *testing.T) {
+	require := require.New(t)
+
+	f, err := NewFromUnixtime(expression.NewGetField(0, sql.Int64, "n", true))
+	require.NoError(err)
+	require.Equal(sql.Datetime, f.Type())
+	require.Equal(time.Date(2018, 1, 1, 1, 1, 1, 0, time.UTC), eval(t, f, sql.NewRow(int64(1514768461))))
+	require.Nil(eval(t, f, sql.NewRow(int64(-1))))
+	require.Nil(eval(t, f, sql.NewRow(nil)))
+
+	f, err = NewFromUnixtime(expression.NewGetField(0, sql.MustDecimal(14, 3), "n", true))
+	require.NoError(err)
+	require.Equal(
+		time.Date(2018, 1, 1, 1, 1, 1, 250000000, time.UTC),
+		eval(t, f, sql.NewRow(decimal.RequireFromString("1514768461.25"))),
+	)
+
+	f, err = NewFromUnixtime(
+		expression.NewGetField(0, sql.Int64, "n", true),
+		expression.NewLiteral("%Y %D %M", sql.Text),
+	)
+	require.NoError(err)
+	require.Equal(sql.Text, f.Type())
+	require.Equal("2018 1st January", eval(t, f, sql.NewRow(int64(1514768461))))
+}
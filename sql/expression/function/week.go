@@ -0,0 +1,255 @@
+package function
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// Week behaviours of calcWeek, which can be combined.
+const (
+	// weekMondayFirst makes weeks start on Monday instead of Sunday.
+	weekMondayFirst = 1
+	// weekYear makes the first days of a year that are not in its first
+	// week belong to the last week of the previous year, instead of to
+	// week 0.
+	weekYear = 2
+	// weekFirstWeekday makes the first week of a year the one with its
+	// first day of the week, instead of the first one with 4 or more days
+	// in the year.
+	weekFirstWeekday = 4
+)
+
+// weekBehaviour returns the behaviour of calcWeek for the given mode of
+// WEEK, as described in the MySQL documentation.
+func weekBehaviour(mode int64) int {
+	behaviour := int(mode & 7)
+	if behaviour&weekMondayFirst == 0 {
+		behaviour ^= weekFirstWeekday
+	}
+	return behaviour
+}
+
+// calcWeek returns the week of the given time with the given behaviour, and
+// the year that week belongs to.
+func calcWeek(t time.Time, behaviour int) (year, week int) {
+	mondayFirst := behaviour&weekMondayFirst != 0
+	weekYearMode := behaviour&weekYear != 0
+	firstWeekday := behaviour&weekFirstWeekday != 0
+
+	day := daysSinceEpoch(t)
+	firstDay := daysSinceEpoch(time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC))
+	weekday := dayOfWeek(firstDay, mondayFirst)
+	year = t.Year()
+
+	// firstWeekStarted returns whether the first week of the year starts
+	// before or on the first day of the year given its weekday.
+	firstWeekStarted := func(weekday int64) bool {
+		if firstWeekday {
+			return weekday == 0
+		}
+		return weekday < 4
+	}
+
+	if t.Month() == time.January && int64(t.Day()) <= 7-weekday {
+		if !weekYearMode && !firstWeekStarted(weekday) {
+			return year, 0
+		}
+
+		weekYearMode = true
+		year--
+		days := int64(daysInYear(year))
+		firstDay -= days
+		weekday = (weekday + 53*7 - days) % 7
+	}
+
+	var days int64
+	if firstWeekStarted(weekday) {
+		days = day - (firstDay - weekday)
+	} else {
+		days = day - (firstDay + 7 - weekday)
+	}
+
+	if weekYearMode && days >= 52*7 {
+		weekday = (weekday + int64(daysInYear(year))) % 7
+		if firstWeekStarted(weekday) {
+			return year + 1, 1
+		}
+	}
+
+	return year, int(days/7) + 1
+}
+
+// dayOfWeek returns the day of the week of the given number of days since
+// 1970-01-01, from 0 for Sunday, or for Monday if mondayFirst is true.
+func dayOfWeek(days int64, mondayFirst bool) int64 {
+	// 1970-01-01 was a Thursday.
+	offset := int64(4)
+	if mondayFirst {
+		offset = 3
+	}
+	return ((days+offset)%7 + 7) % 7
+}
+
+func daysInYear(year int) int {
+	return time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
+
+// Week returns the week number of a date. The optional second argument is
+// the mode, from 0 to 7, which tells the day the weeks start on and which
+// is the first week of the year. It is 0 by default.
+type Week struct {
+	nullPropagating
+}
+
+// NewWeek creates a new Week function.
+func NewWeek(args ...sql.Expression) (sql.Expression, error) {
+	if len(args) != 1 && len(args) != 2 {
+		return nil, sql.ErrInvalidArgumentNumber.New("1 or 2", len(args))
+	}
+
+	return &Week{newNullPropagating("week", args...)}, nil
+}
+
+// Type implements the Expression interface.
+func (f *Week) Type() sql.Type { return sql.Int32 }
+
+// IsNullable implements the Expression interface.
+func (f *Week) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *Week) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.Week")
+	defer span.Finish()
+
+	values, err := f.evalArgs(ctx, row)
+	if err != nil || values == nil {
+		return nil, err
+	}
+
+	date, err := toDatetime(values[0])
+	if err != nil {
+		return nil, nil
+	}
+
+	var mode int64
+	if len(values) > 1 {
+		mode, err = toInt64(values[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, week := calcWeek(date, weekBehaviour(mode))
+	return int32(week), nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *Week) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&Week{newNullPropagating(f.name, args...)})
+}
+
+// WeekDay returns the day of the week of a date, from 0 for Monday to 6 for
+// Sunday.
+type WeekDay struct {
+	expression.UnaryExpression
+}
+
+// NewWeekDay creates a new WeekDay function.
+func NewWeekDay(date sql.Expression) sql.Expression {
+	return &WeekDay{expression.UnaryExpression{Child: date}}
+}
+
+func (f *WeekDay) String() string { return fmt.Sprintf("weekday(%s)", f.Child) }
+
+// Type implements the Expression interface.
+func (f *WeekDay) Type() sql.Type { return sql.Int32 }
+
+// IsNullable implements the Expression interface.
+func (f *WeekDay) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *WeekDay) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.WeekDay")
+	defer span.Finish()
+
+	return evalWeekday(ctx, row, f.Child, true)
+}
+
+// TransformUp implements the Expression interface.
+func (f *WeekDay) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := f.Child.TransformUp(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewWeekDay(child))
+}
+
+// DayOfWeek returns the day of the week of a date, from 1 for Sunday to 7
+// for Saturday.
+type DayOfWeek struct {
+	expression.UnaryExpression
+}
+
+// NewDayOfWeek creates a new DayOfWeek function.
+func NewDayOfWeek(date sql.Expression) sql.Expression {
+	return &DayOfWeek{expression.UnaryExpression{Child: date}}
+}
+
+func (f *DayOfWeek) String() string { return fmt.Sprintf("dayofweek(%s)", f.Child) }
+
+// Type implements the Expression interface.
+func (f *DayOfWeek) Type() sql.Type { return sql.Int32 }
+
+// IsNullable implements the Expression interface.
+func (f *DayOfWeek) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *DayOfWeek) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("function.DayOfWeek")
+	defer span.Finish()
+
+	v, err := evalWeekday(ctx, row, f.Child, false)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	return v.(int32) + 1, nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *DayOfWeek) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := f.Child.TransformUp(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewDayOfWeek(child))
+}
+
+func evalWeekday(
+	ctx *sql.Context,
+	row sql.Row,
+	e sql.Expression,
+	mondayFirst bool,
+) (interface{}, error) {
+	v, err := e.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	date, err := toDatetime(v)
+	if err != nil {
+		return nil, nil
+	}
+
+	return int32(dayOfWeek(daysSinceEpoch(date), mondayFirst)), nil
+}
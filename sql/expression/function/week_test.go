@@ -0,0 +1,64 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestWeek(t *testing.T) {
+	f, err := NewWeek(
+		expression.NewGetField(0, sql.Text, "d", true),
+		expression.NewGetField(1, sql.Int64, "mode", true),
+	)
+	require.NoError(t, err)
+
+	// Expected values are the ones returned by MySQL.
+	testCases := []struct {
+		date     string
+		expected []int32
+	}{
+		{"2008-02-20", []int32{7, 8, 7, 8, 8, 7, 8, 7}},
+		{"2000-01-01", []int32{0, 0, 52, 52, 0, 0, 52, 52}},
+		{"2008-12-31", []int32{52, 53, 52, 1, 53, 52, 53, 52}},
+		{"2017-01-01", []int32{1, 0, 1, 52, 1, 0, 1, 52}},
+		{"2018-12-31", []int32{52, 53, 52, 1, 53, 53, 1, 53}},
+	}
+
+	for _, tt := range testCases {
+		for mode, expected := range tt.expected {
+			require.Equal(
+				t, expected, eval(t, f, sql.NewRow(tt.date, int64(mode))),
+				"WEEK('%s', %d)", tt.date, mode,
+			)
+		}
+	}
+
+	f, err = NewWeek(expression.NewGetField(0, sql.Text, "d", true))
+	require.NoError(t, err)
+	require.Equal(t, int32(7), eval(t, f, sql.NewRow("2008-02-20")))
+	require.Nil(t, eval(t, f, sql.NewRow(nil)))
+}
+
+func TestWeekDay(t *testing.T) {
+	weekday := NewWeekDay(expression.NewGetField(0, sql.Text, "d", true))
+	dayOfWeek := NewDayOfWeek(expression.NewGetField(0, sql.Text, "d", true))
+
+	testCases := []struct {
+		date      interface{}
+		weekday   interface{}
+		dayOfWeek interface{}
+	}{
+		{"2018-01-01", int32(0), int32(2)},
+		{"2018-01-07", int32(6), int32(1)},
+		{"1969-12-31", int32(2), int32(4)},
+		{nil, nil, nil},
+	}
+
+	for _, tt := range testCases {
+		require.Equal(t, tt.weekday, eval(t, weekday, sql.NewRow(tt.date)))
+		require.Equal(t, tt.dayOfWeek, eval(t, dayOfWeek, sql.NewRow(tt.date)))
+	}
+}
@@ -0,0 +1,301 @@
+package function
+
+import (
+	errors "gopkg.in/src-d/go-errors.v1"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+var (
+	// ErrMissingOver is returned when a window function is used without an
+	// OVER clause.
+	ErrMissingOver = errors.NewKind("window function %s requires an OVER clause")
+
+	// ErrInvalidNtile is returned when the number of buckets given to NTILE
+	// is not a positive integer.
+	ErrInvalidNtile = errors.NewKind("the number of buckets of ntile must be a positive integer, got %v")
+
+	// ErrInvalidWindowOffset is returned when the offset given to LAG or
+	// LEAD is not a non-negative integer.
+	ErrInvalidWindowOffset = errors.NewKind("the offset of %s must be a non-negative integer, got %v")
+)
+
+// Ranking is a window function that numbers the rows of each partition,
+// such as ROW_NUMBER, RANK or DENSE_RANK.
+type Ranking struct {
+	nullPropagating
+	rank func(p *sql.WindowPartition, i int) int
+}
+
+// NewRowNumber returns the number of each row in its partition, starting
+// at 1.
+func NewRowNumber(args ...sql.Expression) (sql.Expression, error) {
+	return newRanking("row_number", func(p *sql.WindowPartition, i int) int {
+		return i
+	}, args)
+}
+
+// NewRank returns the rank of each row in its partition, which is the
+// number of the first of its peers, so there are gaps after the rows that
+// have peers.
+func NewRank(args ...sql.Expression) (sql.Expression, error) {
+	return newRanking("rank", (*sql.WindowPartition).PeerStart, args)
+}
+
+// NewDenseRank returns the rank of each row in its partition without gaps,
+// which is the number of its group of peers.
+func NewDenseRank(args ...sql.Expression) (sql.Expression, error) {
+	return newRanking("dense_rank", (*sql.WindowPartition).PeerGroup, args)
+}
+
+func newRanking(
+	name string,
+	rank func(p *sql.WindowPartition, i int) int,
+	args []sql.Expression,
+) (sql.Expression, error) {
+	if len(args) != 0 {
+		return nil, sql.ErrInvalidArgumentNumber.New(0, len(args))
+	}
+	return &Ranking{newNullPropagating(name), rank}, nil
+}
+
+// Type implements the Expression interface.
+func (f *Ranking) Type() sql.Type { return sql.Uint64 }
+
+// IsNullable implements the Expression interface.
+func (f *Ranking) IsNullable() bool { return false }
+
+// Eval implements the Expression interface.
+func (f *Ranking) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return nil, ErrMissingOver.New(f.name)
+}
+
+// EvalWindow implements the sql.WindowFunction interface.
+func (f *Ranking) EvalWindow(ctx *sql.Context, p *sql.WindowPartition) ([]interface{}, error) {
+	var result = make([]interface{}, p.Len())
+	for i := range result {
+		result[i] = uint64(f.rank(p, i) + 1)
+	}
+	return result, nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *Ranking) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	return fn(f)
+}
+
+// Ntile divides the rows of each partition in the given number of buckets
+// and returns the number of the bucket of each row. The first buckets have
+// one more row when the rows cannot be divided evenly.
+type Ntile struct {
+	nullPropagating
+}
+
+// NewNtile creates a new Ntile function.
+func NewNtile(n sql.Expression) sql.Expression {
+	return &Ntile{newNullPropagating("ntile", n)}
+}
+
+// Type implements the Expression interface.
+func (f *Ntile) Type() sql.Type { return sql.Uint64 }
+
+// IsNullable implements the Expression interface.
+func (f *Ntile) IsNullable() bool { return false }
+
+// Eval implements the Expression interface.
+func (f *Ntile) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return nil, ErrMissingOver.New(f.name)
+}
+
+// EvalWindow implements the sql.WindowFunction interface.
+func (f *Ntile) EvalWindow(ctx *sql.Context, p *sql.WindowPartition) ([]interface{}, error) {
+	var result = make([]interface{}, p.Len())
+	if len(result) == 0 {
+		return result, nil
+	}
+
+	v, err := f.args[0].Eval(ctx, p.Rows[0])
+	if err != nil {
+		return nil, err
+	}
+
+	buckets, err := toInt64(v)
+	if err != nil || v == nil || buckets <= 0 {
+		return nil, ErrInvalidNtile.New(v)
+	}
+
+	size := len(result) / int(buckets)
+	// The first rows are in buckets with one more row.
+	bigger := (len(result) % int(buckets)) * (size + 1)
+	for i := range result {
+		if i < bigger {
+			result[i] = uint64(i/(size+1) + 1)
+		} else {
+			result[i] = uint64(bigger/(size+1) + (i-bigger)/size + 1)
+		}
+	}
+
+	return result, nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *Ntile) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(NewNtile(args[0]))
+}
+
+// LagLead returns the value of an expression in the row which is a given
+// number of rows before (LAG) or after (LEAD) the current row of the
+// partition, or a default value if there is no such row. The offset is 1
+// and the default value is NULL unless given.
+type LagLead struct {
+	nullPropagating
+	lead bool
+}
+
+// NewLag creates a new LAG function.
+func NewLag(args ...sql.Expression) (sql.Expression, error) {
+	return newLagLead("lag", false, args)
+}
+
+// NewLead creates a new LEAD function.
+func NewLead(args ...sql.Expression) (sql.Expression, error) {
+	return newLagLead("lead", true, args)
+}
+
+func newLagLead(name string, lead bool, args []sql.Expression) (sql.Expression, error) {
+	if len(args) < 1 || len(args) > 3 {
+		return nil, sql.ErrInvalidArgumentNumber.New("1, 2 or 3", len(args))
+	}
+	return &LagLead{newNullPropagating(name, args...), lead}, nil
+}
+
+// Type implements the Expression interface.
+func (f *LagLead) Type() sql.Type { return f.args[0].Type() }
+
+// IsNullable implements the Expression interface.
+func (f *LagLead) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *LagLead) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return nil, ErrMissingOver.New(f.name)
+}
+
+// EvalWindow implements the sql.WindowFunction interface.
+func (f *LagLead) EvalWindow(ctx *sql.Context, p *sql.WindowPartition) ([]interface{}, error) {
+	var result = make([]interface{}, p.Len())
+	for i, row := range p.Rows {
+		offset := int64(1)
+		if len(f.args) > 1 {
+			v, err := f.args[1].Eval(ctx, row)
+			if err != nil {
+				return nil, err
+			}
+
+			offset, err = toInt64(v)
+			if err != nil || v == nil || offset < 0 {
+				return nil, ErrInvalidWindowOffset.New(f.name, v)
+			}
+		}
+
+		if f.lead {
+			offset = -offset
+		}
+
+		j := int64(i) - offset
+		if j >= 0 && j < int64(len(p.Rows)) {
+			v, err := f.args[0].Eval(ctx, p.Rows[j])
+			if err != nil {
+				return nil, err
+			}
+			result[i] = v
+		} else if len(f.args) > 2 {
+			v, err := f.args[2].Eval(ctx, row)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = v
+		}
+	}
+
+	return result, nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *LagLead) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&LagLead{newNullPropagating(f.name, args...), f.lead})
+}
+
+// FrameValue returns the value of an expression in the first (FIRST_VALUE)
+// or last (LAST_VALUE) row of the frame of the current row, or NULL if the
+// frame is empty.
+type FrameValue struct {
+	nullPropagating
+	last bool
+}
+
+// NewFirstValue creates a new FIRST_VALUE function.
+func NewFirstValue(e sql.Expression) sql.Expression {
+	return &FrameValue{newNullPropagating("first_value", e), false}
+}
+
+// NewLastValue creates a new LAST_VALUE function.
+func NewLastValue(e sql.Expression) sql.Expression {
+	return &FrameValue{newNullPropagating("last_value", e), true}
+}
+
+// Type implements the Expression interface.
+func (f *FrameValue) Type() sql.Type { return f.args[0].Type() }
+
+// IsNullable implements the Expression interface.
+func (f *FrameValue) IsNullable() bool { return true }
+
+// Eval implements the Expression interface.
+func (f *FrameValue) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return nil, ErrMissingOver.New(f.name)
+}
+
+// EvalWindow implements the sql.WindowFunction interface.
+func (f *FrameValue) EvalWindow(ctx *sql.Context, p *sql.WindowPartition) ([]interface{}, error) {
+	var result = make([]interface{}, p.Len())
+	for i := range result {
+		start, end, err := p.Frame(i)
+		if err != nil {
+			return nil, err
+		}
+
+		if start == end {
+			continue
+		}
+
+		j := start
+		if f.last {
+			j = end - 1
+		}
+
+		result[i], err = f.args[0].Eval(ctx, p.Rows[j])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// TransformUp implements the Expression interface.
+func (f *FrameValue) TransformUp(fn sql.TransformExprFunc) (sql.Expression, error) {
+	args, err := transformArgs(fn, f.args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(&FrameValue{newNullPropagating(f.name, args...), f.last})
+}
@@ -0,0 +1,180 @@
+package function
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func windowPartition(t *testing.T, w *sql.WindowDefinition, rows ...sql.Row) *sql.WindowPartition {
+	t.Helper()
+	partitions, err := w.Partition(sql.NewEmptyContext(), rows)
+	require.NoError(t, err)
+	require.Len(t, partitions, 1)
+	return partitions[0]
+}
+
+func evalWindow(t *testing.T, f sql.Expression, p *sql.WindowPartition) []interface{} {
+	t.Helper()
+	values, err := f.(sql.WindowFunction).EvalWindow(sql.NewEmptyContext(), p)
+	require.NoError(t, err)
+	return values
+}
+
+func TestRanking(t *testing.T) {
+	require := require.New(t)
+
+	p := windowPartition(t, &sql.WindowDefinition{
+		OrderBy: []sql.WindowOrderBy{
+			{Column: expression.NewGetField(0, sql.Int64, "a", false)},
+		},
+	},
+		sql.NewRow(int64(3)),
+		sql.NewRow(int64(1)),
+		sql.NewRow(int64(3)),
+		sql.NewRow(int64(2)),
+		sql.NewRow(int64(3)),
+		sql.NewRow(int64(5)),
+	)
+
+	f, err := NewRowNumber()
+	require.NoError(err)
+	require.Equal(
+		[]interface{}{uint64(1), uint64(2), uint64(3), uint64(4), uint64(5), uint64(6)},
+		evalWindow(t, f, p),
+	)
+
+	f, err = NewRank()
+	require.NoError(err)
+	require.Equal(
+		[]interface{}{uint64(1), uint64(2), uint64(3), uint64(3), uint64(3), uint64(6)},
+		evalWindow(t, f, p),
+	)
+
+	f, err = NewDenseRank()
+	require.NoError(err)
+	require.Equal("dense_rank()", f.String())
+	require.Equal(
+		[]interface{}{uint64(1), uint64(2), uint64(3), uint64(3), uint64(3), uint64(4)},
+		evalWindow(t, f, p),
+	)
+
+	_, err = f.Eval(sql.NewEmptyContext(), nil)
+	require.True(ErrMissingOver.Is(err))
+
+	_, err = NewRowNumber(expression.NewLiteral(int64(1), sql.Int64))
+	require.True(sql.ErrInvalidArgumentNumber.Is(err))
+}
+
+func TestNtile(t *testing.T) {
+	var rows []sql.Row
+	for i := 0; i < 5; i++ {
+		rows = append(rows, sql.NewRow(int64(i)))
+	}
+	p := windowPartition(t, &sql.WindowDefinition{}, rows...)
+
+	testCases := []struct {
+		buckets  interface{}
+		expected []interface{}
+		err      bool
+	}{
+		{int64(2), []interface{}{uint64(1), uint64(1), uint64(1), uint64(2), uint64(2)}, false},
+		{int64(3), []interface{}{uint64(1), uint64(1), uint64(2), uint64(2), uint64(3)}, false},
+		{int64(7), []interface{}{uint64(1), uint64(2), uint64(3), uint64(4), uint64(5)}, false},
+		{int64(0), nil, true},
+		{nil, nil, true},
+	}
+
+	for _, tt := range testCases {
+		f := NewNtile(expression.NewLiteral(tt.buckets, sql.Int64))
+		values, err := f.(sql.WindowFunction).EvalWindow(sql.NewEmptyContext(), p)
+		if tt.err {
+			require.True(t, ErrInvalidNtile.Is(err))
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, values)
+		}
+	}
+}
+
+func TestLagLead(t *testing.T) {
+	require := require.New(t)
+
+	p := windowPartition(t, &sql.WindowDefinition{},
+		sql.NewRow(int64(1)),
+		sql.NewRow(int64(2)),
+		sql.NewRow(int64(3)),
+	)
+
+	col := expression.NewGetField(0, sql.Int64, "a", false)
+	two := expression.NewLiteral(int64(2), sql.Int64)
+	def := expression.NewLiteral(int64(0), sql.Int64)
+
+	testCases := []struct {
+		fn       func(...sql.Expression) (sql.Expression, error)
+		args     []sql.Expression
+		expected []interface{}
+	}{
+		{NewLag, []sql.Expression{col}, []interface{}{nil, int64(1), int64(2)}},
+		{NewLead, []sql.Expression{col}, []interface{}{int64(2), int64(3), nil}},
+		{NewLag, []sql.Expression{col, two}, []interface{}{nil, nil, int64(1)}},
+		{NewLead, []sql.Expression{col, two, def}, []interface{}{int64(3), int64(0), int64(0)}},
+	}
+
+	for _, tt := range testCases {
+		f, err := tt.fn(tt.args...)
+		require.NoError(err)
+		require.Equal(tt.expected, evalWindow(t, f, p))
+	}
+
+	f, err := NewLag(col, expression.NewLiteral(int64(-1), sql.Int64))
+	require.NoError(err)
+	_, err = f.(sql.WindowFunction).EvalWindow(sql.NewEmptyContext(), p)
+	require.True(ErrInvalidWindowOffset.Is(err))
+
+	_, err = NewLead()
+	require.True(sql.ErrInvalidArgumentNumber.Is(err))
+}
+
+func TestFrameValue(t *testing.T) {
+	require := require.New(t)
+
+	col := expression.NewGetField(0, sql.Int64, "a", false)
+	rows := []sql.Row{
+		sql.NewRow(int64(1)),
+		sql.NewRow(int64(2)),
+		sql.NewRow(int64(2)),
+		sql.NewRow(int64(3)),
+	}
+
+	// The default frame ends with the last peer of the current row.
+	p := windowPartition(t, &sql.WindowDefinition{
+		OrderBy: []sql.WindowOrderBy{{Column: col}},
+	}, rows...)
+
+	require.Equal(
+		[]interface{}{int64(1), int64(1), int64(1), int64(1)},
+		evalWindow(t, NewFirstValue(col), p),
+	)
+	require.Equal(
+		[]interface{}{int64(1), int64(2), int64(2), int64(3)},
+		evalWindow(t, NewLastValue(col), p),
+	)
+
+	one := expression.NewLiteral(int64(1), sql.Int64)
+	p = windowPartition(t, &sql.WindowDefinition{
+		OrderBy: []sql.WindowOrderBy{{Column: col}},
+		Frame: &sql.WindowFrame{
+			Unit:  sql.RowsFrame,
+			Start: sql.WindowBound{Type: sql.Following, Offset: one},
+			End:   sql.WindowBound{Type: sql.Following, Offset: one},
+		},
+	}, rows...)
+
+	require.Equal(
+		[]interface{}{int64(2), int64(2), int64(3), nil},
+		evalWindow(t, NewFirstValue(col), p),
+	)
+}
@@ -0,0 +1,292 @@
+package expression
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	errors "gopkg.in/src-d/go-errors.v1"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+var (
+	// ErrUnknownIntervalUnit is returned when the unit of an INTERVAL is not
+	// supported.
+	ErrUnknownIntervalUnit = errors.NewKind("unknown interval unit: %s")
+
+	// ErrIntervalInvalidUse is returned when an INTERVAL is evaluated outside
+	// of date arithmetic.
+	ErrIntervalInvalidUse = errors.NewKind("an INTERVAL can only be added to or subtracted from a date")
+
+	errInvalidIntervalValue = errors.NewKind("invalid interval value: %s")
+)
+
+// intervalUnits are the parts of the time that are given in each of the
+// supported units of INTERVAL, from the most to the least significant.
+var intervalUnits = map[string][]string{
+	"MICROSECOND":        {"MICROSECOND"},
+	"SECOND":             {"SECOND"},
+	"MINUTE":             {"MINUTE"},
+	"HOUR":               {"HOUR"},
+	"DAY":                {"DAY"},
+	"WEEK":               {"WEEK"},
+	"MONTH":              {"MONTH"},
+	"QUARTER":            {"QUARTER"},
+	"YEAR":               {"YEAR"},
+	"SECOND_MICROSECOND": {"SECOND", "MICROSECOND"},
+	"MINUTE_MICROSECOND": {"MINUTE", "SECOND", "MICROSECOND"},
+	"MINUTE_SECOND":      {"MINUTE", "SECOND"},
+	"HOUR_MICROSECOND":   {"HOUR", "MINUTE", "SECOND", "MICROSECOND"},
+	"HOUR_SECOND":        {"HOUR", "MINUTE", "SECOND"},
+	"HOUR_MINUTE":        {"HOUR", "MINUTE"},
+	"DAY_MICROSECOND":    {"DAY", "HOUR", "MINUTE", "SECOND", "MICROSECOND"},
+	"DAY_SECOND":         {"DAY", "HOUR", "MINUTE", "SECOND"},
+	"DAY_MINUTE":         {"DAY", "HOUR", "MINUTE"},
+	"DAY_HOUR":           {"DAY", "HOUR"},
+	"YEAR_MONTH":         {"YEAR", "MONTH"},
+}
+
+// TimeDelta is an amount of time made of calendar units, which don't
+// always have the same duration, and clock units.
+type TimeDelta struct {
+	Years        int64
+	Months       int64
+	Days         int64
+	Hours        int64
+	Minutes      int64
+	Seconds      int64
+	Microseconds int64
+}
+
+// Add returns the given time plus the delta. If adding the months or years
+// results in a day that does not exist in the resulting month, the last day
+// of that month is used instead, so 2018-01-31 plus one month is 2018-02-28.
+func (td TimeDelta) Add(t time.Time) time.Time {
+	if months := td.Years*12 + td.Months; months != 0 {
+		y, m, d := t.Date()
+		total := int64(y)*12 + int64(m) - 1 + months
+		year, month := int(floorDiv(total, 12)), time.Month(total-floorDiv(total, 12)*12+1)
+		if last := daysIn(year, month); d > last {
+			d = last
+		}
+
+		t = time.Date(
+			year, month, d,
+			t.Hour(), t.Minute(), t.Second(), t.Nanosecond(),
+			t.Location(),
+		)
+	}
+
+	return t.AddDate(0, 0, int(td.Days)).
+		Add(time.Duration(td.Hours) * time.Hour).
+		Add(time.Duration(td.Minutes) * time.Minute).
+		Add(time.Duration(td.Seconds) * time.Second).
+		Add(time.Duration(td.Microseconds) * time.Microsecond)
+}
+
+// Sub returns the given time minus the delta.
+func (td TimeDelta) Sub(t time.Time) time.Time {
+	return TimeDelta{
+		-td.Years, -td.Months, -td.Days,
+		-td.Hours, -td.Minutes, -td.Seconds, -td.Microseconds,
+	}.Add(t)
+}
+
+// HasTime returns whether the delta has any clock unit.
+func (td TimeDelta) HasTime() bool {
+	return td.Hours != 0 || td.Minutes != 0 || td.Seconds != 0 || td.Microseconds != 0
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Interval is an amount of time in the given unit, such as INTERVAL 1 DAY
+// or INTERVAL '1:30' HOUR_MINUTE. It can only be used in date arithmetic.
+type Interval struct {
+	UnaryExpression
+	Unit string
+}
+
+// NewInterval creates a new Interval expression.
+func NewInterval(child sql.Expression, unit string) (*Interval, error) {
+	unit = strings.ToUpper(unit)
+	if _, ok := intervalUnits[unit]; !ok {
+		return nil, ErrUnknownIntervalUnit.New(unit)
+	}
+
+	return &Interval{UnaryExpression{child}, unit}, nil
+}
+
+// HasTime returns whether the unit of the interval has any clock unit.
+func (i *Interval) HasTime() bool {
+	switch i.Unit {
+	case "DAY", "WEEK", "MONTH", "QUARTER", "YEAR", "YEAR_MONTH":
+		return false
+	default:
+		return true
+	}
+}
+
+// HasMicroseconds returns whether the unit of the interval has
+// microseconds.
+func (i *Interval) HasMicroseconds() bool {
+	return strings.HasSuffix(i.Unit, "MICROSECOND")
+}
+
+// Type implements the Expression interface. Intervals are not values, so
+// they don't have a type of their own.
+func (i *Interval) Type() sql.Type {
+	return sql.Null
+}
+
+// Eval implements the Expression interface. It always fails, because
+// intervals are not values. Use EvalDelta instead.
+func (i *Interval) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return nil, ErrIntervalInvalidUse.New()
+}
+
+var intervalSeparator = regexp.MustCompile(`[^0-9]+`)
+
+// EvalDelta evaluates the amount of time of the interval. It returns nil if
+// the amount is NULL or can't be read in the unit of the interval.
+func (i *Interval) EvalDelta(ctx *sql.Context, row sql.Row) (*TimeDelta, error) {
+	v, err := i.Child.Eval(ctx, row)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	units := intervalUnits[i.Unit]
+	var values []int64
+	if len(units) == 1 {
+		n, micros, err := intervalNumber(i.Child, v, i.Unit == "SECOND")
+		if err != nil {
+			return nil, nil
+		}
+
+		values = []int64{n}
+		if micros != 0 {
+			units = []string{"SECOND", "MICROSECOND"}
+			values = append(values, micros)
+		}
+	} else {
+		s, err := sql.ConvertFrom(sql.Text, i.Child.Type(), v)
+		if err != nil {
+			return nil, err
+		}
+
+		values, err = intervalParts(s.(string), len(units))
+		if err != nil {
+			return nil, nil
+		}
+	}
+
+	// Missing parts are the most significant ones.
+	units = units[len(units)-len(values):]
+
+	var td TimeDelta
+	for j, unit := range units {
+		n := values[j]
+		switch unit {
+		case "MICROSECOND":
+			td.Microseconds = n
+		case "SECOND":
+			td.Seconds = n
+		case "MINUTE":
+			td.Minutes = n
+		case "HOUR":
+			td.Hours = n
+		case "DAY":
+			td.Days = n
+		case "WEEK":
+			td.Days = n * 7
+		case "MONTH":
+			td.Months = n
+		case "QUARTER":
+			td.Months = n * 3
+		case "YEAR":
+			td.Years = n
+		}
+	}
+
+	return &td, nil
+}
+
+// intervalNumber returns the amount of an interval with a single unit,
+// rounded to an integer. If fractional is true, the fractional part is
+// returned as a number of microseconds instead.
+func intervalNumber(e sql.Expression, v interface{}, fractional bool) (int64, int64, error) {
+	s, err := sql.ConvertFrom(sql.Text, e.Type(), v)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	d, err := decimal.NewFromString(strings.TrimSpace(s.(string)))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if !fractional {
+		return d.Round(0).IntPart(), 0, nil
+	}
+
+	n := d.Truncate(0)
+	micros := d.Sub(n).Shift(6).Round(0)
+	return n.IntPart(), micros.IntPart(), nil
+}
+
+// intervalParts returns the numbers in the amount of an interval with
+// several units, such as '1:30'. All the numbers are negative if the amount
+// starts with a minus sign.
+func intervalParts(s string, max int) ([]int64, error) {
+	s = strings.TrimSpace(s)
+	var sign int64 = 1
+	if strings.HasPrefix(s, "-") {
+		sign, s = -1, s[1:]
+	}
+
+	var values []int64
+	for _, part := range intervalSeparator.Split(s, -1) {
+		if part == "" {
+			continue
+		}
+
+		n, err := sql.Int64.Convert(part)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, sign*n.(int64))
+	}
+
+	if len(values) == 0 || len(values) > max {
+		return nil, errInvalidIntervalValue.New(s)
+	}
+
+	return values, nil
+}
+
+// TransformUp implements the Expression interface.
+func (i *Interval) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	child, err := i.Child.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return f(&Interval{UnaryExpression{child}, i.Unit})
+}
+
+func (i *Interval) String() string {
+	return fmt.Sprintf("INTERVAL %s %s", i.Child, i.Unit)
+}
@@ -0,0 +1,76 @@
+package expression
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+func TestNewInterval(t *testing.T) {
+	require := require.New(t)
+
+	i, err := NewInterval(NewLiteral(int64(1), sql.Int64), "day")
+	require.NoError(err)
+	require.Equal("DAY", i.Unit)
+	require.False(i.HasTime())
+	require.Equal("INTERVAL 1 DAY", i.String())
+
+	_, err = NewInterval(NewLiteral(int64(1), sql.Int64), "FORTNIGHT")
+	require.True(ErrUnknownIntervalUnit.Is(err))
+
+	_, err = i.Eval(sql.NewEmptyContext(), nil)
+	require.True(ErrIntervalInvalidUse.Is(err))
+}
+
+func TestIntervalEvalDelta(t *testing.T) {
+	testCases := []struct {
+		value    interface{}
+		typ      sql.Type
+		unit     string
+		expected *TimeDelta
+	}{
+		{int64(2), sql.Int64, "DAY", &TimeDelta{Days: 2}},
+		{int64(-3), sql.Int64, "WEEK", &TimeDelta{Days: -21}},
+		{int64(2), sql.Int64, "QUARTER", &TimeDelta{Months: 6}},
+		{"1.6", sql.Text, "DAY", &TimeDelta{Days: 2}},
+		{1.5, sql.Float64, "SECOND", &TimeDelta{Seconds: 1, Microseconds: 500000}},
+		{"1:30", sql.Text, "HOUR_MINUTE", &TimeDelta{Hours: 1, Minutes: 30}},
+		{"30", sql.Text, "HOUR_MINUTE", &TimeDelta{Minutes: 30}},
+		{"-1 2:03", sql.Text, "DAY_MINUTE", &TimeDelta{Days: -1, Hours: -2, Minutes: -3}},
+		{"2-3", sql.Text, "YEAR_MONTH", &TimeDelta{Years: 2, Months: 3}},
+		{"1:2:3", sql.Text, "HOUR_MINUTE", nil},
+		{"foo", sql.Text, "DAY", nil},
+		{nil, sql.Null, "DAY", nil},
+	}
+
+	for _, tt := range testCases {
+		i, err := NewInterval(NewLiteral(tt.value, tt.typ), tt.unit)
+		require.NoError(t, err)
+
+		t.Run(i.String(), func(t *testing.T) {
+			delta, err := i.EvalDelta(sql.NewEmptyContext(), nil)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, delta)
+		})
+	}
+}
+
+func TestTimeDeltaAdd(t *testing.T) {
+	require := require.New(t)
+	date := time.Date(2018, time.January, 31, 10, 0, 0, 0, time.UTC)
+
+	require.Equal(
+		time.Date(2018, time.February, 28, 10, 0, 0, 0, time.UTC),
+		TimeDelta{Months: 1}.Add(date),
+	)
+	require.Equal(
+		time.Date(2016, time.February, 29, 10, 0, 0, 0, time.UTC),
+		TimeDelta{Years: 2, Months: -1}.Sub(date),
+	)
+	require.Equal(
+		time.Date(2018, time.February, 1, 11, 30, 0, 1000, time.UTC),
+		TimeDelta{Days: 1, Hours: 1, Minutes: 30, Microseconds: 1}.Add(date),
+	)
+}
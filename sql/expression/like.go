@@ -0,0 +1,189 @@
+package expression
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// DefaultLikeEscape is the character used to escape the wildcards of LIKE
+// patterns when no other is given.
+const DefaultLikeEscape = '\\'
+
+// Like is an expression that checks whether a string matches a pattern in
+// which % matches any number of characters and _ matches exactly one
+// character. Strings are matched following the rules of their collation.
+// If the pattern is a literal, it's compiled only once.
+type Like struct {
+	BinaryExpression
+	escape rune
+
+	once sync.Once
+	re   *regexp.Regexp
+	err  error
+}
+
+// NewLike creates a new Like expression using the default escape
+// character.
+func NewLike(left, right sql.Expression) *Like {
+	return NewLikeWithEscape(left, right, DefaultLikeEscape)
+}
+
+// NewLikeWithEscape creates a new Like expression with the given escape
+// character. If escape is zero, wildcards cannot be escaped.
+func NewLikeWithEscape(left, right sql.Expression, escape rune) *Like {
+	return &Like{BinaryExpression: BinaryExpression{left, right}, escape: escape}
+}
+
+// Escape returns the escape character of the pattern, or zero if there is
+// none.
+func (l *Like) Escape() rune {
+	return l.escape
+}
+
+// Type implements the Expression interface.
+func (l *Like) Type() sql.Type {
+	return sql.Boolean
+}
+
+// Eval implements the Expression interface.
+func (l *Like) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	span, ctx := ctx.Span("expression.Like")
+	defer span.Finish()
+
+	left, err := l.Left.Eval(ctx, row)
+	if err != nil || left == nil {
+		return nil, err
+	}
+
+	right, err := l.Right.Eval(ctx, row)
+	if err != nil || right == nil {
+		return nil, err
+	}
+
+	left, err = sql.Text.Convert(left)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err = sql.Text.Convert(right)
+	if err != nil {
+		return nil, err
+	}
+
+	var re *regexp.Regexp
+	if _, ok := l.Right.(*Literal); ok {
+		l.once.Do(func() {
+			l.re, l.err = l.compile(right.(string))
+		})
+		re, err = l.re, l.err
+	} else {
+		re, err = l.compile(right.(string))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return re.MatchString(left.(string)), nil
+}
+
+// compile returns the regular expression of the given pattern.
+func (l *Like) compile(pattern string) (*regexp.Regexp, error) {
+	collation, err := comparisonCollation(l.Left, l.Right)
+	if err != nil {
+		return nil, err
+	}
+
+	return regexp.Compile(likeToRegexp(pattern, l.escape, collation))
+}
+
+// likeToRegexp returns the regular expression equivalent to the given LIKE
+// pattern.
+func likeToRegexp(pattern string, escape rune, collation sql.Collation) string {
+	var buf bytes.Buffer
+	buf.WriteString("(?s)")
+	if collation == sql.CollationUtf8mb4GeneralCI {
+		buf.WriteString("(?i)")
+	}
+	buf.WriteRune('^')
+
+	var escaped bool
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			buf.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case escape != 0 && r == escape:
+			escaped = true
+		case r == '%':
+			buf.WriteString(".*")
+		case r == '_':
+			buf.WriteRune('.')
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	// An escape character at the end of the pattern matches itself.
+	if escaped {
+		buf.WriteString(regexp.QuoteMeta(string(escape)))
+	}
+
+	buf.WriteRune('$')
+	return buf.String()
+}
+
+// Prefix returns the text before the first wildcard of the given pattern,
+// which all the strings matching it start with.
+func (l *Like) Prefix(pattern string) string {
+	var buf bytes.Buffer
+	var escaped bool
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			escaped = false
+		case l.escape != 0 && r == l.escape:
+			escaped = true
+			continue
+		case r == '%' || r == '_':
+			return buf.String()
+		}
+		buf.WriteRune(r)
+	}
+
+	if escaped {
+		buf.WriteRune(l.escape)
+	}
+
+	return buf.String()
+}
+
+// TransformUp implements the Expression interface.
+func (l *Like) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	left, err := l.Left.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := l.Right.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return f(NewLikeWithEscape(left, right, l.escape))
+}
+
+func (l *Like) String() string {
+	switch l.escape {
+	case DefaultLikeEscape:
+		return fmt.Sprintf("%s LIKE %s", l.Left, l.Right)
+	case 0:
+		return fmt.Sprintf("%s LIKE %s ESCAPE ''", l.Left, l.Right)
+	default:
+		return fmt.Sprintf("%s LIKE %s ESCAPE '%c'", l.Left, l.Right, l.escape)
+	}
+}
@@ -0,0 +1,114 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+func TestLike(t *testing.T) {
+	bin, err := sql.WithCollation(sql.Text, sql.CollationUtf8mb4Bin)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		typ      sql.Type
+		value    interface{}
+		pattern  string
+		escape   rune
+		expected interface{}
+	}{
+		{sql.Text, "foobar", "foo%", DefaultLikeEscape, true},
+		{sql.Text, "foobar", "%bar", DefaultLikeEscape, true},
+		{sql.Text, "foobar", "%ob%", DefaultLikeEscape, true},
+		{sql.Text, "foobar", "foo", DefaultLikeEscape, false},
+		{sql.Text, "foobar", "f_obar", DefaultLikeEscape, true},
+		{sql.Text, "foobar", "f_bar", DefaultLikeEscape, false},
+		{sql.Text, "foo\nbar", "foo%", DefaultLikeEscape, true},
+		{sql.Text, "FOOBAR", "foo%", DefaultLikeEscape, true},
+		{bin, "FOOBAR", "foo%", DefaultLikeEscape, false},
+		{bin, "foobar", "foo%", DefaultLikeEscape, true},
+		{sql.Text, "100%", `100\%`, DefaultLikeEscape, true},
+		{sql.Text, "1000", `100\%`, DefaultLikeEscape, false},
+		{sql.Text, "a_b", "a|_b", '|', true},
+		{sql.Text, "axb", "a|_b", '|', false},
+		{sql.Text, `a\b`, `a\b`, 0, true},
+		{sql.Text, "a.b", "a.b", DefaultLikeEscape, true},
+		{sql.Text, "axb", "a.b", DefaultLikeEscape, false},
+		{sql.Text, `ab\`, `ab\`, DefaultLikeEscape, true},
+		{sql.Int64, int64(123), "12%", DefaultLikeEscape, true},
+		{sql.Text, nil, "foo%", DefaultLikeEscape, nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.pattern, func(t *testing.T) {
+			require := require.New(t)
+
+			like := NewLikeWithEscape(
+				NewGetField(0, tt.typ, "foo", true),
+				NewLiteral(tt.pattern, sql.Text),
+				tt.escape,
+			)
+
+			result, err := like.Eval(sql.NewEmptyContext(), sql.NewRow(tt.value))
+			require.NoError(err)
+			require.Equal(tt.expected, result)
+		})
+	}
+}
+
+func TestLikeCompiledOnce(t *testing.T) {
+	require := require.New(t)
+
+	like := NewLike(
+		NewGetField(0, sql.Text, "foo", true),
+		NewLiteral("foo%", sql.Text),
+	)
+
+	var values []interface{}
+	for _, v := range []string{"foobar", "bar"} {
+		result, err := like.Eval(sql.NewEmptyContext(), sql.NewRow(v))
+		require.NoError(err)
+		values = append(values, result)
+	}
+	require.Equal([]interface{}{true, false}, values)
+	require.NotNil(like.re)
+
+	// Patterns that are not literals are compiled for each row.
+	like = NewLike(
+		NewGetField(0, sql.Text, "foo", true),
+		NewGetField(1, sql.Text, "pattern", true),
+	)
+
+	values = nil
+	for _, row := range []sql.Row{{"foobar", "foo%"}, {"foobar", "bar%"}} {
+		result, err := like.Eval(sql.NewEmptyContext(), row)
+		require.NoError(err)
+		values = append(values, result)
+	}
+	require.Equal([]interface{}{true, false}, values)
+	require.Nil(like.re)
+}
+
+func TestLikePrefix(t *testing.T) {
+	testCases := []struct {
+		pattern  string
+		escape   rune
+		expected string
+	}{
+		{"foo%", DefaultLikeEscape, "foo"},
+		{"foo_bar%", DefaultLikeEscape, "foo"},
+		{"%foo", DefaultLikeEscape, ""},
+		{"foo", DefaultLikeEscape, "foo"},
+		{`foo\%bar%`, DefaultLikeEscape, "foo%bar"},
+		{`foo\`, DefaultLikeEscape, `foo\`},
+		{`foo\%`, 0, `foo\`},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.pattern, func(t *testing.T) {
+			like := NewLikeWithEscape(nil, nil, tt.escape)
+			require.Equal(t, tt.expected, like.Prefix(tt.pattern))
+		})
+	}
+}
@@ -77,6 +77,9 @@ type UnresolvedFunction struct {
 	name string
 	// IsAggregate or not.
 	IsAggregate bool
+	// Distinct is whether only distinct values of the arguments are
+	// aggregated, as in COUNT(DISTINCT a).
+	Distinct bool
 	// Children of the expression.
 	Arguments []sql.Expression
 }
@@ -87,7 +90,21 @@ func NewUnresolvedFunction(
 	agg bool,
 	arguments ...sql.Expression,
 ) *UnresolvedFunction {
-	return &UnresolvedFunction{name, agg, arguments}
+	return &UnresolvedFunction{name: name, IsAggregate: agg, Arguments: arguments}
+}
+
+// NewUnresolvedDistinctFunction creates a new UnresolvedFunction expression
+// of an aggregation of distinct values.
+func NewUnresolvedDistinctFunction(
+	name string,
+	arguments ...sql.Expression,
+) *UnresolvedFunction {
+	return &UnresolvedFunction{
+		name:        name,
+		IsAggregate: true,
+		Distinct:    true,
+		Arguments:   arguments,
+	}
 }
 
 // Children implements the Expression interface.
@@ -118,7 +135,11 @@ func (uf *UnresolvedFunction) String() string {
 	for i, e := range uf.Arguments {
 		exprs[i] = e.String()
 	}
-	return fmt.Sprintf("%s(%s)", uf.name, strings.Join(exprs, ", "))
+	var distinct string
+	if uf.Distinct {
+		distinct = "DISTINCT "
+	}
+	return fmt.Sprintf("%s(%s%s)", uf.name, distinct, strings.Join(exprs, ", "))
 }
 
 // Eval implements the Expression interface.
@@ -137,5 +158,7 @@ func (uf *UnresolvedFunction) TransformUp(f sql.TransformExprFunc) (sql.Expressi
 		rc = append(rc, c)
 	}
 
-	return f(NewUnresolvedFunction(uf.name, uf.IsAggregate, rc...))
+	n := *uf
+	n.Arguments = rc
+	return f(&n)
 }
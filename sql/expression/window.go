@@ -0,0 +1,75 @@
+package expression
+
+import (
+	"fmt"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// ErrInvalidWindowContext is returned when a window function is evaluated
+// outside of the node that computes its window.
+var ErrInvalidWindowContext = errors.NewKind("you cannot use the window function %s in this context")
+
+// Window is a function computed over the rows of the window defined in its
+// OVER clause. The function is either a sql.WindowFunction or a
+// sql.Aggregation, which is computed over the frame of each row.
+type Window struct {
+	Function   sql.Expression
+	Definition *sql.WindowDefinition
+}
+
+// NewWindow creates a new Window expression.
+func NewWindow(fn sql.Expression, definition *sql.WindowDefinition) *Window {
+	return &Window{fn, definition}
+}
+
+// Children implements the Expression interface.
+func (w *Window) Children() []sql.Expression {
+	return append([]sql.Expression{w.Function}, w.Definition.Expressions()...)
+}
+
+// Resolved implements the Expression interface.
+func (w *Window) Resolved() bool {
+	for _, e := range w.Children() {
+		if !e.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsNullable implements the Expression interface.
+func (w *Window) IsNullable() bool {
+	return w.Function.IsNullable()
+}
+
+// Type implements the Expression interface.
+func (w *Window) Type() sql.Type {
+	return w.Function.Type()
+}
+
+// Eval implements the Expression interface. Window functions can only be
+// computed by the plan.Window node, so this always returns an error.
+func (w *Window) Eval(ctx *sql.Context, row sql.Row) (interface{}, error) {
+	return nil, ErrInvalidWindowContext.New(w.Function)
+}
+
+func (w *Window) String() string {
+	return fmt.Sprintf("%s OVER (%s)", w.Function, w.Definition)
+}
+
+// TransformUp implements the Expression interface.
+func (w *Window) TransformUp(f sql.TransformExprFunc) (sql.Expression, error) {
+	fn, err := w.Function.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	definition, err := w.Definition.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return f(NewWindow(fn, definition))
+}
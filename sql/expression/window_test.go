@@ -0,0 +1,58 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+func TestWindow(t *testing.T) {
+	require := require.New(t)
+
+	w := NewWindow(
+		NewUnresolvedFunction("sum", true, NewUnresolvedColumn("a")),
+		&sql.WindowDefinition{
+			PartitionBy: []sql.Expression{NewUnresolvedColumn("b")},
+			OrderBy: []sql.WindowOrderBy{
+				{Column: NewUnresolvedColumn("c"), Descending: true},
+			},
+			Frame: &sql.WindowFrame{
+				Unit:  sql.RowsFrame,
+				Start: sql.WindowBound{Type: sql.Preceding, Offset: NewLiteral(int64(1), sql.Int64)},
+				End:   sql.WindowBound{Type: sql.CurrentRow},
+			},
+		},
+	)
+
+	require.False(w.Resolved())
+	require.Len(w.Children(), 3)
+	require.Equal(
+		"sum(a) OVER (PARTITION BY b ORDER BY c DESC ROWS BETWEEN 1 PRECEDING AND CURRENT ROW)",
+		w.String(),
+	)
+
+	schema := sql.Schema{
+		{Name: "a", Type: sql.Int64},
+		{Name: "b", Type: sql.Text},
+		{Name: "c", Type: sql.Int64},
+	}
+
+	e, err := w.TransformUp(func(e sql.Expression) (sql.Expression, error) {
+		if uc, ok := e.(*UnresolvedColumn); ok {
+			idx := schema.IndexOf(uc.Name(), "")
+			return NewGetField(idx, schema[idx].Type, uc.Name(), false), nil
+		}
+		return e, nil
+	})
+	require.NoError(err)
+
+	w = e.(*Window)
+	require.Equal(NewGetField(1, sql.Text, "b", false), w.Definition.PartitionBy[0])
+	require.Equal(NewGetField(2, sql.Int64, "c", false), w.Definition.OrderBy[0].Column)
+	require.True(w.Definition.OrderBy[0].Descending)
+	require.NotNil(w.Definition.Frame)
+
+	_, err = w.Eval(sql.NewEmptyContext(), nil)
+	require.True(ErrInvalidWindowContext.Is(err))
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"gopkg.in/src-d/go-errors.v1"
 )
@@ -140,6 +141,14 @@ type IndexRegistry struct {
 	rcmut            sync.RWMutex
 	refCounts        map[indexKey]int
 	deleteIndexQueue map[indexKey]chan<- struct{}
+
+	version uint64
+}
+
+// Version returns the current version of the registry, which changes every
+// time an index is added, becomes ready or is deleted.
+func (r *IndexRegistry) Version() uint64 {
+	return atomic.LoadUint64(&r.version)
 }
 
 // NewIndexRegistry returns a new Index Registry.
@@ -203,6 +212,10 @@ func (r *IndexRegistry) LoadIndexes(dbs Databases) error {
 					r.indexOrder = append(r.indexOrder, k)
 					r.statuses[k] = IndexReady
 				}
+
+				if len(indexes) > 0 {
+					atomic.AddUint64(&r.version, 1)
+				}
 			}
 		}
 	}
@@ -234,6 +247,7 @@ func (r *IndexRegistry) canUseIndex(idx Index) bool {
 // setStatus is not thread-safe, it should be guarded using mut.
 func (r *IndexRegistry) setStatus(idx Index, status IndexStatus) {
 	r.statuses[indexKey{idx.Database(), idx.ID()}] = status
+	atomic.AddUint64(&r.version, 1)
 }
 
 // ReleaseIndex releases an index after it's been used.
@@ -267,10 +281,36 @@ func (r *IndexRegistry) Index(db, id string) Index {
 	return idx
 }
 
+// HasIndex returns whether the given table has an index with the given id,
+// whether it's ready to be used or not. Ids are case insensitive.
+func (r *IndexRegistry) HasIndex(db, table, id string) bool {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	for k, idx := range r.indexes {
+		if k.db == db && idx.Table() == table && strings.EqualFold(k.id, id) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // IndexByExpression returns an index by the given expression. It will return
 // nil it the index is not found. If more than one expression is given, all
 // of them must match for the index to be matched.
 func (r *IndexRegistry) IndexByExpression(db string, expr ...Expression) Index {
+	return r.IndexByExpressionFunc(db, func(Index) bool { return true }, expr...)
+}
+
+// IndexByExpressionFunc returns the first index matching the given
+// expressions, just like IndexByExpression, but only among the indexes
+// for which the given function returns true.
+func (r *IndexRegistry) IndexByExpressionFunc(
+	db string,
+	fn func(Index) bool,
+	expr ...Expression,
+) Index {
 	r.mut.RLock()
 	defer r.mut.RUnlock()
 
@@ -285,7 +325,7 @@ func (r *IndexRegistry) IndexByExpression(db string, expr ...Expression) Index {
 			continue
 		}
 
-		if idx.Database() == db {
+		if idx.Database() == db && fn(idx) {
 			if exprListsMatch(idx.ExpressionHashes(), expressionHashes) {
 				r.retainIndex(db, idx.ID())
 				return idx
@@ -365,7 +405,7 @@ var (
 	ErrIndexIDAlreadyRegistered = errors.NewKind("an index with id %q has already been registered")
 
 	// ErrIndexExpressionAlreadyRegistered is the error returned when there is
-	// already an index with the same expression.
+	// already an index of the same driver with the same expression.
 	ErrIndexExpressionAlreadyRegistered = errors.NewKind("there is already an index registered for the expressions: %s")
 
 	// ErrIndexNotFound is returned when the index could not be found.
@@ -389,7 +429,10 @@ func (r *IndexRegistry) validateIndexToAdd(idx Index) error {
 			return ErrIndexIDAlreadyRegistered.New(idx.ID())
 		}
 
-		if exprListsEqual(i.ExpressionHashes(), idx.ExpressionHashes()) {
+		// Indexes of different drivers can index the same expressions, so
+		// queries can choose between them with index hints.
+		if i.Driver() == idx.Driver() &&
+			exprListsEqual(i.ExpressionHashes(), idx.ExpressionHashes()) {
 			var exprs = make([]string, len(idx.ExpressionHashes()))
 			for i, e := range idx.ExpressionHashes() {
 				exprs[i] = hex.EncodeToString(e)
@@ -15,10 +15,16 @@ const (
 	ConfigFileName = "config.yml"
 	// ProcessingFileName is the name of the processing index file.
 	ProcessingFileName = ".processing"
+	// Version is the version of the format of the keys of the indexes.
+	// Indexes with keys in an older format must be created again. Version 1
+	// is the first one with keys normalized according to the collation of
+	// the indexed expressions.
+	Version = 1
 )
 
 // Config represents index configuration
 type Config struct {
+	Version     int
 	DB          string
 	Table       string
 	ID          string
@@ -39,6 +45,7 @@ func NewConfig(db, table, id string,
 	}
 
 	cfg := &Config{
+		Version:     Version,
 		DB:          db,
 		Table:       table,
 		ID:          id,
@@ -44,6 +44,7 @@ func TestConfig(t *testing.T) {
 	cfg2, err := ReadConfigFile(path)
 	require.NoError(err)
 	require.Equal(cfg1, cfg2)
+	require.Equal(Version, cfg2.Version)
 }
 
 func TestProcessingFile(t *testing.T) {
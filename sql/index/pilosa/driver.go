@@ -85,7 +85,7 @@ func (d *Driver) LoadAll(db, table string) ([]sql.Index, error) {
 		if info.IsDir() && path != root && info.Name() != "." && info.Name() != ".." {
 			idx, err := d.loadIndex(path)
 			if err != nil {
-				if !errCorruptIndex.Is(err) {
+				if !errCorruptIndex.Is(err) && !errOutdatedIndex.Is(err) {
 					errors = append(errors, err.Error())
 				}
 
@@ -104,7 +104,10 @@ func (d *Driver) LoadAll(db, table string) ([]sql.Index, error) {
 	return indexes, err
 }
 
-var errCorruptIndex = errors.NewKind("the index in %q is corrupt")
+var (
+	errCorruptIndex  = errors.NewKind("the index in %q is corrupt")
+	errOutdatedIndex = errors.NewKind("the index in %q has version %d, but %d is required")
+)
 
 func (d *Driver) loadIndex(path string) (sql.Index, error) {
 	ok, err := index.ExistsProcessingFile(path)
@@ -131,6 +134,16 @@ func (d *Driver) loadIndex(path string) (sql.Index, error) {
 		return nil, err
 	}
 
+	if cfg.Version < index.Version {
+		log := logrus.WithFields(logrus.Fields{
+			"path":    path,
+			"version": cfg.Version,
+		})
+		log.Warn("index was created with an older version of its keys and will not be loaded, it must be created again with CREATE INDEX")
+
+		return nil, errOutdatedIndex.New(path, cfg.Version, index.Version)
+	}
+
 	idx := newPilosaIndex(path, d.client, cfg)
 	return idx, nil
 }
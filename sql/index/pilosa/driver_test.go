@@ -205,6 +205,25 @@ func TestLoadCorruptedIndex(t *testing.T) {
 	require.True(os.IsNotExist(err))
 }
 
+func TestLoadOutdatedIndex(t *testing.T) {
+	require := require.New(t)
+	path, err := ioutil.TempDir(os.TempDir(), "indexes")
+	require.NoError(err)
+	defer os.RemoveAll(path)
+
+	cfg := index.NewConfig("db", "table", "id", makeExpressions("hash1"), DriverID, nil)
+	cfg.Version = 0
+	require.NoError(index.WriteConfigFile(path, cfg))
+
+	_, err = new(Driver).loadIndex(path)
+	require.Error(err)
+	require.True(errOutdatedIndex.Is(err))
+
+	cfg, err = index.ReadConfigFile(path)
+	require.NoError(err)
+	require.Equal(0, cfg.Version)
+}
+
 func TestPilosaHiccup(t *testing.T) {
 	if !dockerIsRunning {
 		t.Skipf("Skip TestPilosaHiccup: %s", dockerCmdOutput)
@@ -43,6 +43,41 @@ func TestIndexByExpression(t *testing.T) {
 	require.Nil(idx)
 }
 
+func TestIndexByExpressionFunc(t *testing.T) {
+	require := require.New(t)
+
+	r := NewIndexRegistry()
+	r.indexOrder = []indexKey{
+		{"foo", "a"},
+		{"foo", "b"},
+	}
+	r.indexes = map[indexKey]Index{
+		indexKey{"foo", "a"}: &dummyIdx{
+			id:       "a",
+			database: "foo",
+			expr:     []Expression{dummyExpr{1, "2"}},
+		},
+		indexKey{"foo", "b"}: &dummyIdx{
+			id:       "b",
+			database: "foo",
+			expr:     []Expression{dummyExpr{1, "2"}},
+		},
+	}
+	r.statuses[indexKey{"foo", "a"}] = IndexReady
+	r.statuses[indexKey{"foo", "b"}] = IndexReady
+
+	idx := r.IndexByExpressionFunc("foo", func(idx Index) bool {
+		return idx.ID() != "a"
+	}, dummyExpr{1, "2"})
+	require.NotNil(idx)
+	require.Equal("b", idx.ID())
+
+	idx = r.IndexByExpressionFunc("foo", func(Index) bool {
+		return false
+	}, dummyExpr{1, "2"})
+	require.Nil(idx)
+}
+
 func TestAddIndex(t *testing.T) {
 	require := require.New(t)
 	r := NewIndexRegistry()
@@ -77,6 +112,16 @@ func TestAddIndex(t *testing.T) {
 	})
 	require.Error(err)
 	require.True(ErrIndexExpressionAlreadyRegistered.Is(err))
+
+	// Indexes of other drivers can have the same expressions.
+	done, err = r.AddIndex(&otherDriverIdx{dummyIdx{
+		id:       "another",
+		expr:     []Expression{new(dummyExpr)},
+		database: "foo",
+		table:    "foo",
+	}})
+	require.NoError(err)
+	close(done)
 }
 
 func TestDeleteIndex(t *testing.T) {
@@ -302,6 +347,12 @@ func (i dummyIdx) Database() string                        { return i.database }
 func (i dummyIdx) Table() string                           { return i.table }
 func (i dummyIdx) Driver() string                          { return "dummy" }
 
+type otherDriverIdx struct {
+	dummyIdx
+}
+
+func (otherDriverIdx) Driver() string { return "other" }
+
 type dummyExpr struct {
 	index   int
 	colName string
@@ -0,0 +1,386 @@
+package sql
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrInvalidJSONText is returned when a string is not a valid JSON
+// document.
+var ErrInvalidJSONText = errors.NewKind("invalid JSON text: %s")
+
+// ErrJSONNullKey is returned when a key of a JSON object is NULL.
+var ErrJSONNullKey = errors.NewKind("JSON documents may not contain NULL member names")
+
+// JSON values are represented in memory as the values that result from
+// decoding a JSON document: nil, bool, int64, uint64, float64, string,
+// []interface{} and map[string]interface{}. Values of JSON columns can also
+// be JSON documents as []byte or any other value that can be encoded as
+// JSON, which JSONValue converts to that representation.
+
+// JSONValue returns the JSON value of a value of a JSON column.
+func JSONValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil, bool, int64, uint64, float64, string:
+		return v, nil
+	case []byte:
+		if !json.Valid(v) {
+			return string(v), nil
+		}
+		return ParseJSON(v)
+	case json.RawMessage:
+		return JSONValue([]byte(v))
+	case []interface{}:
+		var values = make([]interface{}, len(v))
+		for i, e := range v {
+			e, err := JSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = e
+		}
+		return values, nil
+	case map[string]interface{}:
+		var values = make(map[string]interface{}, len(v))
+		for k, e := range v {
+			e, err := JSONValue(e)
+			if err != nil {
+				return nil, err
+			}
+			values[k] = e
+		}
+		return values, nil
+	case int, int8, int16, int32:
+		return Int64.Convert(v)
+	case uint, uint8, uint16, uint32:
+		return Uint64.Convert(v)
+	case float32:
+		return float64(v), nil
+	case decimal.Decimal:
+		return jsonNumber(v.String()), nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return ParseJSON(data)
+	}
+}
+
+// ToJSON converts the value v of type t to a JSON value. Strings and
+// temporal values are converted to JSON strings and booleans to JSON
+// booleans.
+func ToJSON(t Type, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	switch {
+	case t == JSON || IsNumber(t):
+		return JSONValue(v)
+	case t == Boolean:
+		return Boolean.Convert(v)
+	case IsText(t) || IsTemporal(t):
+		return ConvertFrom(Text, t, v)
+	default:
+		if b, ok := v.([]byte); ok {
+			return string(b), nil
+		}
+		return JSONValue(v)
+	}
+}
+
+// ParseJSON parses the given JSON document and returns its JSON value.
+func ParseJSON(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, ErrInvalidJSONText.New(data)
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, ErrInvalidJSONText.New(data)
+	}
+
+	return normalizeJSONNumbers(v), nil
+}
+
+func normalizeJSONNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		return jsonNumber(string(v))
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalizeJSONNumbers(e)
+		}
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalizeJSONNumbers(e)
+		}
+	}
+	return v
+}
+
+// jsonNumber returns the JSON value of the given number, which is an int64
+// or a uint64 if it is an integer that fits in one of them, and a float64
+// otherwise.
+func jsonNumber(n string) interface{} {
+	if i, err := strconv.ParseInt(n, 10, 64); err == nil {
+		return i
+	}
+
+	if u, err := strconv.ParseUint(n, 10, 64); err == nil {
+		return u
+	}
+
+	f, _ := strconv.ParseFloat(n, 64)
+	return f
+}
+
+// MarshalJSON returns the JSON document of a JSON value formatted as MySQL
+// does, with a space after commas and colons and object keys sorted as
+// JSONKeys does.
+func MarshalJSON(v interface{}) ([]byte, error) {
+	v, err := JSONValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeJSON(buf *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, e := range v {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			if err := writeJSON(buf, e); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		buf.WriteByte('{')
+		for i, k := range JSONKeys(v) {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			if err := writeJSON(buf, k); err != nil {
+				return err
+			}
+			buf.WriteString(": ")
+			if err := writeJSON(buf, v[k]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		enc := json.NewEncoder(buf)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		// Encode terminates the document with a newline.
+		buf.Truncate(buf.Len() - 1)
+	}
+	return nil
+}
+
+// jsonTypeOrder is the order of the JSON values of different types, which
+// are compared by their type: null < numbers < strings < objects < arrays
+// < booleans.
+func jsonTypeOrder(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return 0
+	case int64, uint64, float64:
+		return 1
+	case string:
+		return 2
+	case map[string]interface{}:
+		return 3
+	case []interface{}:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// CompareJSON compares two JSON values as MySQL does. Values of different
+// types are ordered by their type. Numbers are compared by their value,
+// strings by their bytes and arrays element by element. Objects that are
+// not equal are in an order that is not specified but deterministic.
+func CompareJSON(a, b interface{}) (int, error) {
+	a, err := JSONValue(a)
+	if err != nil {
+		return 0, err
+	}
+
+	b, err = JSONValue(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return compareJSONValues(a, b), nil
+}
+
+func compareJSONValues(a, b interface{}) int {
+	if ta, tb := jsonTypeOrder(a), jsonTypeOrder(b); ta != tb {
+		return compareInts(ta, tb)
+	}
+
+	switch a := a.(type) {
+	case nil:
+		return 0
+	case bool:
+		b := b.(bool)
+		switch {
+		case a == b:
+			return 0
+		case b:
+			return -1
+		default:
+			return 1
+		}
+	case string:
+		return strings.Compare(a, b.(string))
+	case []interface{}:
+		b := b.([]interface{})
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if cmp := compareJSONValues(a[i], b[i]); cmp != 0 {
+				return cmp
+			}
+		}
+		return compareInts(len(a), len(b))
+	case map[string]interface{}:
+		b := b.(map[string]interface{})
+		if cmp := compareInts(len(a), len(b)); cmp != 0 {
+			return cmp
+		}
+
+		keysA, keysB := JSONKeys(a), JSONKeys(b)
+		for i := range keysA {
+			if cmp := strings.Compare(keysA[i], keysB[i]); cmp != 0 {
+				return cmp
+			}
+		}
+
+		for _, k := range keysA {
+			if cmp := compareJSONValues(a[k], b[k]); cmp != 0 {
+				return cmp
+			}
+		}
+		return 0
+	default:
+		return compareJSONNumbers(a, b)
+	}
+}
+
+func compareJSONNumbers(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		switch b := b.(type) {
+		case int64:
+			return compareInts64(a, b)
+		case uint64:
+			if a < 0 {
+				return -1
+			}
+			return compareUints64(uint64(a), b)
+		}
+	case uint64:
+		switch b := b.(type) {
+		case int64:
+			if b < 0 {
+				return 1
+			}
+			return compareUints64(a, uint64(b))
+		case uint64:
+			return compareUints64(a, b)
+		}
+	}
+
+	fa, fb := jsonFloat(a), jsonFloat(b)
+	switch {
+	case fa < fb:
+		return -1
+	case fa > fb:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func jsonFloat(n interface{}) float64 {
+	switch n := n.(type) {
+	case int64:
+		return float64(n)
+	case uint64:
+		return float64(n)
+	case float64:
+		return n
+	default:
+		return math.NaN()
+	}
+}
+
+func compareInts(a, b int) int {
+	return compareInts64(int64(a), int64(b))
+}
+
+func compareInts64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareUints64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// JSONKeys returns the keys of a JSON object in the order MySQL keeps them,
+// which is by length and then by their bytes.
+func JSONKeys(o map[string]interface{}) []string {
+	var keys = make([]string, 0, len(o))
+	for k := range o {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
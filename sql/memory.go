@@ -0,0 +1,143 @@
+package sql
+
+import (
+	"sync"
+	"time"
+
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+// ErrMemoryLimitExceeded is returned when a query tries to use more memory
+// than the one allowed by its limit or the limit of the engine.
+var ErrMemoryLimitExceeded = errors.NewKind("memory limit exceeded: %d bytes requested with %d bytes in use, but the limit is %d bytes")
+
+// MemoryTracker keeps track of the memory used by the nodes that need to
+// buffer rows in order to produce their results. A tracker may have a parent,
+// in which case all the memory charged to the tracker will be charged to its
+// parent as well. That way, a tracker can be created per query with the
+// engine tracker as its parent to enforce both a per-query and a per-engine
+// limit.
+// A nil *MemoryTracker is valid and does not track anything.
+type MemoryTracker struct {
+	mu     sync.Mutex
+	limit  uint64
+	used   uint64
+	parent *MemoryTracker
+}
+
+// NewMemoryTracker creates a new MemoryTracker with the given limit in bytes
+// and parent. If the limit is zero, the tracker has no limit, and the parent
+// can be nil.
+func NewMemoryTracker(limit uint64, parent *MemoryTracker) *MemoryTracker {
+	return &MemoryTracker{limit: limit, parent: parent}
+}
+
+// Grow charges the given number of bytes to the tracker and all its
+// ancestors. If any of them exceeds its limit, ErrMemoryLimitExceeded is
+// returned and nothing is charged.
+func (t *MemoryTracker) Grow(n uint64) error {
+	if t == nil {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.limit > 0 && t.used+n > t.limit {
+		return ErrMemoryLimitExceeded.New(n, t.used, t.limit)
+	}
+
+	if err := t.parent.Grow(n); err != nil {
+		return err
+	}
+
+	t.used += n
+	return nil
+}
+
+// Shrink releases the given number of bytes from the tracker and all its
+// ancestors.
+func (t *MemoryTracker) Shrink(n uint64) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	if n > t.used {
+		n = t.used
+	}
+	t.used -= n
+	t.mu.Unlock()
+
+	t.parent.Shrink(n)
+}
+
+// Usage returns the number of bytes currently charged to the tracker.
+func (t *MemoryTracker) Usage() uint64 {
+	if t == nil {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.used
+}
+
+// Limit returns the limit in bytes of the tracker. Zero means there is no
+// limit.
+func (t *MemoryTracker) Limit() uint64 {
+	if t == nil {
+		return 0
+	}
+
+	return t.limit
+}
+
+// Sizes used to estimate the memory used by rows. They don't need to be
+// exact, just good enough to detect queries that use way too much memory.
+const (
+	interfaceSize = 16
+	sliceSize     = 24
+	stringSize    = 16
+	numberSize    = 8
+	timeSize      = 24
+)
+
+// EstimateRowSize returns an estimation of the number of bytes used by the
+// given row.
+func EstimateRowSize(row Row) uint64 {
+	size := uint64(sliceSize)
+	for _, v := range row {
+		size += estimateValueSize(v)
+	}
+	return size
+}
+
+func estimateValueSize(v interface{}) uint64 {
+	switch v := v.(type) {
+	case nil:
+		return interfaceSize
+	case bool, int8, uint8:
+		return interfaceSize + 1
+	case int16, uint16:
+		return interfaceSize + 2
+	case int32, uint32, float32:
+		return interfaceSize + 4
+	case string:
+		return interfaceSize + stringSize + uint64(len(v))
+	case []byte:
+		return interfaceSize + sliceSize + uint64(len(v))
+	case time.Time:
+		return interfaceSize + timeSize
+	case []interface{}:
+		size := uint64(interfaceSize + sliceSize)
+		for _, v := range v {
+			size += estimateValueSize(v)
+		}
+		return size
+	case Row:
+		return interfaceSize + EstimateRowSize(v)
+	default:
+		return interfaceSize + numberSize
+	}
+}
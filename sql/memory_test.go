@@ -0,0 +1,67 @@
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryTracker(t *testing.T) {
+	require := require.New(t)
+
+	engine := NewMemoryTracker(100, nil)
+	q1 := NewMemoryTracker(60, engine)
+	q2 := NewMemoryTracker(0, engine)
+
+	require.NoError(q1.Grow(50))
+	require.Equal(uint64(50), q1.Usage())
+	require.Equal(uint64(50), engine.Usage())
+
+	err := q1.Grow(20)
+	require.Error(err)
+	require.True(ErrMemoryLimitExceeded.Is(err))
+	require.Equal(uint64(50), q1.Usage())
+
+	require.NoError(q2.Grow(40))
+	require.Equal(uint64(90), engine.Usage())
+
+	err = q2.Grow(20)
+	require.Error(err)
+	require.True(ErrMemoryLimitExceeded.Is(err))
+	require.Equal(uint64(40), q2.Usage())
+	require.Equal(uint64(90), engine.Usage())
+
+	q1.Shrink(50)
+	require.NoError(q2.Grow(20))
+	require.Equal(uint64(0), q1.Usage())
+	require.Equal(uint64(60), q2.Usage())
+	require.Equal(uint64(60), engine.Usage())
+
+	q2.Shrink(100)
+	require.Equal(uint64(0), q2.Usage())
+	require.Equal(uint64(0), engine.Usage())
+}
+
+func TestNilMemoryTracker(t *testing.T) {
+	require := require.New(t)
+
+	var tracker *MemoryTracker
+	require.NoError(tracker.Grow(100))
+	tracker.Shrink(100)
+	require.Equal(uint64(0), tracker.Usage())
+	require.Equal(uint64(0), tracker.Limit())
+}
+
+func TestEstimateRowSize(t *testing.T) {
+	require := require.New(t)
+
+	small := EstimateRowSize(NewRow(int64(1), "a"))
+	big := EstimateRowSize(NewRow(int64(1), "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"))
+	require.True(small < big)
+
+	require.Equal(
+		EstimateRowSize(NewRow(time.Now())),
+		EstimateRowSize(NewRow(time.Unix(0, 0))),
+	)
+}
@@ -0,0 +1,76 @@
+package parse
+
+import (
+	"bufio"
+	"strings"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+)
+
+func parseAnalyzeTable(s string) (sql.Node, error) {
+	r := bufio.NewReader(strings.NewReader(s))
+
+	var tables []string
+	steps := []parseFunc{
+		expect("analyze"),
+		skipSpaces,
+		expect("table"),
+		skipSpaces,
+		readIdentList(&tables),
+		skipSpaces,
+		checkEOF,
+	}
+
+	for _, step := range steps {
+		if err := step(r); err != nil {
+			return nil, err
+		}
+	}
+
+	var nodes = make([]sql.Node, len(tables))
+	for i, t := range tables {
+		nodes[i] = plan.NewUnresolvedTable(t)
+	}
+
+	return plan.NewAnalyzeTable(nodes...), nil
+}
+
+// readIdentList reads a non-empty list of identifiers separated by commas.
+func readIdentList(idents *[]string) parseFunc {
+	return func(rd *bufio.Reader) error {
+		for {
+			var ident string
+			if err := readIdent(&ident)(rd); err != nil {
+				return err
+			}
+
+			if ident == "" {
+				r, _, err := rd.ReadRune()
+				if err != nil {
+					return errUnexpectedSyntax.New("identifier", "EOF")
+				}
+				return errUnexpectedSyntax.New("identifier", string(r))
+			}
+
+			*idents = append(*idents, ident)
+
+			if err := skipSpaces(rd); err != nil {
+				return err
+			}
+
+			r, _, err := rd.ReadRune()
+			if err != nil {
+				return nil
+			}
+
+			if r != ',' {
+				return rd.UnreadRune()
+			}
+
+			if err := skipSpaces(rd); err != nil {
+				return err
+			}
+		}
+	}
+}
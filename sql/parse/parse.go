@@ -5,12 +5,15 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	opentracing "github.com/opentracing/opentracing-go"
 	"gopkg.in/src-d/go-errors.v1"
 	"gopkg.in/src-d/go-mysql-server.v0/sql"
 	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression/function/aggregation"
 	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+	"gopkg.in/src-d/go-vitess.v0/sqltypes"
 	"gopkg.in/src-d/go-vitess.v0/vt/sqlparser"
 )
 
@@ -33,6 +36,7 @@ var (
 	createIndexRegex    = regexp.MustCompile(`^create\s+index\s+`)
 	dropIndexRegex      = regexp.MustCompile(`^drop\s+index\s+`)
 	describeRegex       = regexp.MustCompile(`^(describe|desc|explain)\s+(.*)\s+`)
+	analyzeTableRegex   = regexp.MustCompile(`^analyze\s+table\s+`)
 )
 
 // Parse parses the given SQL sentence and returns the corresponding node.
@@ -54,14 +58,77 @@ func Parse(ctx *sql.Context, s string) (sql.Node, error) {
 		return parseDropIndex(s)
 	case describeRegex.MatchString(lowerQuery):
 		return parseDescribeQuery(ctx, s)
+	case analyzeTableRegex.MatchString(lowerQuery):
+		return parseAnalyzeTable(s)
 	}
 
-	stmt, err := sqlparser.Parse(s)
+	query, windows, err := extractWindows(s)
 	if err != nil {
 		return nil, err
 	}
 
-	return convert(ctx, stmt, s)
+	query, rollup, err := extractRollup(query)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := convert(ctx, stmt, s)
+	if err != nil {
+		return nil, err
+	}
+
+	if rollup {
+		node, err = resolveRollup(node)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(windows) == 0 {
+		return node, nil
+	}
+
+	return resolveWindows(node, windows)
+}
+
+// decimalLiteral returns the given number as an exact decimal literal, as
+// MySQL does with the numbers that have a decimal point but no exponent.
+// Numbers with an exponent or too many digits for a decimal are not exact.
+func decimalLiteral(s string) (sql.Expression, bool) {
+	if strings.ContainsAny(s, "eE") {
+		return nil, false
+	}
+
+	var integer, fraction = s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+
+	digits := len(strings.TrimLeft(integer, "+-0"))
+	if digits+len(fraction) == 0 {
+		digits = 1
+	}
+
+	if digits+len(fraction) > sql.DecimalMaxPrecision || len(fraction) > sql.DecimalMaxScale {
+		return nil, false
+	}
+
+	typ, err := sql.Decimal(uint8(digits+len(fraction)), uint8(len(fraction)))
+	if err != nil {
+		return nil, false
+	}
+
+	val, err := typ.Convert(s)
+	if err != nil {
+		return nil, false
+	}
+
+	return expression.NewLiteral(val, typ), true
 }
 
 func parseDescribeTables(s string) (sql.Node, error) {
@@ -194,11 +261,23 @@ func columnDefinitionToSchema(colDef []*sqlparser.ColumnDefinition) (sql.Schema,
 	var schema sql.Schema
 	for _, cd := range colDef {
 		typ := cd.Type
-		internalTyp, err := sql.MysqlTypeToType(typ.SQLType())
+		internalTyp, err := columnTypeToType(&typ)
 		if err != nil {
 			return nil, err
 		}
 
+		if typ.Collate != "" {
+			collation, err := sql.ParseCollation(typ.Collate)
+			if err != nil {
+				return nil, err
+			}
+
+			internalTyp, err = sql.WithCollation(internalTyp, collation)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		schema = append(schema, &sql.Column{
 			Nullable: !bool(typ.NotNull),
 			Type:     internalTyp,
@@ -211,6 +290,96 @@ func columnDefinitionToSchema(colDef []*sqlparser.ColumnDefinition) (sql.Schema,
 	return schema, nil
 }
 
+func columnTypeToType(typ *sqlparser.ColumnType) (sql.Type, error) {
+	switch typ.SQLType() {
+	case sqltypes.Decimal:
+		precision, err := sqlValToUint8(typ.Length, sql.DecimalDefaultPrecision)
+		if err != nil {
+			return nil, err
+		}
+
+		scale, err := sqlValToUint8(typ.Scale, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		return sql.Decimal(precision, scale)
+	case sqltypes.Char:
+		length, err := sqlValToInt(typ.Length, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		return sql.Char(length)
+	case sqltypes.VarChar:
+		if typ.Length == nil {
+			return nil, ErrUnsupportedSyntax.New("VARCHAR without length")
+		}
+
+		length, err := sqlValToInt(typ.Length, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		return sql.VarChar(length)
+	case sqltypes.Datetime:
+		precision, err := sqlValToUint8(typ.Length, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		return sql.DatetimeWithPrecision(precision)
+	case sqltypes.Time:
+		precision, err := sqlValToUint8(typ.Length, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		return sql.TimeWithPrecision(precision)
+	case sqltypes.Enum:
+		return sql.Enum(enumValues(typ.EnumValues)...)
+	case sqltypes.Set:
+		return sql.Set(enumValues(typ.EnumValues)...)
+	}
+
+	return sql.MysqlTypeToType(typ.SQLType())
+}
+
+// enumValues returns the values of an ENUM or SET column definition, which
+// the parser keeps quoted, without their quotes.
+func enumValues(values []string) []string {
+	result := make([]string, len(values))
+	for i, v := range values {
+		result[i] = strings.TrimSuffix(strings.TrimPrefix(v, "'"), "'")
+	}
+	return result
+}
+
+// sqlValToInt returns the given value as an int or the default value if
+// it's nil.
+func sqlValToInt(v *sqlparser.SQLVal, def int) (int, error) {
+	if v == nil {
+		return def, nil
+	}
+
+	return strconv.Atoi(string(v.Val))
+}
+
+// sqlValToUint8 returns the given value as an uint8 or the default value if
+// it's nil.
+func sqlValToUint8(v *sqlparser.SQLVal, def uint8) (uint8, error) {
+	if v == nil {
+		return def, nil
+	}
+
+	n, err := strconv.ParseUint(string(v.Val), 10, 8)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint8(n), nil
+}
+
 func columnsToStrings(cols sqlparser.Columns) []string {
 	res := make([]string, len(cols))
 	for i, c := range cols {
@@ -296,11 +465,17 @@ func tableExprToTable(
 				return nil, ErrUnsupportedFeature.New("table name qualifiers")
 			}
 
+			hint, err := indexHint(t.Hints)
+			if err != nil {
+				return nil, err
+			}
+
 			node := plan.NewUnresolvedTable(e.Name.String())
 			if !t.As.IsEmpty() {
-				return plan.NewTableAlias(t.As.String(), node), nil
+				return plan.NewTableAlias(t.As.String(), node).WithIndexHint(hint), nil
 			}
 
+			node.IndexHint = hint
 			return node, nil
 		case *sqlparser.Subquery:
 			node, err := convert(ctx, e.Select, "")
@@ -351,6 +526,31 @@ func tableExprToTable(
 	}
 }
 
+func indexHint(h *sqlparser.IndexHints) (*plan.IndexHint, error) {
+	if h == nil {
+		return nil, nil
+	}
+
+	var typ plan.IndexHintType
+	switch h.Type {
+	case sqlparser.UseStr:
+		typ = plan.UseIndex
+	case sqlparser.ForceStr:
+		typ = plan.ForceIndex
+	case sqlparser.IgnoreStr:
+		typ = plan.IgnoreIndex
+	default:
+		return nil, ErrUnsupportedSyntax.New(h)
+	}
+
+	var indexes = make([]string, len(h.Indexes))
+	for i, idx := range h.Indexes {
+		indexes[i] = idx.String()
+	}
+
+	return plan.NewIndexHint(typ, indexes...), nil
+}
+
 func whereToFilter(w *sqlparser.Where, child sql.Node) (*plan.Filter, error) {
 	c, err := exprToExpression(w.Expr)
 	if err != nil {
@@ -361,6 +561,15 @@ func whereToFilter(w *sqlparser.Where, child sql.Node) (*plan.Filter, error) {
 }
 
 func orderByToSort(ob sqlparser.OrderBy, child sql.Node) (*plan.Sort, error) {
+	sortFields, err := orderByToSortFields(ob)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.NewSort(sortFields, child), nil
+}
+
+func orderByToSortFields(ob sqlparser.OrderBy) ([]plan.SortField, error) {
 	var sortFields []plan.SortField
 	for _, o := range ob {
 		e, err := exprToExpression(o.Expr)
@@ -382,7 +591,7 @@ func orderByToSort(ob sqlparser.OrderBy, child sql.Node) (*plan.Sort, error) {
 		sortFields = append(sortFields, sf)
 	}
 
-	return plan.NewSort(sortFields, child), nil
+	return sortFields, nil
 }
 
 func limitToLimit(
@@ -429,10 +638,64 @@ func offsetToOffset(
 	return plan.NewOffset(n.(int64), child), nil
 }
 
+// aggregateFunctions are the aggregate functions that the SQL parser does
+// not know about.
+var aggregateFunctions = map[string]bool{
+	"any_value":             true,
+	"approx_count_distinct": true,
+	"approx_median":         true,
+	"approx_percentile":     true,
+	"grouping":              true,
+	"json_arrayagg":         true,
+	"json_objectagg":        true,
+}
+
+// groupConcatSeparator matches the separator of GROUP_CONCAT as the SQL
+// parser formats it.
+var groupConcatSeparator = regexp.MustCompile(`^ separator '(?s:(.*))'$`)
+
+func groupConcatToExpression(gc *sqlparser.GroupConcatExpr) (sql.Expression, error) {
+	args, err := selectExprsToExpressions(gc.Exprs)
+	if err != nil {
+		return nil, err
+	}
+
+	sortFields, err := orderByToSortFields(gc.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	var orderBy []aggregation.OrderByField
+	for _, f := range sortFields {
+		orderBy = append(orderBy, aggregation.OrderByField{
+			Column:     f.Column,
+			Descending: f.Order == plan.Descending,
+		})
+	}
+
+	separator := aggregation.DefaultGroupConcatSeparator
+	if gc.Separator != "" {
+		m := groupConcatSeparator.FindStringSubmatch(gc.Separator)
+		if m == nil {
+			return nil, ErrUnsupportedSyntax.New(gc)
+		}
+		separator = m[1]
+	}
+
+	return aggregation.NewGroupConcat(
+		gc.Distinct == sqlparser.DistinctStr,
+		args,
+		orderBy,
+		separator,
+	), nil
+}
+
 func isAggregate(e sql.Expression) bool {
 	switch v := e.(type) {
 	case *expression.UnresolvedFunction:
 		return v.IsAggregate
+	case sql.Aggregation:
+		return true
 	case *expression.Alias:
 		return isAggregate(v.Child)
 	default:
@@ -490,6 +753,18 @@ func exprToExpression(e sqlparser.Expr) (sql.Expression, error) {
 		return comparisonExprToExpression(v)
 	case *sqlparser.IsExpr:
 		return isExprToExpression(v)
+	case *sqlparser.CollateExpr:
+		collation, err := sql.ParseCollation(v.Charset)
+		if err != nil {
+			return nil, err
+		}
+
+		child, err := exprToExpression(v.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		return expression.NewCollate(child, collation), nil
 	case *sqlparser.NotExpr:
 		c, err := exprToExpression(v.Expr)
 		if err != nil {
@@ -518,10 +793,39 @@ func exprToExpression(e sqlparser.Expr) (sql.Expression, error) {
 			return nil, err
 		}
 
-		return expression.NewUnresolvedFunction(v.Name.Lowered(),
-			v.IsAggregate(), exprs...), nil
+		// The unit of TIMESTAMPDIFF is a keyword, which is parsed as a
+		// column name.
+		if v.Name.Lowered() == "timestampdiff" && len(exprs) > 0 {
+			if col, ok := exprs[0].(*expression.UnresolvedColumn); ok {
+				exprs[0] = expression.NewLiteral(strings.ToUpper(col.Name()), sql.Text)
+			}
+		}
+
+		name := v.Name.Lowered()
+		if v.Distinct {
+			return expression.NewUnresolvedDistinctFunction(name, exprs...), nil
+		}
+
+		return expression.NewUnresolvedFunction(name,
+			v.IsAggregate() || aggregateFunctions[name], exprs...), nil
+	case *sqlparser.GroupConcatExpr:
+		return groupConcatToExpression(v)
 	case *sqlparser.ParenExpr:
 		return exprToExpression(v.Expr)
+	case *sqlparser.CaseExpr:
+		return caseExprToExpression(v)
+	case *sqlparser.IntervalExpr:
+		expr, err := exprToExpression(v.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		interval, err := expression.NewInterval(expr, v.Unit)
+		if err != nil {
+			return nil, err
+		}
+
+		return interval, nil
 	case *sqlparser.AndExpr:
 		lhs, err := exprToExpression(v.Left)
 		if err != nil {
@@ -552,6 +856,20 @@ func exprToExpression(e sqlparser.Expr) (sql.Expression, error) {
 			return nil, err
 		}
 
+		if v.Type.Type == expression.ConvertToDecimal {
+			precision, err := sqlValToUint8(v.Type.Length, sql.DecimalDefaultPrecision)
+			if err != nil {
+				return nil, err
+			}
+
+			scale, err := sqlValToUint8(v.Type.Scale, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			return expression.NewDecimalConvert(expr, precision, scale)
+		}
+
 		return expression.NewConvert(expr, v.Type.Type), nil
 	case *sqlparser.RangeCond:
 		val, err := exprToExpression(v.Left)
@@ -605,6 +923,10 @@ func convertVal(v *sqlparser.SQLVal) (sql.Expression, error) {
 		}
 		return expression.NewLiteral(val, sql.Int64), nil
 	case sqlparser.FloatVal:
+		if lit, ok := decimalLiteral(string(v.Val)); ok {
+			return lit, nil
+		}
+
 		val, err := strconv.ParseFloat(string(v.Val), 64)
 		if err != nil {
 			return nil, err
@@ -638,6 +960,43 @@ func convertVal(v *sqlparser.SQLVal) (sql.Expression, error) {
 	return nil, ErrInvalidSQLValType.New(v.Type)
 }
 
+func caseExprToExpression(e *sqlparser.CaseExpr) (sql.Expression, error) {
+	var expr sql.Expression
+	if e.Expr != nil {
+		var err error
+		expr, err = exprToExpression(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var branches = make([]expression.CaseBranch, len(e.Whens))
+	for i, w := range e.Whens {
+		cond, err := exprToExpression(w.Cond)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := exprToExpression(w.Val)
+		if err != nil {
+			return nil, err
+		}
+
+		branches[i] = expression.CaseBranch{Cond: cond, Value: value}
+	}
+
+	var elseExpr sql.Expression
+	if e.Else != nil {
+		var err error
+		elseExpr, err = exprToExpression(e.Else)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return expression.NewCase(expr, branches, elseExpr), nil
+}
+
 func isExprToExpression(c *sqlparser.IsExpr) (sql.Expression, error) {
 	e, err := exprToExpression(c.Expr)
 	if err != nil {
@@ -686,6 +1045,17 @@ func comparisonExprToExpression(c *sqlparser.ComparisonExpr) (sql.Expression, er
 		return expression.NewNot(
 			expression.NewEquals(left, right),
 		), nil
+	case sqlparser.LikeStr, sqlparser.NotLikeStr:
+		escape, err := likeEscape(c.Escape)
+		if err != nil {
+			return nil, err
+		}
+
+		like := expression.NewLikeWithEscape(left, right, escape)
+		if c.Operator == sqlparser.NotLikeStr {
+			return expression.NewNot(like), nil
+		}
+		return like, nil
 	case sqlparser.InStr:
 		return expression.NewIn(left, right), nil
 	case sqlparser.NotInStr:
@@ -693,6 +1063,27 @@ func comparisonExprToExpression(c *sqlparser.ComparisonExpr) (sql.Expression, er
 	}
 }
 
+// likeEscape returns the escape character of a LIKE pattern given in its
+// ESCAPE clause, which must be a string with at most one character. An
+// empty string means there is no escape character.
+func likeEscape(e sqlparser.Expr) (rune, error) {
+	if e == nil {
+		return expression.DefaultLikeEscape, nil
+	}
+
+	v, ok := e.(*sqlparser.SQLVal)
+	if !ok || v.Type != sqlparser.StrVal || utf8.RuneCount(v.Val) > 1 {
+		return 0, ErrUnsupportedSyntax.New(sqlparser.String(e))
+	}
+
+	if len(v.Val) == 0 {
+		return 0, nil
+	}
+
+	r, _ := utf8.DecodeRune(v.Val)
+	return r, nil
+}
+
 func groupByToExpressions(g sqlparser.GroupBy) ([]sql.Expression, error) {
 	es := make([]sql.Expression, len(g))
 	for i, ve := range g {
@@ -756,9 +1147,42 @@ func binaryExprToExpression(be *sqlparser.BinaryExpr) (sql.Expression, error) {
 			return nil, err
 		}
 
+		switch {
+		case be.Operator == sqlparser.PlusStr && isInterval(r):
+			return expression.NewUnresolvedFunction("date_add", false, l, r), nil
+		case be.Operator == sqlparser.PlusStr && isInterval(l):
+			return expression.NewUnresolvedFunction("date_add", false, r, l), nil
+		case be.Operator == sqlparser.MinusStr && isInterval(r):
+			return expression.NewUnresolvedFunction("date_sub", false, l, r), nil
+		}
+
 		return expression.NewArithmetic(l, r, be.Operator), nil
 
+	case sqlparser.JSONExtractOp, sqlparser.JSONUnquoteExtractOp:
+		l, err := exprToExpression(be.Left)
+		if err != nil {
+			return nil, err
+		}
+
+		r, err := exprToExpression(be.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		// column->path is JSON_EXTRACT(column, path) and column->>path is
+		// JSON_UNQUOTE(JSON_EXTRACT(column, path)).
+		var e sql.Expression = expression.NewUnresolvedFunction("json_extract", false, l, r)
+		if be.Operator == sqlparser.JSONUnquoteExtractOp {
+			e = expression.NewUnresolvedFunction("json_unquote", false, e)
+		}
+		return e, nil
+
 	default:
 		return nil, ErrUnsupportedFeature.New(be.Operator)
 	}
 }
+
+func isInterval(e sql.Expression) bool {
+	_, ok := e.(*expression.Interval)
+	return ok
+}
@@ -3,7 +3,9 @@ package parse
 import (
 	"testing"
 
+	"github.com/shopspring/decimal"
 	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression/function/aggregation"
 	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
 
 	"github.com/stretchr/testify/require"
@@ -32,7 +34,7 @@ var fixtures = map[string]sql.Node{
 			Nullable: true,
 		}, {
 			Name:     "e",
-			Type:     sql.Text,
+			Type:     sql.MustVarChar(20),
 			Nullable: true,
 		}, {
 			Name:     "f",
@@ -40,6 +42,203 @@ var fixtures = map[string]sql.Node{
 			Nullable: false,
 		}},
 	),
+	`CREATE TABLE t1(a DECIMAL, b DECIMAL(20,4) NOT NULL, c DECIMAL(5))`: plan.NewCreateTable(
+		&sql.UnresolvedDatabase{},
+		"t1",
+		sql.Schema{{
+			Name:     "a",
+			Type:     sql.MustDecimal(10, 0),
+			Nullable: true,
+		}, {
+			Name:     "b",
+			Type:     sql.MustDecimal(20, 4),
+			Nullable: false,
+		}, {
+			Name:     "c",
+			Type:     sql.MustDecimal(5, 0),
+			Nullable: true,
+		}},
+	),
+	`CREATE TABLE t1(a TINYINT, b SMALLINT UNSIGNED, c MEDIUMINT, d CHAR(3), e CHAR, f DATETIME(3), g TIME, h YEAR, i ENUM('a','b c'), j SET('x','y'))`: plan.NewCreateTable(
+		&sql.UnresolvedDatabase{},
+		"t1",
+		sql.Schema{{
+			Name:     "a",
+			Type:     sql.Int8,
+			Nullable: true,
+		}, {
+			Name:     "b",
+			Type:     sql.Uint16,
+			Nullable: true,
+		}, {
+			Name:     "c",
+			Type:     sql.Int24,
+			Nullable: true,
+		}, {
+			Name:     "d",
+			Type:     sql.MustChar(3),
+			Nullable: true,
+		}, {
+			Name:     "e",
+			Type:     sql.MustChar(1),
+			Nullable: true,
+		}, {
+			Name:     "f",
+			Type:     mustType(sql.DatetimeWithPrecision(3)),
+			Nullable: true,
+		}, {
+			Name:     "g",
+			Type:     sql.Time,
+			Nullable: true,
+		}, {
+			Name:     "h",
+			Type:     sql.Year,
+			Nullable: true,
+		}, {
+			Name:     "i",
+			Type:     sql.MustEnum("a", "b c"),
+			Nullable: true,
+		}, {
+			Name:     "j",
+			Type:     sql.MustSet("x", "y"),
+			Nullable: true,
+		}},
+	),
+	`CREATE TABLE t1(a TEXT COLLATE utf8mb4_bin, b VARCHAR(10) COLLATE utf8mb4_general_ci)`: plan.NewCreateTable(
+		&sql.UnresolvedDatabase{},
+		"t1",
+		sql.Schema{{
+			Name:     "a",
+			Type:     mustType(sql.WithCollation(sql.Text, sql.CollationUtf8mb4Bin)),
+			Nullable: true,
+		}, {
+			Name:     "b",
+			Type:     sql.MustVarChar(10),
+			Nullable: true,
+		}},
+	),
+	`SELECT foo FROM t1 WHERE foo = 'bar' COLLATE utf8mb4_bin`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("foo"),
+		},
+		plan.NewFilter(
+			expression.NewEquals(
+				expression.NewUnresolvedColumn("foo"),
+				expression.NewCollate(
+					expression.NewLiteral("bar", sql.Text),
+					sql.CollationUtf8mb4Bin,
+				),
+			),
+			plan.NewUnresolvedTable("t1"),
+		),
+	),
+	`SELECT foo FROM t1 WHERE foo LIKE 'a%' AND bar NOT LIKE 'b|_%' ESCAPE '|'`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("foo"),
+		},
+		plan.NewFilter(
+			expression.NewAnd(
+				expression.NewLike(
+					expression.NewUnresolvedColumn("foo"),
+					expression.NewLiteral("a%", sql.Text),
+				),
+				expression.NewNot(
+					expression.NewLikeWithEscape(
+						expression.NewUnresolvedColumn("bar"),
+						expression.NewLiteral("b|_%", sql.Text),
+						'|',
+					),
+				),
+			),
+			plan.NewUnresolvedTable("t1"),
+		),
+	),
+	`SELECT CASE foo WHEN 1 THEN 'one' ELSE 'other' END, CASE WHEN bar THEN 1 END FROM t1`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewCase(
+				expression.NewUnresolvedColumn("foo"),
+				[]expression.CaseBranch{
+					{
+						Cond:  expression.NewLiteral(int64(1), sql.Int64),
+						Value: expression.NewLiteral("one", sql.Text),
+					},
+				},
+				expression.NewLiteral("other", sql.Text),
+			),
+			expression.NewCase(
+				nil,
+				[]expression.CaseBranch{
+					{
+						Cond:  expression.NewUnresolvedColumn("bar"),
+						Value: expression.NewLiteral(int64(1), sql.Int64),
+					},
+				},
+				nil,
+			),
+		},
+		plan.NewUnresolvedTable("t1"),
+	),
+	`SELECT foo + INTERVAL 1 DAY, foo - INTERVAL '1:30' HOUR_MINUTE, DATE_ADD(foo, INTERVAL 2 week) FROM t1`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedFunction("date_add", false,
+				expression.NewUnresolvedColumn("foo"),
+				&expression.Interval{
+					UnaryExpression: expression.UnaryExpression{
+						Child: expression.NewLiteral(int64(1), sql.Int64),
+					},
+					Unit: "DAY",
+				},
+			),
+			expression.NewUnresolvedFunction("date_sub", false,
+				expression.NewUnresolvedColumn("foo"),
+				&expression.Interval{
+					UnaryExpression: expression.UnaryExpression{
+						Child: expression.NewLiteral("1:30", sql.Text),
+					},
+					Unit: "HOUR_MINUTE",
+				},
+			),
+			expression.NewUnresolvedFunction("date_add", false,
+				expression.NewUnresolvedColumn("foo"),
+				&expression.Interval{
+					UnaryExpression: expression.UnaryExpression{
+						Child: expression.NewLiteral(int64(2), sql.Int64),
+					},
+					Unit: "WEEK",
+				},
+			),
+		},
+		plan.NewUnresolvedTable("t1"),
+	),
+	`SELECT TIMESTAMPDIFF(month, foo, bar) FROM t1`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedFunction("timestampdiff", false,
+				expression.NewLiteral("MONTH", sql.Text),
+				expression.NewUnresolvedColumn("foo"),
+				expression.NewUnresolvedColumn("bar"),
+			),
+		},
+		plan.NewUnresolvedTable("t1"),
+	),
+	`SELECT foo->'$.a', foo->>'$.b', JSON_ARRAYAGG(bar) FROM t1`: plan.NewGroupBy(
+		[]sql.Expression{
+			expression.NewUnresolvedFunction("json_extract", false,
+				expression.NewUnresolvedColumn("foo"),
+				expression.NewLiteral("$.a", sql.Text),
+			),
+			expression.NewUnresolvedFunction("json_unquote", false,
+				expression.NewUnresolvedFunction("json_extract", false,
+					expression.NewUnresolvedColumn("foo"),
+					expression.NewLiteral("$.b", sql.Text),
+				),
+			),
+			expression.NewUnresolvedFunction("json_arrayagg", true,
+				expression.NewUnresolvedColumn("bar"),
+			),
+		},
+		[]sql.Expression{},
+		plan.NewUnresolvedTable("t1"),
+	),
 	`DESCRIBE TABLE foo;`: plan.NewDescribe(
 		plan.NewUnresolvedTable("foo"),
 	),
@@ -189,6 +388,135 @@ var fixtures = map[string]sql.Node{
 		},
 		plan.NewUnresolvedTable("t1"),
 	),
+	`SELECT foo, bar, GROUPING(foo, bar), COUNT(*) FROM t1 GROUP BY foo, bar WITH ROLLUP`: plan.NewRollup(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("foo"),
+			expression.NewUnresolvedColumn("bar"),
+			expression.NewUnresolvedFunction("grouping", true,
+				expression.NewUnresolvedColumn("foo"),
+				expression.NewUnresolvedColumn("bar"),
+			),
+			expression.NewUnresolvedFunction("count", true,
+				expression.NewStar(),
+			),
+		},
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("foo"),
+			expression.NewUnresolvedColumn("bar"),
+		},
+		plan.NewUnresolvedTable("t1"),
+	),
+	`SELECT COUNT(DISTINCT foo, bar), SUM(DISTINCT foo) FROM t1;`: plan.NewGroupBy(
+		[]sql.Expression{
+			expression.NewUnresolvedDistinctFunction("count",
+				expression.NewUnresolvedColumn("foo"),
+				expression.NewUnresolvedColumn("bar"),
+			),
+			expression.NewUnresolvedDistinctFunction("sum",
+				expression.NewUnresolvedColumn("foo"),
+			),
+		},
+		[]sql.Expression{},
+		plan.NewUnresolvedTable("t1"),
+	),
+	`SELECT GROUP_CONCAT(DISTINCT foo, bar ORDER BY bar DESC, foo SEPARATOR '; '), GROUP_CONCAT(foo) FROM t1`: plan.NewGroupBy(
+		[]sql.Expression{
+			aggregation.NewGroupConcat(
+				true,
+				[]sql.Expression{
+					expression.NewUnresolvedColumn("foo"),
+					expression.NewUnresolvedColumn("bar"),
+				},
+				[]aggregation.OrderByField{
+					{Column: expression.NewUnresolvedColumn("bar"), Descending: true},
+					{Column: expression.NewUnresolvedColumn("foo")},
+				},
+				"; ",
+			),
+			aggregation.NewGroupConcat(
+				false,
+				[]sql.Expression{expression.NewUnresolvedColumn("foo")},
+				nil,
+				",",
+			),
+		},
+		[]sql.Expression{},
+		plan.NewUnresolvedTable("t1"),
+	),
+	`SELECT a, ROW_NUMBER() OVER (PARTITION BY b ORDER BY c DESC) AS n, SUM(c) over (order by c rows between 1 preceding and current row) FROM t1`: plan.NewWindow(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("a"),
+			expression.NewAlias(
+				expression.NewWindow(
+					expression.NewUnresolvedFunction("row_number", false),
+					&sql.WindowDefinition{
+						PartitionBy: []sql.Expression{expression.NewUnresolvedColumn("b")},
+						OrderBy: []sql.WindowOrderBy{
+							{Column: expression.NewUnresolvedColumn("c"), Descending: true},
+						},
+					},
+				),
+				"n",
+			),
+			expression.NewWindow(
+				expression.NewUnresolvedFunction("sum", false, expression.NewUnresolvedColumn("c")),
+				&sql.WindowDefinition{
+					OrderBy: []sql.WindowOrderBy{{Column: expression.NewUnresolvedColumn("c")}},
+					Frame: &sql.WindowFrame{
+						Unit: sql.RowsFrame,
+						Start: sql.WindowBound{
+							Type:   sql.Preceding,
+							Offset: expression.NewLiteral(int64(1), sql.Int64),
+						},
+						End: sql.WindowBound{Type: sql.CurrentRow},
+					},
+				},
+			),
+		},
+		plan.NewUnresolvedTable("t1"),
+	),
+	`SELECT * FROM (SELECT a, ROW_NUMBER() OVER () AS n FROM t1) t`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewSubqueryAlias(
+			"t",
+			plan.NewWindow(
+				[]sql.Expression{
+					expression.NewUnresolvedColumn("a"),
+					expression.NewAlias(
+						expression.NewWindow(
+							expression.NewUnresolvedFunction("row_number", false),
+							&sql.WindowDefinition{},
+						),
+						"n",
+					),
+				},
+				plan.NewUnresolvedTable("t1"),
+			),
+		),
+	),
+	"SELECT LAG(a, 2, 0) OVER (), `over` FROM t1 ORDER BY a": plan.NewSort(
+		[]plan.SortField{
+			{
+				Column:       expression.NewUnresolvedColumn("a"),
+				Order:        plan.Ascending,
+				NullOrdering: plan.NullsFirst,
+			},
+		},
+		plan.NewWindow(
+			[]sql.Expression{
+				expression.NewWindow(
+					expression.NewUnresolvedFunction("lag", false,
+						expression.NewUnresolvedColumn("a"),
+						expression.NewLiteral(int64(2), sql.Int64),
+						expression.NewLiteral(int64(0), sql.Int64),
+					),
+					&sql.WindowDefinition{},
+				),
+				expression.NewUnresolvedColumn("over"),
+			},
+			plan.NewUnresolvedTable("t1"),
+		),
+	),
 	`SELECT COUNT(*) FROM t1;`: plan.NewGroupBy(
 		[]sql.Expression{
 			expression.NewUnresolvedFunction("count", true,
@@ -320,6 +648,27 @@ var fixtures = map[string]sql.Node{
 			plan.NewUnresolvedTable("foo"),
 		),
 	),
+	`SELECT * FROM foo USE INDEX (a, b)`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		&plan.UnresolvedTable{
+			Name:      "foo",
+			IndexHint: plan.NewIndexHint(plan.UseIndex, "a", "b"),
+		},
+	),
+	`SELECT * FROM foo AS bar FORCE INDEX (a)`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		plan.NewTableAlias(
+			"bar",
+			plan.NewUnresolvedTable("foo"),
+		).WithIndexHint(plan.NewIndexHint(plan.ForceIndex, "a")),
+	),
+	`SELECT * FROM foo IGNORE INDEX (a)`: plan.NewProject(
+		[]sql.Expression{expression.NewStar()},
+		&plan.UnresolvedTable{
+			Name:      "foo",
+			IndexHint: plan.NewIndexHint(plan.IgnoreIndex, "a"),
+		},
+	),
 	`SELECT * FROM (SELECT * FROM foo) AS bar`: plan.NewProject(
 		[]sql.Expression{expression.NewStar()},
 		plan.NewSubqueryAlias(
@@ -417,6 +766,13 @@ var fixtures = map[string]sql.Node{
 		},
 		plan.NewUnresolvedTable("foo"),
 	),
+	`SELECT CAST(a AS DECIMAL(10, 2)), CAST(a AS DECIMAL) FROM foo`: plan.NewProject(
+		[]sql.Expression{
+			mustDecimalConvert(expression.NewUnresolvedColumn("a"), 10, 2),
+			mustDecimalConvert(expression.NewUnresolvedColumn("a"), 10, 0),
+		},
+		plan.NewUnresolvedTable("foo"),
+	),
 	`SELECT 2 = 2 FROM foo`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewEquals(expression.NewLiteral(int64(2), sql.Int64), expression.NewLiteral(int64(2), sql.Int64)),
@@ -539,12 +895,20 @@ var fixtures = map[string]sql.Node{
 	`SELECT 1.0 * a + 2.0 * b FROM t;`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewPlus(
-				expression.NewMult(expression.NewLiteral(float64(1.0), sql.Float64), expression.NewUnresolvedColumn("a")),
-				expression.NewMult(expression.NewLiteral(float64(2.0), sql.Float64), expression.NewUnresolvedColumn("b")),
+				expression.NewMult(expression.NewLiteral(decimal.New(10, -1), sql.MustDecimal(2, 1)), expression.NewUnresolvedColumn("a")),
+				expression.NewMult(expression.NewLiteral(decimal.New(20, -1), sql.MustDecimal(2, 1)), expression.NewUnresolvedColumn("b")),
 			),
 		},
 		plan.NewUnresolvedTable("t"),
 	),
+	`SELECT 0.25, 012.50, 1.5e3 FROM t`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewLiteral(decimal.New(25, -2), sql.MustDecimal(2, 2)),
+			expression.NewLiteral(decimal.New(1250, -2), sql.MustDecimal(4, 2)),
+			expression.NewLiteral(float64(1500), sql.Float64),
+		},
+		plan.NewUnresolvedTable("t"),
+	),
 	`SELECT '1.0' + 2;`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewPlus(
@@ -593,6 +957,11 @@ var fixtures = map[string]sql.Node{
 		"foo",
 		plan.NewUnresolvedTable("bar"),
 	),
+	`ANALYZE TABLE foo`: plan.NewAnalyzeTable(plan.NewUnresolvedTable("foo")),
+	`analyze table foo , bar;`: plan.NewAnalyzeTable(
+		plan.NewUnresolvedTable("foo"),
+		plan.NewUnresolvedTable("bar"),
+	),
 	`DESCRIBE FORMAT=TREE SELECT * FROM foo`: plan.NewDescribeQuery(
 		"tree",
 		plan.NewProject(
@@ -618,6 +987,19 @@ func TestParse(t *testing.T) {
 
 var fixturesErrors = map[string]error{
 	`SHOW METHEMONEY`: ErrUnsupportedFeature.New(`SHOW METHEMONEY`),
+	`SELECT foo FROM t1 WHERE foo LIKE 'a%' ESCAPE 'ab'`: ErrUnsupportedSyntax.New(`'ab'`),
+	`SELECT COUNT(*), ROW_NUMBER() OVER () FROM t1`: ErrUnsupportedFeature.New(
+		"window functions outside of the select expressions of queries without aggregations",
+	),
+	`SELECT SUM(a) OVER (ROWS BETWEEN CURRENT ROW AND 1 PRECEDING) FROM t1`: ErrInvalidWindow.New(
+		"ROWS BETWEEN CURRENT ROW AND 1 PRECEDING",
+		sql.ErrInvalidWindowFrame.New("frame start CURRENT ROW cannot be after frame end 1 PRECEDING"),
+	),
+	`SELECT a FROM t1 WITH ROLLUP`: ErrRollupWithoutGroupBy.New(),
+	`ANALYZE TABLE foo,`:           errUnexpectedSyntax.New("identifier", "EOF"),
+	`SELECT SUM(a) OVER (ROWS 1) FROM t1`: ErrInvalidWindow.New(
+		"ROWS 1", "expecting a frame bound",
+	),
 }
 
 func TestParseErrors(t *testing.T) {
@@ -631,3 +1013,18 @@ func TestParseErrors(t *testing.T) {
 		})
 	}
 }
+
+func mustType(t sql.Type, err error) sql.Type {
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func mustDecimalConvert(e sql.Expression, precision, scale uint8) sql.Expression {
+	c, err := expression.NewDecimalConvert(e, precision, scale)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
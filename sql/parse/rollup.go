@@ -0,0 +1,71 @@
+package parse
+
+import (
+	"strings"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+	"gopkg.in/src-d/go-vitess.v0/vt/sqlparser"
+)
+
+// ErrRollupWithoutGroupBy is returned when WITH ROLLUP is used in a query
+// without GROUP BY.
+var ErrRollupWithoutGroupBy = errors.NewKind("WITH ROLLUP can only be used with GROUP BY")
+
+// extractRollup removes the WITH ROLLUP modifier of the query, which the SQL
+// parser does not support, returning the resulting query and whether it was
+// found. Only the one of the outermost query is removed.
+func extractRollup(query string) (string, bool, error) {
+	if !strings.Contains(strings.ToLower(query), "rollup") {
+		return query, false, nil
+	}
+
+	tokens, err := tokenize(query)
+	if err != nil {
+		return "", false, err
+	}
+
+	var depth int
+	for i, t := range tokens {
+		switch t.typ {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case sqlparser.WITH:
+			if depth == 0 && i+1 < len(tokens) &&
+				tokens[i+1].typ == sqlparser.ID && tokens[i+1].val == "rollup" {
+				return query[:t.start] + query[tokens[i+1].end:], true, nil
+			}
+		}
+	}
+
+	return query, false, nil
+}
+
+// resolveRollup replaces the GroupBy of the outermost query of the node with
+// one that computes the super-aggregate rows.
+func resolveRollup(node sql.Node) (sql.Node, error) {
+	var found bool
+	// Subqueries are not transformed, so the only GroupBy is the one of the
+	// outermost query.
+	node, err := node.TransformUp(func(n sql.Node) (sql.Node, error) {
+		g, ok := n.(*plan.GroupBy)
+		if !ok || len(g.Grouping) == 0 {
+			return n, nil
+		}
+
+		found = true
+		return plan.NewRollup(g.Aggregate, g.Grouping, g.Child), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, ErrRollupWithoutGroupBy.New()
+	}
+
+	return node, nil
+}
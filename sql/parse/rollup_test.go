@@ -0,0 +1,47 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractRollup(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected string
+		rollup   bool
+	}{
+		{
+			"SELECT a, COUNT(*) FROM t GROUP BY a",
+			"SELECT a, COUNT(*) FROM t GROUP BY a",
+			false,
+		},
+		{
+			"SELECT a, COUNT(*) FROM t GROUP BY a With Rollup ORDER BY a",
+			"SELECT a, COUNT(*) FROM t GROUP BY a  ORDER BY a",
+			true,
+		},
+		{
+			"SELECT rollup FROM t WHERE a = 'with rollup'",
+			"SELECT rollup FROM t WHERE a = 'with rollup'",
+			false,
+		},
+		{
+			"SELECT * FROM (SELECT a FROM t GROUP BY a WITH ROLLUP) AS t2",
+			"SELECT * FROM (SELECT a FROM t GROUP BY a WITH ROLLUP) AS t2",
+			false,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			require := require.New(t)
+
+			query, rollup, err := extractRollup(tt.query)
+			require.NoError(err)
+			require.Equal(tt.expected, query)
+			require.Equal(tt.rollup, rollup)
+		})
+	}
+}
@@ -0,0 +1,421 @@
+package parse
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/plan"
+	"gopkg.in/src-d/go-vitess.v0/vt/sqlparser"
+)
+
+// ErrInvalidWindow is returned when the OVER clause of a window function
+// cannot be parsed.
+var ErrInvalidWindow = errors.NewKind("invalid window definition %q: %s")
+
+// The SQL parser has no support for window functions, so their OVER clauses
+// are removed from the query before parsing it, and their functions are
+// renamed with a prefix containing the number of their window, so they can
+// be replaced by window expressions once the query is converted.
+const windowFunctionPrefix = "__window"
+
+var windowFunctionRegex = regexp.MustCompile(`^` + windowFunctionPrefix + `(\d+)_(.+)$`)
+
+type token struct {
+	typ        int
+	val        string
+	start, end int
+}
+
+// tokenize splits the query in the tokens of the SQL parser, with their
+// lowercased values and their positions in the query. Comments are skipped.
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+	tkn := sqlparser.NewStringTokenizer(query)
+	for {
+		typ, val := tkn.Scan()
+		switch typ {
+		case 0:
+			return tokens, nil
+		case sqlparser.LEX_ERROR:
+			return nil, ErrUnsupportedSyntax.New(query)
+		case sqlparser.COMMENT:
+			continue
+		}
+
+		// The tokenizer is always one character past the end of the token.
+		end := tkn.Position - 1
+		start := end - len(val)
+		switch {
+		case val == nil:
+			start = end - 1
+		case query[end-1] == '`':
+			start = strings.LastIndex(query[:end-1], "`")
+		}
+
+		tokens = append(tokens, token{typ, strings.ToLower(string(val)), start, end})
+	}
+}
+
+type queryEdit struct {
+	start, end int
+	text       string
+}
+
+// extractWindows removes the OVER clauses of the window functions of the
+// query and renames their functions, returning the resulting query and the
+// contents of the OVER clauses, in order.
+func extractWindows(query string) (string, []string, error) {
+	if !strings.Contains(strings.ToLower(query), "over") {
+		return query, nil, nil
+	}
+
+	tokens, err := tokenize(query)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var windows []string
+	var edits []queryEdit
+	// functions contains, for each parenthesis that has been opened, the
+	// index of the function name before it, or -1 if there is none.
+	var functions []int
+	for i := 0; i < len(tokens); i++ {
+		switch tokens[i].typ {
+		case '(':
+			fn := -1
+			if i > 0 && tokens[i-1].typ == sqlparser.ID {
+				fn = i - 1
+			}
+			functions = append(functions, fn)
+		case ')':
+			if len(functions) == 0 {
+				return query, nil, nil
+			}
+
+			fn := functions[len(functions)-1]
+			functions = functions[:len(functions)-1]
+			if !isOver(query, tokens, i+1) {
+				continue
+			}
+
+			if fn < 0 {
+				return "", nil, ErrUnsupportedSyntax.New(query)
+			}
+
+			end := matchingParen(tokens, i+2)
+			if end < 0 {
+				return "", nil, ErrUnsupportedSyntax.New(query)
+			}
+
+			name := fmt.Sprintf("%s%d_%s", windowFunctionPrefix, len(windows), tokens[fn].val)
+			windows = append(windows, query[tokens[i+2].end:tokens[end].start])
+			edits = append(edits,
+				queryEdit{tokens[fn].start, tokens[fn].end, name},
+				queryEdit{tokens[i+1].start, tokens[end].end, ""},
+			)
+			i = end
+		}
+	}
+
+	if len(windows) == 0 {
+		return query, nil, nil
+	}
+
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	var result []string
+	var pos int
+	for _, e := range edits {
+		result = append(result, query[pos:e.start], e.text)
+		pos = e.end
+	}
+	result = append(result, query[pos:])
+
+	return strings.Join(result, ""), windows, nil
+}
+
+// isOver returns whether the token at index i is an OVER followed by an
+// opening parenthesis.
+func isOver(query string, tokens []token, i int) bool {
+	return i+1 < len(tokens) &&
+		tokens[i].typ == sqlparser.ID &&
+		strings.EqualFold(query[tokens[i].start:tokens[i].end], "over") &&
+		tokens[i+1].typ == '('
+}
+
+// matchingParen returns the index of the parenthesis that closes the one at
+// index i, or -1 if it's never closed.
+func matchingParen(tokens []token, i int) int {
+	var depth int
+	for j := i; j < len(tokens); j++ {
+		switch tokens[j].typ {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// parseWindowDefinition parses the contents of an OVER clause, which can
+// have a PARTITION BY, an ORDER BY and a frame, in that order.
+func parseWindowDefinition(spec string) (*sql.WindowDefinition, error) {
+	tokens, err := tokenize(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// The clauses start in the tokens with these indexes, which are -1 for
+	// the missing ones.
+	var partition, order, frame = -1, -1, -1
+	var depth int
+	for i, t := range tokens {
+		switch {
+		case t.typ == '(':
+			depth++
+		case t.typ == ')':
+			depth--
+		case depth > 0:
+		case t.typ == sqlparser.PARTITION && partition < 0 && order < 0 && frame < 0:
+			partition = i
+		case t.typ == sqlparser.ORDER && order < 0 && frame < 0:
+			order = i
+		case (t.val == "rows" || t.val == "range") && frame < 0:
+			frame = i
+		}
+	}
+
+	var clauses = []int{partition, order, frame}
+	if len(tokens) > 0 && partition != 0 && order != 0 && frame != 0 {
+		return nil, ErrInvalidWindow.New(spec, "expecting PARTITION BY, ORDER BY or a frame")
+	}
+
+	clause := func(i int) string {
+		end := len(tokens)
+		for _, c := range clauses {
+			if c > i && c < end {
+				end = c
+			}
+		}
+
+		if end == len(tokens) {
+			return spec[tokens[i].start:]
+		}
+		return spec[tokens[i].start:tokens[end].start]
+	}
+
+	var def sql.WindowDefinition
+	var query = "SELECT 1 FROM dual"
+	if partition >= 0 {
+		query += " GROUP" + clause(partition)[len("partition"):]
+	}
+
+	if order >= 0 {
+		query += " " + clause(order)
+	}
+
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return nil, ErrInvalidWindow.New(spec, err)
+	}
+
+	s := stmt.(*sqlparser.Select)
+	if partition >= 0 {
+		def.PartitionBy, err = groupByToExpressions(s.GroupBy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sortFields, err := orderByToSortFields(s.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range sortFields {
+		def.OrderBy = append(def.OrderBy, sql.WindowOrderBy{
+			Column:     f.Column,
+			Descending: f.Order == plan.Descending,
+		})
+	}
+
+	if frame >= 0 {
+		def.Frame, err = parseWindowFrame(spec, tokens[frame:])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &def, nil
+}
+
+// parseWindowFrame parses a frame with the syntax
+// {ROWS | RANGE} {bound | BETWEEN bound AND bound}, where the end of the
+// frame is the current row if only its start is given.
+func parseWindowFrame(spec string, tokens []token) (*sql.WindowFrame, error) {
+	unit := sql.RowsFrame
+	if tokens[0].val == "range" {
+		unit = sql.RangeFrame
+	}
+
+	var start, end sql.WindowBound
+	var err error
+	rest := tokens[1:]
+	if len(rest) > 0 && rest[0].typ == sqlparser.BETWEEN {
+		start, rest, err = parseWindowBound(spec, rest[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rest) == 0 || rest[0].typ != sqlparser.AND {
+			return nil, ErrInvalidWindow.New(spec, "expecting AND")
+		}
+
+		end, rest, err = parseWindowBound(spec, rest[1:])
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		start, rest, err = parseWindowBound(spec, rest)
+		if err != nil {
+			return nil, err
+		}
+		end = sql.WindowBound{Type: sql.CurrentRow}
+	}
+
+	if len(rest) > 0 {
+		return nil, ErrInvalidWindow.New(spec, fmt.Sprintf("unexpected %q", rest[0].val))
+	}
+
+	f, err := sql.NewWindowFrame(unit, start, end)
+	if err != nil {
+		return nil, ErrInvalidWindow.New(spec, err)
+	}
+
+	return f, nil
+}
+
+// parseWindowBound parses a frame bound, which is UNBOUNDED PRECEDING,
+// UNBOUNDED FOLLOWING, CURRENT ROW, or a number followed by PRECEDING or
+// FOLLOWING, and returns the tokens after it.
+func parseWindowBound(spec string, tokens []token) (sql.WindowBound, []token, error) {
+	if len(tokens) < 2 {
+		return sql.WindowBound{}, nil, ErrInvalidWindow.New(spec, "expecting a frame bound")
+	}
+
+	var b sql.WindowBound
+	first, second := tokens[0], tokens[1].val
+	switch {
+	case first.val == "unbounded" && second == "preceding":
+		b.Type = sql.UnboundedPreceding
+	case first.val == "unbounded" && second == "following":
+		b.Type = sql.UnboundedFollowing
+	case first.val == "current" && second == "row":
+		b.Type = sql.CurrentRow
+	case first.typ == sqlparser.INTEGRAL || first.typ == sqlparser.FLOAT:
+		switch second {
+		case "preceding":
+			b.Type = sql.Preceding
+		case "following":
+			b.Type = sql.Following
+		default:
+			return b, nil, ErrInvalidWindow.New(spec, "expecting PRECEDING or FOLLOWING")
+		}
+
+		if n, err := strconv.ParseInt(first.val, 10, 64); err == nil {
+			b.Offset = expression.NewLiteral(n, sql.Int64)
+		} else {
+			f, err := strconv.ParseFloat(first.val, 64)
+			if err != nil {
+				return b, nil, ErrInvalidWindow.New(spec, err)
+			}
+			b.Offset = expression.NewLiteral(f, sql.Float64)
+		}
+	default:
+		return b, nil, ErrInvalidWindow.New(spec, "expecting a frame bound")
+	}
+
+	return b, tokens[2:], nil
+}
+
+// resolveWindows replaces the renamed functions of the node with window
+// expressions using the given OVER clauses, and the projections that contain
+// them with plan.Window nodes.
+func resolveWindows(node sql.Node, windows []string) (sql.Node, error) {
+	var defs = make([]*sql.WindowDefinition, len(windows))
+	for i, w := range windows {
+		var err error
+		defs[i], err = parseWindowDefinition(w)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return replaceWindows(node, defs)
+}
+
+// replaceWindows replaces the window functions of the node and of the
+// subqueries it contains, whose children are not transformed by TransformUp.
+func replaceWindows(node sql.Node, defs []*sql.WindowDefinition) (sql.Node, error) {
+	return node.TransformUp(func(n sql.Node) (sql.Node, error) {
+		if sq, ok := n.(*plan.SubqueryAlias); ok {
+			child, err := replaceWindows(sq.Child, defs)
+			if err != nil {
+				return nil, err
+			}
+			return plan.NewSubqueryAlias(sq.Name(), child), nil
+		}
+
+		expressioner, ok := n.(sql.Expressioner)
+		if !ok {
+			return n, nil
+		}
+
+		var found bool
+		n, err := expressioner.TransformExpressions(func(e sql.Expression) (sql.Expression, error) {
+			uf, ok := e.(*expression.UnresolvedFunction)
+			if !ok {
+				return e, nil
+			}
+
+			m := windowFunctionRegex.FindStringSubmatch(uf.Name())
+			if m == nil {
+				return e, nil
+			}
+
+			found = true
+			idx, _ := strconv.Atoi(m[1])
+
+			var fn sql.Expression
+			if uf.Distinct {
+				fn = expression.NewUnresolvedDistinctFunction(m[2], uf.Arguments...)
+			} else {
+				fn = expression.NewUnresolvedFunction(m[2], false, uf.Arguments...)
+			}
+
+			return expression.NewWindow(fn, defs[idx]), nil
+		})
+		if err != nil || !found {
+			return n, err
+		}
+
+		project, ok := n.(*plan.Project)
+		if !ok {
+			return nil, ErrUnsupportedFeature.New(
+				"window functions outside of the select expressions of queries without aggregations",
+			)
+		}
+
+		return plan.NewWindow(project.Projections, project.Child), nil
+	})
+}
@@ -0,0 +1,50 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractWindows(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected string
+		windows  []string
+	}{
+		{
+			"SELECT a, b FROM t",
+			"SELECT a, b FROM t",
+			nil,
+		},
+		{
+			"SELECT `over` FROM t WHERE a = 'over ()'",
+			"SELECT `over` FROM t WHERE a = 'over ()'",
+			nil,
+		},
+		{
+			"SELECT Row_Number() Over (PARTITION BY a), SUM(b) /* x */ OVER(ORDER BY (c + 1)) FROM t",
+			"SELECT __window0_row_number() , __window1_sum(b) /* x */  FROM t",
+			[]string{"PARTITION BY a", "ORDER BY (c + 1)"},
+		},
+		{
+			"SELECT COUNT(*) over FROM t",
+			"SELECT COUNT(*) over FROM t",
+			nil,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.query, func(t *testing.T) {
+			require := require.New(t)
+
+			query, windows, err := extractWindows(tt.query)
+			require.NoError(err)
+			require.Equal(tt.expected, query)
+			require.Equal(tt.windows, windows)
+		})
+	}
+
+	_, _, err := extractWindows("SELECT (a) OVER () FROM t")
+	require.Error(t, err)
+}
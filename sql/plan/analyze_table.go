@@ -0,0 +1,123 @@
+package plan
+
+import (
+	"fmt"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// AnalyzeTable is a node that computes the statistics of the rows of some
+// tables and stores them in the catalog, so the analyzer can use them to
+// estimate the cost of the plans. Tables that provide their own statistics
+// are left untouched.
+type AnalyzeTable struct {
+	Tables          []sql.Node
+	Catalog         *sql.Catalog
+	CurrentDatabase string
+}
+
+// NewAnalyzeTable creates a new AnalyzeTable node.
+func NewAnalyzeTable(tables ...sql.Node) *AnalyzeTable {
+	return &AnalyzeTable{Tables: tables}
+}
+
+// Resolved implements the Node interface.
+func (a *AnalyzeTable) Resolved() bool {
+	for _, t := range a.Tables {
+		if !t.Resolved() {
+			return false
+		}
+	}
+	return true
+}
+
+// Schema implements the Node interface.
+func (a *AnalyzeTable) Schema() sql.Schema {
+	return sql.Schema{
+		{Name: "Table", Type: sql.Text},
+		{Name: "Op", Type: sql.Text},
+		{Name: "Msg_type", Type: sql.Text},
+		{Name: "Msg_text", Type: sql.Text},
+	}
+}
+
+// Children implements the Node interface.
+func (a *AnalyzeTable) Children() []sql.Node { return a.Tables }
+
+// RowIter implements the Node interface.
+func (a *AnalyzeTable) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	var rows []sql.Row
+	for _, t := range a.Tables {
+		table, ok := t.(sql.Table)
+		if !ok {
+			return nil, ErrTableNotNameable.New()
+		}
+
+		name := fmt.Sprintf("%s.%s", a.CurrentDatabase, table.Name())
+		if _, ok := table.(sql.StatisticsTable); ok {
+			rows = append(rows, sql.NewRow(name, "analyze", "status", "Table is already up to date"))
+			continue
+		}
+
+		iter, err := table.RowIter(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		stats, err := sql.ComputeStatistics(table.Schema(), iter)
+		if err != nil {
+			return nil, err
+		}
+
+		a.Catalog.SetStatistics(a.CurrentDatabase, table.Name(), stats)
+		rows = append(rows, sql.NewRow(name, "analyze", "status", "OK"))
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+func (a *AnalyzeTable) String() string {
+	var tables = make([]string, len(a.Tables))
+	for i, t := range a.Tables {
+		tables[i] = t.String()
+	}
+
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("AnalyzeTable")
+	_ = pr.WriteChildren(tables...)
+	return pr.String()
+}
+
+// TransformExpressionsUp implements the Node interface.
+func (a *AnalyzeTable) TransformExpressionsUp(fn sql.TransformExprFunc) (sql.Node, error) {
+	return a.transformTables(func(t sql.Node) (sql.Node, error) {
+		return t.TransformExpressionsUp(fn)
+	})
+}
+
+// TransformUp implements the Node interface.
+func (a *AnalyzeTable) TransformUp(fn sql.TransformNodeFunc) (sql.Node, error) {
+	n, err := a.transformTables(func(t sql.Node) (sql.Node, error) {
+		return t.TransformUp(fn)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fn(n)
+}
+
+func (a *AnalyzeTable) transformTables(fn sql.TransformNodeFunc) (*AnalyzeTable, error) {
+	var tables = make([]sql.Node, len(a.Tables))
+	for i, t := range a.Tables {
+		var err error
+		tables[i], err = fn(t)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	na := *a
+	na.Tables = tables
+	return &na, nil
+}
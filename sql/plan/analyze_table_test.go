@@ -0,0 +1,41 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/mem"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+func TestAnalyzeTable(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	memTable := mem.NewTable("foo", sql.Schema{
+		{Name: "a", Type: sql.Int64, Source: "foo"},
+	})
+	require.NoError(memTable.Insert(sql.NewRow(int64(1))))
+	require.NoError(memTable.Insert(sql.NewRow(int64(1))))
+
+	// The wrapper hides the statistics of the table.
+	table := &indexableTable{memTable}
+
+	catalog := sql.NewCatalog()
+	node := NewAnalyzeTable(table, mem.NewTable("bar", nil))
+	node.Catalog = catalog
+	node.CurrentDatabase = "db"
+
+	rows, err := sql.NodeToRows(ctx, node)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		{"db.foo", "analyze", "status", "OK"},
+		{"db.bar", "analyze", "status", "Table is already up to date"},
+	}, rows)
+
+	stats := catalog.Statistics("db", "foo")
+	require.NotNil(stats)
+	require.Equal(uint64(2), stats.RowCount)
+	require.Equal(uint64(1), stats.Column("a").DistinctCount)
+	require.Nil(catalog.Statistics("db", "bar"))
+}
@@ -317,7 +317,7 @@ func (eit *evalKeyValueIter) Next() ([]interface{}, []byte, error) {
 			return nil, nil, err
 		}
 
-		evals[i] = eval
+		evals[i] = sql.NormalizeKey(ex.Type(), eval)
 	}
 
 	return evals, loc, nil
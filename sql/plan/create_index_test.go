@@ -120,6 +120,36 @@ func TestCreateIndexWithIter(t *testing.T) {
 	require.NoError(iter.Close())
 }
 
+func TestCreateIndexCollationKeys(t *testing.T) {
+	require := require.New(t)
+
+	bin, err := sql.WithCollation(sql.Text, sql.CollationUtf8mb4Bin)
+	require.NoError(err)
+
+	foo := mem.NewTable("foo", sql.Schema{
+		{Name: "ci", Source: "foo", Type: sql.Text},
+		{Name: "bin", Source: "foo", Type: bin},
+	})
+	require.NoError(foo.Insert(sql.NewRow("John ", "John ")))
+
+	table := &indexableTable{foo}
+	exprs := []sql.Expression{
+		expression.NewGetFieldWithTable(0, sql.Text, "foo", "ci", false),
+		expression.NewGetFieldWithTable(1, bin, "foo", "bin", false),
+	}
+
+	columns, exprs, _, err := getColumnsAndPrepareExpressions(exprs)
+	require.NoError(err)
+
+	iter, err := getIndexKeyValueIter(sql.NewEmptyContext(), table, columns, exprs)
+	require.NoError(err)
+
+	vals, _, err := iter.Next()
+	require.NoError(err)
+	require.Equal([]interface{}{"JOHN", "John"}, vals)
+	require.NoError(iter.Close())
+}
+
 type mockIndex struct {
 	db    string
 	table string
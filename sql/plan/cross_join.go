@@ -118,6 +118,10 @@ type crossJoinIterator struct {
 
 func (i *crossJoinIterator) Next() (sql.Row, error) {
 	for {
+		if err := i.s.Interrupted(); err != nil {
+			return nil, err
+		}
+
 		if i.leftRow == nil {
 			r, err := i.l.Next()
 			if err != nil {
@@ -1,6 +1,7 @@
 package plan
 
 import (
+	"context"
 	"io"
 	"testing"
 
@@ -133,3 +134,26 @@ func insertData(t *testing.T, table *mem.Table) {
 	err = table.Insert(sql.NewRow("col1_2", "col2_2", int32(3333), int64(4444)))
 	require.NoError(err)
 }
+
+func TestCrossJoinCancelled(t *testing.T) {
+	require := require.New(t)
+
+	ltable := mem.NewTable("left", lSchema)
+	rtable := mem.NewTable("right", rSchema)
+	insertData(t, ltable)
+	insertData(t, rtable)
+
+	cctx, cancel := context.WithCancel(context.TODO())
+	ctx := sql.NewContext(cctx)
+
+	iter, err := NewCrossJoin(ltable, rtable).RowIter(ctx)
+	require.NoError(err)
+
+	_, err = iter.Next()
+	require.NoError(err)
+
+	cancel()
+	_, err = iter.Next()
+	require.Error(err)
+	require.True(sql.ErrQueryCanceled.Is(err))
+}
@@ -34,7 +34,7 @@ func (d *Distinct) RowIter(ctx *sql.Context) (sql.RowIter, error) {
 		return nil, err
 	}
 
-	return sql.NewSpanIter(span, newDistinctIter(it)), nil
+	return sql.NewSpanIter(span, newDistinctIter(ctx, it, d.Child.Schema())), nil
 }
 
 // TransformUp implements the Transformable interface.
@@ -68,13 +68,25 @@ func (d Distinct) String() string {
 // Even though they are just 64-bit integers, this could be a problem in large
 // result sets.
 type distinctIter struct {
+	ctx       *sql.Context
 	childIter sql.RowIter
+	schema    sql.Schema
 	seen      map[uint64]struct{}
 }
 
-func newDistinctIter(child sql.RowIter) *distinctIter {
+// distinctEntrySize is the approximate number of bytes used by each hash
+// stored in the set of seen hashes.
+const distinctEntrySize = 16
+
+func newDistinctIter(
+	ctx *sql.Context,
+	child sql.RowIter,
+	schema sql.Schema,
+) *distinctIter {
 	return &distinctIter{
+		ctx:       ctx,
 		childIter: child,
+		schema:    schema,
 		seen:      make(map[uint64]struct{}),
 	}
 }
@@ -86,7 +98,7 @@ func (di *distinctIter) Next() (sql.Row, error) {
 			return nil, err
 		}
 
-		hash, err := hashstructure.Hash(row, nil)
+		hash, err := hashRow(di.schema, row)
 		if err != nil {
 			return nil, fmt.Errorf("unable to hash row: %s", err)
 		}
@@ -95,12 +107,18 @@ func (di *distinctIter) Next() (sql.Row, error) {
 			continue
 		}
 
+		if err := di.ctx.Memory().Grow(distinctEntrySize); err != nil {
+			return nil, err
+		}
+
 		di.seen[hash] = struct{}{}
 		return row, nil
 	}
 }
 
 func (di *distinctIter) Close() error {
+	di.ctx.Memory().Shrink(uint64(len(di.seen)) * distinctEntrySize)
+	di.seen = make(map[uint64]struct{})
 	return di.childIter.Close()
 }
 
@@ -198,3 +216,17 @@ func (di *orderedDistinctIter) Next() (sql.Row, error) {
 func (di *orderedDistinctIter) Close() error {
 	return di.childIter.Close()
 }
+
+// hashRow returns the hash of the given row. Values are hashed using their
+// normalized keys, so rows whose strings are only equal according to the
+// collation of their columns have the same hash. Besides, hashstructure
+// ignores the unexported fields of decimals and would give the same hash to
+// all of them.
+func hashRow(schema sql.Schema, row sql.Row) (uint64, error) {
+	values := make(sql.Row, len(row))
+	for i, v := range row {
+		values[i] = sql.NormalizeKey(schema[i].Type, v)
+	}
+
+	return hashstructure.Hash(values, nil)
+}
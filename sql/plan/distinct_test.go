@@ -155,3 +155,29 @@ func BenchmarkOrderedDistinct(b *testing.B) {
 		require.Equal(100, rows)
 	}
 }
+
+func TestDistinctCollation(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	bin, err := sql.WithCollation(sql.Text, sql.CollationUtf8mb4Bin)
+	require.NoError(err)
+
+	child := mem.NewTable("test", sql.Schema{
+		{Name: "ci", Type: sql.Text},
+		{Name: "bin", Type: bin},
+	})
+	require.NoError(child.Insert(sql.NewRow("john", "john")))
+	require.NoError(child.Insert(sql.NewRow("John", "John")))
+	require.NoError(child.Insert(sql.NewRow("JOHN ", "john ")))
+
+	for i, expected := range []int{1, 2} {
+		p := NewProject([]sql.Expression{
+			expression.NewGetField(i, child.Schema()[i].Type, "name", false),
+		}, child)
+
+		rows, err := sql.NodeToRows(ctx, NewDistinct(p))
+		require.NoError(err)
+		require.Len(rows, expected)
+	}
+}
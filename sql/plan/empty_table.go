@@ -0,0 +1,48 @@
+package plan
+
+import "gopkg.in/src-d/go-mysql-server.v0/sql"
+
+// EmptyTable is a node with a schema but no rows, which replaces the nodes
+// that are known to return no rows without executing them.
+type EmptyTable struct {
+	schema sql.Schema
+}
+
+// NewEmptyTable creates a new EmptyTable node with the given schema.
+func NewEmptyTable(schema sql.Schema) *EmptyTable {
+	return &EmptyTable{schema}
+}
+
+// Resolved implements the Resolvable interface.
+func (*EmptyTable) Resolved() bool {
+	return true
+}
+
+// Children implements the Node interface.
+func (*EmptyTable) Children() []sql.Node {
+	return nil
+}
+
+// Schema implements the Node interface.
+func (e *EmptyTable) Schema() sql.Schema {
+	return e.schema
+}
+
+// RowIter implements the Node interface.
+func (*EmptyTable) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	return sql.RowsToRowIter(), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (e *EmptyTable) TransformUp(f sql.TransformNodeFunc) (sql.Node, error) {
+	return f(NewEmptyTable(e.schema))
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (e *EmptyTable) TransformExpressionsUp(f sql.TransformExprFunc) (sql.Node, error) {
+	return e, nil
+}
+
+func (*EmptyTable) String() string {
+	return "EmptyTable"
+}
@@ -0,0 +1,21 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+func TestEmptyTable(t *testing.T) {
+	require := require.New(t)
+
+	schema := sql.Schema{{Name: "a", Type: sql.Int64, Source: "test"}}
+	e := NewEmptyTable(schema)
+	require.True(e.Resolved())
+	require.Equal(schema, e.Schema())
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), e)
+	require.NoError(err)
+	require.Len(rows, 0)
+}
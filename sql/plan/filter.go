@@ -85,9 +85,10 @@ func (p *Filter) TransformExpressions(f sql.TransformExprFunc) (sql.Node, error)
 // FilterIter is an iterator that filters another iterator and skips rows that
 // don't match the given condition.
 type FilterIter struct {
-	cond      sql.Expression
-	childIter sql.RowIter
-	ctx       *sql.Context
+	cond       sql.Expression
+	childIter  sql.RowIter
+	childBatch sql.BatchIter
+	ctx        *sql.Context
 }
 
 // NewFilterIter creates a new FilterIter.
@@ -96,9 +97,11 @@ func NewFilterIter(
 	cond sql.Expression,
 	child sql.RowIter,
 ) *FilterIter {
-	return &FilterIter{cond, child, ctx}
+	return &FilterIter{cond, child, sql.ToBatchIter(child), ctx}
 }
 
+var _ sql.RowBatchIter = (*FilterIter)(nil)
+
 // Next implements the RowIter interface.
 func (i *FilterIter) Next() (sql.Row, error) {
 	for {
@@ -118,6 +121,32 @@ func (i *FilterIter) Next() (sql.Row, error) {
 	}
 }
 
+// NextBatch implements the BatchIter interface.
+func (i *FilterIter) NextBatch(max int) ([]sql.Row, error) {
+	for {
+		rows, err := i.childBatch.NextBatch(max)
+		if err != nil {
+			return nil, err
+		}
+
+		var result = rows[:0]
+		for _, row := range rows {
+			ok, err := i.cond.Eval(i.ctx, row)
+			if err != nil {
+				return nil, err
+			}
+
+			if ok == true {
+				result = append(result, row)
+			}
+		}
+
+		if len(result) > 0 {
+			return result, nil
+		}
+	}
+}
+
 // Close implements the RowIter interface.
 func (i *FilterIter) Close() error {
 	return i.childIter.Close()
@@ -1,6 +1,7 @@
 package plan
 
 import (
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -82,3 +83,79 @@ func TestFilter(t *testing.T) {
 	require.Equal(int32(3333), row[2])
 	require.Equal(int64(4444), row[3])
 }
+
+func TestFilterNextBatch(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	child := mem.NewTable("test", sql.Schema{{Name: "col1", Type: sql.Int64}})
+	for i := 0; i < 10; i++ {
+		require.NoError(child.Insert(sql.NewRow(int64(i))))
+	}
+
+	f := NewFilter(
+		expression.NewGreaterThanOrEqual(
+			expression.NewGetField(0, sql.Int64, "col1", false),
+			expression.NewLiteral(int64(7), sql.Int64),
+		),
+		child,
+	)
+
+	iter, err := f.RowIter(ctx)
+	require.NoError(err)
+
+	// The first batches of the child don't have matching rows, so they are
+	// skipped until one with matching rows is found.
+	b := iter.(sql.BatchIter)
+	batch, err := b.NextBatch(4)
+	require.NoError(err)
+	require.Equal([]sql.Row{{int64(7)}}, batch)
+
+	batch, err = b.NextBatch(4)
+	require.NoError(err)
+	require.Equal([]sql.Row{{int64(8)}, {int64(9)}}, batch)
+
+	_, err = b.NextBatch(4)
+	require.Equal(io.EOF, err)
+	require.NoError(b.Close())
+}
+
+func BenchmarkFilter(b *testing.B) {
+	benchmarkFilter(b, func(iter sql.RowIter) error {
+		for {
+			if _, err := iter.Next(); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+func BenchmarkFilterBatch(b *testing.B) {
+	benchmarkFilter(b, func(iter sql.RowIter) error {
+		batches := sql.ToBatchIter(iter)
+		for {
+			if _, err := batches.NextBatch(sql.DefaultBatchSize); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+func benchmarkFilter(b *testing.B, read func(sql.RowIter) error) {
+	require := require.New(b)
+	ctx := sql.NewEmptyContext()
+
+	for i := 0; i < b.N; i++ {
+		f := NewFilter(
+			expression.NewGreaterThan(
+				expression.NewGetField(3, sql.Int32, "intfield", false),
+				expression.NewLiteral(int32(25), sql.Int32),
+			),
+			benchtable,
+		)
+
+		iter, err := f.RowIter(ctx)
+		require.NoError(err)
+		require.Equal(io.EOF, read(iter))
+	}
+}
@@ -14,11 +14,18 @@ import (
 // ErrGroupBy is returned when the aggregation is not supported.
 var ErrGroupBy = errors.NewKind("group by aggregation '%v' not supported")
 
-// GroupBy groups the rows by some expressions.
+// ErrInvalidGroupingArgument is returned when an argument of GROUPING is
+// not one of the grouping expressions.
+var ErrInvalidGroupingArgument = errors.NewKind("argument of GROUPING is not a grouping expression: %s")
+
+// GroupBy groups the rows by some expressions. If Rollup is set, the rows
+// of the groups are followed by super-aggregate rows, as with the WITH ROLLUP
+// modifier of MySQL.
 type GroupBy struct {
 	UnaryNode
 	Aggregate []sql.Expression
 	Grouping  []sql.Expression
+	Rollup    bool
 }
 
 // NewGroupBy creates a new GroupBy node.
@@ -35,6 +42,33 @@ func NewGroupBy(
 	}
 }
 
+// NewRollup creates a new GroupBy node with super-aggregate rows. After the
+// rows of the groups with the same values in the first N grouping
+// expressions, there is a row with the aggregations of all of them, in which
+// the rest of grouping expressions are NULL. The last row aggregates all the
+// rows.
+func NewRollup(
+	aggregate []sql.Expression,
+	grouping []sql.Expression,
+	child sql.Node,
+) *GroupBy {
+	g := NewGroupBy(aggregate, grouping, child)
+	g.Rollup = true
+	return g
+}
+
+// WithExpressions returns a copy of the node, with the same Rollup, with the
+// given expressions and child.
+func (p *GroupBy) WithExpressions(
+	aggregate []sql.Expression,
+	grouping []sql.Expression,
+	child sql.Node,
+) *GroupBy {
+	g := NewGroupBy(aggregate, grouping, child)
+	g.Rollup = p.Rollup
+	return g
+}
+
 // Resolved implements the Resolvable interface.
 func (p *GroupBy) Resolved() bool {
 	return p.UnaryNode.Child.Resolved() &&
@@ -90,7 +124,7 @@ func (p *GroupBy) TransformUp(f sql.TransformNodeFunc) (sql.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	return f(NewGroupBy(p.Aggregate, p.Grouping, child))
+	return f(p.WithExpressions(p.Aggregate, p.Grouping, child))
 }
 
 // TransformExpressionsUp implements the Transformable interface.
@@ -110,12 +144,16 @@ func (p *GroupBy) TransformExpressionsUp(f sql.TransformExprFunc) (sql.Node, err
 		return nil, err
 	}
 
-	return NewGroupBy(aggregate, grouping, child), nil
+	return p.WithExpressions(aggregate, grouping, child), nil
 }
 
 func (p *GroupBy) String() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("GroupBy")
+	if p.Rollup {
+		_ = pr.WriteNode("GroupBy WITH ROLLUP")
+	} else {
+		_ = pr.WriteNode("GroupBy")
+	}
 
 	var aggregate = make([]string, len(p.Aggregate))
 	for i, agg := range p.Aggregate {
@@ -155,7 +193,7 @@ func (p *GroupBy) TransformExpressions(f sql.TransformExprFunc) (sql.Node, error
 		return nil, err
 	}
 
-	return NewGroupBy(agg, group, p.Child), nil
+	return p.WithExpressions(agg, group, p.Child), nil
 }
 
 type groupByIter struct {
@@ -164,6 +202,7 @@ type groupByIter struct {
 	rows      []sql.Row
 	idx       int
 	ctx       *sql.Context
+	memory    uint64
 }
 
 func newGroupByIter(s *sql.Context, p *GroupBy, child sql.RowIter) *groupByIter {
@@ -180,6 +219,7 @@ func (i *groupByIter) Next() (sql.Row, error) {
 	if i.idx == -1 {
 		err := i.computeRows()
 		if err != nil {
+			i.releaseMemory()
 			return nil, err
 		}
 		i.idx = 0
@@ -192,33 +232,96 @@ func (i *groupByIter) Next() (sql.Row, error) {
 	return row, nil
 }
 
+func (i *groupByIter) NextBatch(max int) ([]sql.Row, error) {
+	if i.idx == -1 {
+		err := i.computeRows()
+		if err != nil {
+			i.releaseMemory()
+			return nil, err
+		}
+		i.idx = 0
+	}
+	if i.idx >= len(i.rows) {
+		return nil, io.EOF
+	}
+
+	end := i.idx + max
+	if end > len(i.rows) {
+		end = len(i.rows)
+	}
+
+	rows := make([]sql.Row, end-i.idx)
+	copy(rows, i.rows[i.idx:end])
+	i.idx = end
+	return rows, nil
+}
+
 func (i *groupByIter) Close() error {
 	i.rows = nil
+	i.releaseMemory()
 	return i.childIter.Close()
 }
 
 func (i *groupByIter) computeRows() error {
 	rows := []sql.Row{}
+	child := sql.ToBatchIter(i.childIter)
 	for {
-		childRow, err := i.childIter.Next()
+		if err := i.ctx.Interrupted(); err != nil {
+			return err
+		}
+
+		batch, err := child.NextBatch(sql.DefaultBatchSize)
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
 			return err
 		}
-		rows = append(rows, childRow)
+
+		for _, childRow := range batch {
+			if err := i.chargeRow(childRow); err != nil {
+				return err
+			}
+		}
+		rows = append(rows, batch...)
 	}
 
-	rows, err := groupBy(i.ctx, rows, i.p.Aggregate, i.p.Grouping)
+	var err error
+	if i.p.Rollup {
+		rows, err = rollup(i.ctx, rows, i.p.Aggregate, i.p.Grouping)
+	} else {
+		rows, err = groupBy(i.ctx, rows, i.p.Aggregate, i.p.Grouping)
+	}
 	if err != nil {
 		return err
 	}
 
+	// Rows from the child are no longer needed, only the aggregated ones.
+	i.releaseMemory()
+	for _, row := range rows {
+		if err := i.chargeRow(row); err != nil {
+			return err
+		}
+	}
+
 	i.rows = rows
 	return nil
 }
 
+func (i *groupByIter) chargeRow(row sql.Row) error {
+	size := sql.EstimateRowSize(row)
+	if err := i.ctx.Memory().Grow(size); err != nil {
+		return err
+	}
+	i.memory += size
+	return nil
+}
+
+func (i *groupByIter) releaseMemory() {
+	i.ctx.Memory().Shrink(i.memory)
+	i.memory = 0
+}
+
 func groupBy(
 	ctx *sql.Context,
 	rows []sql.Row,
@@ -255,6 +358,21 @@ func groupingKey(
 	exprs []sql.Expression,
 	row sql.Row,
 ) (interface{}, error) {
+	vals, err := groupingValues(ctx, exprs, row)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Join(vals, ","), nil
+}
+
+// groupingValues returns the values of the grouping expressions for the
+// row, which are equal for rows in the same group.
+func groupingValues(
+	ctx *sql.Context,
+	exprs []sql.Expression,
+	row sql.Row,
+) ([]string, error) {
 	//TODO: use a more robust/efficient way of calculating grouping keys.
 	vals := make([]string, 0, len(exprs))
 	for _, expr := range exprs {
@@ -262,10 +380,14 @@ func groupingKey(
 		if err != nil {
 			return nil, err
 		}
-		vals = append(vals, fmt.Sprintf("%#v", v))
+		// Values are normalized so that strings which are equal according to
+		// their collation are in the same group and decimals are formatted
+		// using their string representation, as their Go representation only
+		// shows the pointer to their value.
+		vals = append(vals, fmt.Sprintf("%#v", sql.NormalizeKey(expr.Type(), v)))
 	}
 
-	return strings.Join(vals, ","), nil
+	return vals, nil
 }
 
 func aggregate(
@@ -273,6 +395,19 @@ func aggregate(
 	exprs []sql.Expression,
 	rows []sql.Row,
 ) (sql.Row, error) {
+	buffers, err := aggregateBuffers(ctx, exprs, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return evalBuffers(ctx, exprs, buffers)
+}
+
+func aggregateBuffers(
+	ctx *sql.Context,
+	exprs []sql.Expression,
+	rows []sql.Row,
+) ([]sql.Row, error) {
 	buffers := make([]sql.Row, len(exprs))
 	for i, expr := range exprs {
 		buffers[i] = fillBuffer(expr)
@@ -286,6 +421,14 @@ func aggregate(
 		}
 	}
 
+	return buffers, nil
+}
+
+func evalBuffers(
+	ctx *sql.Context,
+	exprs []sql.Expression,
+	buffers []sql.Row,
+) (sql.Row, error) {
 	fields := make([]interface{}, 0, len(exprs))
 	for i, expr := range exprs {
 		field, err := expr.Eval(ctx, buffers[i])
@@ -319,8 +462,7 @@ func updateBuffer(
 ) error {
 	switch n := expr.(type) {
 	case sql.Aggregation:
-		n.Update(ctx, buffers[idx], row)
-		return nil
+		return n.Update(ctx, buffers[idx], row)
 	case *expression.Alias:
 		return updateBuffer(ctx, buffers, idx, n.Child, row)
 	case *expression.GetField:
@@ -330,3 +472,23 @@ func updateBuffer(
 		return ErrGroupBy.New(n.String())
 	}
 }
+
+func mergeBuffer(
+	ctx *sql.Context,
+	buffers []sql.Row,
+	idx int,
+	expr sql.Expression,
+	partial sql.Row,
+) error {
+	switch n := expr.(type) {
+	case sql.Aggregation:
+		return n.Merge(ctx, buffers[idx], partial)
+	case *expression.Alias:
+		return mergeBuffer(ctx, buffers, idx, n.Child, partial)
+	case *expression.GetField:
+		buffers[idx] = partial
+		return nil
+	default:
+		return ErrGroupBy.New(n.String())
+	}
+}
@@ -89,6 +89,38 @@ func TestGroupBy_RowIter(t *testing.T) {
 	require.Equal(sql.NewRow("col1_2", int64(4444)), rows[1])
 }
 
+func TestGroupBy_NextBatch(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	child := mem.NewTable("test", sql.Schema{{Name: "col1", Type: sql.Int64}})
+	for i := 0; i < 3000; i++ {
+		require.NoError(child.Insert(sql.NewRow(int64(i % 3))))
+	}
+
+	p := NewGroupBy(
+		[]sql.Expression{
+			expression.NewGetField(0, sql.Int64, "col1", false),
+		},
+		[]sql.Expression{
+			expression.NewGetField(0, sql.Int64, "col1", false),
+		},
+		child,
+	)
+
+	iter, err := p.RowIter(ctx)
+	require.NoError(err)
+
+	b := iter.(sql.BatchIter)
+	batch, err := b.NextBatch(2)
+	require.NoError(err)
+	require.Len(batch, 2)
+
+	rows, err := sql.RowIterToRows(sql.ToRowIter(b))
+	require.NoError(err)
+	require.Len(rows, 1)
+}
+
 func TestGroupBy_Error(t *testing.T) {
 	require := require.New(t)
 	ctx := sql.NewEmptyContext()
@@ -0,0 +1,58 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IndexHintType is the type of an index hint.
+type IndexHintType byte
+
+const (
+	// UseIndex hints that only the given indexes should be used.
+	UseIndex IndexHintType = iota
+	// ForceIndex hints that one of the given indexes must be used.
+	ForceIndex
+	// IgnoreIndex hints that the given indexes must not be used.
+	IgnoreIndex
+)
+
+func (t IndexHintType) String() string {
+	switch t {
+	case UseIndex:
+		return "USE"
+	case ForceIndex:
+		return "FORCE"
+	case IgnoreIndex:
+		return "IGNORE"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// IndexHint is a hint given in a table reference about the indexes the
+// analyzer can use to query the table.
+type IndexHint struct {
+	Type    IndexHintType
+	Indexes []string
+}
+
+// NewIndexHint creates a new index hint.
+func NewIndexHint(typ IndexHintType, indexes ...string) *IndexHint {
+	return &IndexHint{typ, indexes}
+}
+
+// Contains returns whether the given index is one of the indexes of the
+// hint. Index names are case insensitive.
+func (h *IndexHint) Contains(id string) bool {
+	for _, idx := range h.Indexes {
+		if strings.EqualFold(idx, id) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *IndexHint) String() string {
+	return fmt.Sprintf("%s INDEX (%s)", h.Type, strings.Join(h.Indexes, ", "))
+}
@@ -0,0 +1,20 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexHint(t *testing.T) {
+	require := require.New(t)
+
+	hint := NewIndexHint(UseIndex, "foo", "Bar")
+	require.True(hint.Contains("FOO"))
+	require.True(hint.Contains("bar"))
+	require.False(hint.Contains("baz"))
+	require.Equal("USE INDEX (foo, Bar)", hint.String())
+
+	require.Equal("FORCE INDEX (foo)", NewIndexHint(ForceIndex, "foo").String())
+	require.Equal("IGNORE INDEX (foo)", NewIndexHint(IgnoreIndex, "foo").String())
+}
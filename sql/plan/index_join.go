@@ -0,0 +1,255 @@
+package plan
+
+import (
+	"io"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+)
+
+// IndexJoin is an inner join that, instead of scanning the right side for
+// every row of the left side, evaluates a key with each row of the left side
+// and looks up the matching rows of the right table using an index.
+// The join condition is still evaluated against the joined rows, so the key
+// only needs to narrow down the rows of the right side.
+type IndexJoin struct {
+	BinaryNode
+	Cond sql.Expression
+	// Index is the index of the table in the right side used to look up the
+	// rows.
+	Index sql.Index
+	// IndexExpr is the indexed expression of the table in the right side.
+	IndexExpr sql.Expression
+	// Key is the expression evaluated with every row of the left side to get
+	// the key to look up in the index.
+	Key sql.Expression
+}
+
+// NewIndexJoin creates a new index join node.
+func NewIndexJoin(
+	left, right sql.Node,
+	cond sql.Expression,
+	index sql.Index,
+	indexExpr, key sql.Expression,
+) *IndexJoin {
+	return &IndexJoin{
+		BinaryNode: BinaryNode{
+			Left:  left,
+			Right: right,
+		},
+		Cond:      cond,
+		Index:     index,
+		IndexExpr: indexExpr,
+		Key:       key,
+	}
+}
+
+// Schema implements the Node interface.
+func (j *IndexJoin) Schema() sql.Schema {
+	return append(j.Left.Schema(), j.Right.Schema()...)
+}
+
+// Resolved implements the Resolvable interface.
+func (j *IndexJoin) Resolved() bool {
+	return j.Left.Resolved() && j.Right.Resolved() &&
+		j.Cond.Resolved() && j.Key.Resolved()
+}
+
+// RowIter implements the Node interface.
+func (j *IndexJoin) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.IndexJoin", opentracing.Tags{
+		"index": j.Index.ID(),
+		"table": j.Index.Table(),
+	})
+
+	l, err := j.Left.RowIter(ctx)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+
+	return sql.NewSpanIter(span, NewFilterIter(
+		ctx,
+		j.Cond,
+		&indexJoinIterator{
+			l:    l,
+			join: j,
+			s:    ctx,
+		},
+	)), nil
+}
+
+// lookup returns an iterator over the rows of the right side matching the
+// key evaluated with the given row of the left side. If the key is NULL,
+// no row can match and a nil iterator is returned.
+func (j *IndexJoin) lookup(ctx *sql.Context, row sql.Row) (sql.RowIter, error) {
+	key, err := j.Key.Eval(ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	if key == nil {
+		return nil, nil
+	}
+
+	lookup, err := j.Index.Get(sql.NormalizeKey(j.IndexExpr.Type(), key))
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := j.Right.TransformUp(func(n sql.Node) (sql.Node, error) {
+		switch n := n.(type) {
+		case *IndexableTable:
+			return n, nil
+		case *PushdownProjectionAndFiltersTable:
+			indexable, ok := n.PushdownProjectionAndFiltersTable.(sql.Indexable)
+			if !ok || n.Name() != j.Index.Table() {
+				return n, nil
+			}
+
+			return NewIndexableTable(n.Columns, n.Filters, lookup, indexable), nil
+		case sql.Indexable:
+			if n.Name() != j.Index.Table() {
+				return n, nil
+			}
+
+			return NewIndexableTable(nil, nil, lookup, n), nil
+		}
+		return n, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return right.RowIter(ctx)
+}
+
+// TransformUp implements the Transformable interface.
+func (j *IndexJoin) TransformUp(f sql.TransformNodeFunc) (sql.Node, error) {
+	left, err := j.Left.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := j.Right.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return f(NewIndexJoin(left, right, j.Cond, j.Index, j.IndexExpr, j.Key))
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (j *IndexJoin) TransformExpressionsUp(f sql.TransformExprFunc) (sql.Node, error) {
+	left, err := j.Left.TransformExpressionsUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := j.Right.TransformExpressionsUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIndexJoin(left, right, j.Cond, j.Index, j.IndexExpr, j.Key).
+		TransformExpressions(f)
+}
+
+func (j *IndexJoin) String() string {
+	pr := sql.NewTreePrinter()
+	_ = pr.WriteNode("IndexJoin(%s, index=%s, key=%s)", j.Cond, j.Index.ID(), j.Key)
+	_ = pr.WriteChildren(j.Left.String(), j.Right.String())
+	return pr.String()
+}
+
+// Expressions implements the Expressioner interface.
+func (j *IndexJoin) Expressions() []sql.Expression {
+	return []sql.Expression{j.Cond, j.Key}
+}
+
+// TransformExpressions implements the Expressioner interface.
+func (j *IndexJoin) TransformExpressions(f sql.TransformExprFunc) (sql.Node, error) {
+	cond, err := j.Cond.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := j.Key.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewIndexJoin(j.Left, j.Right, cond, j.Index, j.IndexExpr, key), nil
+}
+
+type indexJoinIterator struct {
+	l    sql.RowIter
+	join *IndexJoin
+	r    sql.RowIter
+	s    *sql.Context
+
+	leftRow sql.Row
+}
+
+func (i *indexJoinIterator) Next() (sql.Row, error) {
+	for {
+		if err := i.s.Interrupted(); err != nil {
+			return nil, err
+		}
+
+		if i.leftRow == nil {
+			r, err := i.l.Next()
+			if err != nil {
+				return nil, err
+			}
+
+			iter, err := i.join.lookup(i.s, r)
+			if err != nil {
+				return nil, err
+			}
+
+			if iter == nil {
+				continue
+			}
+
+			i.leftRow = r
+			i.r = iter
+		}
+
+		rightRow, err := i.r.Next()
+		if err == io.EOF {
+			if err := i.r.Close(); err != nil {
+				return nil, err
+			}
+
+			i.r = nil
+			i.leftRow = nil
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		var row = make(sql.Row, 0, len(i.leftRow)+len(rightRow))
+		row = append(row, i.leftRow...)
+		row = append(row, rightRow...)
+
+		return row, nil
+	}
+}
+
+func (i *indexJoinIterator) Close() error {
+	if err := i.l.Close(); err != nil {
+		if i.r != nil {
+			_ = i.r.Close()
+		}
+		return err
+	}
+
+	if i.r != nil {
+		return i.r.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package plan
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/mem"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestIndexJoin(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	left := mem.NewTable("a", sql.Schema{
+		{Name: "x", Type: sql.Int64, Source: "a", Nullable: true},
+	})
+	for _, v := range []interface{}{int64(1), int64(2), int64(3), nil} {
+		require.NoError(left.Insert(sql.NewRow(v)))
+	}
+
+	right := mem.NewTable("b", sql.Schema{
+		{Name: "y", Type: sql.Int64, Source: "b"},
+		{Name: "z", Type: sql.Text, Source: "b"},
+	})
+	require.NoError(right.Insert(sql.NewRow(int64(1), "one")))
+	require.NoError(right.Insert(sql.NewRow(int64(2), "two")))
+	require.NoError(right.Insert(sql.NewRow(int64(4), "four")))
+	require.NoError(right.Insert(sql.NewRow(int64(2), "two again")))
+
+	index := newRowIndex(t, right, "y")
+
+	cond := expression.NewEquals(
+		expression.NewGetFieldWithTable(0, sql.Int64, "a", "x", true),
+		expression.NewGetFieldWithTable(1, sql.Int64, "b", "y", false),
+	)
+
+	expected := []sql.Row{
+		{int64(1), int64(1), "one"},
+		{int64(2), int64(2), "two"},
+		{int64(2), int64(2), "two again"},
+	}
+
+	rights := []sql.Node{
+		right,
+		NewPushdownProjectionAndFiltersTable(nil, nil, right),
+		NewTableAlias("c", NewPushdownProjectionAndFiltersTable(nil, nil, right)),
+	}
+
+	for _, r := range rights {
+		index.lookups = 0
+
+		join := NewIndexJoin(
+			left, r, cond, index,
+			expression.NewGetFieldWithTable(0, sql.Int64, "b", "y", false),
+			expression.NewGetFieldWithTable(0, sql.Int64, "a", "x", true),
+		)
+		require.Equal(append(left.Schema(), right.Schema()...), join.Schema())
+
+		rows, err := sql.NodeToRows(ctx, join)
+		require.NoError(err)
+		require.Equal(expected, rows)
+
+		// The NULL key is not looked up.
+		require.Equal(3, index.lookups)
+
+		rows, err = sql.NodeToRows(ctx, NewInnerJoin(left, r, cond))
+		require.NoError(err)
+		require.ElementsMatch(expected, rows)
+	}
+}
+
+// rowIndex is an index of a single column of a mem table that keeps the
+// locations of the rows by the value of the column.
+type rowIndex struct {
+	table     string
+	locations map[interface{}][][]byte
+	lookups   int
+}
+
+func newRowIndex(t *testing.T, table *mem.Table, column string) *rowIndex {
+	iter, err := table.IndexKeyValueIter(sql.NewEmptyContext(), []string{column})
+	require.NoError(t, err)
+
+	var idx = &rowIndex{
+		table:     table.Name(),
+		locations: make(map[interface{}][][]byte),
+	}
+	for {
+		values, location, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		idx.locations[values[0]] = append(idx.locations[values[0]], location)
+	}
+
+	return idx
+}
+
+var _ sql.Index = (*rowIndex)(nil)
+
+func (i *rowIndex) ID() string                             { return "row_index" }
+func (i *rowIndex) Table() string                          { return i.table }
+func (i *rowIndex) Database() string                       { return "" }
+func (i *rowIndex) Driver() string                         { return "" }
+func (i *rowIndex) ExpressionHashes() []sql.ExpressionHash { return nil }
+func (i *rowIndex) Get(key ...interface{}) (sql.IndexLookup, error) {
+	i.lookups++
+	return rowIndexLookup(i.locations[key[0]]), nil
+}
+func (i *rowIndex) Has(key ...interface{}) (bool, error) {
+	_, ok := i.locations[key[0]]
+	return ok, nil
+}
+
+type rowIndexLookup [][]byte
+
+func (l rowIndexLookup) Values() (sql.IndexValueIter, error) {
+	return &rowIndexValueIter{locations: l}, nil
+}
+
+type rowIndexValueIter struct {
+	locations [][]byte
+	pos       int
+}
+
+func (i *rowIndexValueIter) Next() ([]byte, error) {
+	if i.pos >= len(i.locations) {
+		return nil, io.EOF
+	}
+
+	i.pos++
+	return i.locations[i.pos-1], nil
+}
+
+func (i *rowIndexValueIter) Close() error {
+	i.pos = len(i.locations)
+	return nil
+}
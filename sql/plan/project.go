@@ -66,7 +66,7 @@ func (p *Project) RowIter(ctx *sql.Context) (sql.RowIter, error) {
 		span.Finish()
 		return nil, err
 	}
-	return sql.NewSpanIter(span, &iter{p, i, ctx}), nil
+	return sql.NewSpanIter(span, &iter{p, i, sql.ToBatchIter(i), ctx}), nil
 }
 
 // TransformUp implements the Transformable interface.
@@ -120,9 +120,10 @@ func (p *Project) TransformExpressions(f sql.TransformExprFunc) (sql.Node, error
 }
 
 type iter struct {
-	p         *Project
-	childIter sql.RowIter
-	ctx       *sql.Context
+	p          *Project
+	childIter  sql.RowIter
+	childBatch sql.BatchIter
+	ctx        *sql.Context
 }
 
 func (i *iter) Next() (sql.Row, error) {
@@ -133,6 +134,22 @@ func (i *iter) Next() (sql.Row, error) {
 	return filterRow(i.ctx, i.p.Projections, childRow)
 }
 
+func (i *iter) NextBatch(max int) ([]sql.Row, error) {
+	rows, err := i.childBatch.NextBatch(max)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, row := range rows {
+		rows[j], err = filterRow(i.ctx, i.p.Projections, row)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rows, nil
+}
+
 func (i *iter) Close() error {
 	return i.childIter.Close()
 }
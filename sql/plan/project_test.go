@@ -59,7 +59,58 @@ func TestProject(t *testing.T) {
 	require.Equal(schema, p.Schema())
 }
 
+func TestProjectNextBatch(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	child := mem.NewTable("test", sql.Schema{
+		{Name: "col1", Type: sql.Text},
+		{Name: "col2", Type: sql.Text},
+	})
+	require.NoError(child.Insert(sql.NewRow("col1_1", "col2_1")))
+	require.NoError(child.Insert(sql.NewRow("col1_2", "col2_2")))
+	require.NoError(child.Insert(sql.NewRow("col1_3", "col2_3")))
+
+	p := NewProject([]sql.Expression{expression.NewGetField(1, sql.Text, "col2", false)}, child)
+	iter, err := p.RowIter(ctx)
+	require.NoError(err)
+
+	b := iter.(sql.BatchIter)
+	batch, err := b.NextBatch(2)
+	require.NoError(err)
+	require.Equal([]sql.Row{{"col2_1"}, {"col2_2"}}, batch)
+
+	batch, err = b.NextBatch(2)
+	require.NoError(err)
+	require.Equal([]sql.Row{{"col2_3"}}, batch)
+
+	_, err = b.NextBatch(2)
+	require.Equal(io.EOF, err)
+	require.NoError(b.Close())
+}
+
 func BenchmarkProject(b *testing.B) {
+	benchmarkProject(b, func(iter sql.RowIter) error {
+		for {
+			if _, err := iter.Next(); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+func BenchmarkProjectBatch(b *testing.B) {
+	benchmarkProject(b, func(iter sql.RowIter) error {
+		batches := sql.ToBatchIter(iter)
+		for {
+			if _, err := batches.NextBatch(sql.DefaultBatchSize); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+func benchmarkProject(b *testing.B, read func(sql.RowIter) error) {
 	require := require.New(b)
 	ctx := sql.NewEmptyContext()
 
@@ -76,14 +127,6 @@ func BenchmarkProject(b *testing.B) {
 		iter, err := d.RowIter(ctx)
 		require.NoError(err)
 		require.NotNil(iter)
-
-		for {
-			_, err := iter.Next()
-			if err == io.EOF {
-				break
-			}
-
-			require.NoError(err)
-		}
+		require.Equal(io.EOF, read(iter))
 	}
 }
@@ -0,0 +1,201 @@
+package plan
+
+import (
+	"sort"
+	"strings"
+
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression/function/aggregation"
+)
+
+// rollupGroup is a group of rows with the same values in all the grouping
+// expressions.
+type rollupGroup struct {
+	values []string
+	// order contains, for each non-empty prefix of the grouping expressions,
+	// the position of the first row with the same values in them, so
+	// groups can be sorted by it.
+	order   []int
+	rows    []sql.Row
+	buffers []sql.Row
+}
+
+// rollup groups the rows like groupBy, adding after the rows of the groups
+// with the same values in the first k grouping expressions a row with the
+// aggregations of all of them, in which the rest of grouping expressions are
+// NULL, for every k down to 0, which aggregates all the rows. Groups are
+// sorted by the position in which the values of each prefix of the grouping
+// expressions were first found, so the ones sharing a prefix are together.
+// Super-aggregate rows are computed merging the buffers of the groups, so
+// rows are only aggregated once.
+func rollup(
+	ctx *sql.Context,
+	rows []sql.Row,
+	aggExpr []sql.Expression,
+	groupExpr []sql.Expression,
+) ([]sql.Row, error) {
+	var indexes = make(map[string]int, len(groupExpr))
+	for i, e := range groupExpr {
+		if _, ok := indexes[e.String()]; !ok {
+			indexes[e.String()] = i
+		}
+	}
+
+	for _, e := range aggExpr {
+		if g, ok := unaliased(e).(*aggregation.Grouping); ok {
+			for _, arg := range g.Children() {
+				if _, ok := indexes[arg.String()]; !ok {
+					return nil, ErrInvalidGroupingArgument.New(arg)
+				}
+			}
+		}
+	}
+
+	var groups []*rollupGroup
+	var byKey = make(map[string]*rollupGroup)
+	var firstSeen = make(map[string]int)
+	for i, row := range rows {
+		values, err := groupingValues(ctx, groupExpr, row)
+		if err != nil {
+			return nil, err
+		}
+
+		key := strings.Join(values, ",")
+		g, ok := byKey[key]
+		if !ok {
+			g = &rollupGroup{values: values, order: make([]int, len(values))}
+			for k := range values {
+				prefix := strings.Join(values[:k+1], ",")
+				if _, ok := firstSeen[prefix]; !ok {
+					firstSeen[prefix] = i
+				}
+				g.order[k] = firstSeen[prefix]
+			}
+
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+
+		g.rows = append(g.rows, row)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		for k := range groups[i].order {
+			if groups[i].order[k] != groups[j].order[k] {
+				return groups[i].order[k] < groups[j].order[k]
+			}
+		}
+		return false
+	})
+
+	// supers contains the buffers of the super-aggregate rows of the groups
+	// with the same values in the first k grouping expressions.
+	var supers = make([][]sql.Row, len(groupExpr))
+	var result []sql.Row
+	for i, g := range groups {
+		var err error
+		g.buffers, err = aggregateBuffers(ctx, aggExpr, g.rows)
+		if err != nil {
+			return nil, err
+		}
+		g.rows = nil
+
+		row, err := evalBuffers(ctx, aggExpr, g.buffers)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+
+		for k := range supers {
+			if supers[k] == nil {
+				supers[k] = make([]sql.Row, len(aggExpr))
+				for j, e := range aggExpr {
+					supers[k][j] = fillBuffer(e)
+				}
+			}
+
+			for j, e := range aggExpr {
+				if err := mergeBuffer(ctx, supers[k], j, e, g.buffers[j]); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		// The super-aggregate rows of the prefixes that the next group does
+		// not share are complete.
+		var shared int
+		if i+1 < len(groups) {
+			shared = 1 + sharedPrefix(g.values, groups[i+1].values)
+		}
+
+		for k := len(supers) - 1; k >= shared; k-- {
+			row, err := evalRollup(ctx, aggExpr, indexes, k, supers[k])
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, row)
+			supers[k] = nil
+		}
+	}
+
+	return result, nil
+}
+
+// sharedPrefix returns the number of leading values that a and b share.
+func sharedPrefix(a, b []string) int {
+	var n int
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// evalRollup evaluates the expressions of the super-aggregate row of the
+// groups with the same values in the first k grouping expressions, whose
+// positions are in indexes.
+func evalRollup(
+	ctx *sql.Context,
+	exprs []sql.Expression,
+	indexes map[string]int,
+	k int,
+	buffers []sql.Row,
+) (sql.Row, error) {
+	fields := make(sql.Row, len(exprs))
+	for i, expr := range exprs {
+		switch e := unaliased(expr).(type) {
+		case *aggregation.Grouping:
+			var mask int64
+			for _, arg := range e.Children() {
+				mask <<= 1
+				if indexes[arg.String()] >= k {
+					mask |= 1
+				}
+			}
+			fields[i] = mask
+			continue
+		case sql.Aggregation:
+		default:
+			if idx, ok := indexes[e.String()]; ok && idx >= k {
+				fields[i] = nil
+				continue
+			}
+		}
+
+		v, err := expr.Eval(ctx, buffers[i])
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = v
+	}
+
+	return fields, nil
+}
+
+func unaliased(e sql.Expression) sql.Expression {
+	if a, ok := e.(*expression.Alias); ok {
+		return a.Child
+	}
+	return e
+}
@@ -0,0 +1,88 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/mem"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression/function/aggregation"
+)
+
+func rollupTestTable(t *testing.T) *mem.Table {
+	t.Helper()
+
+	child := mem.NewTable("test", sql.Schema{
+		{Name: "a", Type: sql.Text, Source: "test"},
+		{Name: "b", Type: sql.Int64, Source: "test"},
+		{Name: "c", Type: sql.Int64, Source: "test"},
+	})
+	rows := []sql.Row{
+		sql.NewRow("x", int64(1), int64(10)),
+		sql.NewRow("y", int64(1), int64(5)),
+		sql.NewRow("x", int64(2), int64(1)),
+		sql.NewRow("x", int64(1), int64(2)),
+		sql.NewRow("y", int64(2), int64(3)),
+	}
+	for _, r := range rows {
+		require.NoError(t, child.Insert(r))
+	}
+
+	return child
+}
+
+func TestRollup(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	a := expression.NewGetFieldWithTable(0, sql.Text, "test", "a", false)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "test", "b", false)
+	c := expression.NewGetFieldWithTable(2, sql.Int64, "test", "c", false)
+
+	groupingAB, err := aggregation.NewGrouping(a, b)
+	require.NoError(err)
+	groupingB, err := aggregation.NewGrouping(b)
+	require.NoError(err)
+
+	p := NewRollup(
+		[]sql.Expression{
+			a,
+			expression.NewAlias(b, "b2"),
+			aggregation.NewCount(expression.NewStar()),
+			aggregation.NewMax(c),
+			groupingAB,
+			expression.NewAlias(groupingB, "gb"),
+		},
+		[]sql.Expression{a, b},
+		rollupTestTable(t),
+	)
+	require.Equal(p, p.WithExpressions(p.Aggregate, p.Grouping, p.Child))
+
+	rows, err := sql.NodeToRows(ctx, p)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		{"x", int64(1), int32(2), int64(10), int64(0), int64(0)},
+		{"x", int64(2), int32(1), int64(1), int64(0), int64(0)},
+		{"x", nil, int32(3), int64(10), int64(1), int64(1)},
+		{"y", int64(1), int32(1), int64(5), int64(0), int64(0)},
+		{"y", int64(2), int32(1), int64(3), int64(0), int64(0)},
+		{"y", nil, int32(2), int64(5), int64(1), int64(1)},
+		{nil, nil, int32(5), int64(10), int64(3), int64(1)},
+	}, rows)
+}
+
+func TestRollup_InvalidGrouping(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	a := expression.NewGetFieldWithTable(0, sql.Text, "test", "a", false)
+	c := expression.NewGetFieldWithTable(2, sql.Int64, "test", "c", false)
+	grouping, err := aggregation.NewGrouping(c)
+	require.NoError(err)
+
+	p := NewRollup([]sql.Expression{a, grouping}, []sql.Expression{a}, rollupTestTable(t))
+
+	_, err = sql.NodeToRows(ctx, p)
+	require.True(ErrInvalidGroupingArgument.Is(err))
+}
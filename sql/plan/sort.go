@@ -90,7 +90,7 @@ func (s *Sort) RowIter(ctx *sql.Context) (sql.RowIter, error) {
 		span.Finish()
 		return nil, err
 	}
-	return sql.NewSpanIter(span, newSortIter(s, i)), nil
+	return sql.NewSpanIter(span, newSortIter(ctx, s, i)), nil
 }
 
 // TransformUp implements the Transformable interface.
@@ -160,14 +160,17 @@ func (s *Sort) TransformExpressions(f sql.TransformExprFunc) (sql.Node, error) {
 }
 
 type sortIter struct {
+	ctx        *sql.Context
 	s          *Sort
 	childIter  sql.RowIter
 	sortedRows []sql.Row
 	idx        int
+	memory     uint64
 }
 
-func newSortIter(s *Sort, child sql.RowIter) *sortIter {
+func newSortIter(ctx *sql.Context, s *Sort, child sql.RowIter) *sortIter {
 	return &sortIter{
+		ctx:        ctx,
 		s:          s,
 		childIter:  child,
 		sortedRows: nil,
@@ -179,6 +182,7 @@ func (i *sortIter) Next() (sql.Row, error) {
 	if i.idx == -1 {
 		err := i.computeSortedRows()
 		if err != nil {
+			i.releaseMemory()
 			return nil, err
 		}
 		i.idx = 0
@@ -193,12 +197,22 @@ func (i *sortIter) Next() (sql.Row, error) {
 
 func (i *sortIter) Close() error {
 	i.sortedRows = nil
+	i.releaseMemory()
 	return i.childIter.Close()
 }
 
+func (i *sortIter) releaseMemory() {
+	i.ctx.Memory().Shrink(i.memory)
+	i.memory = 0
+}
+
 func (i *sortIter) computeSortedRows() error {
 	var rows []sql.Row
 	for {
+		if err := i.ctx.Interrupted(); err != nil {
+			return err
+		}
+
 		childRow, err := i.childIter.Next()
 		if err == io.EOF {
 			break
@@ -207,6 +221,12 @@ func (i *sortIter) computeSortedRows() error {
 			return err
 		}
 
+		size := sql.EstimateRowSize(childRow)
+		if err := i.ctx.Memory().Grow(size); err != nil {
+			return err
+		}
+		i.memory += size
+
 		rows = append(rows, childRow)
 	}
 
@@ -214,6 +234,7 @@ func (i *sortIter) computeSortedRows() error {
 		sortFields: i.s.SortFields,
 		rows:       rows,
 		lastError:  nil,
+		ctx:        i.ctx,
 	}
 	sort.Stable(sorter)
 	if sorter.lastError != nil {
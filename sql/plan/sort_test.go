@@ -131,3 +131,38 @@ func TestSortDescending(t *testing.T) {
 	require.NoError(err)
 	require.Equal(expected, actual)
 }
+
+func TestSortMemoryLimit(t *testing.T) {
+	require := require.New(t)
+
+	schema := sql.Schema{
+		{Name: "col1", Type: sql.Text, Nullable: true},
+	}
+
+	child := mem.NewTable("test", schema)
+	for _, s := range []string{"c", "a", "b"} {
+		require.NoError(child.Insert(sql.NewRow(s)))
+	}
+
+	sf := []SortField{
+		{Column: expression.NewGetField(0, sql.Text, "col1", true), Order: Ascending},
+	}
+
+	memory := sql.NewMemoryTracker(sql.EstimateRowSize(sql.NewRow("a"))*2, nil)
+	ctx := sql.NewEmptyContext().WithMemory(memory)
+	_, err := sql.NodeToRows(ctx, NewSort(sf, child))
+	require.Error(err)
+	require.True(sql.ErrMemoryLimitExceeded.Is(err))
+
+	memory = sql.NewMemoryTracker(0, nil)
+	ctx = sql.NewEmptyContext().WithMemory(memory)
+	iter, err := NewSort(sf, child).RowIter(ctx)
+	require.NoError(err)
+
+	_, err = iter.Next()
+	require.NoError(err)
+	require.Equal(sql.EstimateRowSize(sql.NewRow("a"))*3, memory.Usage())
+
+	require.NoError(iter.Close())
+	require.Equal(uint64(0), memory.Usage())
+}
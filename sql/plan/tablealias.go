@@ -11,6 +11,8 @@ import (
 type TableAlias struct {
 	*UnaryNode
 	name string
+	// IndexHint about the indexes to use for the aliased table, if any.
+	IndexHint *IndexHint
 }
 
 // NewTableAlias returns a new Table alias node.
@@ -18,6 +20,13 @@ func NewTableAlias(name string, node sql.Node) *TableAlias {
 	return &TableAlias{UnaryNode: &UnaryNode{Child: node}, name: name}
 }
 
+// WithIndexHint returns a copy of the alias with the given index hint.
+func (t *TableAlias) WithIndexHint(hint *IndexHint) *TableAlias {
+	nt := *t
+	nt.IndexHint = hint
+	return &nt
+}
+
 // Name implements the Nameable interface.
 func (t *TableAlias) Name() string {
 	return t.name
@@ -29,7 +38,7 @@ func (t *TableAlias) TransformUp(f sql.TransformNodeFunc) (sql.Node, error) {
 	if err != nil {
 		return nil, err
 	}
-	return f(NewTableAlias(t.name, child))
+	return f(t.withChild(child))
 }
 
 // TransformExpressionsUp implements the Transformable interface.
@@ -38,7 +47,11 @@ func (t *TableAlias) TransformExpressionsUp(f sql.TransformExprFunc) (sql.Node,
 	if err != nil {
 		return nil, err
 	}
-	return NewTableAlias(t.name, child), nil
+	return t.withChild(child), nil
+}
+
+func (t *TableAlias) withChild(child sql.Node) *TableAlias {
+	return &TableAlias{&UnaryNode{Child: child}, t.name, t.IndexHint}
 }
 
 // RowIter implements the Node interface.
@@ -63,7 +76,11 @@ func (t *TableAlias) RowIter(ctx *sql.Context) (sql.RowIter, error) {
 
 func (t TableAlias) String() string {
 	pr := sql.NewTreePrinter()
-	_ = pr.WriteNode("TableAlias(%s)", t.name)
+	if t.IndexHint != nil {
+		_ = pr.WriteNode("TableAlias(%s %s)", t.name, t.IndexHint)
+	} else {
+		_ = pr.WriteNode("TableAlias(%s)", t.name)
+	}
 	_ = pr.WriteChildren(t.Child.String())
 	return pr.String()
 }
@@ -14,11 +14,13 @@ var ErrUnresolvedTable = errors.NewKind("unresolved table")
 type UnresolvedTable struct {
 	// Name of the table.
 	Name string
+	// IndexHint about the indexes to use for the table, if any.
+	IndexHint *IndexHint
 }
 
 // NewUnresolvedTable creates a new Unresolved table.
 func NewUnresolvedTable(name string) *UnresolvedTable {
-	return &UnresolvedTable{name}
+	return &UnresolvedTable{Name: name}
 }
 
 // Resolved implements the Resolvable interface.
@@ -43,7 +45,7 @@ func (*UnresolvedTable) RowIter(ctx *sql.Context) (sql.RowIter, error) {
 
 // TransformUp implements the Transformable interface.
 func (t *UnresolvedTable) TransformUp(f sql.TransformNodeFunc) (sql.Node, error) {
-	return f(NewUnresolvedTable(t.Name))
+	return f(&UnresolvedTable{t.Name, t.IndexHint})
 }
 
 // TransformExpressionsUp implements the Transformable interface.
@@ -52,5 +54,8 @@ func (t *UnresolvedTable) TransformExpressionsUp(f sql.TransformExprFunc) (sql.N
 }
 
 func (t UnresolvedTable) String() string {
+	if t.IndexHint != nil {
+		return fmt.Sprintf("UnresolvedTable(%s %s)", t.Name, t.IndexHint)
+	}
 	return fmt.Sprintf("UnresolvedTable(%s)", t.Name)
 }
@@ -0,0 +1,286 @@
+package plan
+
+import (
+	"io"
+	"strings"
+
+	opentracing "github.com/opentracing/opentracing-go"
+	errors "gopkg.in/src-d/go-errors.v1"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+// ErrInvalidWindowFunction is returned when the function of a window is
+// neither a window function nor an aggregation.
+var ErrInvalidWindowFunction = errors.NewKind("%s cannot be used as a window function")
+
+// Window is a projection of expressions of which some are window functions,
+// which are computed over all the rows of the child node.
+type Window struct {
+	UnaryNode
+	SelectExprs []sql.Expression
+}
+
+// NewWindow creates a new Window node.
+func NewWindow(selectExprs []sql.Expression, child sql.Node) *Window {
+	return &Window{
+		UnaryNode:   UnaryNode{child},
+		SelectExprs: selectExprs,
+	}
+}
+
+// Schema implements the Node interface.
+func (w *Window) Schema() sql.Schema {
+	// The schema is the same as the one of a projection of the expressions.
+	return NewProject(w.SelectExprs, w.Child).Schema()
+}
+
+// Resolved implements the Resolvable interface.
+func (w *Window) Resolved() bool {
+	return w.UnaryNode.Child.Resolved() &&
+		expressionsResolved(w.SelectExprs...)
+}
+
+// RowIter implements the Node interface.
+func (w *Window) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	span, ctx := ctx.Span("plan.Window", opentracing.Tag{
+		Key:   "expressions",
+		Value: len(w.SelectExprs),
+	})
+
+	i, err := w.Child.RowIter(ctx)
+	if err != nil {
+		span.Finish()
+		return nil, err
+	}
+	return sql.NewSpanIter(span, &windowIter{w: w, childIter: i, ctx: ctx}), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (w *Window) TransformUp(f sql.TransformNodeFunc) (sql.Node, error) {
+	child, err := w.Child.TransformUp(f)
+	if err != nil {
+		return nil, err
+	}
+	return f(NewWindow(w.SelectExprs, child))
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (w *Window) TransformExpressionsUp(f sql.TransformExprFunc) (sql.Node, error) {
+	exprs, err := transformExpressionsUp(f, w.SelectExprs)
+	if err != nil {
+		return nil, err
+	}
+
+	child, err := w.Child.TransformExpressionsUp(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWindow(exprs, child), nil
+}
+
+func (w *Window) String() string {
+	pr := sql.NewTreePrinter()
+	var exprs = make([]string, len(w.SelectExprs))
+	for i, expr := range w.SelectExprs {
+		exprs[i] = expr.String()
+	}
+	_ = pr.WriteNode("Window(%s)", strings.Join(exprs, ", "))
+	_ = pr.WriteChildren(w.Child.String())
+	return pr.String()
+}
+
+// Expressions implements the Expressioner interface.
+func (w *Window) Expressions() []sql.Expression {
+	return w.SelectExprs
+}
+
+// TransformExpressions implements the Expressioner interface.
+func (w *Window) TransformExpressions(f sql.TransformExprFunc) (sql.Node, error) {
+	exprs, err := transformExpressionsUp(f, w.SelectExprs)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWindow(exprs, w.Child), nil
+}
+
+type windowIter struct {
+	w         *Window
+	childIter sql.RowIter
+	ctx       *sql.Context
+	rows      []sql.Row
+	pos       int
+	memory    uint64
+}
+
+func (i *windowIter) Next() (sql.Row, error) {
+	if i.rows == nil {
+		if err := i.compute(); err != nil {
+			i.releaseMemory()
+			return nil, err
+		}
+	}
+
+	if i.pos >= len(i.rows) {
+		return nil, io.EOF
+	}
+
+	row := i.rows[i.pos]
+	i.pos++
+	return row, nil
+}
+
+func (i *windowIter) Close() error {
+	i.rows = nil
+	i.releaseMemory()
+	return i.childIter.Close()
+}
+
+func (i *windowIter) releaseMemory() {
+	i.ctx.Memory().Shrink(i.memory)
+	i.memory = 0
+}
+
+// compute computes the values of all the windows, which are appended to the
+// rows of the child, and then evaluates the expressions with the windows
+// replaced by the fields with their values.
+func (i *windowIter) compute() error {
+	var rows []sql.Row
+	for {
+		if err := i.ctx.Interrupted(); err != nil {
+			return err
+		}
+
+		row, err := i.childIter.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		size := sql.EstimateRowSize(row)
+		if err := i.ctx.Memory().Grow(size); err != nil {
+			return err
+		}
+		i.memory += size
+
+		rows = append(rows, row)
+	}
+
+	width := len(i.w.Child.Schema())
+	var windows []*expression.Window
+	exprs, err := transformExpressionsUp(func(e sql.Expression) (sql.Expression, error) {
+		w, ok := e.(*expression.Window)
+		if !ok {
+			return e, nil
+		}
+
+		windows = append(windows, w)
+		return expression.NewGetField(
+			width+len(windows)-1,
+			w.Type(),
+			w.String(),
+			w.IsNullable(),
+		), nil
+	}, i.w.SelectExprs)
+	if err != nil {
+		return err
+	}
+
+	var values = make([][]interface{}, len(windows))
+	for j, w := range windows {
+		values[j], err = evalWindow(i.ctx, w, rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	i.rows = make([]sql.Row, len(rows))
+	for j, row := range rows {
+		var fields = append(make(sql.Row, 0, len(row)+len(windows)), row...)
+		for _, v := range values {
+			fields = append(fields, v[j])
+		}
+
+		i.rows[j], err = filterRow(i.ctx, exprs, fields)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// evalWindow returns the value of the window function for each one of the
+// given rows.
+func evalWindow(ctx *sql.Context, w *expression.Window, rows []sql.Row) ([]interface{}, error) {
+	partitions, err := w.Definition.Partition(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var result = make([]interface{}, len(rows))
+	for _, p := range partitions {
+		var values []interface{}
+		switch fn := w.Function.(type) {
+		case sql.WindowFunction:
+			values, err = fn.EvalWindow(ctx, p)
+		case sql.Aggregation:
+			values, err = evalWindowAggregation(ctx, fn, p)
+		default:
+			return nil, ErrInvalidWindowFunction.New(w.Function)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		for j, idx := range p.Indexes {
+			result[idx] = values[j]
+		}
+	}
+
+	return result, nil
+}
+
+// evalWindowAggregation returns the value of the aggregation over the frame
+// of each one of the rows of the partition. Frames which start with the same
+// row as the previous one reuse its buffer, so frames that start with the
+// partition are computed incrementally.
+func evalWindowAggregation(
+	ctx *sql.Context,
+	agg sql.Aggregation,
+	p *sql.WindowPartition,
+) ([]interface{}, error) {
+	var result = make([]interface{}, p.Len())
+	var buffer sql.Row
+	var bufferStart, bufferEnd int
+	for i := range result {
+		start, end, err := p.Frame(i)
+		if err != nil {
+			return nil, err
+		}
+
+		if buffer == nil || start != bufferStart || end < bufferEnd {
+			buffer = agg.NewBuffer()
+			bufferStart, bufferEnd = start, start
+		}
+
+		for ; bufferEnd < end; bufferEnd++ {
+			if err := agg.Update(ctx, buffer, p.Rows[bufferEnd]); err != nil {
+				return nil, err
+			}
+		}
+
+		result[i], err = agg.Eval(ctx, buffer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
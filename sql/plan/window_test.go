@@ -0,0 +1,177 @@
+package plan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/mem"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression/function"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression/function/aggregation"
+)
+
+func TestWindow_Schema(t *testing.T) {
+	require := require.New(t)
+
+	rowNumber, err := function.NewRowNumber()
+	require.NoError(err)
+
+	w := NewWindow([]sql.Expression{
+		expression.NewGetFieldWithTable(0, sql.Text, "test", "a", false),
+		expression.NewAlias(
+			expression.NewWindow(rowNumber, &sql.WindowDefinition{}),
+			"n",
+		),
+	}, mem.NewTable("test", sql.Schema{}))
+
+	require.Equal(sql.Schema{
+		{Name: "a", Type: sql.Text, Source: "test"},
+		{Name: "n", Type: sql.Uint64},
+	}, w.Schema())
+}
+
+func TestWindow_RowIter(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	child := mem.NewTable("test", sql.Schema{
+		{Name: "a", Type: sql.Text, Source: "test"},
+		{Name: "b", Type: sql.Int64, Source: "test"},
+	})
+	rows := []sql.Row{
+		sql.NewRow("x", int64(3)),
+		sql.NewRow("y", int64(1)),
+		sql.NewRow("x", int64(1)),
+		sql.NewRow("y", int64(2)),
+		sql.NewRow("x", int64(2)),
+	}
+	for _, r := range rows {
+		require.NoError(child.Insert(r))
+	}
+
+	a := expression.NewGetFieldWithTable(0, sql.Text, "test", "a", false)
+	b := expression.NewGetFieldWithTable(1, sql.Int64, "test", "b", false)
+	rowNumber, err := function.NewRowNumber()
+	require.NoError(err)
+
+	byA := &sql.WindowDefinition{
+		PartitionBy: []sql.Expression{a},
+		OrderBy:     []sql.WindowOrderBy{{Column: b}},
+	}
+
+	w := NewWindow([]sql.Expression{
+		a,
+		b,
+		expression.NewWindow(rowNumber, byA),
+		// running count, as the default frame ends with the current row
+		expression.NewWindow(aggregation.NewCount(b), byA),
+		expression.NewArithmetic(
+			expression.NewWindow(aggregation.NewCount(b), &sql.WindowDefinition{}),
+			expression.NewLiteral(int64(1), sql.Int64),
+			"+",
+		),
+		expression.NewWindow(aggregation.NewMax(b), &sql.WindowDefinition{
+			OrderBy: []sql.WindowOrderBy{{Column: b}},
+			Frame: &sql.WindowFrame{
+				Unit:  sql.RowsFrame,
+				Start: sql.WindowBound{Type: sql.Following, Offset: expression.NewLiteral(int64(1), sql.Int64)},
+				End:   sql.WindowBound{Type: sql.UnboundedFollowing},
+			},
+		}),
+	}, child)
+
+	iter, err := w.RowIter(ctx)
+	require.NoError(err)
+
+	result, err := sql.RowIterToRows(iter)
+	require.NoError(err)
+
+	require.Equal([]sql.Row{
+		{"x", int64(3), uint64(3), int32(3), int64(6), nil},
+		{"y", int64(1), uint64(1), int32(1), int64(6), int64(3)},
+		{"x", int64(1), uint64(1), int32(1), int64(6), int64(3)},
+		{"y", int64(2), uint64(2), int32(2), int64(6), int64(3)},
+		{"x", int64(2), uint64(2), int32(2), int64(6), int64(3)},
+	}, result)
+}
+
+func TestWindow_InvalidFunction(t *testing.T) {
+	require := require.New(t)
+
+	child := mem.NewTable("test", sql.Schema{{Name: "a", Type: sql.Int64, Source: "test"}})
+	require.NoError(child.Insert(sql.NewRow(int64(1))))
+
+	w := NewWindow([]sql.Expression{
+		expression.NewWindow(
+			expression.NewGetFieldWithTable(0, sql.Int64, "test", "a", false),
+			&sql.WindowDefinition{},
+		),
+	}, child)
+
+	iter, err := w.RowIter(sql.NewEmptyContext())
+	require.NoError(err)
+
+	_, err = sql.RowIterToRows(iter)
+	require.True(ErrInvalidWindowFunction.Is(err))
+}
+
+func TestWindow_MemoryLimit(t *testing.T) {
+	require := require.New(t)
+
+	child := mem.NewTable("test", sql.Schema{{Name: "a", Type: sql.Int64, Source: "test"}})
+	for i := int64(0); i < 3; i++ {
+		require.NoError(child.Insert(sql.NewRow(i)))
+	}
+
+	rowNumber, err := function.NewRowNumber()
+	require.NoError(err)
+
+	w := NewWindow([]sql.Expression{
+		expression.NewWindow(rowNumber, &sql.WindowDefinition{}),
+	}, child)
+
+	rowSize := sql.EstimateRowSize(sql.NewRow(int64(0)))
+	memory := sql.NewMemoryTracker(rowSize*2, nil)
+	_, err = sql.NodeToRows(sql.NewEmptyContext().WithMemory(memory), w)
+	require.Error(err)
+	require.True(sql.ErrMemoryLimitExceeded.Is(err))
+	require.Equal(uint64(0), memory.Usage())
+
+	memory = sql.NewMemoryTracker(0, nil)
+	iter, err := w.RowIter(sql.NewEmptyContext().WithMemory(memory))
+	require.NoError(err)
+
+	_, err = iter.Next()
+	require.NoError(err)
+	require.Equal(rowSize*3, memory.Usage())
+
+	require.NoError(iter.Close())
+	require.Equal(uint64(0), memory.Usage())
+}
+
+func TestWindow_Cancelled(t *testing.T) {
+	require := require.New(t)
+
+	child := mem.NewTable("test", sql.Schema{{Name: "a", Type: sql.Int64, Source: "test"}})
+	require.NoError(child.Insert(sql.NewRow(int64(1))))
+
+	rowNumber, err := function.NewRowNumber()
+	require.NoError(err)
+
+	w := NewWindow([]sql.Expression{
+		expression.NewWindow(rowNumber, &sql.WindowDefinition{}),
+	}, child)
+
+	cctx, cancel := context.WithCancel(context.TODO())
+	ctx := sql.NewContext(cctx)
+
+	iter, err := w.RowIter(ctx)
+	require.NoError(err)
+
+	cancel()
+	_, err = iter.Next()
+	require.Error(err)
+	require.True(sql.ErrQueryCanceled.Is(err))
+}
@@ -50,7 +50,29 @@ type RowIter interface {
 
 // RowIterToRows converts a row iterator to a slice of rows.
 func RowIterToRows(i RowIter) ([]Row, error) {
+	return rowIterToRows(nil, i)
+}
+
+// NodeToRows converts a node to a slice of rows. The rows are charged to the
+// memory tracker of the context while they are being collected.
+func NodeToRows(ctx *Context, n Node) ([]Row, error) {
+	i, err := n.RowIter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return rowIterToRows(ctx.Memory(), i)
+}
+
+func rowIterToRows(memory *MemoryTracker, i RowIter) ([]Row, error) {
 	var rows []Row
+	var used uint64
+	// Once collected, the rows belong to the caller, so they are no longer
+	// accounted for.
+	defer func() {
+		memory.Shrink(used)
+	}()
+
 	for {
 		row, err := i.Next()
 		if err == io.EOF {
@@ -61,22 +83,19 @@ func RowIterToRows(i RowIter) ([]Row, error) {
 			return nil, err
 		}
 
+		size := EstimateRowSize(row)
+		if err := memory.Grow(size); err != nil {
+			_ = i.Close()
+			return nil, err
+		}
+		used += size
+
 		rows = append(rows, row)
 	}
 
 	return rows, i.Close()
 }
 
-// NodeToRows converts a node to a slice of rows.
-func NodeToRows(ctx *Context, n Node) ([]Row, error) {
-	i, err := n.RowIter(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	return RowIterToRows(i)
-}
-
 // RowsToRowIter creates a RowIter that iterates over the given rows.
 func RowsToRowIter(rows ...Row) RowIter {
 	return &sliceRowIter{rows: rows}
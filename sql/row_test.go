@@ -50,3 +50,40 @@ func TestRowsToRowIter(t *testing.T) {
 	err = iter.Close()
 	require.NoError(err)
 }
+
+func TestNodeToRowsMemoryLimit(t *testing.T) {
+	require := require.New(t)
+
+	rows := []Row{
+		NewRow("first row"),
+		NewRow("second row"),
+		NewRow("third row"),
+	}
+	node := &rowsNode{rows}
+
+	memory := NewMemoryTracker(EstimateRowSize(rows[0])+1, nil)
+	ctx := NewEmptyContext().WithMemory(memory)
+	_, err := NodeToRows(ctx, node)
+	require.Error(err)
+	require.True(ErrMemoryLimitExceeded.Is(err))
+	require.Equal(uint64(0), memory.Usage())
+
+	memory = NewMemoryTracker(0, nil)
+	ctx = NewEmptyContext().WithMemory(memory)
+	result, err := NodeToRows(ctx, node)
+	require.NoError(err)
+	require.Equal(rows, result)
+	require.Equal(uint64(0), memory.Usage())
+}
+
+type rowsNode struct {
+	rows []Row
+}
+
+func (n *rowsNode) Resolved() bool                                         { return true }
+func (n *rowsNode) String() string                                         { return "rowsNode" }
+func (n *rowsNode) Schema() Schema                                         { return nil }
+func (n *rowsNode) Children() []Node                                       { return nil }
+func (n *rowsNode) RowIter(*Context) (RowIter, error)                      { return RowsToRowIter(n.rows...), nil }
+func (n *rowsNode) TransformUp(f TransformNodeFunc) (Node, error)          { return f(n) }
+func (n *rowsNode) TransformExpressionsUp(TransformExprFunc) (Node, error) { return n, nil }
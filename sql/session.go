@@ -3,10 +3,22 @@ package sql
 import (
 	"context"
 	"io"
+	"sync"
 	"time"
 
 	opentracing "github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/log"
+	"gopkg.in/src-d/go-errors.v1"
+)
+
+var (
+	// ErrQueryCanceled is returned when a query is canceled before it
+	// finishes, e.g. using KILL QUERY.
+	ErrQueryCanceled = errors.NewKind("query execution was interrupted")
+
+	// ErrQueryTimeout is returned when a query takes longer than the maximum
+	// execution time allowed.
+	ErrQueryTimeout = errors.NewKind("query execution was interrupted, maximum statement execution time exceeded")
 )
 
 // Session holds the session data.
@@ -14,9 +26,26 @@ type Session interface {
 	// TODO: add config
 }
 
+// ExecutionTimeLimiter is a Session that limits the time a query can take to
+// execute.
+type ExecutionTimeLimiter interface {
+	// MaxExecutionTime returns the maximum time a query can take to execute.
+	// Zero means there is no limit.
+	MaxExecutionTime() time.Duration
+}
+
+// DatabaseSession is a Session with a current database, in which the tables
+// of its queries are looked up.
+type DatabaseSession interface {
+	// CurrentDatabase returns the current database of the session. An empty
+	// name means the session has no current database.
+	CurrentDatabase() string
+}
+
 // BaseSession is the basic session type.
 type BaseSession struct {
-	// TODO: add config
+	maxExecutionTime time.Duration
+	currentDatabase  string
 }
 
 // NewBaseSession creates a new basic session.
@@ -24,11 +53,42 @@ func NewBaseSession() Session {
 	return &BaseSession{}
 }
 
+// MaxExecutionTime implements the ExecutionTimeLimiter interface.
+func (s *BaseSession) MaxExecutionTime() time.Duration {
+	return s.maxExecutionTime
+}
+
+// SetMaxExecutionTime sets the maximum time a query can take to execute in
+// this session. Zero means there is no limit.
+func (s *BaseSession) SetMaxExecutionTime(d time.Duration) {
+	s.maxExecutionTime = d
+}
+
+// CurrentDatabase implements the DatabaseSession interface.
+func (s *BaseSession) CurrentDatabase() string {
+	return s.currentDatabase
+}
+
+// SetCurrentDatabase changes the current database of the session.
+func (s *BaseSession) SetCurrentDatabase(db string) {
+	s.currentDatabase = db
+}
+
 // Context of the query execution.
 type Context struct {
 	context.Context
 	Session
-	tracer opentracing.Tracer
+	tracer    opentracing.Tracer
+	memory    *MemoryTracker
+	queryTime time.Time
+	values    *queryValues
+}
+
+// queryValues are the values kept during the execution of a query. They are
+// shared by all the contexts derived from the context of the query.
+type queryValues struct {
+	mu     sync.Mutex
+	values map[interface{}]interface{}
 }
 
 // ContextOption is a function to configure the context.
@@ -48,15 +108,33 @@ func WithTracer(t opentracing.Tracer) ContextOption {
 	}
 }
 
+// WithMemoryTracker sets the tracker that will account for the memory used by
+// the query.
+func WithMemoryTracker(t *MemoryTracker) ContextOption {
+	return func(ctx *Context) {
+		ctx.memory = t
+	}
+}
+
+// WithQueryTime sets the time at which the query started, which is the time
+// returned by functions such as NOW.
+func WithQueryTime(t time.Time) ContextOption {
+	return func(ctx *Context) {
+		ctx.queryTime = t
+	}
+}
+
 // NewContext creates a new query context. Options can be passed to configure
 // the context. If some aspect of the context is not configure, the default
 // value will be used.
-// By default, the context will have an empty base session and a noop tracer.
+// By default, the context will have an empty base session, a noop tracer, no
+// memory tracker and the current time as the time at which the query
+// started.
 func NewContext(
 	ctx context.Context,
 	opts ...ContextOption,
 ) *Context {
-	c := &Context{ctx, NewBaseSession(), opentracing.NoopTracer{}}
+	c := &Context{ctx, NewBaseSession(), opentracing.NoopTracer{}, nil, time.Now(), new(queryValues)}
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -80,17 +158,82 @@ func (c *Context) Span(
 	span := c.tracer.StartSpan(opName, opts...)
 	ctx := opentracing.ContextWithSpan(c.Context, span)
 
-	return span, &Context{ctx, c.Session, c.tracer}
+	return span, &Context{ctx, c.Session, c.tracer, c.memory, c.queryTime, c.values}
+}
+
+// Memory returns the memory tracker of the context, which may be nil if the
+// memory of the query is not being tracked.
+func (c *Context) Memory() *MemoryTracker {
+	return c.memory
+}
+
+// WithTimeout returns a copy of the context that will be cancelled after the
+// given duration, along with the function to cancel it and release its
+// resources.
+func (c *Context) WithTimeout(d time.Duration) (*Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(c.Context, d)
+	return &Context{ctx, c.Session, c.tracer, c.memory, c.queryTime, c.values}, cancel
+}
+
+// Interrupted returns an error if the query has been cancelled or it
+// exceeded its deadline, or nil otherwise. Iterators that may take a long
+// time to produce a row should check it periodically so they can stop as
+// soon as possible.
+func (c *Context) Interrupted() error {
+	select {
+	case <-c.Done():
+		if c.Err() == context.DeadlineExceeded {
+			return ErrQueryTimeout.New()
+		}
+		return ErrQueryCanceled.New()
+	default:
+		return nil
+	}
+}
+
+// WithMemory returns a copy of the context using the given memory tracker.
+func (c *Context) WithMemory(t *MemoryTracker) *Context {
+	return &Context{c.Context, c.Session, c.tracer, t, c.queryTime, c.values}
+}
+
+// QueryTime returns the time at which the query started. All the functions
+// that return the current time use it, so they return the same value during
+// the whole execution of the query.
+func (c *Context) QueryTime() time.Time {
+	return c.queryTime
+}
+
+// QueryValue returns the value kept for the given key during the execution of
+// the query, creating it with the given function the first time. Expressions
+// use it for the state they keep between rows, because the same plan may be
+// executed several times, even concurrently, and each execution must start
+// with its own state.
+func (c *Context) QueryValue(key interface{}, create func() interface{}) interface{} {
+	c.values.mu.Lock()
+	defer c.values.mu.Unlock()
+
+	if v, ok := c.values.values[key]; ok {
+		return v
+	}
+
+	if c.values.values == nil {
+		c.values.values = make(map[interface{}]interface{})
+	}
+
+	v := create()
+	c.values.values[key] = v
+	return v
 }
 
 // NewSpanIter creates a RowIter executed in the given span.
 func NewSpanIter(span opentracing.Span, iter RowIter) RowIter {
-	return &spanIter{span, iter, 0, false}
+	return &spanIter{span: span, iter: iter}
 }
 
 type spanIter struct {
 	span  opentracing.Span
 	iter  RowIter
+	batch BatchIter
 	count int
 	done  bool
 }
@@ -115,6 +258,30 @@ func (i *spanIter) Next() (Row, error) {
 	return row, nil
 }
 
+func (i *spanIter) NextBatch(max int) ([]Row, error) {
+	if i.done {
+		return nil, io.EOF
+	}
+
+	if i.batch == nil {
+		i.batch = ToBatchIter(i.iter)
+	}
+
+	rows, err := i.batch.NextBatch(max)
+	if err == io.EOF {
+		i.finish()
+		return nil, err
+	}
+
+	if err != nil {
+		i.finishWithError(err)
+		return nil, err
+	}
+
+	i.count += len(rows)
+	return rows, nil
+}
+
 func (i *spanIter) finish() {
 	i.span.FinishWithOptions(opentracing.FinishOptions{
 		LogRecords: []opentracing.LogRecord{
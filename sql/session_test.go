@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -91,3 +92,43 @@ func TestSessionIterator(t *testing.T) {
 
 	cancelFunc()
 }
+
+func TestContextInterrupted(t *testing.T) {
+	require := require.New(t)
+
+	ctx := NewEmptyContext()
+	require.NoError(ctx.Interrupted())
+
+	cctx, cancel := context.WithCancel(context.TODO())
+	ctx = NewContext(cctx)
+	cancel()
+	err := ctx.Interrupted()
+	require.Error(err)
+	require.True(ErrQueryCanceled.Is(err))
+
+	ctx, cancel = NewEmptyContext().WithTimeout(time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	err = ctx.Interrupted()
+	require.Error(err)
+	require.True(ErrQueryTimeout.Is(err))
+}
+
+func TestContextQueryValue(t *testing.T) {
+	require := require.New(t)
+
+	var created int
+	create := func() interface{} {
+		created++
+		return created
+	}
+
+	ctx := NewEmptyContext()
+	require.Equal(1, ctx.QueryValue("foo", create))
+
+	_, spanCtx := ctx.Span("span")
+	require.Equal(1, spanCtx.QueryValue("foo", create))
+	require.Equal(2, spanCtx.QueryValue("bar", create))
+
+	require.Equal(3, NewEmptyContext().QueryValue("foo", create))
+}
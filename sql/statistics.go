@@ -0,0 +1,237 @@
+package sql
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// DefaultHistogramBuckets is the maximum number of buckets of the histograms
+// computed by ComputeStatistics.
+const DefaultHistogramBuckets = 10
+
+// TableStatistics contains statistics about the rows of a table, which the
+// analyzer uses to estimate how many rows each part of a plan returns.
+type TableStatistics struct {
+	// RowCount is the number of rows of the table.
+	RowCount uint64
+	// Columns contains the statistics of the columns of the table by name.
+	// Columns without statistics are not in the map.
+	Columns map[string]*ColumnStatistics
+}
+
+// Column returns the statistics of the column with the given name, or nil
+// if there are none.
+func (s *TableStatistics) Column(name string) *ColumnStatistics {
+	if s == nil {
+		return nil
+	}
+	return s.Columns[name]
+}
+
+// ColumnStatistics contains statistics about the values of a column.
+type ColumnStatistics struct {
+	// DistinctCount is the number of distinct non-NULL values.
+	DistinctCount uint64
+	// NullCount is the number of NULL values.
+	NullCount uint64
+	// Histogram of the non-NULL values. It's optional and may be nil.
+	Histogram Histogram
+}
+
+// Histogram is an equi-height histogram of the values of a column, whose
+// buckets are sorted by their upper bound.
+type Histogram []HistogramBucket
+
+// HistogramBucket is a bucket of a histogram, which contains the values
+// greater than the upper bound of the previous bucket and less than or equal
+// to its own.
+type HistogramBucket struct {
+	// UpperBound is the greatest value in the bucket.
+	UpperBound interface{}
+	// Count is the number of values in the bucket.
+	Count uint64
+}
+
+// FractionLessThan returns the estimated fraction of the values of the
+// histogram, whose type is t, that are less than v, and whether it could be
+// estimated.
+func (h Histogram) FractionLessThan(t Type, v interface{}) (float64, bool) {
+	var total, less float64
+	for _, b := range h {
+		total += float64(b.Count)
+	}
+
+	if total == 0 {
+		return 0, false
+	}
+
+	for _, b := range h {
+		cmp, err := t.Compare(b.UpperBound, v)
+		if err != nil {
+			return 0, false
+		}
+
+		if cmp < 0 {
+			less += float64(b.Count)
+			continue
+		}
+
+		// The values of the bucket that contains v are assumed to be
+		// uniformly distributed, so half of them are less than it.
+		less += float64(b.Count) / 2
+		break
+	}
+
+	return less / total, true
+}
+
+// StatisticsTable is a table that can provide statistics about its rows.
+// Statistics of the rest of tables can be computed with ANALYZE TABLE.
+type StatisticsTable interface {
+	Table
+	// Statistics returns the statistics of the rows of the table.
+	Statistics(ctx *Context) (*TableStatistics, error)
+}
+
+type statisticsKey struct {
+	db, table string
+}
+
+// StatisticsRegistry keeps the statistics computed for the tables that
+// can't provide them.
+type StatisticsRegistry struct {
+	mut   sync.RWMutex
+	stats map[statisticsKey]*TableStatistics
+}
+
+// NewStatisticsRegistry returns a new empty StatisticsRegistry.
+func NewStatisticsRegistry() *StatisticsRegistry {
+	return &StatisticsRegistry{
+		stats: make(map[statisticsKey]*TableStatistics),
+	}
+}
+
+// Statistics returns the statistics of the given table of the given
+// database, or nil if there are none.
+func (r *StatisticsRegistry) Statistics(db, table string) *TableStatistics {
+	if r == nil {
+		return nil
+	}
+
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	return r.stats[statisticsKey{db, table}]
+}
+
+// SetStatistics sets the statistics of the given table of the given
+// database, replacing the previous ones.
+func (r *StatisticsRegistry) SetStatistics(db, table string, stats *TableStatistics) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.stats[statisticsKey{db, table}] = stats
+}
+
+// ComputeStatistics computes the statistics of all the rows returned by the
+// given iterator, whose schema is the given one. The iterator is closed
+// once all the rows have been read.
+func ComputeStatistics(schema Schema, iter RowIter) (*TableStatistics, error) {
+	var rows []Row
+	for {
+		row, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			_ = iter.Close()
+			return nil, err
+		}
+
+		rows = append(rows, row)
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	stats := &TableStatistics{
+		RowCount: uint64(len(rows)),
+		Columns:  make(map[string]*ColumnStatistics, len(schema)),
+	}
+
+	for i, col := range schema {
+		stats.Columns[col.Name] = columnStatistics(col.Type, rows, i)
+	}
+
+	return stats, nil
+}
+
+func columnStatistics(t Type, rows []Row, idx int) *ColumnStatistics {
+	var stats ColumnStatistics
+	var values []interface{}
+	var distinct = make(map[string]struct{})
+	for _, row := range rows {
+		v := row[idx]
+		if v == nil {
+			stats.NullCount++
+			continue
+		}
+
+		values = append(values, v)
+		distinct[fmt.Sprintf("%#v", NormalizeKey(t, v))] = struct{}{}
+	}
+
+	stats.DistinctCount = uint64(len(distinct))
+	stats.Histogram = newHistogram(t, values, DefaultHistogramBuckets)
+	return &stats
+}
+
+// newHistogram returns a histogram of the given values with at most the
+// given number of buckets, or nil if the values can't be sorted. Values that
+// are equal are always in the same bucket.
+func newHistogram(t Type, values []interface{}, buckets int) Histogram {
+	if len(values) == 0 {
+		return nil
+	}
+
+	var err error
+	sort.Slice(values, func(i, j int) bool {
+		cmp, e := t.Compare(values[i], values[j])
+		if e != nil {
+			err = e
+		}
+		return cmp < 0
+	})
+
+	if err != nil {
+		return nil
+	}
+
+	size := (len(values) + buckets - 1) / buckets
+	var result Histogram
+	var count uint64
+	for i, v := range values {
+		count++
+		if i+1 < len(values) {
+			if count < uint64(size) {
+				continue
+			}
+
+			cmp, err := t.Compare(v, values[i+1])
+			if err != nil {
+				return nil
+			}
+
+			if cmp == 0 {
+				continue
+			}
+		}
+
+		result = append(result, HistogramBucket{UpperBound: v, Count: count})
+		count = 0
+	}
+
+	return result
+}
@@ -0,0 +1,93 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeStatistics(t *testing.T) {
+	require := require.New(t)
+
+	schema := Schema{
+		{Name: "i", Type: Int64, Nullable: true},
+		{Name: "s", Type: Text},
+	}
+
+	var rows []Row
+	for i := 0; i < 20; i++ {
+		rows = append(rows, NewRow(int64(i%5), "foo"))
+	}
+	rows = append(rows, NewRow(nil, "bar"))
+
+	stats, err := ComputeStatistics(schema, RowsToRowIter(rows...))
+	require.NoError(err)
+
+	require.Equal(uint64(21), stats.RowCount)
+
+	i := stats.Column("i")
+	require.Equal(uint64(5), i.DistinctCount)
+	require.Equal(uint64(1), i.NullCount)
+	require.Equal(Histogram{
+		{UpperBound: int64(0), Count: 4},
+		{UpperBound: int64(1), Count: 4},
+		{UpperBound: int64(2), Count: 4},
+		{UpperBound: int64(3), Count: 4},
+		{UpperBound: int64(4), Count: 4},
+	}, i.Histogram)
+
+	s := stats.Column("s")
+	require.Equal(uint64(2), s.DistinctCount)
+	require.Equal(uint64(0), s.NullCount)
+	require.Equal(Histogram{
+		{UpperBound: "foo", Count: 21},
+	}, s.Histogram)
+
+	require.Nil(stats.Column("unknown"))
+}
+
+func TestHistogramFractionLessThan(t *testing.T) {
+	require := require.New(t)
+
+	h := Histogram{
+		{UpperBound: int64(10), Count: 10},
+		{UpperBound: int64(20), Count: 10},
+		{UpperBound: int64(30), Count: 20},
+	}
+
+	testCases := []struct {
+		value    interface{}
+		expected float64
+	}{
+		{int64(0), 0.125},
+		{int64(15), 0.375},
+		{int64(30), 0.75},
+		{int64(40), 1},
+	}
+
+	for _, tt := range testCases {
+		f, ok := h.FractionLessThan(Int64, tt.value)
+		require.True(ok)
+		require.Equal(tt.expected, f)
+	}
+
+	_, ok := Histogram(nil).FractionLessThan(Int64, int64(1))
+	require.False(ok)
+}
+
+func TestStatisticsRegistry(t *testing.T) {
+	require := require.New(t)
+
+	c := NewCatalog()
+	require.Nil(c.Statistics("db", "t"))
+
+	version := c.Version()
+	stats := &TableStatistics{RowCount: 1}
+	c.SetStatistics("db", "t", stats)
+	require.Equal(stats, c.Statistics("db", "t"))
+	require.Nil(c.Statistics("db", "other"))
+	require.NotEqual(version, c.Version())
+
+	var r *StatisticsRegistry
+	require.Nil(r.Statistics("db", "t"))
+}
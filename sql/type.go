@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cast"
 	"gopkg.in/src-d/go-errors.v1"
 	"gopkg.in/src-d/go-vitess.v0/sqltypes"
@@ -37,6 +40,54 @@ var (
 
 	// ErrNotArray is returned when the value is not an array.
 	ErrNotArray = errors.NewKind("value of type %T is not an array")
+
+	// ErrInvalidDecimal is returned when a decimal type is defined with an
+	// invalid precision or scale.
+	ErrInvalidDecimal = errors.NewKind("invalid decimal type with precision %d and scale %d")
+
+	// ErrDecimalOutOfRange is returned when a value does not fit in the
+	// precision of a decimal type.
+	ErrDecimalOutOfRange = errors.NewKind("value %s is out of range for %s")
+
+	// ErrConvertingToDecimal is returned when a value cannot be converted to
+	// a decimal.
+	ErrConvertingToDecimal = errors.NewKind("value %v can't be converted to decimal")
+
+	// ErrValueOutOfRange is returned when a value does not fit in the range
+	// of an integer type.
+	ErrValueOutOfRange = errors.NewKind("value %v is out of range for %v")
+
+	// ErrInvalidCharLength is returned when a CHAR or VARCHAR type is
+	// defined with an invalid length.
+	ErrInvalidCharLength = errors.NewKind("invalid length %d for %s, maximum is %d")
+
+	// ErrLengthExceeded is returned when a string is longer than the length
+	// of its CHAR or VARCHAR type.
+	ErrLengthExceeded = errors.NewKind("value %q is too long for %s")
+
+	// ErrInvalidFractionalPrecision is returned when a temporal type is
+	// defined with an invalid fractional seconds precision.
+	ErrInvalidFractionalPrecision = errors.NewKind("invalid fractional seconds precision %d, maximum is %d")
+
+	// ErrConvertingToDuration is thrown when a value cannot be converted to
+	// a TIME value.
+	ErrConvertingToDuration = errors.NewKind("value %v can't be converted to time")
+
+	// ErrConvertingToYear is thrown when a value cannot be converted to a
+	// YEAR value.
+	ErrConvertingToYear = errors.NewKind("value %v can't be converted to year")
+
+	// ErrInvalidEnumValue is returned when a value is not one of the
+	// values of an ENUM or SET type.
+	ErrInvalidEnumValue = errors.NewKind("value %v is not valid for %s")
+
+	// ErrDuplicatedEnumValue is returned when an ENUM or SET type is
+	// defined with a duplicated value.
+	ErrDuplicatedEnumValue = errors.NewKind("duplicated value %q in %s")
+
+	// ErrTooManySetValues is returned when a SET type is defined with more
+	// values than allowed.
+	ErrTooManySetValues = errors.NewKind("SET types can have at most %d values, but %d were given")
 )
 
 // Schema is the definition of a table.
@@ -150,10 +201,22 @@ var (
 
 	// Numeric types
 
+	// Int8 is an integer of 8 bits.
+	Int8 = numberT{t: sqltypes.Int8}
+	// Int16 is an integer of 16 bits.
+	Int16 = numberT{t: sqltypes.Int16}
+	// Int24 is an integer of 24 bits.
+	Int24 = numberT{t: sqltypes.Int24}
 	// Int32 is an integer of 32 bits.
 	Int32 = numberT{t: sqltypes.Int32}
 	// Int64 is an integer of 64 bytes.
 	Int64 = numberT{t: sqltypes.Int64}
+	// Uint8 is an unsigned integer of 8 bits.
+	Uint8 = numberT{t: sqltypes.Uint8}
+	// Uint16 is an unsigned integer of 16 bits.
+	Uint16 = numberT{t: sqltypes.Uint16}
+	// Uint24 is an unsigned integer of 24 bits.
+	Uint24 = numberT{t: sqltypes.Uint24}
 	// Uint32 is an unsigned integer of 32 bytes.
 	Uint32 = numberT{t: sqltypes.Uint32}
 	// Uint64 is an unsigned integer of 64 bytes.
@@ -167,8 +230,15 @@ var (
 	Timestamp timestampT
 	// Date is a date with day, month and year.
 	Date dateT
-	// Text is a string type.
-	Text textT
+	// Datetime is a date and a time of the day without fractional seconds.
+	Datetime datetimeT
+	// Time is a time of the day or an elapsed time, without fractional
+	// seconds.
+	Time timeT
+	// Year is a year between 1901 and 2155, or zero.
+	Year yearT
+	// Text is a string type with the default collation.
+	Text = textT{DefaultCollation}
 	// Boolean is a boolean type.
 	Boolean booleanT
 	// JSON is a type that holds any valid JSON object.
@@ -177,6 +247,40 @@ var (
 	Blob blobT
 )
 
+const (
+	// DecimalMaxPrecision is the maximum number of digits of a decimal.
+	DecimalMaxPrecision = 65
+	// DecimalMaxScale is the maximum number of digits after the decimal
+	// point of a decimal.
+	DecimalMaxScale = 30
+	// DecimalDefaultPrecision is the precision of a decimal defined without
+	// precision.
+	DecimalDefaultPrecision = 10
+)
+
+// Decimal returns a new fixed-point decimal type with the given precision,
+// which is the total number of digits, and scale, which is the number of
+// digits after the decimal point. Values of decimal types are of type
+// decimal.Decimal.
+func Decimal(precision, scale uint8) (Type, error) {
+	if precision == 0 || precision > DecimalMaxPrecision ||
+		scale > DecimalMaxScale || scale > precision {
+		return nil, ErrInvalidDecimal.New(precision, scale)
+	}
+
+	return decimalT{precision, scale}, nil
+}
+
+// MustDecimal returns a new decimal type with the given precision and scale
+// and panics if they are not valid.
+func MustDecimal(precision, scale uint8) Type {
+	t, err := Decimal(precision, scale)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
 // Tuple returns a new tuple type with the given element types.
 func Tuple(types ...Type) Type {
 	return tupleT(types)
@@ -187,11 +291,142 @@ func Array(underlying Type) Type {
 	return arrayT{underlying}
 }
 
+const (
+	// CharMaxLength is the maximum length of a CHAR type.
+	CharMaxLength = 255
+	// VarCharMaxLength is the maximum length of a VARCHAR type.
+	VarCharMaxLength = 65535
+	// FractionalMaxPrecision is the maximum number of digits of the
+	// fractional seconds of DATETIME and TIME types.
+	FractionalMaxPrecision = 6
+	// SetMaxValues is the maximum number of values of a SET type.
+	SetMaxValues = 64
+)
+
+// Char returns a new fixed-length string type of the given length. Values
+// of CHAR types are strings without trailing spaces.
+func Char(length int) (Type, error) {
+	if length < 0 || length > CharMaxLength {
+		return nil, ErrInvalidCharLength.New(length, "CHAR", CharMaxLength)
+	}
+
+	return charT{sqltypes.Char, length, DefaultCollation}, nil
+}
+
+// MustChar returns a new CHAR type with the given length and panics if it
+// is not valid.
+func MustChar(length int) Type {
+	t, err := Char(length)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// VarChar returns a new variable-length string type whose values have at
+// most the given length.
+func VarChar(length int) (Type, error) {
+	if length < 0 || length > VarCharMaxLength {
+		return nil, ErrInvalidCharLength.New(length, "VARCHAR", VarCharMaxLength)
+	}
+
+	return charT{sqltypes.VarChar, length, DefaultCollation}, nil
+}
+
+// MustVarChar returns a new VARCHAR type with the given length and panics if
+// it is not valid.
+func MustVarChar(length int) Type {
+	t, err := VarChar(length)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// DatetimeWithPrecision returns a new DATETIME type with the given number of
+// digits in its fractional seconds.
+func DatetimeWithPrecision(precision uint8) (Type, error) {
+	if precision > FractionalMaxPrecision {
+		return nil, ErrInvalidFractionalPrecision.New(precision, FractionalMaxPrecision)
+	}
+
+	return datetimeT{precision}, nil
+}
+
+// TimeWithPrecision returns a new TIME type with the given number of digits
+// in its fractional seconds.
+func TimeWithPrecision(precision uint8) (Type, error) {
+	if precision > FractionalMaxPrecision {
+		return nil, ErrInvalidFractionalPrecision.New(precision, FractionalMaxPrecision)
+	}
+
+	return timeT{precision}, nil
+}
+
+// Enum returns a new ENUM type with the given values. Values of ENUM types
+// are strings, which must be one of the given values, and they are sorted
+// by their position in the definition.
+func Enum(values ...string) (Type, error) {
+	vs, err := newEnumValues("ENUM", values)
+	if err != nil {
+		return nil, err
+	}
+
+	return enumT{vs}, nil
+}
+
+// MustEnum returns a new ENUM type with the given values and panics if they
+// are not valid.
+func MustEnum(values ...string) Type {
+	t, err := Enum(values...)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Set returns a new SET type with the given values. Values of SET types are
+// strings with zero or more of the given values separated by commas.
+func Set(values ...string) (Type, error) {
+	if len(values) > SetMaxValues {
+		return nil, ErrTooManySetValues.New(SetMaxValues, len(values))
+	}
+
+	vs, err := newEnumValues("SET", values)
+	if err != nil {
+		return nil, err
+	}
+
+	return setT{vs}, nil
+}
+
+// MustSet returns a new SET type with the given values and panics if they
+// are not valid.
+func MustSet(values ...string) Type {
+	t, err := Set(values...)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
 // MysqlTypeToType gets the column type using the mysql type
 func MysqlTypeToType(sql query.Type) (Type, error) {
 	switch sql {
 	case sqltypes.Null:
 		return Null, nil
+	case sqltypes.Int8:
+		return Int8, nil
+	case sqltypes.Int16:
+		return Int16, nil
+	case sqltypes.Int24:
+		return Int24, nil
+	case sqltypes.Uint8:
+		return Uint8, nil
+	case sqltypes.Uint16:
+		return Uint16, nil
+	case sqltypes.Uint24:
+		return Uint24, nil
 	case sqltypes.Int32:
 		return Int32, nil
 	case sqltypes.Int64:
@@ -204,10 +439,20 @@ func MysqlTypeToType(sql query.Type) (Type, error) {
 		return Float32, nil
 	case sqltypes.Float64:
 		return Float64, nil
+	case sqltypes.Decimal:
+		return MustDecimal(DecimalDefaultPrecision, 0), nil
 	case sqltypes.Timestamp:
 		return Timestamp, nil
 	case sqltypes.Date:
 		return Date, nil
+	case sqltypes.Datetime:
+		return Datetime, nil
+	case sqltypes.Time:
+		return Time, nil
+	case sqltypes.Year:
+		return Year, nil
+	case sqltypes.Char:
+		return MustChar(1), nil
 	case sqltypes.Text, sqltypes.VarChar:
 		return Text, nil
 	case sqltypes.Bit:
@@ -259,12 +504,69 @@ func (t numberT) Type() query.Type {
 
 // SQL implements Type interface.
 func (t numberT) SQL(v interface{}) sqltypes.Value {
-	return sqltypes.MakeTrusted(t.t, strconv.AppendInt(nil, cast.ToInt64(v), 10))
+	if v == nil {
+		return sqltypes.NULL
+	}
+
+	switch {
+	case t == Float32:
+		return sqltypes.MakeTrusted(t.t, strconv.AppendFloat(nil, cast.ToFloat64(v), 'g', -1, 32))
+	case t == Float64:
+		return sqltypes.MakeTrusted(t.t, strconv.AppendFloat(nil, cast.ToFloat64(v), 'g', -1, 64))
+	case IsUnsigned(t):
+		return sqltypes.MakeTrusted(t.t, strconv.AppendUint(nil, cast.ToUint64(v), 10))
+	default:
+		return sqltypes.MakeTrusted(t.t, strconv.AppendInt(nil, cast.ToInt64(v), 10))
+	}
 }
 
 // Convert implements Type interface.
 func (t numberT) Convert(v interface{}) (interface{}, error) {
+	if d, ok := v.(decimal.Decimal); ok {
+		if IsDecimal(t) {
+			v, _ = d.Float64()
+		} else {
+			v = d.IntPart()
+		}
+	}
+
 	switch t.t {
+	case sqltypes.Int8:
+		n, err := convertSigned(t, v, math.MinInt8, math.MaxInt8)
+		if err != nil {
+			return nil, err
+		}
+		return int8(n), nil
+	case sqltypes.Int16:
+		n, err := convertSigned(t, v, math.MinInt16, math.MaxInt16)
+		if err != nil {
+			return nil, err
+		}
+		return int16(n), nil
+	case sqltypes.Int24:
+		n, err := convertSigned(t, v, minInt24, maxInt24)
+		if err != nil {
+			return nil, err
+		}
+		return int32(n), nil
+	case sqltypes.Uint8:
+		n, err := convertUnsigned(t, v, math.MaxUint8)
+		if err != nil {
+			return nil, err
+		}
+		return uint8(n), nil
+	case sqltypes.Uint16:
+		n, err := convertUnsigned(t, v, math.MaxUint16)
+		if err != nil {
+			return nil, err
+		}
+		return uint16(n), nil
+	case sqltypes.Uint24:
+		n, err := convertUnsigned(t, v, maxUint24)
+		if err != nil {
+			return nil, err
+		}
+		return uint32(n), nil
 	case sqltypes.Int32:
 		return cast.ToInt32E(v)
 	case sqltypes.Int64:
@@ -283,15 +585,76 @@ func (t numberT) Convert(v interface{}) (interface{}, error) {
 
 }
 
+const (
+	minInt24  = -1 << 23
+	maxInt24  = 1<<23 - 1
+	maxUint24 = 1<<24 - 1
+)
+
+// convertSigned converts the given value to an int64 and checks that it's
+// in the given range.
+func convertSigned(t numberT, v interface{}, min, max int64) (int64, error) {
+	n, err := cast.ToInt64E(v)
+	if err != nil {
+		return 0, err
+	}
+
+	if n < min || n > max {
+		return 0, ErrValueOutOfRange.New(v, t.t)
+	}
+
+	return n, nil
+}
+
+// convertUnsigned converts the given value to an uint64 and checks that
+// it's not greater than max.
+func convertUnsigned(t numberT, v interface{}, max uint64) (uint64, error) {
+	n, err := cast.ToUint64E(v)
+	if err != nil {
+		return 0, err
+	}
+
+	if n > max {
+		return 0, ErrValueOutOfRange.New(v, t.t)
+	}
+
+	return n, nil
+}
+
 // Compare implements Type interface.
 func (t numberT) Compare(a interface{}, b interface{}) (int, error) {
 	if IsUnsigned(t) {
 		return compareUnsigned(a, b)
 	}
 
+	if IsDecimal(t) {
+		return compareFloat(a, b)
+	}
+
 	return compareSigned(a, b)
 }
 
+func compareFloat(a interface{}, b interface{}) (int, error) {
+	ca, err := cast.ToFloat64E(a)
+	if err != nil {
+		return 0, err
+	}
+	cb, err := cast.ToFloat64E(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if ca == cb {
+		return 0, nil
+	}
+
+	if ca < cb {
+		return -1, nil
+	}
+
+	return +1, nil
+}
+
 func compareSigned(a interface{}, b interface{}) (int, error) {
 	ca, err := cast.ToInt64E(a)
 	if err != nil {
@@ -334,6 +697,120 @@ func compareUnsigned(a interface{}, b interface{}) (int, error) {
 	return +1, nil
 }
 
+type decimalT struct {
+	precision, scale uint8
+}
+
+// Type implements Type interface.
+func (t decimalT) Type() query.Type {
+	return sqltypes.Decimal
+}
+
+// Precision returns the total number of digits of the type.
+func (t decimalT) Precision() uint8 {
+	return t.precision
+}
+
+// Scale returns the number of digits after the decimal point of the type.
+func (t decimalT) Scale() uint8 {
+	return t.scale
+}
+
+func (t decimalT) String() string {
+	return fmt.Sprintf("DECIMAL(%d,%d)", t.precision, t.scale)
+}
+
+// SQL implements Type interface.
+func (t decimalT) SQL(v interface{}) sqltypes.Value {
+	if v == nil {
+		return sqltypes.NULL
+	}
+
+	d := MustConvert(t, v).(decimal.Decimal)
+	return sqltypes.MakeTrusted(
+		sqltypes.Decimal,
+		[]byte(d.StringFixed(int32(t.scale))),
+	)
+}
+
+// Convert implements Type interface. The value is rounded to the scale of
+// the type and an error is returned if it has more integer digits than the
+// type allows.
+func (t decimalT) Convert(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	d, err := toDecimal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	d = d.Round(int32(t.scale))
+	if decimalIntegerDigits(d) > int(t.precision-t.scale) {
+		return nil, ErrDecimalOutOfRange.New(d.String(), t)
+	}
+
+	return d, nil
+}
+
+// Compare implements Type interface.
+func (t decimalT) Compare(a interface{}, b interface{}) (int, error) {
+	da, err := toDecimal(a)
+	if err != nil {
+		return 0, err
+	}
+
+	db, err := toDecimal(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return da.Cmp(db), nil
+}
+
+func toDecimal(v interface{}) (decimal.Decimal, error) {
+	switch v := v.(type) {
+	case decimal.Decimal:
+		return v, nil
+	case int, int8, int16, int32, int64:
+		return decimal.New(cast.ToInt64(v), 0), nil
+	case uint, uint8, uint16, uint32:
+		return decimal.New(cast.ToInt64(v), 0), nil
+	case uint64:
+		return decimal.NewFromString(strconv.FormatUint(v, 10))
+	case float32:
+		return decimal.NewFromString(strconv.FormatFloat(float64(v), 'g', -1, 32))
+	case float64:
+		return decimal.NewFromFloat(v), nil
+	case bool:
+		if v {
+			return decimal.New(1, 0), nil
+		}
+		return decimal.New(0, 0), nil
+	case string:
+		d, err := decimal.NewFromString(strings.TrimSpace(v))
+		if err != nil {
+			return decimal.Decimal{}, ErrConvertingToDecimal.Wrap(err, v)
+		}
+		return d, nil
+	case []byte:
+		return toDecimal(string(v))
+	default:
+		return decimal.Decimal{}, ErrConvertingToDecimal.New(v)
+	}
+}
+
+// decimalIntegerDigits returns the number of digits before the decimal point
+// of the given decimal.
+func decimalIntegerDigits(d decimal.Decimal) int {
+	digits := len(d.Abs().Truncate(0).String())
+	if digits == 1 && d.Abs().LessThan(decimal.New(1, 0)) {
+		return 0
+	}
+	return digits
+}
+
 type timestampT struct{}
 
 // Type implements Type interface.
@@ -347,6 +824,10 @@ const TimestampLayout = "2006-01-02 15:04:05"
 
 // SQL implements Type interface.
 func (t timestampT) SQL(v interface{}) sqltypes.Value {
+	if v == nil {
+		return sqltypes.NULL
+	}
+
 	time := MustConvert(t, v).(time.Time)
 	return sqltypes.MakeTrusted(
 		sqltypes.Timestamp,
@@ -402,6 +883,10 @@ func (t dateT) Type() query.Type {
 }
 
 func (t dateT) SQL(v interface{}) sqltypes.Value {
+	if v == nil {
+		return sqltypes.NULL
+	}
+
 	time := MustConvert(t, v).(time.Time)
 	return sqltypes.MakeTrusted(
 		sqltypes.Timestamp,
@@ -440,73 +925,519 @@ func (t dateT) Compare(a, b interface{}) (int, error) {
 	return 0, nil
 }
 
-type textT struct{}
+type datetimeT struct {
+	precision uint8
+}
 
 // Type implements Type interface.
-func (t textT) Type() query.Type {
-	return sqltypes.Text
+func (t datetimeT) Type() query.Type {
+	return sqltypes.Datetime
 }
 
-// SQL implements Type interface.
-func (t textT) SQL(v interface{}) sqltypes.Value {
-	return sqltypes.MakeTrusted(sqltypes.Text, []byte(MustConvert(t, v).(string)))
+// Precision returns the number of digits of the fractional seconds.
+func (t datetimeT) Precision() uint8 {
+	return t.precision
 }
 
-// Convert implements Type interface.
-func (t textT) Convert(v interface{}) (interface{}, error) {
-	return cast.ToStringE(v)
+func (t datetimeT) String() string {
+	if t.precision == 0 {
+		return "DATETIME"
+	}
+	return fmt.Sprintf("DATETIME(%d)", t.precision)
 }
 
-// Compare implements Type interface.
-func (t textT) Compare(a interface{}, b interface{}) (int, error) {
-	return strings.Compare(a.(string), b.(string)), nil
+// SQL implements Type interface.
+func (t datetimeT) SQL(v interface{}) sqltypes.Value {
+	if v == nil {
+		return sqltypes.NULL
+	}
+
+	time := MustConvert(t, v).(time.Time)
+	return sqltypes.MakeTrusted(
+		sqltypes.Datetime,
+		[]byte(time.Format(TimestampLayout+fractionalLayout(t.precision))),
+	)
 }
 
-type booleanT struct{}
+// Convert implements Type interface.
+func (t datetimeT) Convert(v interface{}) (interface{}, error) {
+	switch value := v.(type) {
+	case time.Time:
+		return value.UTC().Round(fractionalUnit(t.precision)), nil
+	case string:
+		for _, layout := range datetimeLayouts {
+			if parsed, err := time.Parse(layout, value); err == nil {
+				return parsed.UTC().Round(fractionalUnit(t.precision)), nil
+			}
+		}
+		return nil, ErrConvertingToTime.New(v)
+	default:
+		ts, err := Int64.Convert(v)
+		if err != nil {
+			return nil, ErrInvalidType.New(reflect.TypeOf(v))
+		}
 
-// Type implements Type interface.
-func (t booleanT) Type() query.Type {
-	return sqltypes.Bit
+		return time.Unix(ts.(int64), 0).UTC(), nil
+	}
 }
 
-// SQL implements Type interface.
-func (t booleanT) SQL(v interface{}) sqltypes.Value {
-	b := []byte{'0'}
-	if cast.ToBool(v) {
-		b[0] = '1'
+// datetimeLayouts are the layouts accepted when converting strings to
+// DATETIME values. Fractional seconds are accepted after the seconds even if
+// the layout doesn't contain them.
+var datetimeLayouts = []string{
+	TimestampLayout,
+	"2006-01-02T15:04:05",
+	DateLayout,
+}
+
+// Compare implements Type interface.
+func (t datetimeT) Compare(a interface{}, b interface{}) (int, error) {
+	av, err := t.Convert(a)
+	if err != nil {
+		return 0, err
 	}
 
-	return sqltypes.MakeTrusted(sqltypes.Bit, b)
-}
+	bv, err := t.Convert(b)
+	if err != nil {
+		return 0, err
+	}
 
-// Convert implements Type interface.
-func (t booleanT) Convert(v interface{}) (interface{}, error) {
-	return cast.ToBoolE(v)
+	return Timestamp.Compare(av, bv)
 }
 
-// Compare implements Type interface.
-func (t booleanT) Compare(a interface{}, b interface{}) (int, error) {
-	if a == b {
-		return 0, nil
+// fractionalLayout returns the layout of the fractional seconds with the
+// given precision.
+func fractionalLayout(precision uint8) string {
+	if precision == 0 {
+		return ""
 	}
+	return "." + strings.Repeat("0", int(precision))
+}
 
-	if a.(bool) == false {
-		return -1, nil
+// fractionalUnit returns the smallest duration that can be represented
+// with the given precision.
+func fractionalUnit(precision uint8) time.Duration {
+	unit := time.Second
+	for i := uint8(0); i < precision; i++ {
+		unit /= 10
 	}
+	return unit
+}
 
-	return +1, nil
+type timeT struct {
+	precision uint8
 }
 
-type blobT struct{}
+// maxTime is the maximum absolute value of TIME values.
+const maxTime = 838*time.Hour + 59*time.Minute + 59*time.Second
 
 // Type implements Type interface.
-func (t blobT) Type() query.Type {
-	return sqltypes.Blob
+func (t timeT) Type() query.Type {
+	return sqltypes.Time
 }
 
-// SQL implements Type interface.
-func (t blobT) SQL(v interface{}) sqltypes.Value {
-	return sqltypes.MakeTrusted(sqltypes.Blob, MustConvert(t, v).([]byte))
+// Precision returns the number of digits of the fractional seconds.
+func (t timeT) Precision() uint8 {
+	return t.precision
+}
+
+func (t timeT) String() string {
+	if t.precision == 0 {
+		return "TIME"
+	}
+	return fmt.Sprintf("TIME(%d)", t.precision)
+}
+
+// SQL implements Type interface.
+func (t timeT) SQL(v interface{}) sqltypes.Value {
+	if v == nil {
+		return sqltypes.NULL
+	}
+
+	d := MustConvert(t, v).(time.Duration)
+
+	var sign string
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	s := fmt.Sprintf(
+		"%s%02d:%02d:%02d",
+		sign,
+		d/time.Hour,
+		d%time.Hour/time.Minute,
+		d%time.Minute/time.Second,
+	)
+	if t.precision > 0 {
+		s += "." + fmt.Sprintf("%09d", d%time.Second)[:t.precision]
+	}
+
+	return sqltypes.MakeTrusted(sqltypes.Time, []byte(s))
+}
+
+// Convert implements Type interface. Values of TIME types are of type
+// time.Duration. Strings are expected in the "[-][D ]HH:MM:SS[.fraction]"
+// format, although some parts may be omitted, and numbers in the HHMMSS
+// format.
+func (t timeT) Convert(v interface{}) (interface{}, error) {
+	var d time.Duration
+	switch value := v.(type) {
+	case time.Duration:
+		d = value
+	case time.Time:
+		h, m, s := value.Clock()
+		d = time.Duration(h)*time.Hour +
+			time.Duration(m)*time.Minute +
+			time.Duration(s)*time.Second +
+			time.Duration(value.Nanosecond())
+	case string:
+		var err error
+		d, err = parseTime(value)
+		if err != nil {
+			return nil, err
+		}
+	case decimal.Decimal:
+		var err error
+		d, err = parseTime(value.String())
+		if err != nil {
+			return nil, err
+		}
+	default:
+		f, err := cast.ToFloat64E(v)
+		if err != nil {
+			return nil, ErrConvertingToDuration.New(v)
+		}
+
+		d, err = parseTime(strconv.FormatFloat(f, 'f', -1, 64))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	d = d.Round(fractionalUnit(t.precision))
+	if d > maxTime || d < -maxTime {
+		return nil, ErrValueOutOfRange.New(v, t)
+	}
+
+	return d, nil
+}
+
+// parseTime parses a TIME value in the "[-][D ]HH:MM:SS[.fraction]" or
+// "[-]HHMMSS[.fraction]" formats.
+func parseTime(s string) (time.Duration, error) {
+	str := strings.TrimSpace(s)
+
+	var negative bool
+	if strings.HasPrefix(str, "-") {
+		negative = true
+		str = str[1:]
+	}
+
+	var days int
+	var hasDays bool
+	if i := strings.IndexByte(str, ' '); i >= 0 {
+		var err error
+		days, err = strconv.Atoi(str[:i])
+		if err != nil {
+			return 0, ErrConvertingToDuration.New(s)
+		}
+		hasDays = true
+		str = strings.TrimSpace(str[i+1:])
+	}
+
+	var fraction string
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		fraction = str[i+1:]
+		str = str[:i]
+	}
+
+	var parts []int
+	for _, p := range strings.Split(str, ":") {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return 0, ErrConvertingToDuration.New(s)
+		}
+		parts = append(parts, n)
+	}
+
+	var hours, minutes, seconds int
+	switch {
+	case len(parts) == 1 && hasDays:
+		hours = parts[0]
+	case len(parts) == 1:
+		hours, minutes, seconds = parts[0]/10000, parts[0]/100%100, parts[0]%100
+	case len(parts) == 2:
+		hours, minutes = parts[0], parts[1]
+	case len(parts) == 3:
+		hours, minutes, seconds = parts[0], parts[1], parts[2]
+	default:
+		return 0, ErrConvertingToDuration.New(s)
+	}
+
+	if minutes >= 60 || seconds >= 60 || len(fraction) > 9 {
+		return 0, ErrConvertingToDuration.New(s)
+	}
+
+	var nanos int
+	if fraction != "" {
+		var err error
+		nanos, err = strconv.Atoi(fraction + strings.Repeat("0", 9-len(fraction)))
+		if err != nil || nanos < 0 {
+			return 0, ErrConvertingToDuration.New(s)
+		}
+	}
+
+	d := time.Duration(days*24+hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second +
+		time.Duration(nanos)
+	if negative {
+		d = -d
+	}
+
+	return d, nil
+}
+
+// Compare implements Type interface.
+func (t timeT) Compare(a interface{}, b interface{}) (int, error) {
+	av, err := t.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+
+	bv, err := t.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return compareSigned(int64(av.(time.Duration)), int64(bv.(time.Duration)))
+}
+
+type yearT struct{}
+
+const (
+	minYear = 1901
+	maxYear = 2155
+)
+
+// Type implements Type interface.
+func (t yearT) Type() query.Type {
+	return sqltypes.Year
+}
+
+func (t yearT) String() string {
+	return "YEAR"
+}
+
+// SQL implements Type interface.
+func (t yearT) SQL(v interface{}) sqltypes.Value {
+	if v == nil {
+		return sqltypes.NULL
+	}
+
+	year := MustConvert(t, v).(int16)
+	return sqltypes.MakeTrusted(sqltypes.Year, []byte(fmt.Sprintf("%04d", year)))
+}
+
+// Convert implements Type interface. Values of YEAR types are of type int16.
+// As in MySQL, years with one or two digits are in the range 1970-2069, but
+// the number 0 is the year zero while the string "0" is the year 2000.
+func (t yearT) Convert(v interface{}) (interface{}, error) {
+	var year int64
+	switch value := v.(type) {
+	case time.Time:
+		year = int64(value.Year())
+	case string:
+		str := strings.TrimSpace(value)
+		n, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return nil, ErrConvertingToYear.New(v)
+		}
+
+		if len(str) <= 2 {
+			year = twoDigitYear(n)
+		} else {
+			year = n
+		}
+	default:
+		n, err := cast.ToInt64E(v)
+		if err != nil {
+			return nil, ErrConvertingToYear.New(v)
+		}
+
+		if n != 0 && n < 100 {
+			year = twoDigitYear(n)
+		} else {
+			year = n
+		}
+	}
+
+	if year != 0 && (year < minYear || year > maxYear) {
+		return nil, ErrValueOutOfRange.New(v, t)
+	}
+
+	return int16(year), nil
+}
+
+func twoDigitYear(n int64) int64 {
+	if n < 70 {
+		return 2000 + n
+	}
+	return 1900 + n
+}
+
+// Compare implements Type interface.
+func (t yearT) Compare(a interface{}, b interface{}) (int, error) {
+	av, err := t.Convert(a)
+	if err != nil {
+		return 0, err
+	}
+
+	bv, err := t.Convert(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return compareSigned(av, bv)
+}
+
+type textT struct {
+	collation Collation
+}
+
+// Type implements Type interface.
+func (t textT) Type() query.Type {
+	return sqltypes.Text
+}
+
+// SQL implements Type interface.
+func (t textT) SQL(v interface{}) sqltypes.Value {
+	if v == nil {
+		return sqltypes.NULL
+	}
+
+	return sqltypes.MakeTrusted(sqltypes.Text, []byte(MustConvert(t, v).(string)))
+}
+
+// Convert implements Type interface.
+func (t textT) Convert(v interface{}) (interface{}, error) {
+	return cast.ToStringE(v)
+}
+
+// Compare implements Type interface.
+func (t textT) Compare(a interface{}, b interface{}) (int, error) {
+	return t.collation.Compare(a.(string), b.(string)), nil
+}
+
+type charT struct {
+	t         query.Type
+	length    int
+	collation Collation
+}
+
+// Type implements Type interface.
+func (t charT) Type() query.Type {
+	return t.t
+}
+
+// Length returns the maximum number of characters of the values.
+func (t charT) Length() int {
+	return t.length
+}
+
+func (t charT) String() string {
+	if t.t == sqltypes.Char {
+		return fmt.Sprintf("CHAR(%d)", t.length)
+	}
+	return fmt.Sprintf("VARCHAR(%d)", t.length)
+}
+
+// SQL implements Type interface.
+func (t charT) SQL(v interface{}) sqltypes.Value {
+	if v == nil {
+		return sqltypes.NULL
+	}
+
+	return sqltypes.MakeTrusted(t.t, []byte(MustConvert(t, v).(string)))
+}
+
+// Convert implements Type interface.
+func (t charT) Convert(v interface{}) (interface{}, error) {
+	s, err := cast.ToStringE(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.t == sqltypes.Char {
+		s = strings.TrimRight(s, " ")
+	}
+
+	if utf8.RuneCountInString(s) > t.length {
+		return nil, ErrLengthExceeded.New(s, t)
+	}
+
+	return s, nil
+}
+
+// Compare implements Type interface.
+func (t charT) Compare(a interface{}, b interface{}) (int, error) {
+	return t.collation.Compare(a.(string), b.(string)), nil
+}
+
+type booleanT struct{}
+
+// Type implements Type interface.
+func (t booleanT) Type() query.Type {
+	return sqltypes.Bit
+}
+
+// SQL implements Type interface.
+func (t booleanT) SQL(v interface{}) sqltypes.Value {
+	if v == nil {
+		return sqltypes.NULL
+	}
+
+	b := []byte{'0'}
+	if cast.ToBool(v) {
+		b[0] = '1'
+	}
+
+	return sqltypes.MakeTrusted(sqltypes.Bit, b)
+}
+
+// Convert implements Type interface.
+func (t booleanT) Convert(v interface{}) (interface{}, error) {
+	return cast.ToBoolE(v)
+}
+
+// Compare implements Type interface.
+func (t booleanT) Compare(a interface{}, b interface{}) (int, error) {
+	if a == b {
+		return 0, nil
+	}
+
+	if a.(bool) == false {
+		return -1, nil
+	}
+
+	return +1, nil
+}
+
+type blobT struct{}
+
+// Type implements Type interface.
+func (t blobT) Type() query.Type {
+	return sqltypes.Blob
+}
+
+// SQL implements Type interface.
+func (t blobT) SQL(v interface{}) sqltypes.Value {
+	if v == nil {
+		return sqltypes.NULL
+	}
+
+	return sqltypes.MakeTrusted(sqltypes.Blob, MustConvert(t, v).([]byte))
 }
 
 // Convert implements Type interface.
@@ -537,17 +1468,252 @@ func (t jsonT) Type() query.Type {
 
 // SQL implements Type interface.
 func (t jsonT) SQL(v interface{}) sqltypes.Value {
+	if v == nil {
+		return sqltypes.NULL
+	}
+
+	if s, ok := v.(string); ok {
+		// Strings in memory are JSON strings, not JSON documents.
+		v = jsonString(s)
+	}
+
 	return sqltypes.MakeTrusted(sqltypes.TypeJSON, MustConvert(t, v).([]byte))
 }
 
-// Convert implements Type interface.
+// jsonString is a string that is converted to a JSON string instead of being
+// parsed as a JSON document.
+type jsonString string
+
+// Convert implements Type interface. It returns the JSON document of the
+// value. Strings must be valid JSON documents, as in MySQL, and any other
+// value is converted to its JSON document.
 func (t jsonT) Convert(v interface{}) (interface{}, error) {
-	return json.Marshal(v)
+	switch v := v.(type) {
+	case []byte:
+		if json.Valid(v) {
+			return v, nil
+		}
+		return nil, ErrInvalidJSONText.New(v)
+	case string:
+		return t.Convert([]byte(v))
+	case json.RawMessage:
+		return t.Convert([]byte(v))
+	case jsonString:
+		return json.Marshal(string(v))
+	default:
+		return json.Marshal(v)
+	}
 }
 
-// Compare implements Type interface.
+// Compare implements Type interface. See CompareJSON.
 func (t jsonT) Compare(a interface{}, b interface{}) (int, error) {
-	return bytes.Compare(a.([]byte), b.([]byte)), nil
+	return CompareJSON(a, b)
+}
+
+// enumValues are the values of an ENUM or SET type. They are kept behind a
+// pointer so the types are still comparable.
+type enumValues struct {
+	list []string
+	// index is the position of each value, by its lowercased value.
+	index map[string]int
+}
+
+func newEnumValues(kind string, values []string) (*enumValues, error) {
+	vs := &enumValues{
+		list:  values,
+		index: make(map[string]int, len(values)),
+	}
+
+	for i, v := range values {
+		key := strings.ToLower(v)
+		if _, ok := vs.index[key]; ok {
+			return nil, ErrDuplicatedEnumValue.New(v, kind)
+		}
+
+		if kind == "SET" && strings.Contains(v, ",") {
+			return nil, ErrInvalidEnumValue.New(v, kind)
+		}
+
+		vs.index[key] = i
+	}
+
+	return vs, nil
+}
+
+// position returns the position of the given value, which is matched
+// ignoring case.
+func (vs *enumValues) position(v string) (int, bool) {
+	i, ok := vs.index[strings.ToLower(v)]
+	return i, ok
+}
+
+func (vs *enumValues) format(kind string) string {
+	quoted := make([]string, len(vs.list))
+	for i, v := range vs.list {
+		quoted[i] = "'" + strings.Replace(v, "'", "''", -1) + "'"
+	}
+	return fmt.Sprintf("%s(%s)", kind, strings.Join(quoted, ","))
+}
+
+type enumT struct {
+	values *enumValues
+}
+
+// Type implements Type interface.
+func (t enumT) Type() query.Type {
+	return sqltypes.Enum
+}
+
+func (t enumT) String() string {
+	return t.values.format("ENUM")
+}
+
+// SQL implements Type interface.
+func (t enumT) SQL(v interface{}) sqltypes.Value {
+	if v == nil {
+		return sqltypes.NULL
+	}
+
+	return sqltypes.MakeTrusted(sqltypes.Enum, []byte(MustConvert(t, v).(string)))
+}
+
+// Convert implements Type interface. Both the values and their positions,
+// starting at 1, can be converted.
+func (t enumT) Convert(v interface{}) (interface{}, error) {
+	i, err := t.position(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.values.list[i], nil
+}
+
+func (t enumT) position(v interface{}) (int, error) {
+	switch value := v.(type) {
+	case string:
+		if i, ok := t.values.position(value); ok {
+			return i, nil
+		}
+	case []byte:
+		if i, ok := t.values.position(string(value)); ok {
+			return i, nil
+		}
+	default:
+		n, err := cast.ToInt64E(v)
+		if err == nil && n >= 1 && n <= int64(len(t.values.list)) {
+			return int(n) - 1, nil
+		}
+	}
+
+	return 0, ErrInvalidEnumValue.New(v, t)
+}
+
+// Compare implements Type interface. Values are sorted by their position
+// in the type definition.
+func (t enumT) Compare(a interface{}, b interface{}) (int, error) {
+	ia, err := t.position(a)
+	if err != nil {
+		return 0, err
+	}
+
+	ib, err := t.position(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return compareSigned(ia, ib)
+}
+
+type setT struct {
+	values *enumValues
+}
+
+// Type implements Type interface.
+func (t setT) Type() query.Type {
+	return sqltypes.Set
+}
+
+func (t setT) String() string {
+	return t.values.format("SET")
+}
+
+// SQL implements Type interface.
+func (t setT) SQL(v interface{}) sqltypes.Value {
+	if v == nil {
+		return sqltypes.NULL
+	}
+
+	return sqltypes.MakeTrusted(sqltypes.Set, []byte(MustConvert(t, v).(string)))
+}
+
+// Convert implements Type interface. Both comma separated values and the
+// numbers whose bits are the positions of the values can be converted.
+// Values are returned in the order of the type definition and without
+// duplicates.
+func (t setT) Convert(v interface{}) (interface{}, error) {
+	bits, err := t.bits(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for i, value := range t.values.list {
+		if bits&(1<<uint(i)) != 0 {
+			values = append(values, value)
+		}
+	}
+
+	return strings.Join(values, ","), nil
+}
+
+func (t setT) bits(v interface{}) (uint64, error) {
+	if b, ok := v.([]byte); ok {
+		v = string(b)
+	}
+
+	var bits uint64
+	switch value := v.(type) {
+	case string:
+		if value == "" {
+			return 0, nil
+		}
+
+		for _, s := range strings.Split(value, ",") {
+			i, ok := t.values.position(s)
+			if !ok {
+				return 0, ErrInvalidEnumValue.New(v, t)
+			}
+			bits |= 1 << uint(i)
+		}
+	default:
+		var err error
+		bits, err = cast.ToUint64E(v)
+		if err != nil {
+			return 0, ErrInvalidEnumValue.New(v, t)
+		}
+
+		if n := uint(len(t.values.list)); n < 64 && bits>>n != 0 {
+			return 0, ErrInvalidEnumValue.New(v, t)
+		}
+	}
+
+	return bits, nil
+}
+
+// Compare implements Type interface. Values are sorted by the number whose
+// bits are the positions of their values.
+func (t setT) Compare(a interface{}, b interface{}) (int, error) {
+	ba, err := t.bits(a)
+	if err != nil {
+		return 0, err
+	}
+
+	bb, err := t.bits(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return compareUnsigned(ba, bb)
 }
 
 type tupleT []Type
@@ -681,32 +1847,328 @@ func MustConvert(t Type, v interface{}) interface{} {
 
 // IsNumber checks if t is a number type
 func IsNumber(t Type) bool {
-	return IsInteger(t) || IsDecimal(t)
+	return IsInteger(t) || IsDecimal(t) || IsFixedPoint(t)
 }
 
 // IsSigned checks if t is a signed type.
 func IsSigned(t Type) bool {
-	return t == Int32 || t == Int64
+	return t == Int8 || t == Int16 || t == Int24 || t == Int32 || t == Int64
 }
 
 // IsUnsigned checks if t is an unsigned type.
 func IsUnsigned(t Type) bool {
-	return t == Uint32 || t == Uint64
+	return t == Uint8 || t == Uint16 || t == Uint24 || t == Uint32 || t == Uint64
 }
 
-// IsInteger check if t is a (U)Int32/64 type
+// IsInteger check if t is a (U)Int8/16/24/32/64 type
 func IsInteger(t Type) bool {
 	return IsSigned(t) || IsUnsigned(t)
 }
 
-// IsDecimal checks if t is decimal type.
+// IsDecimal checks if t is a floating point decimal type. Fixed-point DECIMAL
+// types are checked with IsFixedPoint.
 func IsDecimal(t Type) bool {
 	return t == Float32 || t == Float64
 }
 
+// IsFixedPoint checks if t is a fixed-point DECIMAL type.
+func IsFixedPoint(t Type) bool {
+	_, ok := t.(decimalT)
+	return ok
+}
+
+// NumericPrecision returns the precision and scale of the given integer or
+// fixed-point type, that is, the maximum number of digits of its values and
+// how many of those digits are after the decimal point. For any other type,
+// zero is returned.
+func NumericPrecision(t Type) (precision, scale uint8) {
+	switch t {
+	case Int8, Uint8:
+		return 3, 0
+	case Int16, Uint16:
+		return 5, 0
+	case Int24, Uint24:
+		return 8, 0
+	case Int32, Uint32:
+		return 10, 0
+	case Int64, Uint64:
+		return 20, 0
+	}
+
+	if d, ok := t.(decimalT); ok {
+		return d.precision, d.scale
+	}
+
+	return 0, 0
+}
+
+// CommonType returns the type of an expression whose value may come from
+// expressions of any of the given types, such as the branches of a CASE.
+// NULL types are ignored, numeric types are promoted to a numeric type that
+// can hold the values of all of them, date and time types other than TIME
+// are promoted to DATETIME and any other mix of types results in text.
+func CommonType(types ...Type) Type {
+	var result Type = Null
+	for _, t := range types {
+		result = commonType(result, t)
+	}
+	return result
+}
+
+// ConvertFrom converts the value v of type from to the type t, which is
+// usually their common type. Values converted to text have the same
+// representation they would have in a result set.
+func ConvertFrom(t, from Type, v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	if IsText(t) && !IsText(from) && from != Null {
+		v = from.SQL(v).ToString()
+	}
+
+	return t.Convert(v)
+}
+
+func commonType(a, b Type) Type {
+	switch {
+	case a == Null:
+		return b
+	case b == Null:
+		return a
+	case reflect.DeepEqual(a, b):
+		return a
+	case isNumeric(a) && isNumeric(b):
+		return commonNumberType(a, b)
+	case isDatetime(a) && isDatetime(b):
+		return datetimeT{max8(datetimePrecision(a), datetimePrecision(b))}
+	case IsText(a) && IsText(b) && CollationOf(a) == CollationOf(b):
+		if t, err := WithCollation(Text, CollationOf(a)); err == nil {
+			return t
+		}
+	}
+
+	return Text
+}
+
+// isNumeric returns whether t is a number type or a boolean, which is
+// treated as a number when mixed with them.
+func isNumeric(t Type) bool {
+	return IsNumber(t) || t == Boolean
+}
+
+func commonNumberType(a, b Type) Type {
+	if a == Boolean {
+		a = Int8
+	}
+
+	if b == Boolean {
+		b = Int8
+	}
+
+	switch {
+	case IsDecimal(a) || IsDecimal(b):
+		return Float64
+	case IsInteger(a) && IsInteger(b):
+		if IsUnsigned(a) {
+			a, b = b, a
+		}
+
+		pa, _ := NumericPrecision(a)
+		pb, _ := NumericPrecision(b)
+		switch {
+		case IsSigned(a) == IsSigned(b) && pa >= pb:
+			return a
+		case IsSigned(a) == IsSigned(b):
+			return b
+		case pb < pa:
+			// The signed type can hold all the unsigned values.
+			return a
+		case b != Uint64:
+			return Int64
+		}
+	}
+
+	// A mix of signed integers and BIGINT UNSIGNED or of fixed-point decimals
+	// and integers needs a decimal with enough digits to hold any of them.
+	pa, sa := NumericPrecision(a)
+	pb, sb := NumericPrecision(b)
+	scale := max8(sa, sb)
+	precision := max8(pa-sa, pb-sb) + scale
+	if precision > DecimalMaxPrecision {
+		precision = DecimalMaxPrecision
+	}
+
+	return decimalT{precision, scale}
+}
+
+// isDatetime returns whether t is a type whose values are both a date and
+// a time of the day, or just a date.
+func isDatetime(t Type) bool {
+	switch t.(type) {
+	case timestampT, dateT, datetimeT:
+		return true
+	default:
+		return false
+	}
+}
+
+func datetimePrecision(t Type) uint8 {
+	if t, ok := t.(datetimeT); ok {
+		return t.precision
+	}
+	return 0
+}
+
+func max8(a, b uint8) uint8 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 // IsText checks if t is a text type.
 func IsText(t Type) bool {
-	return t == Text || t == Blob || t == JSON
+	switch t.(type) {
+	case textT, charT, blobT, jsonT:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsChar checks if t is a CHAR or VARCHAR type.
+func IsChar(t Type) bool {
+	_, ok := t.(charT)
+	return ok
+}
+
+// IsTemporal checks if t is a date or time type.
+func IsTemporal(t Type) bool {
+	switch t.(type) {
+	case timestampT, dateT, datetimeT, timeT, yearT:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsEnum checks if t is an ENUM type.
+func IsEnum(t Type) bool {
+	_, ok := t.(enumT)
+	return ok
+}
+
+// IsSet checks if t is a SET type.
+func IsSet(t Type) bool {
+	_, ok := t.(setT)
+	return ok
+}
+
+// ColumnLength returns the maximum length in bytes of the text
+// representation of the values of type t, which is the column length
+// reported to MySQL clients. Strings are assumed to be encoded in utf8mb4,
+// which uses up to 4 bytes per character.
+func ColumnLength(t Type) uint32 {
+	const maxBytesPerChar = 4
+
+	switch t := t.(type) {
+	case numberT:
+		switch t {
+		case Float32:
+			return 12
+		case Float64:
+			return 22
+		}
+
+		precision, _ := NumericPrecision(t)
+		if IsSigned(t) {
+			// Extra character for the sign.
+			return uint32(precision) + 1
+		}
+		return uint32(precision)
+	case decimalT:
+		length := uint32(t.precision) + 1
+		if t.scale > 0 {
+			length++
+		}
+		return length
+	case charT:
+		return uint32(t.length) * maxBytesPerChar
+	case timestampT:
+		return uint32(len(TimestampLayout))
+	case dateT:
+		return uint32(len(DateLayout))
+	case datetimeT:
+		return uint32(len(TimestampLayout)) + fractionalLength(t.precision)
+	case timeT:
+		return uint32(len("-838:59:59")) + fractionalLength(t.precision)
+	case yearT:
+		return 4
+	case enumT:
+		var length int
+		for _, v := range t.values.list {
+			if n := utf8.RuneCountInString(v); n > length {
+				length = n
+			}
+		}
+		return uint32(length) * maxBytesPerChar
+	case setT:
+		var length int
+		for _, v := range t.values.list {
+			length += utf8.RuneCountInString(v)
+		}
+		if len(t.values.list) > 1 {
+			// Commas between the values.
+			length += len(t.values.list) - 1
+		}
+		return uint32(length) * maxBytesPerChar
+	case booleanT:
+		return 1
+	case textT:
+		return math.MaxUint16 * maxBytesPerChar
+	case blobT:
+		return math.MaxUint16
+	case jsonT:
+		return math.MaxUint32
+	default:
+		return 0
+	}
+}
+
+// notFixedDecimals is the number of decimals reported for floating point
+// types, whose number of digits after the decimal point is not fixed.
+const notFixedDecimals = 31
+
+// Decimals returns the number of digits after the decimal point of the
+// values of type t, as reported to MySQL clients: the scale of fixed-point
+// types, the fractional seconds precision of temporal types and zero for
+// any other type except floating point types.
+func Decimals(t Type) uint8 {
+	switch t := t.(type) {
+	case numberT:
+		if IsDecimal(t) {
+			return notFixedDecimals
+		}
+		return 0
+	case decimalT:
+		return t.scale
+	case datetimeT:
+		return t.precision
+	case timeT:
+		return t.precision
+	default:
+		return 0
+	}
+}
+
+// fractionalLength returns the length of the fractional seconds with the
+// given precision, including the decimal point.
+func fractionalLength(precision uint8) uint32 {
+	if precision == 0 {
+		return 0
+	}
+	return uint32(precision) + 1
 }
 
 // IsTuple checks if t is a tuple type.
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/src-d/go-vitess.v0/sqltypes"
 )
@@ -41,6 +42,10 @@ func TestNumberComparison(t *testing.T) {
 	gt(t, Uint32, int64(5), uint32(1))
 	gt(t, Uint32, uint32(5), int64(1))
 	lt(t, Uint32, uint64(1), int32(5))
+
+	gt(t, Float64, 2.5, int64(2))
+	lt(t, Float64, float32(-0.5), 0.25)
+	eq(t, Float32, float32(1.5), 1.5)
 }
 
 func TestInt64(t *testing.T) {
@@ -55,6 +60,62 @@ func TestInt64(t *testing.T) {
 	gt(t, Int64, int64(3), int64(2))
 }
 
+func TestSQLNull(t *testing.T) {
+	types := []Type{
+		Int8, Uint8, Int16, Uint16, Int24, Uint24, Int32, Int64, Uint64,
+		Float32, Float64, MustDecimal(10, 2),
+		Timestamp, Date, Datetime, Time, Year,
+		Text, MustChar(3), MustVarChar(3), MustEnum("a", "b"), MustSet("a", "b"),
+		Boolean, Blob, JSON,
+	}
+
+	for _, typ := range types {
+		require.Equal(t, sqltypes.NULL, typ.SQL(nil), "type %s", typ)
+	}
+}
+
+func TestNumberSQL(t *testing.T) {
+	require := require.New(t)
+	require.Equal("1.5", Float64.SQL(1.5).ToString())
+	require.Equal("0.1", Float32.SQL(float32(0.1)).ToString())
+	require.Equal("18446744073709551615", Uint64.SQL(uint64(1<<64-1)).ToString())
+	require.Equal("-3", Int8.SQL(int8(-3)).ToString())
+}
+
+func TestDecimal(t *testing.T) {
+	require := require.New(t)
+
+	_, err := Decimal(0, 0)
+	require.True(ErrInvalidDecimal.Is(err))
+	_, err = Decimal(66, 2)
+	require.True(ErrInvalidDecimal.Is(err))
+	_, err = Decimal(10, 11)
+	require.True(ErrInvalidDecimal.Is(err))
+
+	typ := MustDecimal(5, 2)
+	require.True(IsNumber(typ))
+	require.True(IsFixedPoint(typ))
+	require.False(IsDecimal(typ))
+
+	convert(t, typ, 1, decimal.New(100, -2))
+	convert(t, typ, "1.235", decimal.New(124, -2))
+	convert(t, typ, 0.1, decimal.New(10, -2))
+	convert(t, typ, "-999.99", decimal.New(-99999, -2))
+	convertErr(t, typ, "1000")
+	convertErr(t, typ, "foo")
+
+	lt(t, typ, "0.1", 0.2)
+	eq(t, typ, decimal.New(10, -1), int64(1))
+	gt(t, typ, "100.01", decimal.New(100, 0))
+
+	require.Equal("1.50", typ.SQL(decimal.New(15, -1)).ToString())
+	require.Equal("-3.00", typ.SQL(-3).ToString())
+
+	precision, scale := NumericPrecision(typ)
+	require.Equal(uint8(5), precision)
+	require.Equal(uint8(2), scale)
+}
+
 func TestTimestamp(t *testing.T) {
 	require := require.New(t)
 
@@ -122,6 +183,244 @@ func TestDate(t *testing.T) {
 	gt(t, Date, after, now)
 }
 
+func TestSmallIntegers(t *testing.T) {
+	require := require.New(t)
+
+	convert(t, Int8, 127, int8(127))
+	convert(t, Int8, "-128", int8(-128))
+	convertErr(t, Int8, 128)
+	convert(t, Uint8, 255, uint8(255))
+	convertErr(t, Uint8, 256)
+	convertErr(t, Uint8, -1)
+	convert(t, Int16, -32768, int16(-32768))
+	convertErr(t, Int16, 32768)
+	convert(t, Uint16, 65535, uint16(65535))
+	convert(t, Int24, -8388608, int32(-8388608))
+	convertErr(t, Int24, 8388608)
+	convert(t, Uint24, 16777215, uint32(16777215))
+	convertErr(t, Uint24, 16777216)
+
+	_, err := Int8.Convert(200)
+	require.True(ErrValueOutOfRange.Is(err))
+
+	require.True(IsSigned(Int24))
+	require.True(IsUnsigned(Uint8))
+	require.True(IsInteger(Uint16))
+
+	lt(t, Int8, int8(-1), int8(1))
+	gt(t, Uint16, uint16(3), uint16(2))
+
+	require.Equal(uint32(4), ColumnLength(Int8))
+	require.Equal(uint32(3), ColumnLength(Uint8))
+	require.Equal(uint32(8), ColumnLength(Uint24))
+}
+
+func TestChar(t *testing.T) {
+	require := require.New(t)
+
+	_, err := Char(256)
+	require.True(ErrInvalidCharLength.Is(err))
+	_, err = VarChar(65536)
+	require.True(ErrInvalidCharLength.Is(err))
+
+	typ := MustChar(3)
+	require.True(IsText(typ))
+	require.Equal(sqltypes.Char, typ.Type())
+	convert(t, typ, "ab ", "ab")
+	convert(t, typ, "añb  ", "añb")
+	convert(t, typ, 12, "12")
+	_, err = typ.Convert("abcd")
+	require.True(ErrLengthExceeded.Is(err))
+
+	typ = MustVarChar(3)
+	require.Equal(sqltypes.VarChar, typ.Type())
+	convert(t, typ, "ab ", "ab ")
+	convertErr(t, typ, "abcd")
+
+	lt(t, typ, "a", "b")
+	eq(t, typ, "a", "a")
+	require.Equal("ab", typ.SQL("ab").ToString())
+	require.Equal(uint32(12), ColumnLength(typ))
+}
+
+func TestDatetime(t *testing.T) {
+	require := require.New(t)
+
+	_, err := DatetimeWithPrecision(7)
+	require.True(ErrInvalidFractionalPrecision.Is(err))
+
+	date := time.Date(2018, time.March, 4, 12, 30, 15, 123456789, time.UTC)
+	convert(t, Datetime, date, date.Round(time.Second))
+	convert(t, Datetime, "2018-03-04 12:30:15", date.Truncate(time.Second))
+	convert(t, Datetime, "2018-03-04T12:30:15", date.Truncate(time.Second))
+	convert(t, Datetime, "2018-03-04", time.Date(2018, time.March, 4, 0, 0, 0, 0, time.UTC))
+	convertErr(t, Datetime, "foo")
+
+	typ, err := DatetimeWithPrecision(3)
+	require.NoError(err)
+	convert(t, typ, "2018-03-04 12:30:15.123456", date.Truncate(time.Millisecond))
+	require.Equal("2018-03-04 12:30:15.123", typ.SQL(date).ToString())
+	require.Equal("2018-03-04 12:30:15", Datetime.SQL(date).ToString())
+	require.Equal(uint32(23), ColumnLength(typ))
+
+	lt(t, typ, date, date.Add(time.Millisecond))
+	eq(t, typ, date, "2018-03-04 12:30:15.123")
+	gt(t, Datetime, date.Add(time.Second), date)
+}
+
+func TestTime(t *testing.T) {
+	require := require.New(t)
+
+	d := 12*time.Hour + 30*time.Minute + 15*time.Second
+	convert(t, Time, d, d)
+	convert(t, Time, "12:30:15", d)
+	convert(t, Time, "12:30", d-15*time.Second)
+	convert(t, Time, "-12:30:15", -d)
+	convert(t, Time, "1 12:30:15", d+24*time.Hour)
+	convert(t, Time, "123015", d)
+	convert(t, Time, 123015, d)
+	convert(t, Time, "12:30:15.6", d+time.Second)
+	convert(t, Time, time.Date(2018, time.March, 4, 12, 30, 15, 0, time.UTC), d)
+	convertErr(t, Time, "12:60:00")
+	convertErr(t, Time, "foo")
+
+	_, err := Time.Convert("839:00:00")
+	require.True(ErrValueOutOfRange.Is(err))
+
+	typ, err := TimeWithPrecision(2)
+	require.NoError(err)
+	convert(t, typ, "12:30:15.123", d+120*time.Millisecond)
+	require.Equal("12:30:15.12", typ.SQL("12:30:15.123").ToString())
+	require.Equal("-838:59:59", Time.SQL("-838:59:59").ToString())
+	require.Equal("00:00:05", Time.SQL(5*time.Second).ToString())
+
+	lt(t, Time, "-01:00:00", "00:00:00")
+	eq(t, Time, d, "12:30:15")
+	gt(t, Time, "100:00:00", d)
+}
+
+func TestYear(t *testing.T) {
+	require := require.New(t)
+
+	convert(t, Year, 2018, int16(2018))
+	convert(t, Year, "2018", int16(2018))
+	convert(t, Year, 0, int16(0))
+	convert(t, Year, "0", int16(2000))
+	convert(t, Year, 69, int16(2069))
+	convert(t, Year, "70", int16(1970))
+	convert(t, Year, time.Date(2018, time.March, 4, 0, 0, 0, 0, time.UTC), int16(2018))
+	convertErr(t, Year, 1900)
+	convertErr(t, Year, 2156)
+	convertErr(t, Year, "foo")
+
+	require.Equal("2018", Year.SQL(2018).ToString())
+	require.Equal("0000", Year.SQL(0).ToString())
+
+	lt(t, Year, 1999, 2000)
+	eq(t, Year, "18", 2018)
+}
+
+func TestEnum(t *testing.T) {
+	require := require.New(t)
+
+	_, err := Enum("a", "A")
+	require.True(ErrDuplicatedEnumValue.Is(err))
+
+	typ := MustEnum("small", "medium", "large")
+	require.True(IsEnum(typ))
+	require.Equal("ENUM('small','medium','large')", typ.(enumT).String())
+	convert(t, typ, "medium", "medium")
+	convert(t, typ, "LARGE", "large")
+	convert(t, typ, 1, "small")
+	convertErr(t, typ, "huge")
+	convertErr(t, typ, 0)
+	convertErr(t, typ, 4)
+
+	lt(t, typ, "small", "large")
+	eq(t, typ, "medium", 2)
+	gt(t, typ, "medium", "small")
+
+	require.Equal("large", typ.SQL(3).ToString())
+	require.Equal(uint32(24), ColumnLength(typ))
+}
+
+func TestSet(t *testing.T) {
+	require := require.New(t)
+
+	_, err := Set("a,b")
+	require.True(ErrInvalidEnumValue.Is(err))
+	_, err = Set(make([]string, SetMaxValues+1)...)
+	require.True(ErrTooManySetValues.Is(err))
+
+	typ := MustSet("a", "b", "c")
+	require.True(IsSet(typ))
+	convert(t, typ, "c,a", "a,c")
+	convert(t, typ, "a,a,B", "a,b")
+	convert(t, typ, "", "")
+	convert(t, typ, 5, "a,c")
+	convertErr(t, typ, "d")
+	convertErr(t, typ, 8)
+
+	lt(t, typ, "a", "b")
+	eq(t, typ, "a,c", 5)
+	gt(t, typ, "c", "a,b")
+
+	require.Equal("a,b", typ.SQL(3).ToString())
+	require.Equal(uint32(20), ColumnLength(typ))
+}
+
+func TestCommonType(t *testing.T) {
+	bin, err := WithCollation(Text, CollationUtf8mb4Bin)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		types    []Type
+		expected Type
+	}{
+		{nil, Null},
+		{[]Type{Null, Int32, Null}, Int32},
+		{[]Type{Int8, Int32}, Int32},
+		{[]Type{Uint64, Uint16}, Uint64},
+		{[]Type{Boolean, Int64}, Int64},
+		{[]Type{Int64, Uint64}, MustDecimal(20, 0)},
+		{[]Type{Uint8, Int32}, Int32},
+		{[]Type{Int32, Uint32}, Int64},
+		{[]Type{Int32, MustDecimal(5, 2)}, MustDecimal(12, 2)},
+		{[]Type{MustDecimal(5, 2), Float32}, Float64},
+		{[]Type{Date, datetimeT{3}, Timestamp}, datetimeT{3}},
+		{[]Type{Int64, Text}, Text},
+		{[]Type{Date, Time}, Text},
+		{[]Type{MustVarChar(10), MustChar(3)}, Text},
+		{[]Type{bin, MustVarChar(10)}, Text},
+		{[]Type{bin, bin}, bin},
+	}
+
+	for _, tt := range testCases {
+		require.Equal(t, tt.expected, CommonType(tt.types...), "%v", tt.types)
+	}
+}
+
+func TestConvertFrom(t *testing.T) {
+	require := require.New(t)
+
+	v, err := ConvertFrom(Text, Float64, 0.5)
+	require.NoError(err)
+	require.Equal("0.5", v)
+
+	date := time.Date(2018, time.March, 4, 0, 0, 0, 0, time.UTC)
+	v, err = ConvertFrom(Text, Date, date)
+	require.NoError(err)
+	require.Equal("2018-03-04", v)
+
+	v, err = ConvertFrom(Int64, Int8, int8(3))
+	require.NoError(err)
+	require.Equal(int64(3), v)
+
+	v, err = ConvertFrom(Int64, Int8, nil)
+	require.NoError(err)
+	require.Nil(v)
+}
+
 func TestBlob(t *testing.T) {
 	require := require.New(t)
 
@@ -137,12 +436,66 @@ func TestBlob(t *testing.T) {
 }
 
 func TestJSON(t *testing.T) {
-	convert(t, JSON, "", []byte(`""`))
+	convert(t, JSON, `{"a": {"b": "x"}}`, []byte(`{"a": {"b": "x"}}`))
+	convert(t, JSON, `"foo"`, []byte(`"foo"`))
+	convertErr(t, JSON, "")
+	convertErr(t, JSON, "foo")
 	convert(t, JSON, []int{1, 2}, []byte("[1,2]"))
 
 	lt(t, JSON, []byte("A"), []byte("B"))
 	eq(t, JSON, []byte("A"), []byte("A"))
 	gt(t, JSON, []byte("C"), []byte("B"))
+
+	convert(t, JSON, []byte(`{"a": 1}`), []byte(`{"a": 1}`))
+	convertErr(t, JSON, []byte("foo"))
+	require.Equal(t, `"foo"`, JSON.SQL("foo").ToString())
+
+	eq(t, JSON, []byte("1"), []byte("1.0"))
+	lt(t, JSON, []byte("2"), []byte("10"))
+	lt(t, JSON, []byte("-1"), []byte("18446744073709551615"))
+	lt(t, JSON, []byte("null"), []byte("1"))
+	lt(t, JSON, []byte("1"), []byte(`"a"`))
+	lt(t, JSON, []byte(`"a"`), []byte(`{}`))
+	lt(t, JSON, []byte(`{}`), []byte(`[]`))
+	lt(t, JSON, []byte(`[]`), []byte(`false`))
+	lt(t, JSON, []byte(`false`), []byte(`true`))
+	lt(t, JSON, []byte(`[1, 2]`), []byte(`[1, 3]`))
+	lt(t, JSON, []byte(`[1, 2]`), []byte(`[1, 2, 0]`))
+	eq(t, JSON, []byte(`{"a": 1, "b": [2]}`), []byte(`{"b": [2], "a": 1}`))
+	eq(t, JSON, []string{"a", "b"}, []byte(`["a", "b"]`))
+}
+
+func TestParseJSON(t *testing.T) {
+	require := require.New(t)
+
+	v, err := ParseJSON([]byte(`{"a": [1, -2, 1.5, 18446744073709551615, "b", null, true]}`))
+	require.NoError(err)
+	require.Equal(map[string]interface{}{
+		"a": []interface{}{int64(1), int64(-2), 1.5, uint64(18446744073709551615), "b", nil, true},
+	}, v)
+
+	_, err = ParseJSON([]byte(`{"a": 1} 2`))
+	require.True(ErrInvalidJSONText.Is(err))
+
+	_, err = ParseJSON([]byte(`{"a": `))
+	require.True(ErrInvalidJSONText.Is(err))
+}
+
+func TestMarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	data, err := MarshalJSON([]byte(`{"bb":[1,2.5,"<a>"],"a":{"c":null,"b":true}}`))
+	require.NoError(err)
+	require.Equal(`{"a": {"b": true, "c": null}, "bb": [1, 2.5, "<a>"]}`, string(data))
+
+	data, err = MarshalJSON("foo")
+	require.NoError(err)
+	require.Equal(`"foo"`, string(data))
+}
+
+func TestJSONKeys(t *testing.T) {
+	keys := JSONKeys(map[string]interface{}{"bb": 1, "a": 2, "c": 3, "aaa": 4})
+	require.Equal(t, []string{"a", "c", "bb", "aaa"}, keys)
 }
 
 func TestTuple(t *testing.T) {
@@ -0,0 +1,582 @@
+package sql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	errors "gopkg.in/src-d/go-errors.v1"
+)
+
+var (
+	// ErrInvalidWindowFrame is returned when the frame of a window is not
+	// valid.
+	ErrInvalidWindowFrame = errors.NewKind("invalid window frame: %s")
+
+	// ErrInvalidWindowFrameOffset is returned when the offset of a bound of
+	// a window frame is not a non-negative number.
+	ErrInvalidWindowFrameOffset = errors.NewKind("window frame offset must be a non-negative number, got %v")
+)
+
+// WindowFunction is a function that is computed over the rows of the
+// partition of a window, such as ROW_NUMBER or RANK.
+type WindowFunction interface {
+	Expression
+	// EvalWindow returns the value of the function for each one of the rows
+	// of the given partition, in the same order.
+	EvalWindow(ctx *Context, p *WindowPartition) ([]interface{}, error)
+}
+
+// WindowFrameUnit is the unit in which the bounds of a window frame are
+// expressed.
+type WindowFrameUnit byte
+
+const (
+	// RowsFrame is a frame whose bounds are expressed in number of rows.
+	RowsFrame WindowFrameUnit = iota
+	// RangeFrame is a frame whose bounds are expressed in values of the
+	// ORDER BY expression of the window.
+	RangeFrame
+)
+
+func (u WindowFrameUnit) String() string {
+	if u == RowsFrame {
+		return "ROWS"
+	}
+	return "RANGE"
+}
+
+// WindowBoundType is the type of a bound of a window frame.
+type WindowBoundType byte
+
+const (
+	// UnboundedPreceding is the first row of the partition.
+	UnboundedPreceding WindowBoundType = iota
+	// Preceding is the row which is a given offset before the current row.
+	Preceding
+	// CurrentRow is the current row.
+	CurrentRow
+	// Following is the row which is a given offset after the current row.
+	Following
+	// UnboundedFollowing is the last row of the partition.
+	UnboundedFollowing
+)
+
+// WindowBound is one of the bounds of a window frame.
+type WindowBound struct {
+	Type WindowBoundType
+	// Offset of the bound, only used by the Preceding and Following types.
+	Offset Expression
+}
+
+func (b WindowBound) String() string {
+	switch b.Type {
+	case UnboundedPreceding:
+		return "UNBOUNDED PRECEDING"
+	case Preceding:
+		return fmt.Sprintf("%s PRECEDING", b.Offset)
+	case CurrentRow:
+		return "CURRENT ROW"
+	case Following:
+		return fmt.Sprintf("%s FOLLOWING", b.Offset)
+	default:
+		return "UNBOUNDED FOLLOWING"
+	}
+}
+
+// WindowFrame is the set of rows of a partition used to compute the value
+// of a function for each one of its rows.
+type WindowFrame struct {
+	Unit  WindowFrameUnit
+	Start WindowBound
+	End   WindowBound
+}
+
+// NewWindowFrame creates a new window frame with the given bounds, which
+// must not start after they end.
+func NewWindowFrame(unit WindowFrameUnit, start, end WindowBound) (*WindowFrame, error) {
+	if start.Type == UnboundedFollowing {
+		return nil, ErrInvalidWindowFrame.New("frame start cannot be UNBOUNDED FOLLOWING")
+	}
+
+	if end.Type == UnboundedPreceding {
+		return nil, ErrInvalidWindowFrame.New("frame end cannot be UNBOUNDED PRECEDING")
+	}
+
+	if start.Type > end.Type {
+		return nil, ErrInvalidWindowFrame.New(
+			fmt.Sprintf("frame start %s cannot be after frame end %s", start, end),
+		)
+	}
+
+	return &WindowFrame{unit, start, end}, nil
+}
+
+func (f *WindowFrame) String() string {
+	return fmt.Sprintf("%s BETWEEN %s AND %s", f.Unit, f.Start, f.End)
+}
+
+// defaultWindowFrame is the frame of windows without an explicit one, which
+// contains all the rows up to the last peer of the current row.
+var defaultWindowFrame = &WindowFrame{
+	Unit:  RangeFrame,
+	Start: WindowBound{Type: UnboundedPreceding},
+	End:   WindowBound{Type: CurrentRow},
+}
+
+// WindowOrderBy is an expression used to sort the rows of a window.
+type WindowOrderBy struct {
+	Column     Expression
+	Descending bool
+}
+
+// WindowDefinition is the definition of the window given in the OVER clause
+// of a window function.
+type WindowDefinition struct {
+	PartitionBy []Expression
+	OrderBy     []WindowOrderBy
+	// Frame of the window, which is nil if none was given.
+	Frame *WindowFrame
+}
+
+func (w *WindowDefinition) String() string {
+	var parts []string
+	if len(w.PartitionBy) > 0 {
+		var exprs = make([]string, len(w.PartitionBy))
+		for i, e := range w.PartitionBy {
+			exprs[i] = e.String()
+		}
+		parts = append(parts, "PARTITION BY "+strings.Join(exprs, ", "))
+	}
+
+	if len(w.OrderBy) > 0 {
+		var exprs = make([]string, len(w.OrderBy))
+		for i, o := range w.OrderBy {
+			exprs[i] = o.Column.String()
+			if o.Descending {
+				exprs[i] += " DESC"
+			}
+		}
+		parts = append(parts, "ORDER BY "+strings.Join(exprs, ", "))
+	}
+
+	if w.Frame != nil {
+		parts = append(parts, w.Frame.String())
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Expressions returns the expressions of the PARTITION BY and the ORDER BY
+// of the window, in that order.
+func (w *WindowDefinition) Expressions() []Expression {
+	var exprs = append([]Expression{}, w.PartitionBy...)
+	for _, o := range w.OrderBy {
+		exprs = append(exprs, o.Column)
+	}
+	return exprs
+}
+
+// TransformUp returns a copy of the window definition with its expressions
+// transformed by the given function.
+func (w *WindowDefinition) TransformUp(f TransformExprFunc) (*WindowDefinition, error) {
+	var partitionBy = make([]Expression, len(w.PartitionBy))
+	for i, e := range w.PartitionBy {
+		var err error
+		partitionBy[i], err = e.TransformUp(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var orderBy = make([]WindowOrderBy, len(w.OrderBy))
+	for i, o := range w.OrderBy {
+		col, err := o.Column.TransformUp(f)
+		if err != nil {
+			return nil, err
+		}
+		orderBy[i] = WindowOrderBy{col, o.Descending}
+	}
+
+	return &WindowDefinition{partitionBy, orderBy, w.Frame}, nil
+}
+
+// Partition splits the given rows into the partitions of the window, with
+// the rows of each partition sorted by the ORDER BY of the window. Rows
+// that are equal according to the ORDER BY keep their relative order.
+func (w *WindowDefinition) Partition(ctx *Context, rows []Row) ([]*WindowPartition, error) {
+	frame := w.Frame
+	if frame == nil {
+		frame = defaultWindowFrame
+	}
+
+	if frame.Unit == RangeFrame && len(w.OrderBy) != 1 &&
+		(hasOffset(frame.Start) || hasOffset(frame.End)) {
+		return nil, ErrInvalidWindowFrame.New(
+			"RANGE frames with an offset require exactly one ORDER BY expression",
+		)
+	}
+
+	startOffset, err := evalFrameOffset(ctx, frame.Start)
+	if err != nil {
+		return nil, err
+	}
+
+	endOffset, err := evalFrameOffset(ctx, frame.End)
+	if err != nil {
+		return nil, err
+	}
+
+	var partitions []*WindowPartition
+	var byKey = make(map[string]*WindowPartition)
+	for i, row := range rows {
+		key, err := w.partitionKey(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+
+		p, ok := byKey[key]
+		if !ok {
+			p = &WindowPartition{
+				window:      w,
+				frame:       frame,
+				startOffset: startOffset,
+				endOffset:   endOffset,
+			}
+			byKey[key] = p
+			partitions = append(partitions, p)
+		}
+
+		var keys = make([]interface{}, len(w.OrderBy))
+		for j, o := range w.OrderBy {
+			keys[j], err = o.Column.Eval(ctx, row)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		p.Rows = append(p.Rows, row)
+		p.Indexes = append(p.Indexes, i)
+		p.keys = append(p.keys, keys)
+	}
+
+	for _, p := range partitions {
+		if err := p.sort(); err != nil {
+			return nil, err
+		}
+	}
+
+	return partitions, nil
+}
+
+func (w *WindowDefinition) partitionKey(ctx *Context, row Row) (string, error) {
+	var vals = make([]string, len(w.PartitionBy))
+	for i, e := range w.PartitionBy {
+		v, err := e.Eval(ctx, row)
+		if err != nil {
+			return "", err
+		}
+		vals[i] = fmt.Sprintf("%#v", NormalizeKey(e.Type(), v))
+	}
+	return strings.Join(vals, ","), nil
+}
+
+func hasOffset(b WindowBound) bool {
+	return b.Type == Preceding || b.Type == Following
+}
+
+func evalFrameOffset(ctx *Context, b WindowBound) (float64, error) {
+	if !hasOffset(b) {
+		return 0, nil
+	}
+
+	v, err := b.Offset.Eval(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := Float64.Convert(v)
+	if err != nil || v == nil || n.(float64) < 0 {
+		return 0, ErrInvalidWindowFrameOffset.New(v)
+	}
+
+	return n.(float64), nil
+}
+
+// WindowPartition is a partition of the rows of a window, which are sorted
+// by the ORDER BY of the window.
+type WindowPartition struct {
+	// Rows of the partition.
+	Rows []Row
+	// Indexes of each one of the rows of the partition in the rows that
+	// were partitioned.
+	Indexes []int
+
+	window      *WindowDefinition
+	frame       *WindowFrame
+	startOffset float64
+	endOffset   float64
+	keys        [][]interface{}
+	// peers contains the index of the first peer of each row, and groups
+	// the number of the group of peers each row belongs to.
+	peers  []int
+	groups []int
+	// values contains the numeric values of the ORDER BY of each row, used
+	// to compute RANGE frames with an offset.
+	values []*float64
+}
+
+// Len returns the number of rows in the partition.
+func (p *WindowPartition) Len() int { return len(p.Rows) }
+
+func (p *WindowPartition) sort() error {
+	var err error
+	sort.Stable(&partitionSorter{p, &err})
+	if err != nil {
+		return err
+	}
+
+	p.peers = make([]int, len(p.Rows))
+	p.groups = make([]int, len(p.Rows))
+	for i := 1; i < len(p.Rows); i++ {
+		cmp, err := p.compare(i-1, i)
+		if err != nil {
+			return err
+		}
+
+		if cmp == 0 {
+			p.peers[i] = p.peers[i-1]
+			p.groups[i] = p.groups[i-1]
+		} else {
+			p.peers[i] = i
+			p.groups[i] = p.groups[i-1] + 1
+		}
+	}
+
+	return nil
+}
+
+func (p *WindowPartition) compare(i, j int) (int, error) {
+	for k, o := range p.window.OrderBy {
+		a, b := p.keys[i][k], p.keys[j][k]
+		var cmp int
+		switch {
+		case a == nil && b == nil:
+			cmp = 0
+		case a == nil:
+			cmp = -1
+		case b == nil:
+			cmp = 1
+		default:
+			var err error
+			cmp, err = o.Column.Type().Compare(a, b)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		if o.Descending {
+			cmp = -cmp
+		}
+
+		if cmp != 0 {
+			return cmp, nil
+		}
+	}
+
+	return 0, nil
+}
+
+type partitionSorter struct {
+	p   *WindowPartition
+	err *error
+}
+
+func (s *partitionSorter) Len() int { return len(s.p.Rows) }
+
+func (s *partitionSorter) Less(i, j int) bool {
+	if *s.err != nil {
+		return false
+	}
+
+	cmp, err := s.p.compare(i, j)
+	if err != nil {
+		*s.err = err
+		return false
+	}
+
+	return cmp < 0
+}
+
+func (s *partitionSorter) Swap(i, j int) {
+	p := s.p
+	p.Rows[i], p.Rows[j] = p.Rows[j], p.Rows[i]
+	p.Indexes[i], p.Indexes[j] = p.Indexes[j], p.Indexes[i]
+	p.keys[i], p.keys[j] = p.keys[j], p.keys[i]
+}
+
+// PeerStart returns the index of the first row that is a peer of the row at
+// index i, that is, equal to it according to the ORDER BY of the window.
+func (p *WindowPartition) PeerStart(i int) int { return p.peers[i] }
+
+// PeerEnd returns the index after the last row that is a peer of the row
+// at index i.
+func (p *WindowPartition) PeerEnd(i int) int {
+	j := i + 1
+	for j < len(p.Rows) && p.peers[j] == p.peers[i] {
+		j++
+	}
+	return j
+}
+
+// PeerGroup returns the number of groups of peers that come before the row
+// at index i.
+func (p *WindowPartition) PeerGroup(i int) int { return p.groups[i] }
+
+// Frame returns the rows of the frame of the row at index i, as the index
+// of its first row and the index after its last row. Both are equal if the
+// frame is empty.
+func (p *WindowPartition) Frame(i int) (start, end int, err error) {
+	if p.frame.Unit == RowsFrame {
+		start = p.rowsBound(i, p.frame.Start, p.startOffset)
+		end = p.rowsBound(i, p.frame.End, p.endOffset) + 1
+	} else {
+		start, err = p.rangeStart(i)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		end, err = p.rangeEnd(i)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	start = clampIndex(start, len(p.Rows))
+	end = clampIndex(end, len(p.Rows))
+
+	if start > end {
+		start = end
+	}
+
+	return start, end, nil
+}
+
+func clampIndex(i, n int) int {
+	if i < 0 {
+		return 0
+	}
+
+	if i > n {
+		return n
+	}
+
+	return i
+}
+
+func (p *WindowPartition) rowsBound(i int, b WindowBound, offset float64) int {
+	switch b.Type {
+	case UnboundedPreceding:
+		return 0
+	case Preceding:
+		return i - int(offset)
+	case CurrentRow:
+		return i
+	case Following:
+		return i + int(offset)
+	default:
+		return len(p.Rows) - 1
+	}
+}
+
+func (p *WindowPartition) rangeStart(i int) (int, error) {
+	switch p.frame.Start.Type {
+	case UnboundedPreceding:
+		return 0, nil
+	case CurrentRow:
+		return p.PeerStart(i), nil
+	}
+
+	v, err := p.value(i)
+	if err != nil || v == nil {
+		return p.PeerStart(i), err
+	}
+
+	low := *v + p.startOffset
+	if p.frame.Start.Type == Preceding {
+		low = *v - p.startOffset
+	}
+
+	return p.search(func(x float64) bool { return x >= low }), nil
+}
+
+func (p *WindowPartition) rangeEnd(i int) (int, error) {
+	switch p.frame.End.Type {
+	case UnboundedFollowing:
+		return len(p.Rows), nil
+	case CurrentRow:
+		return p.PeerEnd(i), nil
+	}
+
+	v, err := p.value(i)
+	if err != nil || v == nil {
+		return p.PeerEnd(i), err
+	}
+
+	high := *v + p.endOffset
+	if p.frame.End.Type == Preceding {
+		high = *v - p.endOffset
+	}
+
+	return p.search(func(x float64) bool { return x > high }), nil
+}
+
+// search returns the index of the first row whose ORDER BY value satisfies
+// the given condition, among the rows with a non NULL value.
+func (p *WindowPartition) search(f func(float64) bool) int {
+	start, end := 0, len(p.values)
+	for start < end && p.values[start] == nil {
+		start++
+	}
+
+	for end > start && p.values[end-1] == nil {
+		end--
+	}
+
+	return start + sort.Search(end-start, func(i int) bool {
+		return f(*p.values[start+i])
+	})
+}
+
+// value returns the numeric value of the ORDER BY of the row at index i,
+// negated if the order is descending so values are always ascending.
+func (p *WindowPartition) value(i int) (*float64, error) {
+	if p.values == nil {
+		p.values = make([]*float64, len(p.Rows))
+		o := p.window.OrderBy[0]
+		for j, keys := range p.keys {
+			if keys[0] == nil {
+				continue
+			}
+
+			if !IsNumber(o.Column.Type()) && !IsDecimal(o.Column.Type()) {
+				return nil, ErrInvalidWindowFrame.New(
+					"RANGE frames with an offset require a numeric ORDER BY expression",
+				)
+			}
+
+			v, err := Float64.Convert(keys[0])
+			if err != nil {
+				return nil, err
+			}
+
+			f := v.(float64)
+			if o.Descending {
+				f = -f
+			}
+			p.values[j] = &f
+		}
+	}
+
+	return p.values[i], nil
+}
@@ -0,0 +1,224 @@
+package sql_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-mysql-server.v0/sql"
+	"gopkg.in/src-d/go-mysql-server.v0/sql/expression"
+)
+
+func TestNewWindowFrame(t *testing.T) {
+	require := require.New(t)
+
+	offset := expression.NewLiteral(int64(1), sql.Int64)
+	_, err := sql.NewWindowFrame(
+		sql.RowsFrame,
+		sql.WindowBound{Type: sql.Preceding, Offset: offset},
+		sql.WindowBound{Type: sql.Following, Offset: offset},
+	)
+	require.NoError(err)
+
+	invalid := [][2]sql.WindowBound{
+		{{Type: sql.UnboundedFollowing}, {Type: sql.UnboundedFollowing}},
+		{{Type: sql.UnboundedPreceding}, {Type: sql.UnboundedPreceding}},
+		{{Type: sql.CurrentRow}, {Type: sql.Preceding, Offset: offset}},
+		{{Type: sql.Following, Offset: offset}, {Type: sql.CurrentRow}},
+	}
+
+	for _, bounds := range invalid {
+		_, err := sql.NewWindowFrame(sql.RowsFrame, bounds[0], bounds[1])
+		require.Error(err)
+		require.True(sql.ErrInvalidWindowFrame.Is(err))
+	}
+}
+
+func TestWindowDefinitionPartition(t *testing.T) {
+	require := require.New(t)
+
+	w := &sql.WindowDefinition{
+		PartitionBy: []sql.Expression{expression.NewGetField(0, sql.Text, "a", false)},
+		OrderBy: []sql.WindowOrderBy{
+			{Column: expression.NewGetField(1, sql.Int64, "b", true), Descending: true},
+		},
+	}
+
+	rows := []sql.Row{
+		sql.NewRow("x", int64(1)),
+		sql.NewRow("y", int64(5)),
+		sql.NewRow("x", nil),
+		sql.NewRow("x", int64(3)),
+		sql.NewRow("x", int64(3)),
+		sql.NewRow("y", int64(2)),
+	}
+
+	partitions, err := w.Partition(sql.NewEmptyContext(), rows)
+	require.NoError(err)
+	require.Len(partitions, 2)
+
+	x := partitions[0]
+	require.Equal([]int{3, 4, 0, 2}, x.Indexes)
+	require.Equal(rows[3], x.Rows[0])
+	require.Equal(4, x.Len())
+
+	var peers, groups []int
+	for i := range x.Rows {
+		peers = append(peers, x.PeerStart(i))
+		groups = append(groups, x.PeerGroup(i))
+	}
+	require.Equal([]int{0, 0, 2, 3}, peers)
+	require.Equal([]int{0, 0, 1, 2}, groups)
+	require.Equal(2, x.PeerEnd(0))
+
+	// The default frame goes up to the last peer of the current row.
+	start, end, err := x.Frame(0)
+	require.NoError(err)
+	require.Equal([2]int{0, 2}, [2]int{start, end})
+
+	require.Equal([]int{1, 5}, partitions[1].Indexes)
+}
+
+func TestWindowPartitionFrame(t *testing.T) {
+	col := expression.NewGetField(0, sql.Int64, "a", true)
+	offset := func(n int64) sql.Expression {
+		return expression.NewLiteral(n, sql.Int64)
+	}
+
+	rows := []sql.Row{
+		sql.NewRow(nil),
+		sql.NewRow(int64(1)),
+		sql.NewRow(int64(2)),
+		sql.NewRow(int64(2)),
+		sql.NewRow(int64(4)),
+		sql.NewRow(int64(7)),
+	}
+
+	testCases := []struct {
+		name       string
+		descending bool
+		frame      *sql.WindowFrame
+		expected   [][2]int
+	}{
+		{
+			"rows between 1 preceding and 1 following",
+			false,
+			&sql.WindowFrame{
+				Unit:  sql.RowsFrame,
+				Start: sql.WindowBound{Type: sql.Preceding, Offset: offset(1)},
+				End:   sql.WindowBound{Type: sql.Following, Offset: offset(1)},
+			},
+			[][2]int{{0, 2}, {0, 3}, {1, 4}, {2, 5}, {3, 6}, {4, 6}},
+		},
+		{
+			"rows between current row and unbounded following",
+			false,
+			&sql.WindowFrame{
+				Unit:  sql.RowsFrame,
+				Start: sql.WindowBound{Type: sql.CurrentRow},
+				End:   sql.WindowBound{Type: sql.UnboundedFollowing},
+			},
+			[][2]int{{0, 6}, {1, 6}, {2, 6}, {3, 6}, {4, 6}, {5, 6}},
+		},
+		{
+			"rows between 3 preceding and 2 preceding",
+			false,
+			&sql.WindowFrame{
+				Unit:  sql.RowsFrame,
+				Start: sql.WindowBound{Type: sql.Preceding, Offset: offset(3)},
+				End:   sql.WindowBound{Type: sql.Preceding, Offset: offset(2)},
+			},
+			[][2]int{{0, 0}, {0, 0}, {0, 1}, {0, 2}, {1, 3}, {2, 4}},
+		},
+		{
+			"range between 2 preceding and current row",
+			false,
+			&sql.WindowFrame{
+				Unit:  sql.RangeFrame,
+				Start: sql.WindowBound{Type: sql.Preceding, Offset: offset(2)},
+				End:   sql.WindowBound{Type: sql.CurrentRow},
+			},
+			[][2]int{{0, 1}, {1, 2}, {1, 4}, {1, 4}, {2, 5}, {5, 6}},
+		},
+		{
+			"range between current row and 2 following",
+			false,
+			&sql.WindowFrame{
+				Unit:  sql.RangeFrame,
+				Start: sql.WindowBound{Type: sql.CurrentRow},
+				End:   sql.WindowBound{Type: sql.Following, Offset: offset(2)},
+			},
+			[][2]int{{0, 1}, {1, 4}, {2, 5}, {2, 5}, {4, 5}, {5, 6}},
+		},
+		{
+			"range between 1 preceding and 1 following descending",
+			true,
+			&sql.WindowFrame{
+				Unit:  sql.RangeFrame,
+				Start: sql.WindowBound{Type: sql.Preceding, Offset: offset(1)},
+				End:   sql.WindowBound{Type: sql.Following, Offset: offset(1)},
+			},
+			// sorted: 7, 4, 2, 2, 1, NULL
+			[][2]int{{0, 1}, {1, 2}, {2, 5}, {2, 5}, {2, 5}, {5, 6}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			w := &sql.WindowDefinition{
+				OrderBy: []sql.WindowOrderBy{{Column: col, Descending: tt.descending}},
+				Frame:   tt.frame,
+			}
+
+			partitions, err := w.Partition(sql.NewEmptyContext(), rows)
+			require.NoError(err)
+			require.Len(partitions, 1)
+
+			var frames [][2]int
+			for i := range partitions[0].Rows {
+				start, end, err := partitions[0].Frame(i)
+				require.NoError(err)
+				frames = append(frames, [2]int{start, end})
+			}
+
+			require.Equal(tt.expected, frames)
+		})
+	}
+}
+
+func TestWindowPartitionRangeOffsetErrors(t *testing.T) {
+	require := require.New(t)
+
+	frame := &sql.WindowFrame{
+		Unit:  sql.RangeFrame,
+		Start: sql.WindowBound{Type: sql.Preceding, Offset: expression.NewLiteral(int64(1), sql.Int64)},
+		End:   sql.WindowBound{Type: sql.CurrentRow},
+	}
+
+	rows := []sql.Row{sql.NewRow("a", int64(1))}
+
+	w := &sql.WindowDefinition{Frame: frame}
+	_, err := w.Partition(sql.NewEmptyContext(), rows)
+	require.True(sql.ErrInvalidWindowFrame.Is(err))
+
+	w = &sql.WindowDefinition{
+		OrderBy: []sql.WindowOrderBy{{Column: expression.NewGetField(0, sql.Text, "a", false)}},
+		Frame:   frame,
+	}
+	partitions, err := w.Partition(sql.NewEmptyContext(), rows)
+	require.NoError(err)
+	_, _, err = partitions[0].Frame(0)
+	require.True(sql.ErrInvalidWindowFrame.Is(err))
+
+	w = &sql.WindowDefinition{
+		OrderBy: []sql.WindowOrderBy{{Column: expression.NewGetField(1, sql.Int64, "b", false)}},
+		Frame: &sql.WindowFrame{
+			Unit:  sql.RowsFrame,
+			Start: sql.WindowBound{Type: sql.Preceding, Offset: expression.NewLiteral(int64(-1), sql.Int64)},
+			End:   sql.WindowBound{Type: sql.CurrentRow},
+		},
+	}
+	_, err = w.Partition(sql.NewEmptyContext(), rows)
+	require.True(sql.ErrInvalidWindowFrameOffset.Is(err))
+}